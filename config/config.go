@@ -37,14 +37,198 @@ type GKEConfig struct {
 	CredentialsFile string `json:"credentialsFile"`
 }
 
+// WriteConfig 寫入操作配置，預設關閉，所有會變更叢集狀態的工具都必須先啟用
+type WriteConfig struct {
+	Enabled     bool  `json:"enabled"`
+	MinReplicas int32 `json:"minReplicas"` // scale_workload 允許的最小副本數
+	MaxReplicas int32 `json:"maxReplicas"` // scale_workload 允許的最大副本數
+}
+
+// ExecConfig exec_in_pod 配置，只允許執行 AllowedCommands 中列出的指令，清單為空時停用
+type ExecConfig struct {
+	AllowedCommands []string `json:"allowedCommands"`
+}
+
+// MetricsConfig 背景指標收集配置，啟用後定期取樣 Pod 與節點的使用量供 get_metrics_history 查詢
+type MetricsConfig struct {
+	Enabled         bool   `json:"enabled"`
+	IntervalSeconds int    `json:"intervalSeconds"` // 取樣間隔
+	RetentionHours  int    `json:"retentionHours"`  // 保存時間
+	StorePath       string `json:"storePath"`       // 持久化檔案路徑，空字串表示只保存在記憶體中
+
+	// Provider 優化分析使用的使用量資料來源 (auto、metrics-api、cloud-monitoring、prometheus、collector)
+	// auto 依序使用第一個可用的歷史資料來源，都不可用時使用 metrics-api
+	Provider string `json:"provider"`
+}
+
+// PrometheusConfig Prometheus 資料來源配置，url 為空時停用
+// 使用 Google Cloud Managed Service for Prometheus 時 url 為
+// https://monitoring.googleapis.com/v1/projects/PROJECT_ID/location/global/prometheus 並啟用 useGoogleCredentials
+type PrometheusConfig struct {
+	URL                  string `json:"url"`
+	BearerToken          string `json:"bearerToken"`
+	UseGoogleCredentials bool   `json:"useGoogleCredentials"`
+}
+
+// OptimizationConfig 優化分析配置
+type OptimizationConfig struct {
+	// ExcludeSelectors 符合任一標籤選擇器 (例如 "app=canary"、"job-type in (batch)") 的 Pod 不納入優化分析
+	// Pod 也可以加上 mcp-optimizer/ignore: "true" 註解個別排除
+	ExcludeSelectors []string `json:"excludeSelectors"`
+
+	// CriteriaPath 以 update_optimization_criteria 更新的優化標準會寫入此檔案，啟動時載入；空字串表示不保存
+	CriteriaPath string `json:"criteriaPath"`
+
+	// AnalysisWorkers 產生優化報告時並行分析 Pod 的 worker 數量
+	AnalysisWorkers int `json:"analysisWorkers"`
+
+	// ProductionNamespaces 正式環境的命名空間，其中的 BestEffort Pod 會被列為問題
+	ProductionNamespaces []string `json:"productionNamespaces"`
+
+	// AllowedRegistries 允許的映像檔 registry，來自其他 registry 的映像檔會被列為問題；空白時不檢查
+	AllowedRegistries []string `json:"allowedRegistries"`
+
+	// RequiredLabels 工作負載必須有的標籤 (例如 team、cost-center)，缺少時列為低優先級建議；app.kubernetes.io/name 一律檢查
+	RequiredLabels []string `json:"requiredLabels"`
+
+	// IdleNamespaceDays 命名空間所有工作負載持續閒置多少天才建議封存或刪除
+	IdleNamespaceDays int `json:"idleNamespaceDays"`
+
+	// Pricing 估算建議每月節省成本的單價，未設定時使用 GKE Standard E2 隨選節點 (us-central1) 的 USD 價格
+	Pricing PricingConfig `json:"pricing"`
+
+	// ReportHistoryDir generate_optimization_report 產生的報告會保存在此目錄，供 compare_optimization_reports 比較；空字串表示不保存
+	ReportHistoryDir string `json:"reportHistoryDir"`
+
+	// ExportDir export_optimization_report 匯出的檔案會寫入此目錄；空字串表示不允許匯出
+	ExportDir string `json:"exportDir"`
+
+	// ReportBucket 產生的報告 (JSON 與 HTML) 會上傳到此 Cloud Storage 位置 (gs://bucket/prefix)，使用 gke 的服務帳戶凭证；空字串表示不上傳
+	ReportBucket string `json:"reportBucket"`
+
+	// SnoozePath 以 snooze_recommendation 暫停的建議會寫入此檔案，啟動時載入；空字串表示不保存
+	SnoozePath string `json:"snoozePath"`
+
+	// SLOTiers 可用性等級，key 為等級名稱 (例如 critical、standard)；所屬命名空間的建議值改用等級的餘裕係數、request 依據與副本數下限
+	SLOTiers map[string]SLOTierConfig `json:"sloTiers"`
+
+	// SeverityOverrides 覆寫問題類型的嚴重程度 (例如在開發環境將 MEMORY_OVER_PROVISIONED 降為 LOW)，建議的優先級與優化分數依覆寫後的嚴重程度計算
+	SeverityOverrides []SeverityOverrideConfig `json:"severityOverrides"`
+}
+
+// SeverityOverrideConfig 問題類型的嚴重程度覆寫，namespaces 為空時套用在所有命名空間，命名空間的覆寫優先於全域的覆寫
+type SeverityOverrideConfig struct {
+	Issue      string   `json:"issue"`    // 問題類型，例如 MEMORY_OVER_PROVISIONED
+	Severity   string   `json:"severity"` // HIGH、MEDIUM 或 LOW
+	Namespaces []string `json:"namespaces"`
+}
+
+// SLOTierConfig 可用性等級的建議值設定，未設定的欄位沿用優化標準
+type SLOTierConfig struct {
+	Namespaces     []string `json:"namespaces"`
+	HeadroomFactor float64  `json:"headroomFactor"` // 建議值 = 使用量 × 係數，需大於或等於 1
+	RequestBasis   string   `json:"requestBasis"`   // 建議的 request 依據: p95 (預設) 或 peak
+	MinReplicas    int32    `json:"minReplicas"`    // 副本數與 HPA 建議的下限
+}
+
+// PricingConfig 依 requests 估算成本的單價
+type PricingConfig struct {
+	Currency      string  `json:"currency"`
+	CPUCoreHour   float64 `json:"cpuCoreHour"`   // 每 vCPU 每小時
+	MemoryGiBHour float64 `json:"memoryGiBHour"` // 每 GiB 記憶體每小時
+
+	// StorageGiBMonth 各永久磁碟類型每 GiB 每月的單價，未設定的類型使用預設值
+	StorageGiBMonth map[string]float64 `json:"storageGiBMonth"`
+
+	// Autopilot 一般用途 Pod 每小時的單價，用於 assess_autopilot_suitability
+	AutopilotCPUCoreHour    float64 `json:"autopilotCpuCoreHour"`
+	AutopilotMemoryGiBHour  float64 `json:"autopilotMemoryGiBHour"`
+	AutopilotStorageGiBHour float64 `json:"autopilotStorageGiBHour"`
+}
+
+// NotificationConfig 報告通知配置，webhookURL 為空時停用
+type NotificationConfig struct {
+	WebhookURL string `json:"webhookURL"`
+	Format     string `json:"format"` // slack (預設) 或 generic
+
+	// IntervalHours 定期傳送報告的間隔，0 表示只在呼叫 send_report 時傳送
+	IntervalHours int `json:"intervalHours"`
+
+	// Namespaces 定期傳送報告的命名空間，空白時使用 gke.namespace
+	Namespaces []string `json:"namespaces"`
+}
+
+// TimeoutConfig 工具執行時間上限，超過時取消執行並回傳逾時錯誤
+type TimeoutConfig struct {
+	DefaultSeconds int            `json:"defaultSeconds"` // 所有工具的預設上限，0 表示不限制
+	Tools          map[string]int `json:"tools"`          // 工具名稱對應的上限 (秒)，覆寫 defaultSeconds；0 表示該工具不限制
+}
+
+// SSEConfig SSE 模式配置
+type SSEConfig struct {
+	BaseURL   string          `json:"baseURL"`
+	Port      interface{}     `json:"port"`
+	Auth      AuthConfig      `json:"auth"`
+	RateLimit RateLimitConfig `json:"rateLimit"`
+}
+
+// RateLimitConfig SSE 模式的工具呼叫頻率限制，requestsPerMinute 為 0 時不限制
+// 通過驗證的用戶端依身分計算，未啟用驗證時依來源 IP 計算
+type RateLimitConfig struct {
+	RequestsPerMinute int `json:"requestsPerMinute"`
+	Burst             int `json:"burst"` // 可以連續呼叫的次數，0 表示與 requestsPerMinute 相同
+}
+
+// AuthConfig SSE 模式的驗證配置，未設定 API key 與 OIDC 時不驗證
+type AuthConfig struct {
+	// APIKeys 用戶端名稱對應的 API key，用戶端以 Authorization: Bearer <key> 或 X-API-Key 標頭傳送
+	// 通過驗證的用戶端名稱會記錄在日誌中
+	APIKeys map[string]string `json:"apiKeys"`
+
+	// OIDC 以 OIDC ID token 驗證用戶端，可以與 API key 同時使用
+	OIDC OIDCConfig `json:"oidc"`
+}
+
+// OIDCConfig OIDC ID token 驗證配置，issuer 為空時停用
+// issuer 為 https://accounts.google.com 時驗證 Google 簽發的 ID token (例如服務帳號以 gcloud auth print-identity-token 取得的 token)
+// 其他 issuer 從 {issuer}/.well-known/openid-configuration 取得簽章金鑰
+type OIDCConfig struct {
+	Issuer   string `json:"issuer"`
+	Audience string `json:"audience"` // ID token 的 aud 必須包含此值
+
+	// AllowedEmails 與 AllowedDomains 限制可以連線的身分，至少必須設定其中一個
+	// ID token 必須包含已驗證的 email
+	AllowedEmails  []string `json:"allowedEmails"`
+	AllowedDomains []string `json:"allowedDomains"` // email 的網域，例如 example.com
+}
+
 type Config struct {
-	ServerType ServerType `json:"serverType"`
-	SSE        struct {
-		BaseURL string      `json:"baseURL"`
-		Port    interface{} `json:"port"`
-	} `json:"sse"`
-	GKE         GKEConfig       `json:"gke"`
-	Credentials *GkeCredentials `json:"-"` // 不序列化到JSON
+	ServerType   ServerType         `json:"serverType"`
+	SSE          SSEConfig          `json:"sse"`
+	GKE          GKEConfig          `json:"gke"`
+	Write        WriteConfig        `json:"write"`
+	Exec         ExecConfig         `json:"exec"`
+	Metrics      MetricsConfig      `json:"metrics"`
+	Prometheus   PrometheusConfig   `json:"prometheus"`
+	Optimization OptimizationConfig `json:"optimization"`
+	Notification NotificationConfig `json:"notification"`
+	Timeouts     TimeoutConfig      `json:"timeouts"`
+	Credentials  *GkeCredentials    `json:"-"` // 不序列化到JSON
+
+	// Clusters 其他叢集的連線設定，compare_clusters 會在這些叢集與目前的叢集上執行優化分析並比較結果
+	Clusters []ClusterProfile `json:"clusters"`
+}
+
+// ClusterProfile 叢集的連線設定
+// 設定 credentialsFile 時以 Google Cloud 凭证連線，叢集名稱、位置與專案未設定時使用凭证文件中的值；否則使用 kubeconfig
+type ClusterProfile struct {
+	Name            string `json:"name"` // 比較結果中顯示的叢集名稱，不可重複
+	KubeConfigPath  string `json:"kubeConfigPath"`
+	Context         string `json:"context"` // kubeconfig 中的 context，空字串表示使用目前的 context
+	CredentialsFile string `json:"credentialsFile"`
+	ProjectID       string `json:"projectId"`
+	Location        string `json:"location"`
+	GkeClusterName  string `json:"gkeClusterName"`
 }
 
 func DefaultConfig() Config {
@@ -57,6 +241,20 @@ func DefaultConfig() Config {
 	cfg.GKE.Namespace = "default"                  // 預設命名空間
 	cfg.GKE.ClusterName = ""                       // 空字串表示使用當前上下文
 	cfg.GKE.CredentialsFile = "irich-h5-test.json" // 預設凭证文件
+	cfg.Write.MinReplicas = 1                      // 預設不允許縮減到 0
+	cfg.Write.MaxReplicas = 20
+	cfg.Metrics.IntervalSeconds = 60
+	cfg.Metrics.RetentionHours = 24
+	cfg.Metrics.Provider = "auto"
+	cfg.Optimization.CriteriaPath = "optimization_criteria.json"
+	cfg.Optimization.AnalysisWorkers = 10
+	cfg.Optimization.ProductionNamespaces = []string{"production", "prod"}
+	cfg.Optimization.IdleNamespaceDays = 7
+	cfg.Optimization.ReportHistoryDir = "optimization_reports"
+	cfg.Optimization.ExportDir = "optimization_exports"
+	cfg.Optimization.SnoozePath = "optimization_snoozes.json"
+	cfg.Timeouts.DefaultSeconds = 300
+	cfg.Timeouts.Tools = map[string]int{"drain_node": 600} // drain_node 本身預設等待 300 秒
 	return cfg
 }
 
@@ -98,6 +296,51 @@ func LoadConfig() (Config, error) {
 		cfg = DefaultConfig()
 	}
 
+	names := make(map[string]bool, len(cfg.Clusters))
+	for _, cluster := range cfg.Clusters {
+		if cluster.Name == "" {
+			return cfg, fmt.Errorf("clusters 中的叢集必須設定 name")
+		}
+		if names[cluster.Name] {
+			return cfg, fmt.Errorf("clusters 中的叢集名稱 %s 重複", cluster.Name)
+		}
+		names[cluster.Name] = true
+	}
+
+	keys := make(map[string]string, len(cfg.SSE.Auth.APIKeys))
+	for client, key := range cfg.SSE.Auth.APIKeys {
+		if client == "" {
+			return cfg, fmt.Errorf("sse.auth.apiKeys 中的用戶端名稱不可為空")
+		}
+		if key == "" {
+			return cfg, fmt.Errorf("sse.auth.apiKeys 中用戶端 %s 的 API key 不可為空", client)
+		}
+		if other, ok := keys[key]; ok {
+			return cfg, fmt.Errorf("sse.auth.apiKeys 中用戶端 %s 與 %s 使用相同的 API key", other, client)
+		}
+		keys[key] = client
+	}
+	if cfg.SSE.Auth.OIDC.Issuer != "" {
+		if cfg.SSE.Auth.OIDC.Audience == "" {
+			return cfg, fmt.Errorf("sse.auth.oidc 必須設定 audience")
+		}
+		// 任何 Google 帳號或服務帳號都能取得指定 audience 的 ID token，audience 無法限制可以連線的身分
+		if len(cfg.SSE.Auth.OIDC.AllowedEmails) == 0 && len(cfg.SSE.Auth.OIDC.AllowedDomains) == 0 {
+			return cfg, fmt.Errorf("sse.auth.oidc 必須設定 allowedEmails 或 allowedDomains")
+		}
+	}
+	if cfg.Timeouts.DefaultSeconds < 0 {
+		return cfg, fmt.Errorf("timeouts.defaultSeconds 不可為負數")
+	}
+	for tool, seconds := range cfg.Timeouts.Tools {
+		if seconds < 0 {
+			return cfg, fmt.Errorf("timeouts.tools 中工具 %s 的上限不可為負數", tool)
+		}
+	}
+	if cfg.SSE.RateLimit.RequestsPerMinute < 0 || cfg.SSE.RateLimit.Burst < 0 {
+		return cfg, fmt.Errorf("sse.rateLimit 的 requestsPerMinute 與 burst 不可為負數")
+	}
+
 	// 加載 GKE 凭证
 	if cfg.GKE.CredentialsFile != "" {
 		credentials, err := LoadGkeCredentials(cfg.GKE.CredentialsFile)
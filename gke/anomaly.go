@@ -0,0 +1,206 @@
+package gke
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// defaultAnomalyBaseline 計算基準值的預設時間範圍
+	defaultAnomalyBaseline = 24 * time.Hour
+
+	// defaultAnomalyRecent 與基準值比較的最近時間範圍
+	defaultAnomalyRecent = 30 * time.Minute
+
+	// defaultAnomalyThreshold 預設的 robust z-score 門檻 (常用值 3.5)
+	defaultAnomalyThreshold = 3.5
+
+	// minBaselineSamples 基準值最少需要的樣本數
+	minBaselineSamples = 10
+
+	// madScale 將 MAD 換算為常態分佈標準差的係數
+	madScale = 0.6745
+)
+
+// DetectAnomalies 比較各 Pod 最近一段時間的使用量與基準時間範圍的中位數，以 MAD (median absolute deviation)
+// 計算 robust z-score，並找出最近重啟過的 Pod
+func (s *Service) DetectAnomalies(ctx context.Context, namespace, source string, baseline, recent time.Duration, threshold float64) (*AnomalyReport, error) {
+	if baseline <= 0 {
+		baseline = defaultAnomalyBaseline
+	}
+	if recent <= 0 {
+		recent = defaultAnomalyRecent
+	}
+	if recent >= baseline {
+		return nil, fmt.Errorf("最近時間範圍 (%s) 必須小於基準時間範圍 (%s)", recent, baseline)
+	}
+	if threshold <= 0 {
+		threshold = defaultAnomalyThreshold
+	}
+
+	s.mu.RLock()
+	namespace = s.resolveListNamespace(namespace)
+	podList, err := s.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	s.mu.RUnlock()
+	if err != nil {
+		return nil, fmt.Errorf("無法取得 Pod 列表: %w", err)
+	}
+
+	report := &AnomalyReport{
+		Namespace:      namespace,
+		BaselineWindow: baseline.String(),
+		RecentWindow:   recent.String(),
+		Threshold:      threshold,
+		Anomalies:      []UsageAnomaly{},
+	}
+
+	recentStart := time.Now().Add(-recent)
+	podsByNamespace := make(map[string][]string)
+	for _, pod := range podList.Items {
+		if pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		podsByNamespace[pod.Namespace] = append(podsByNamespace[pod.Namespace], pod.Name)
+		report.PodsAnalyzed++
+
+		if anomaly := detectRestartAnomaly(&pod, recentStart); anomaly != nil {
+			report.Anomalies = append(report.Anomalies, *anomaly)
+		}
+	}
+
+	for podNamespace, pods := range podsByNamespace {
+		samples, usedSource, err := s.podUsageSamples(ctx, source, podNamespace, pods, baseline)
+		if err != nil {
+			// 沒有可用的使用量資料時仍回報重啟異常
+			report.Warnings = append(report.Warnings, fmt.Sprintf("無法取得使用量歷史，僅偵測重啟異常: %v", err))
+			break
+		}
+		report.Source = usedSource
+
+		for _, pod := range pods {
+			var baselineCPU, baselineMemory, recentCPU, recentMemory []float64
+			for _, sample := range samples[pod] {
+				if sample.Timestamp.Before(recentStart) {
+					baselineCPU = append(baselineCPU, float64(sample.CPUMillicores))
+					baselineMemory = append(baselineMemory, float64(sample.MemoryBytes))
+				} else {
+					recentCPU = append(recentCPU, float64(sample.CPUMillicores))
+					recentMemory = append(recentMemory, float64(sample.MemoryBytes))
+				}
+			}
+
+			if anomaly := detectUsageAnomaly("cpu", baselineCPU, recentCPU, threshold); anomaly != nil {
+				anomaly.PodName, anomaly.Namespace = pod, podNamespace
+				anomaly.Message = fmt.Sprintf("CPU 使用量 %.0fm，基準中位數 %.0fm (z=%.1f)", anomaly.Recent, anomaly.BaselineMedian, anomaly.Score)
+				report.Anomalies = append(report.Anomalies, *anomaly)
+			}
+			if anomaly := detectUsageAnomaly("memory", baselineMemory, recentMemory, threshold); anomaly != nil {
+				anomaly.PodName, anomaly.Namespace = pod, podNamespace
+				anomaly.Message = fmt.Sprintf("記憶體使用量 %.0fMi，基準中位數 %.0fMi (z=%.1f)", anomaly.Recent/(1024*1024), anomaly.BaselineMedian/(1024*1024), anomaly.Score)
+				report.Anomalies = append(report.Anomalies, *anomaly)
+			}
+		}
+	}
+
+	// 依異常程度由高到低排序
+	sort.SliceStable(report.Anomalies, func(i, j int) bool {
+		return math.Abs(report.Anomalies[i].Score) > math.Abs(report.Anomalies[j].Score)
+	})
+
+	return report, nil
+}
+
+// detectUsageAnomaly 以 robust z-score 比較最近的平均值與基準值
+func detectUsageAnomaly(metric string, baseline, recent []float64, threshold float64) *UsageAnomaly {
+	if len(baseline) < minBaselineSamples || len(recent) == 0 {
+		return nil
+	}
+
+	median, mad := medianAbsoluteDeviation(baseline)
+	// 基準值完全平穩 (MAD 為 0) 時以中位數的 5% 作為尺度，避免任何微小變化都被視為異常
+	scale := mad
+	if scale == 0 {
+		scale = median * 0.05
+	}
+	if scale == 0 {
+		return nil
+	}
+
+	total := 0.0
+	for _, value := range recent {
+		total += value
+	}
+	average := total / float64(len(recent))
+
+	score := madScale * (average - median) / scale
+	if math.Abs(score) < threshold {
+		return nil
+	}
+
+	direction := "spike"
+	if score < 0 {
+		direction = "drop"
+	}
+
+	return &UsageAnomaly{
+		Metric:         metric,
+		Direction:      direction,
+		Recent:         average,
+		BaselineMedian: median,
+		Score:          math.Round(score*10) / 10,
+	}
+}
+
+// medianAbsoluteDeviation 計算中位數與 MAD
+func medianAbsoluteDeviation(values []float64) (float64, float64) {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	median := percentile(sorted, 50)
+
+	deviations := make([]float64, len(sorted))
+	for i, value := range sorted {
+		deviations[i] = math.Abs(value - median)
+	}
+	sort.Float64s(deviations)
+
+	return median, percentile(deviations, 50)
+}
+
+// detectRestartAnomaly 找出在最近時間範圍內重啟過的容器，並與 Pod 生命週期內的平均重啟頻率比較
+func detectRestartAnomaly(pod *corev1.Pod, recentStart time.Time) *UsageAnomaly {
+	var restarts int32
+	var lastRestart time.Time
+	var reason string
+	for _, status := range pod.Status.ContainerStatuses {
+		restarts += status.RestartCount
+		if terminated := status.LastTerminationState.Terminated; terminated != nil && terminated.FinishedAt.After(lastRestart) {
+			lastRestart = terminated.FinishedAt.Time
+			reason = fmt.Sprintf("容器 %s 結束原因 %s (exit code %d)", status.Name, terminated.Reason, terminated.ExitCode)
+		}
+	}
+	if restarts == 0 || lastRestart.Before(recentStart) {
+		return nil
+	}
+
+	hours := time.Since(pod.CreationTimestamp.Time).Hours()
+	ratePerHour := float64(restarts)
+	if hours > 1 {
+		ratePerHour = float64(restarts) / hours
+	}
+
+	return &UsageAnomaly{
+		PodName:        pod.Name,
+		Namespace:      pod.Namespace,
+		Metric:         "restarts",
+		Direction:      "spike",
+		Recent:         float64(restarts),
+		BaselineMedian: math.Round(ratePerHour*100) / 100,
+		Message:        fmt.Sprintf("最近於 %s 重啟，累計 %d 次 (平均每小時 %.2f 次)；%s", lastRestart.Format(time.RFC3339), restarts, ratePerHour, reason),
+	}
+}
@@ -0,0 +1,26 @@
+package gke
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetAutoscaledWorkloads 取得命名空間內由 HPA 管理的工作負載，以 namespace/Kind/name 為鍵，值為 HPA 名稱
+func (s *Service) GetAutoscaledWorkloads(ctx context.Context, namespace string) (map[string]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	hpas, err := s.clientset.AutoscalingV2().HorizontalPodAutoscalers(s.resolveListNamespace(namespace)).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("無法取得 HPA 列表: %w", err)
+	}
+
+	result := make(map[string]string, len(hpas.Items))
+	for _, hpa := range hpas.Items {
+		target := hpa.Spec.ScaleTargetRef
+		result[hpa.Namespace+"/"+target.Kind+"/"+target.Name] = hpa.Name
+	}
+	return result, nil
+}
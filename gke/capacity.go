@@ -0,0 +1,410 @@
+package gke
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+)
+
+const (
+	// zoneLabel 節點所在區域的標準標籤
+	zoneLabel = "topology.kubernetes.io/zone"
+
+	// hostnameLabel 節點主機名稱的標準標籤
+	hostnameLabel = "kubernetes.io/hostname"
+)
+
+// nodeSelectorOperators 節點選擇運算子與標籤選擇運算子的對應
+var nodeSelectorOperators = map[corev1.NodeSelectorOperator]selection.Operator{
+	corev1.NodeSelectorOpIn:           selection.In,
+	corev1.NodeSelectorOpNotIn:        selection.NotIn,
+	corev1.NodeSelectorOpExists:       selection.Exists,
+	corev1.NodeSelectorOpDoesNotExist: selection.DoesNotExist,
+	corev1.NodeSelectorOpGt:           selection.GreaterThan,
+	corev1.NodeSelectorOpLt:           selection.LessThan,
+}
+
+// capacityNode 容量規劃時單一節點的計算狀態
+type capacityNode struct {
+	node     *corev1.Node
+	free     corev1.ResourceList
+	freePods int64
+	existing int // 節點上已有的副本數
+	fits     int
+	limiting string
+	excluded string
+}
+
+// GetCapacityPlan 依 Pod 範本的請求量、節點可分配量、節點選擇/污點與分散限制，估算工作負載還能再增加多少副本
+// 結果為排程器可接受的上限估計，不考慮 Pod 間親和性 (affinity) 與搶佔
+func (s *Service) GetCapacityPlan(ctx context.Context, kind, name, namespace, nodePool string) (*CapacityPlan, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	kind, err := normalizeWorkloadKind(kind)
+	if err != nil {
+		return nil, err
+	}
+	if namespace == "" {
+		namespace = s.defaultNamespace
+	}
+
+	var template corev1.PodTemplateSpec
+	var selector *metav1.LabelSelector
+	var replicas int32
+	switch kind {
+	case "Deployment":
+		deployment, err := s.clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("無法取得 Deployment %s: %w", name, err)
+		}
+		template, selector = deployment.Spec.Template, deployment.Spec.Selector
+		replicas = deployment.Status.Replicas
+	case "StatefulSet":
+		statefulSet, err := s.clientset.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("無法取得 StatefulSet %s: %w", name, err)
+		}
+		template, selector = statefulSet.Spec.Template, statefulSet.Spec.Selector
+		replicas = statefulSet.Status.Replicas
+	default:
+		return nil, fmt.Errorf("DaemonSet 的副本數由節點數決定，無法規劃副本容量")
+	}
+
+	workloadSelector, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, fmt.Errorf("無效的標籤選擇器: %w", err)
+	}
+
+	listOptions := metav1.ListOptions{}
+	if nodePool != "" {
+		listOptions.LabelSelector = labels.Set{NodePoolLabel: nodePool}.String()
+	}
+	nodes, err := s.clientset.CoreV1().Nodes().List(ctx, listOptions)
+	if err != nil {
+		return nil, fmt.Errorf("無法取得節點列表: %w", err)
+	}
+	pods, err := s.clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("無法取得 Pod 列表: %w", err)
+	}
+
+	perReplica, _ := podRequestsAndLimits(&corev1.Pod{Spec: template.Spec})
+	plan := &CapacityPlan{
+		Kind:            kind,
+		Name:            name,
+		Namespace:       namespace,
+		NodePool:        nodePool,
+		CurrentReplicas: replicas,
+		PerReplica:      resourceListToMap(perReplica),
+		Nodes:           []CapacityPlanNode{},
+		Constraints:     []CapacityConstraint{},
+	}
+	if perReplica.Cpu().IsZero() && perReplica.Memory().IsZero() {
+		plan.Warnings = append(plan.Warnings, "Pod 範本未設定 CPU 與記憶體請求量，容量只受節點 Pod 數量上限限制")
+	}
+
+	// 依節點彙總執行中 Pod 的請求量與數量
+	requested := make(map[string]corev1.ResourceList)
+	podCounts := make(map[string]int64)
+	var workloadPods []corev1.Pod
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Spec.NodeName == "" || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		if _, ok := requested[pod.Spec.NodeName]; !ok {
+			requested[pod.Spec.NodeName] = corev1.ResourceList{}
+		}
+		podRequests, _ := podRequestsAndLimits(pod)
+		addResourceList(requested[pod.Spec.NodeName], podRequests)
+		podCounts[pod.Spec.NodeName]++
+		if pod.Namespace == namespace {
+			workloadPods = append(workloadPods, *pod)
+		}
+	}
+
+	candidates := make([]*capacityNode, 0, len(nodes.Items))
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		candidate := &capacityNode{node: node}
+		for _, pod := range workloadPods {
+			if pod.Spec.NodeName == node.Name && workloadSelector.Matches(labels.Set(pod.Labels)) {
+				candidate.existing++
+			}
+		}
+
+		if reason := nodeExclusionReason(node, &template.Spec); reason != "" {
+			candidate.excluded = reason
+		} else {
+			candidate.free = node.Status.Allocatable.DeepCopy()
+			for resourceName, quantity := range requested[node.Name] {
+				if value, ok := candidate.free[resourceName]; ok {
+					value.Sub(quantity)
+					candidate.free[resourceName] = value
+				}
+			}
+			candidate.freePods = node.Status.Allocatable.Pods().Value() - podCounts[node.Name]
+			candidate.fits, candidate.limiting = replicasThatFit(candidate.free, candidate.freePods, perReplica)
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	additional := 0
+	for _, candidate := range candidates {
+		if candidate.excluded == "" {
+			additional += candidate.fits
+			plan.EligibleNodes++
+		}
+	}
+	plan.LimitingFactor = "resources"
+
+	// 自身的必要反親和性：每個拓撲網域最多只能有一個副本
+	if antiAffinity := template.Spec.Affinity; antiAffinity != nil && antiAffinity.PodAntiAffinity != nil {
+		for _, term := range antiAffinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution {
+			if !termSelectsTemplate(term.LabelSelector, template.Labels) {
+				continue
+			}
+			limit := antiAffinityCapacity(candidates, term.TopologyKey)
+			plan.Constraints = append(plan.Constraints, CapacityConstraint{
+				Type:               "podAntiAffinity",
+				TopologyKey:        term.TopologyKey,
+				AdditionalReplicas: limit,
+			})
+			if term.TopologyKey == hostnameLabel {
+				// 節點層級的限制直接套用到各節點，讓其他限制以此為基礎計算
+				for _, candidate := range candidates {
+					if candidate.existing > 0 {
+						candidate.fits = 0
+					} else if candidate.fits > 1 {
+						candidate.fits = 1
+					}
+				}
+			}
+			if limit < additional {
+				additional = limit
+				plan.LimitingFactor = "podAntiAffinity(" + term.TopologyKey + ")"
+			}
+		}
+	}
+
+	// 分散限制：只有 DoNotSchedule 會阻止排程
+	for _, constraint := range template.Spec.TopologySpreadConstraints {
+		if constraint.WhenUnsatisfiable != corev1.DoNotSchedule || !termSelectsTemplate(constraint.LabelSelector, template.Labels) {
+			continue
+		}
+		limit := spreadCapacity(candidates, constraint.TopologyKey, constraint.MaxSkew)
+		plan.Constraints = append(plan.Constraints, CapacityConstraint{
+			Type:               "topologySpread",
+			TopologyKey:        constraint.TopologyKey,
+			MaxSkew:            constraint.MaxSkew,
+			AdditionalReplicas: limit,
+		})
+		if limit < additional {
+			additional = limit
+			plan.LimitingFactor = "topologySpread(" + constraint.TopologyKey + ")"
+		}
+	}
+
+	plan.AdditionalReplicas = additional
+	plan.MaxReplicas = replicas + int32(additional)
+	if plan.EligibleNodes == 0 {
+		plan.LimitingFactor = "noEligibleNodes"
+	}
+
+	for _, candidate := range candidates {
+		plan.Nodes = append(plan.Nodes, CapacityPlanNode{
+			NodeName:         candidate.node.Name,
+			NodePool:         candidate.node.Labels[NodePoolLabel],
+			Zone:             candidate.node.Labels[zoneLabel],
+			ExistingReplicas: candidate.existing,
+			Fits:             candidate.fits,
+			LimitingResource: candidate.limiting,
+			Excluded:         candidate.excluded,
+		})
+	}
+	sort.Slice(plan.Nodes, func(i, j int) bool {
+		if plan.Nodes[i].Fits != plan.Nodes[j].Fits {
+			return plan.Nodes[i].Fits > plan.Nodes[j].Fits
+		}
+		return plan.Nodes[i].NodeName < plan.Nodes[j].NodeName
+	})
+
+	return plan, nil
+}
+
+// replicasThatFit 計算節點剩餘資源可容納的副本數與限制因素
+func replicasThatFit(free corev1.ResourceList, freePods int64, perReplica corev1.ResourceList) (int, string) {
+	fits := freePods
+	limiting := string(corev1.ResourcePods)
+
+	for resourceName, quantity := range perReplica {
+		if quantity.IsZero() {
+			continue
+		}
+		available, ok := free[resourceName]
+		if !ok {
+			return 0, string(resourceName)
+		}
+		count := int64(math.Floor(available.AsApproximateFloat64() / quantity.AsApproximateFloat64()))
+		if count < fits {
+			fits = count
+			limiting = string(resourceName)
+		}
+	}
+
+	if fits < 0 {
+		fits = 0
+	}
+	return int(fits), limiting
+}
+
+// nodeExclusionReason 判斷 Pod 範本無法排程到節點的原因，可以排程時回傳空字串
+func nodeExclusionReason(node *corev1.Node, spec *corev1.PodSpec) string {
+	if node.Spec.Unschedulable {
+		return "節點已停止排程 (cordoned)"
+	}
+
+	if len(spec.NodeSelector) > 0 && !labels.SelectorFromSet(spec.NodeSelector).Matches(labels.Set(node.Labels)) {
+		return "不符合 nodeSelector"
+	}
+
+	if spec.Affinity != nil && spec.Affinity.NodeAffinity != nil && spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution != nil {
+		matched := false
+		for _, term := range spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+			if nodeSelectorTermMatches(term, node) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return "不符合必要的節點親和性"
+		}
+	}
+
+	for i := range node.Spec.Taints {
+		taint := &node.Spec.Taints[i]
+		if taint.Effect == corev1.TaintEffectPreferNoSchedule {
+			continue
+		}
+		tolerated := false
+		for j := range spec.Tolerations {
+			if spec.Tolerations[j].ToleratesTaint(taint) {
+				tolerated = true
+				break
+			}
+		}
+		if !tolerated {
+			return fmt.Sprintf("未容忍污點 %s=%s:%s", taint.Key, taint.Value, taint.Effect)
+		}
+	}
+
+	return ""
+}
+
+// nodeSelectorTermMatches 判斷節點是否符合節點親和性的單一選擇條件 (條件內的所有運算式都必須成立)
+func nodeSelectorTermMatches(term corev1.NodeSelectorTerm, node *corev1.Node) bool {
+	if len(term.MatchExpressions) == 0 && len(term.MatchFields) == 0 {
+		return false
+	}
+
+	for _, expression := range term.MatchExpressions {
+		operator, ok := nodeSelectorOperators[expression.Operator]
+		if !ok {
+			return false
+		}
+		requirement, err := labels.NewRequirement(expression.Key, operator, expression.Values)
+		if err != nil || !requirement.Matches(labels.Set(node.Labels)) {
+			return false
+		}
+	}
+
+	// matchFields 只支援 metadata.name
+	for _, field := range term.MatchFields {
+		if field.Key != "metadata.name" {
+			return false
+		}
+		found := false
+		for _, value := range field.Values {
+			found = found || value == node.Name
+		}
+		if (field.Operator == corev1.NodeSelectorOpIn) != found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// termSelectsTemplate 判斷限制的標籤選擇器是否選中 Pod 範本本身
+func termSelectsTemplate(selector *metav1.LabelSelector, templateLabels map[string]string) bool {
+	if selector == nil {
+		return false
+	}
+	parsed, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return false
+	}
+	return parsed.Matches(labels.Set(templateLabels))
+}
+
+// capacityDomains 依拓撲鍵將可排程節點分組，回傳各網域的既有副本數與可容納副本數
+// 缺少拓撲鍵標籤的節點無法滿足限制，不列入網域
+func capacityDomains(candidates []*capacityNode, topologyKey string) (map[string]int, map[string]int) {
+	existing := make(map[string]int)
+	capacity := make(map[string]int)
+	for _, candidate := range candidates {
+		domain, ok := candidate.node.Labels[topologyKey]
+		if !ok || candidate.excluded != "" {
+			continue
+		}
+		existing[domain] += candidate.existing
+		capacity[domain] += candidate.fits
+	}
+	return existing, capacity
+}
+
+// antiAffinityCapacity 計算自身反親和性限制下可增加的副本數：每個網域最多一個副本
+func antiAffinityCapacity(candidates []*capacityNode, topologyKey string) int {
+	existing, capacity := capacityDomains(candidates, topologyKey)
+
+	total := 0
+	for domain, fits := range capacity {
+		if existing[domain] == 0 && fits > 0 {
+			total++
+		}
+	}
+	return total
+}
+
+// spreadCapacity 模擬排程器逐一放置副本，計算在 maxSkew 限制下可增加的副本數
+// 每次放在副本數最少且仍有容量的網域，放置後與全域最小值的差距不得超過 maxSkew
+func spreadCapacity(candidates []*capacityNode, topologyKey string, maxSkew int32) int {
+	existing, capacity := capacityDomains(candidates, topologyKey)
+
+	added := 0
+	for {
+		best := ""
+		minCount := math.MaxInt
+		for domain, count := range existing {
+			if count < minCount {
+				minCount = count
+			}
+			if capacity[domain] > 0 && (best == "" || count < existing[best] || (count == existing[best] && domain < best)) {
+				best = domain
+			}
+		}
+		if best == "" || existing[best]+1-minCount > int(maxSkew) {
+			return added
+		}
+		existing[best]++
+		capacity[best]--
+		added++
+	}
+}
@@ -0,0 +1,218 @@
+package gke
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// NodePoolLabel GKE 節點所屬節點池的標籤
+const NodePoolLabel = "cloud.google.com/gke-nodepool"
+
+// commitmentTally 以整數累計單一資源的可分配量、請求量與閒置量
+type commitmentTally struct {
+	allocatable int64
+	requested   int64
+	stranded    int64
+}
+
+// poolTally 節點池的 CPU、記憶體與 Pod 數量統計
+type poolTally struct {
+	nodes   int
+	tallies [3]commitmentTally
+}
+
+// GetNodeCommitment 比較各節點上 Pod 請求量總和與節點可分配量，計算承諾比例與閒置 (stranded) 容量
+// nodePool 不為空時只分析該節點池的節點
+func (s *Service) GetNodeCommitment(ctx context.Context, nodePool string) (*NodeCommitmentReport, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	listOptions := metav1.ListOptions{}
+	if nodePool != "" {
+		listOptions.LabelSelector = labels.Set{NodePoolLabel: nodePool}.String()
+	}
+	nodes, err := s.clientset.CoreV1().Nodes().List(ctx, listOptions)
+	if err != nil {
+		return nil, fmt.Errorf("無法取得節點列表: %w", err)
+	}
+	if len(nodes.Items) == 0 {
+		if nodePool != "" {
+			return nil, fmt.Errorf("找不到節點池 %s 的節點", nodePool)
+		}
+		return nil, fmt.Errorf("叢集中沒有節點")
+	}
+
+	pods, err := s.clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("無法取得 Pod 列表: %w", err)
+	}
+
+	// 依節點彙總執行中 Pod 的請求量，已結束的 Pod 不佔用排程容量
+	requestsByNode := make(map[string]corev1.ResourceList)
+	podCounts := make(map[string]int64)
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Spec.NodeName == "" || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		if _, ok := requestsByNode[pod.Spec.NodeName]; !ok {
+			requestsByNode[pod.Spec.NodeName] = corev1.ResourceList{}
+		}
+		requests, _ := podRequestsAndLimits(pod)
+		addResourceList(requestsByNode[pod.Spec.NodeName], requests)
+		podCounts[pod.Spec.NodeName]++
+	}
+
+	report := &NodeCommitmentReport{
+		NodePool: nodePool,
+		Nodes:    []NodeCommitment{},
+		Pools:    []NodePoolCommitment{},
+	}
+
+	pools := make(map[string]*poolTally)
+	cluster := &poolTally{}
+	for _, node := range nodes.Items {
+		requests := requestsByNode[node.Name]
+		tallies := [3]commitmentTally{
+			{allocatable: node.Status.Allocatable.Cpu().MilliValue(), requested: requests.Cpu().MilliValue()},
+			{allocatable: node.Status.Allocatable.Memory().Value(), requested: requests.Memory().Value()},
+			{allocatable: node.Status.Allocatable.Pods().Value(), requested: podCounts[node.Name]},
+		}
+		binding := computeStranded(&tallies)
+
+		poolName := node.Labels[NodePoolLabel]
+		report.Nodes = append(report.Nodes, NodeCommitment{
+			NodeName:        node.Name,
+			NodePool:        poolName,
+			Unschedulable:   node.Spec.Unschedulable,
+			CPU:             cpuCommitment(tallies[0]),
+			Memory:          memoryCommitment(tallies[1]),
+			Pods:            countCommitment(tallies[2]),
+			BindingResource: binding,
+		})
+
+		pool, ok := pools[poolName]
+		if !ok {
+			pool = &poolTally{}
+			pools[poolName] = pool
+		}
+		pool.add(tallies)
+		cluster.add(tallies)
+	}
+
+	for name, pool := range pools {
+		report.Pools = append(report.Pools, pool.commitment(name))
+	}
+	report.Total = cluster.commitment("")
+
+	// 閒置容量多的節點排在前面，方便找出形狀不合適的節點
+	sort.Slice(report.Nodes, func(i, j int) bool {
+		si := report.Nodes[i].CPU.StrandedPct + report.Nodes[i].Memory.StrandedPct
+		sj := report.Nodes[j].CPU.StrandedPct + report.Nodes[j].Memory.StrandedPct
+		if si != sj {
+			return si > sj
+		}
+		return report.Nodes[i].NodeName < report.Nodes[j].NodeName
+	})
+	sort.Slice(report.Pools, func(i, j int) bool {
+		return report.Pools[i].Name < report.Pools[j].Name
+	})
+
+	return report, nil
+}
+
+// computeStranded 計算各資源的閒置容量並回傳最先耗盡的資源 (cpu、memory、pods)
+// 假設新的 Pod 與現有 Pod 的資源比例相同，當承諾比例最高的資源用盡時，其他資源剩下的量即無法被排程使用
+func computeStranded(tallies *[3]commitmentTally) string {
+	names := [3]string{"cpu", "memory", "pods"}
+
+	binding := -1
+	maxRatio := 0.0
+	ratios := [3]float64{}
+	for i, tally := range tallies {
+		if tally.allocatable == 0 {
+			continue
+		}
+		ratios[i] = float64(tally.requested) / float64(tally.allocatable)
+		if ratios[i] > maxRatio {
+			maxRatio = ratios[i]
+			binding = i
+		}
+	}
+	if binding < 0 {
+		return ""
+	}
+
+	for i := range tallies {
+		if i == binding || tallies[i].requested >= tallies[i].allocatable {
+			continue
+		}
+		tallies[i].stranded = int64(float64(tallies[i].allocatable) * (1 - ratios[i]/maxRatio))
+	}
+
+	return names[binding]
+}
+
+// add 加入一個節點的資源統計
+func (p *poolTally) add(tallies [3]commitmentTally) {
+	p.nodes++
+	for i := range tallies {
+		p.tallies[i].allocatable += tallies[i].allocatable
+		p.tallies[i].requested += tallies[i].requested
+		p.tallies[i].stranded += tallies[i].stranded
+	}
+}
+
+// commitment 將節點池統計轉換為回應格式，閒置容量為各節點閒置量的總和
+func (p *poolTally) commitment(name string) NodePoolCommitment {
+	return NodePoolCommitment{
+		Name:      name,
+		NodeCount: p.nodes,
+		CPU:       cpuCommitment(p.tallies[0]),
+		Memory:    memoryCommitment(p.tallies[1]),
+		Pods:      countCommitment(p.tallies[2]),
+	}
+}
+
+// cpuCommitment 將 CPU 統計 (millicores) 轉換為回應格式
+func cpuCommitment(tally commitmentTally) ResourceCommitment {
+	return newResourceCommitment(tally, func(value int64) string {
+		return resource.NewMilliQuantity(value, resource.DecimalSI).String()
+	})
+}
+
+// memoryCommitment 將記憶體統計 (bytes) 轉換為回應格式
+func memoryCommitment(tally commitmentTally) ResourceCommitment {
+	return newResourceCommitment(tally, func(value int64) string {
+		return fmt.Sprintf("%dMi", value/(1024*1024))
+	})
+}
+
+// countCommitment 將 Pod 數量統計轉換為回應格式
+func countCommitment(tally commitmentTally) ResourceCommitment {
+	return newResourceCommitment(tally, func(value int64) string {
+		return fmt.Sprintf("%d", value)
+	})
+}
+
+// newResourceCommitment 依格式化函式建立資源承諾比例
+func newResourceCommitment(tally commitmentTally, format func(int64) string) ResourceCommitment {
+	free := tally.allocatable - tally.requested
+	if free < 0 {
+		free = 0
+	}
+	return ResourceCommitment{
+		Allocatable:  format(tally.allocatable),
+		Requested:    format(tally.requested),
+		Free:         format(free),
+		Stranded:     format(tally.stranded),
+		CommittedPct: percentage(tally.requested, tally.allocatable),
+		StrandedPct:  percentage(tally.stranded, tally.allocatable),
+	}
+}
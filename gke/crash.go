@@ -0,0 +1,222 @@
+package gke
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// crashExcerptLines 日誌摘要最多保留的行數
+	crashExcerptLines = 10
+
+	// crashExcerptLineLength 日誌摘要每行最多保留的字元數
+	crashExcerptLineLength = 300
+
+	// crashRecentEvents 保留最近的 Warning 事件數量
+	crashRecentEvents = 5
+)
+
+// 重啟原因，依容器最近一次終止的狀態與 Pod 事件判斷
+const (
+	RestartCauseOOMKilled      = "oom-killed"      // 超過記憶體限制被終止
+	RestartCauseLivenessProbe  = "liveness-probe"  // liveness probe 失敗被 kubelet 終止
+	RestartCauseNodePreemption = "node-preemption" // 節點被搶占 (Spot/Preemptible) 或重新啟動
+	RestartCauseErrorExit      = "error-exit"      // 應用程式以非 0 的 exit code 結束
+	RestartCauseCompleted      = "completed"       // 主程式正常結束 (exit code 0) 後被重新啟動
+	RestartCauseUnknown        = "unknown"         // 沒有終止狀態可判斷
+)
+
+// preemptionEventReasons 代表節點被搶占或關機的 Pod 事件原因
+var preemptionEventReasons = map[string]bool{
+	"Preempting":   true,
+	"NodeShutdown": true,
+}
+
+// crashLogPattern 日誌中代表錯誤的關鍵字
+var crashLogPattern = regexp.MustCompile(`(?i)\b(error|exception|panic|fatal|failed|traceback|caused by|segmentation fault|out of memory)\b`)
+
+// GetCrashDiagnosis 取得重啟過的容器上一次執行的最後 tailLines 行日誌與最近的 Warning 事件，並整理為摘要
+// 摘要優先保留含有錯誤關鍵字的行，沒有時保留最後幾行
+func (s *Service) GetCrashDiagnosis(ctx context.Context, podName, namespace string, tailLines int) (*CrashDiagnosis, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if namespace == "" {
+		namespace = s.defaultNamespace
+	}
+
+	pod, err := s.clientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("無法取得 Pod 資訊: %w", err)
+	}
+
+	diagnosis := &CrashDiagnosis{
+		PodName:    pod.Name,
+		Namespace:  pod.Namespace,
+		Containers: []ContainerCrash{},
+		Events:     []Event{},
+
+		RestartCauses: make(map[string]int32),
+	}
+
+	statuses := append([]corev1.ContainerStatus(nil), pod.Status.ContainerStatuses...)
+	sort.SliceStable(statuses, func(i, j int) bool {
+		return statuses[i].RestartCount > statuses[j].RestartCount
+	})
+	for _, status := range statuses {
+		if status.RestartCount == 0 {
+			continue
+		}
+		crash := ContainerCrash{
+			Container:    status.Name,
+			RestartCount: status.RestartCount,
+		}
+		if terminated := status.LastTerminationState.Terminated; terminated != nil {
+			crash.Reason = terminated.Reason
+			crash.ExitCode = terminated.ExitCode
+			crash.Message = terminated.Message
+			if !terminated.FinishedAt.IsZero() {
+				finishedAt := terminated.FinishedAt.Time
+				crash.TerminatedAt = &finishedAt
+			}
+		}
+
+		logs, err := s.previousContainerLogs(ctx, pod.Name, pod.Namespace, status.Name, tailLines)
+		if err != nil {
+			crash.LogError = err.Error()
+		} else {
+			crash.Excerpt = logExcerpt(logs)
+		}
+		diagnosis.Containers = append(diagnosis.Containers, crash)
+	}
+
+	events, err := s.getPodEvents(ctx, pod.Name, pod.Namespace)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Printf("警告: 無法取得 Pod 事件: %v", err)
+		}
+	}
+	for _, event := range events {
+		if event.Type == corev1.EventTypeWarning {
+			diagnosis.Events = append(diagnosis.Events, event)
+		}
+	}
+
+	// 容器狀態只保留最近一次終止，各容器的重啟次數以最近一次的原因估計
+	for i := range diagnosis.Containers {
+		crash := &diagnosis.Containers[i]
+		crash.Cause = classifyRestart(*crash, events)
+		diagnosis.RestartCauses[crash.Cause] += crash.RestartCount
+	}
+	sort.SliceStable(diagnosis.Events, func(i, j int) bool {
+		return lastOccurrence(diagnosis.Events[i]).After(lastOccurrence(diagnosis.Events[j]))
+	})
+	if len(diagnosis.Events) > crashRecentEvents {
+		diagnosis.Events = diagnosis.Events[:crashRecentEvents]
+	}
+
+	return diagnosis, nil
+}
+
+// previousContainerLogs 取得容器上一次執行 (重啟前) 的最後 tailLines 行日誌
+func (s *Service) previousContainerLogs(ctx context.Context, podName, namespace, container string, tailLines int) (string, error) {
+	tail := int64(tailLines)
+	stream, err := s.clientset.CoreV1().Pods(namespace).GetLogs(podName, &corev1.PodLogOptions{
+		Container: container,
+		Previous:  true,
+		TailLines: &tail,
+	}).Stream(ctx)
+	if err != nil {
+		return "", fmt.Errorf("無法取得容器 %s 上一次執行的日誌: %w", container, err)
+	}
+	defer stream.Close()
+
+	content, err := io.ReadAll(io.LimitReader(stream, 1024*1024))
+	if err != nil {
+		return "", fmt.Errorf("無法讀取容器 %s 的日誌: %w", container, err)
+	}
+	return string(content), nil
+}
+
+// logExcerpt 從日誌中挑出含有錯誤關鍵字的最後幾行，沒有時取最後幾行，過長的行會被截斷
+func logExcerpt(logs string) []string {
+	var lines []string
+	for _, line := range strings.Split(logs, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	var matched []string
+	for _, line := range lines {
+		if crashLogPattern.MatchString(line) {
+			matched = append(matched, line)
+		}
+	}
+	if len(matched) == 0 {
+		matched = lines
+	}
+	if len(matched) > crashExcerptLines {
+		matched = matched[len(matched)-crashExcerptLines:]
+	}
+
+	excerpt := make([]string, 0, len(matched))
+	for _, line := range matched {
+		if runes := []rune(line); len(runes) > crashExcerptLineLength {
+			line = string(runes[:crashExcerptLineLength]) + "..."
+		}
+		excerpt = append(excerpt, line)
+	}
+	return excerpt
+}
+
+// classifyRestart 依容器最近一次終止的原因、exit code 與 Pod 事件判斷重啟原因
+// 節點重新啟動後 kubelet 無法得知容器的結束狀態，終止原因為 Unknown；liveness probe 失敗時 kubelet 以 SIGKILL/SIGTERM 終止容器 (exit code 137/143)，並留下 Killing 或 Unhealthy 事件
+func classifyRestart(crash ContainerCrash, events []Event) string {
+	if crash.Reason == "OOMKilled" {
+		return RestartCauseOOMKilled
+	}
+	if crash.Reason == "Unknown" {
+		return RestartCauseNodePreemption
+	}
+	for _, event := range events {
+		if preemptionEventReasons[event.Reason] {
+			return RestartCauseNodePreemption
+		}
+	}
+	if crash.ExitCode == 137 || crash.ExitCode == 143 {
+		for _, event := range events {
+			message := strings.ToLower(event.Message)
+			if !strings.Contains(message, "liveness probe") {
+				continue
+			}
+			// Killing 事件會註明容器名稱，Unhealthy 事件則沒有
+			if event.Reason == "Unhealthy" || strings.Contains(event.Message, crash.Container) {
+				return RestartCauseLivenessProbe
+			}
+		}
+	}
+	switch {
+	case crash.Reason == "" && crash.ExitCode == 0:
+		return RestartCauseUnknown
+	case crash.ExitCode == 0:
+		return RestartCauseCompleted
+	}
+	return RestartCauseErrorExit
+}
+
+// lastOccurrence 事件最後一次發生的時間
+func lastOccurrence(event Event) time.Time {
+	if event.LastSeen != nil {
+		return *event.LastSeen
+	}
+	return event.Timestamp
+}
@@ -0,0 +1,457 @@
+package gke
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+// DescribePod 取得 Pod 的綜合描述，包含規格、狀態、條件、事件、容忍設定與卷
+func (s *Service) DescribePod(ctx context.Context, podName, namespace string) (*PodDescription, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if namespace == "" {
+		namespace = s.defaultNamespace
+	}
+
+	pod, err := s.clientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("無法取得 Pod 資訊: %w", err)
+	}
+
+	events, err := s.getPodEvents(ctx, podName, namespace)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Printf("警告: 無法取得 Pod 事件: %v", err)
+		}
+	}
+	if events == nil {
+		events = []Event{}
+	}
+
+	description := &PodDescription{
+		Pod:               s.convertPod(pod),
+		ServiceAccount:    pod.Spec.ServiceAccountName,
+		PriorityClassName: pod.Spec.PriorityClassName,
+		RestartPolicy:     string(pod.Spec.RestartPolicy),
+		NodeSelector:      pod.Spec.NodeSelector,
+		Events:            events,
+	}
+
+	if pod.Status.StartTime != nil {
+		startTime := pod.Status.StartTime.Time
+		description.StartTime = &startTime
+	}
+
+	for _, toleration := range pod.Spec.Tolerations {
+		description.Tolerations = append(description.Tolerations, Toleration{
+			Key:               toleration.Key,
+			Operator:          string(toleration.Operator),
+			Value:             toleration.Value,
+			Effect:            string(toleration.Effect),
+			TolerationSeconds: toleration.TolerationSeconds,
+		})
+	}
+
+	for _, container := range pod.Spec.Containers {
+		description.ContainerSpecs = append(description.ContainerSpecs, s.convertContainerSpec(container))
+	}
+
+	description.Volumes = s.buildPodVolumes(pod)
+
+	return description, nil
+}
+
+// convertContainerSpec 轉換容器規格為內部 ContainerSpec 結構
+func (s *Service) convertContainerSpec(container corev1.Container) ContainerSpec {
+	spec := ContainerSpec{
+		Name:     container.Name,
+		Image:    container.Image,
+		Command:  container.Command,
+		Args:     container.Args,
+		Requests: resourceListToMap(container.Resources.Requests),
+		Limits:   resourceListToMap(container.Resources.Limits),
+	}
+
+	for _, port := range container.Ports {
+		spec.Ports = append(spec.Ports, ContainerPort{
+			Name:          port.Name,
+			ContainerPort: port.ContainerPort,
+			Protocol:      string(port.Protocol),
+		})
+	}
+
+	for _, mount := range container.VolumeMounts {
+		spec.VolumeMounts = append(spec.VolumeMounts, VolumeMount{
+			Name:      mount.Name,
+			MountPath: mount.MountPath,
+			SubPath:   mount.SubPath,
+			ReadOnly:  mount.ReadOnly,
+		})
+	}
+
+	return spec
+}
+
+// resourceListToMap 將資源列表轉為字串對應表，空列表回傳 nil
+func resourceListToMap(resources corev1.ResourceList) map[string]string {
+	if len(resources) == 0 {
+		return nil
+	}
+
+	result := make(map[string]string, len(resources))
+	for name, quantity := range resources {
+		result[string(name)] = quantity.String()
+	}
+	return result
+}
+
+// DescribeNode 取得節點的綜合描述，包含狀態條件、污點、容量、執行中的 Pod 與事件
+func (s *Service) DescribeNode(ctx context.Context, nodeName string) (*NodeDescription, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	node, err := s.clientset.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("無法取得節點資訊: %w", err)
+	}
+
+	description := &NodeDescription{
+		Name:          node.Name,
+		Labels:        node.Labels,
+		Annotations:   node.Annotations,
+		CreatedAt:     node.CreationTimestamp.Time,
+		Zone:          nodeZoneFromLabels(node.Labels),
+		InstanceType:  node.Labels[corev1.LabelInstanceTypeStable],
+		Unschedulable: node.Spec.Unschedulable,
+		Capacity:      resourceListToMap(node.Status.Capacity),
+		Allocatable:   resourceListToMap(node.Status.Allocatable),
+		SystemInfo: NodeSystemInfo{
+			KubeletVersion:          node.Status.NodeInfo.KubeletVersion,
+			OSImage:                 node.Status.NodeInfo.OSImage,
+			KernelVersion:           node.Status.NodeInfo.KernelVersion,
+			ContainerRuntimeVersion: node.Status.NodeInfo.ContainerRuntimeVersion,
+			Architecture:            node.Status.NodeInfo.Architecture,
+		},
+		Pods: []NodePodResource{},
+	}
+
+	for _, taint := range node.Spec.Taints {
+		description.Taints = append(description.Taints, Taint{
+			Key:    taint.Key,
+			Value:  taint.Value,
+			Effect: string(taint.Effect),
+		})
+	}
+
+	for _, condition := range node.Status.Conditions {
+		description.Conditions = append(description.Conditions, NodeCondition{
+			Type:               string(condition.Type),
+			Status:             string(condition.Status),
+			Reason:             condition.Reason,
+			Message:            condition.Message,
+			LastTransitionTime: condition.LastTransitionTime.Time,
+		})
+	}
+
+	for _, address := range node.Status.Addresses {
+		description.Addresses = append(description.Addresses, NodeAddress{
+			Type:    string(address.Type),
+			Address: address.Address,
+		})
+	}
+
+	// 與 kubectl describe node 相同，只列出未終止的 Pod
+	pods, err := s.clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{
+		FieldSelector: fields.AndSelectors(
+			fields.OneTermEqualSelector("spec.nodeName", nodeName),
+			fields.OneTermNotEqualSelector("status.phase", string(corev1.PodSucceeded)),
+			fields.OneTermNotEqualSelector("status.phase", string(corev1.PodFailed)),
+		).String(),
+	})
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Printf("警告: 無法取得節點 %s 上的 Pod: %v", nodeName, err)
+		}
+	} else {
+		description.Pods, description.AllocatedResources = summarizeNodeAllocation(pods.Items, node.Status.Allocatable)
+		description.PodCount = len(description.Pods)
+	}
+
+	events, err := s.getNodeEvents(ctx, nodeName, time.Time{}, "")
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Printf("警告: 無法取得節點事件: %v", err)
+		}
+	}
+	if events == nil {
+		events = []Event{}
+	}
+	description.Events = events
+
+	return description, nil
+}
+
+// getNodeEvents 取得單一節點的事件
+func (s *Service) getNodeEvents(ctx context.Context, nodeName string, since time.Time, eventType string) ([]Event, error) {
+	events, err := s.listNodeEvents(ctx, nodeName, since, eventType)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Event
+	for _, event := range events {
+		result = append(result, convertEvent(event))
+	}
+
+	return result, nil
+}
+
+// listNodeEvents 列出節點事件 (節點事件不屬於特定命名空間，因此查詢所有命名空間)
+// nodeName 為空時取得所有節點的事件，since 為零值時不過濾時間，eventType 為空時不過濾類型
+func (s *Service) listNodeEvents(ctx context.Context, nodeName string, since time.Time, eventType string) ([]corev1.Event, error) {
+	selectors := []fields.Selector{fields.OneTermEqualSelector("involvedObject.kind", "Node")}
+	if nodeName != "" {
+		selectors = append(selectors, fields.OneTermEqualSelector("involvedObject.name", nodeName))
+	}
+	if eventType != "" {
+		selectors = append(selectors, fields.OneTermEqualSelector("type", eventType))
+	}
+
+	events, err := s.clientset.CoreV1().Events("").List(ctx, metav1.ListOptions{
+		FieldSelector: fields.AndSelectors(selectors...).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result []corev1.Event
+	for _, event := range events.Items {
+		if !since.IsZero() && eventTimestamp(event).Before(since) {
+			continue
+		}
+		result = append(result, event)
+	}
+
+	return result, nil
+}
+
+// GetNodeEvents 取得節點事件並依節點分組，預設只包含 Warning 事件 (例如 SystemOOM、NodeNotReady、磁碟壓力)
+// eventType 為 "all" 時包含所有類型
+func (s *Service) GetNodeEvents(ctx context.Context, nodeName string, since time.Time, eventType string) (*NodeEvents, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	switch strings.ToLower(eventType) {
+	case "":
+		eventType = corev1.EventTypeWarning
+	case "all":
+		eventType = ""
+	case "warning":
+		eventType = corev1.EventTypeWarning
+	case "normal":
+		eventType = corev1.EventTypeNormal
+	default:
+		return nil, fmt.Errorf("不支援的事件類型 %q，可用值: Warning, Normal, all", eventType)
+	}
+
+	events, err := s.listNodeEvents(ctx, nodeName, since, eventType)
+	if err != nil {
+		return nil, fmt.Errorf("無法取得節點事件: %w", err)
+	}
+
+	result := &NodeEvents{
+		Since: since,
+		Nodes: []NodeEventGroup{},
+	}
+
+	groups := make(map[string]*NodeEventGroup)
+	for _, event := range events {
+		name := event.InvolvedObject.Name
+		group, ok := groups[name]
+		if !ok {
+			group = &NodeEventGroup{NodeName: name, ReasonCounts: make(map[string]int32)}
+			groups[name] = group
+		}
+
+		converted := convertEvent(event)
+		count := converted.Count
+		if count == 0 {
+			count = 1
+		}
+		group.ReasonCounts[converted.Reason] += count
+		group.Events = append(group.Events, converted)
+		result.TotalEvents++
+	}
+
+	for _, group := range groups {
+		// 依最後發生時間由新到舊排序
+		sort.Slice(group.Events, func(i, j int) bool {
+			return lastSeen(group.Events[i]).After(lastSeen(group.Events[j]))
+		})
+		result.Nodes = append(result.Nodes, *group)
+	}
+
+	// 事件數量多的節點排在前面
+	sort.Slice(result.Nodes, func(i, j int) bool {
+		if len(result.Nodes[i].Events) != len(result.Nodes[j].Events) {
+			return len(result.Nodes[i].Events) > len(result.Nodes[j].Events)
+		}
+		return result.Nodes[i].NodeName < result.Nodes[j].NodeName
+	})
+
+	return result, nil
+}
+
+// lastSeen 取得事件的最後發生時間
+func lastSeen(event Event) time.Time {
+	if event.LastSeen != nil {
+		return *event.LastSeen
+	}
+	return event.Timestamp
+}
+
+// summarizeNodeAllocation 計算節點上各 Pod 的請求量/限制量及節點總已分配資源
+func summarizeNodeAllocation(pods []corev1.Pod, allocatable corev1.ResourceList) ([]NodePodResource, AllocatedResources) {
+	allocatableCPU := allocatable.Cpu().MilliValue()
+	allocatableMemory := allocatable.Memory().Value()
+
+	totalRequests := corev1.ResourceList{}
+	totalLimits := corev1.ResourceList{}
+	podResources := []NodePodResource{}
+
+	for i := range pods {
+		requests, limits := podRequestsAndLimits(&pods[i])
+		addResourceList(totalRequests, requests)
+		addResourceList(totalLimits, limits)
+
+		podResources = append(podResources, NodePodResource{
+			Namespace:         pods[i].Namespace,
+			Name:              pods[i].Name,
+			CPURequests:       requests.Cpu().String(),
+			CPULimits:         limits.Cpu().String(),
+			MemoryRequests:    requests.Memory().String(),
+			MemoryLimits:      limits.Memory().String(),
+			CPURequestsPct:    percentage(requests.Cpu().MilliValue(), allocatableCPU),
+			CPULimitsPct:      percentage(limits.Cpu().MilliValue(), allocatableCPU),
+			MemoryRequestsPct: percentage(requests.Memory().Value(), allocatableMemory),
+			MemoryLimitsPct:   percentage(limits.Memory().Value(), allocatableMemory),
+		})
+	}
+
+	sort.Slice(podResources, func(i, j int) bool {
+		if podResources[i].Namespace != podResources[j].Namespace {
+			return podResources[i].Namespace < podResources[j].Namespace
+		}
+		return podResources[i].Name < podResources[j].Name
+	})
+
+	allocated := AllocatedResources{
+		CPURequests:       totalRequests.Cpu().String(),
+		CPULimits:         totalLimits.Cpu().String(),
+		MemoryRequests:    totalRequests.Memory().String(),
+		MemoryLimits:      totalLimits.Memory().String(),
+		CPURequestsPct:    percentage(totalRequests.Cpu().MilliValue(), allocatableCPU),
+		CPULimitsPct:      percentage(totalLimits.Cpu().MilliValue(), allocatableCPU),
+		MemoryRequestsPct: percentage(totalRequests.Memory().Value(), allocatableMemory),
+		MemoryLimitsPct:   percentage(totalLimits.Memory().Value(), allocatableMemory),
+	}
+
+	// 擴充資源：節點可分配或 Pod 有請求的非 CPU/記憶體資源 (pods 數量除外)
+	names := make(map[corev1.ResourceName]bool)
+	for _, list := range []corev1.ResourceList{allocatable, totalRequests, totalLimits} {
+		for name := range list {
+			if name != corev1.ResourceCPU && name != corev1.ResourceMemory && name != corev1.ResourcePods {
+				names[name] = true
+			}
+		}
+	}
+	for name := range names {
+		extended := ExtendedAllocation{Name: string(name), Requests: "0", Limits: "0", Allocatable: "0"}
+		if quantity, ok := totalRequests[name]; ok {
+			extended.Requests = quantity.String()
+		}
+		if quantity, ok := totalLimits[name]; ok {
+			extended.Limits = quantity.String()
+		}
+		if quantity, ok := allocatable[name]; ok {
+			extended.Allocatable = quantity.String()
+		}
+		// 節點沒有配置且沒有 Pod 使用的擴充資源 (例如 hugepages 為 0) 不列出
+		if extended.Requests == "0" && extended.Limits == "0" && extended.Allocatable == "0" {
+			continue
+		}
+		allocated.Extended = append(allocated.Extended, extended)
+	}
+	sort.Slice(allocated.Extended, func(i, j int) bool {
+		return allocated.Extended[i].Name < allocated.Extended[j].Name
+	})
+
+	return podResources, allocated
+}
+
+// podRequestsAndLimits 計算 Pod 的有效請求量與限制量
+// 與排程器相同：一般容器加總後與各初始化容器取最大值，再加上 Pod overhead
+func podRequestsAndLimits(pod *corev1.Pod) (corev1.ResourceList, corev1.ResourceList) {
+	requests := corev1.ResourceList{}
+	limits := corev1.ResourceList{}
+
+	for _, container := range pod.Spec.Containers {
+		addResourceList(requests, container.Resources.Requests)
+		addResourceList(limits, container.Resources.Limits)
+	}
+
+	for _, container := range pod.Spec.InitContainers {
+		maxResourceList(requests, container.Resources.Requests)
+		maxResourceList(limits, container.Resources.Limits)
+	}
+
+	if pod.Spec.Overhead != nil {
+		addResourceList(requests, pod.Spec.Overhead)
+		// 只有在已設定限制量時才將 overhead 加到限制量
+		for name, quantity := range pod.Spec.Overhead {
+			if value, ok := limits[name]; ok {
+				value.Add(quantity)
+				limits[name] = value
+			}
+		}
+	}
+
+	return requests, limits
+}
+
+// addResourceList 將 add 中的資源加到 target
+func addResourceList(target, add corev1.ResourceList) {
+	for name, quantity := range add {
+		if value, ok := target[name]; ok {
+			value.Add(quantity)
+			target[name] = value
+		} else {
+			target[name] = quantity.DeepCopy()
+		}
+	}
+}
+
+// maxResourceList 將 target 中的資源更新為與 other 比較後的較大值
+func maxResourceList(target, other corev1.ResourceList) {
+	for name, quantity := range other {
+		if value, ok := target[name]; !ok || quantity.Cmp(value) > 0 {
+			target[name] = quantity.DeepCopy()
+		}
+	}
+}
+
+// percentage 計算百分比，分母為 0 時回傳 0
+func percentage(value, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(value) / float64(total) * 100
+}
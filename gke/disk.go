@@ -0,0 +1,151 @@
+package gke
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// diskStatsQueryTimeout 查詢 kubelet stats summary 的逾時時間
+const diskStatsQueryTimeout = 10 * time.Second
+
+// kubeletSummary kubelet /stats/summary 回應中磁碟使用量相關的欄位
+type kubeletSummary struct {
+	Pods []struct {
+		PodRef struct {
+			Name      string `json:"name"`
+			Namespace string `json:"namespace"`
+		} `json:"podRef"`
+		EphemeralStorage *kubeletFsStats      `json:"ephemeral-storage"`
+		Volumes          []kubeletVolumeStats `json:"volume"`
+	} `json:"pods"`
+}
+
+// kubeletFsStats 檔案系統的使用量 (bytes)
+type kubeletFsStats struct {
+	AvailableBytes *int64 `json:"availableBytes"`
+	CapacityBytes  *int64 `json:"capacityBytes"`
+	UsedBytes      *int64 `json:"usedBytes"`
+}
+
+// kubeletVolumeStats 卷的使用量
+type kubeletVolumeStats struct {
+	kubeletFsStats
+	Name   string `json:"name"`
+	PVCRef *struct {
+		Name      string `json:"name"`
+		Namespace string `json:"namespace"`
+	} `json:"pvcRef"`
+}
+
+// getKubeletSummary 透過 API 伺服器的節點 proxy 讀取 kubelet stats summary
+// 呼叫端需持有 s.mu 讀鎖
+func (s *Service) getKubeletSummary(ctx context.Context, nodeName string) (*kubeletSummary, error) {
+	ctx, cancel := context.WithTimeout(ctx, diskStatsQueryTimeout)
+	defer cancel()
+
+	data, err := s.clientset.CoreV1().RESTClient().Get().
+		AbsPath("/api/v1/nodes", nodeName, "proxy/stats/summary").
+		DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("無法取得節點 %s 的 kubelet stats: %w", nodeName, err)
+	}
+
+	var summary kubeletSummary
+	if err := json.Unmarshal(data, &summary); err != nil {
+		return nil, fmt.Errorf("解析 kubelet stats 失敗: %w", err)
+	}
+	return &summary, nil
+}
+
+// getPodDiskUsage 從 kubelet stats summary 取得 Pod 的 ephemeral storage 與各卷的實際使用量
+// ephemeral storage 的總量在所有容器都設定 ephemeral-storage limit 時為 limit 總和 (超過時 Pod 會被驅逐)，否則為使用量加上節點磁碟剩餘空間；
+// 卷只有 PVC 與設定 sizeLimit 的 emptyDir 有獨立的總量，其他卷與節點共用磁碟，不提供總量
+// 呼叫端需持有 s.mu 讀鎖
+func (s *Service) getPodDiskUsage(ctx context.Context, pod *corev1.Pod) (DiskUsage, error) {
+	if pod.Spec.NodeName == "" {
+		return DiskUsage{}, fmt.Errorf("Pod 尚未排程到節點")
+	}
+
+	summary, err := s.getKubeletSummary(ctx, pod.Spec.NodeName)
+	if err != nil {
+		return DiskUsage{}, err
+	}
+
+	for _, stats := range summary.Pods {
+		if stats.PodRef.Name != pod.Name || stats.PodRef.Namespace != pod.Namespace {
+			continue
+		}
+
+		usage := DiskUsage{Volumes: make(map[string]Volume)}
+		if ephemeral := stats.EphemeralStorage; ephemeral != nil && ephemeral.UsedBytes != nil {
+			used := *ephemeral.UsedBytes
+			total := ephemeralStorageLimit(pod)
+			if total == 0 && ephemeral.AvailableBytes != nil {
+				total = used + *ephemeral.AvailableBytes
+			}
+			usage.Used = formatDiskBytes(used)
+			if total > 0 {
+				usage.Total = formatDiskBytes(total)
+				usage.Available = formatDiskBytes(max(total-used, 0))
+			}
+		}
+
+		specs := make(map[string]*corev1.Volume, len(pod.Spec.Volumes))
+		for i := range pod.Spec.Volumes {
+			specs[pod.Spec.Volumes[i].Name] = &pod.Spec.Volumes[i]
+		}
+		for _, volumeStats := range stats.Volumes {
+			spec, ok := specs[volumeStats.Name]
+			if !ok || volumeStats.UsedBytes == nil {
+				continue
+			}
+
+			volume := Volume{
+				Name: volumeStats.Name,
+				Type: s.getVolumeType(spec),
+				Used: formatDiskBytes(*volumeStats.UsedBytes),
+			}
+			if mounts := getVolumeMounts(pod, volumeStats.Name); len(mounts) > 0 {
+				volume.MountPath = mounts[0].MountPath
+			}
+
+			var total int64
+			switch {
+			case spec.PersistentVolumeClaim != nil && volumeStats.CapacityBytes != nil:
+				total = *volumeStats.CapacityBytes
+			case spec.EmptyDir != nil && spec.EmptyDir.SizeLimit != nil:
+				total = spec.EmptyDir.SizeLimit.Value()
+			}
+			if total > 0 {
+				volume.Total = formatDiskBytes(total)
+				volume.Available = formatDiskBytes(max(total-*volumeStats.UsedBytes, 0))
+			}
+			usage.Volumes[volume.Name] = volume
+		}
+		return usage, nil
+	}
+
+	return DiskUsage{}, fmt.Errorf("kubelet stats 中沒有 Pod %s 的資料", pod.Name)
+}
+
+// ephemeralStorageLimit 所有容器都設定 ephemeral-storage limit 時回傳總和，否則回傳 0
+func ephemeralStorageLimit(pod *corev1.Pod) int64 {
+	var total int64
+	for _, container := range pod.Spec.Containers {
+		limit, ok := container.Resources.Limits[corev1.ResourceEphemeralStorage]
+		if !ok {
+			return 0
+		}
+		total += limit.Value()
+	}
+	return total
+}
+
+// formatDiskBytes 將 bytes 轉換為 Mi 表示
+func formatDiskBytes(bytes int64) string {
+	return fmt.Sprintf("%dMi", bytes/(1024*1024))
+}
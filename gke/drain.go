@@ -0,0 +1,301 @@
+package gke
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// mirrorPodAnnotation 靜態 Pod 在 API 伺服器上的鏡像 Pod 註解，無法透過 API 驅逐
+	mirrorPodAnnotation = "kubernetes.io/config.mirror"
+
+	// defaultDrainTimeout 節點排空的預設逾時時間
+	defaultDrainTimeout = 5 * time.Minute
+
+	// evictionRetryInterval 驅逐被 PodDisruptionBudget 拒絕時的重試間隔
+	evictionRetryInterval = 5 * time.Second
+
+	// podDeletionPollInterval 等待 Pod 移除的輪詢間隔
+	podDeletionPollInterval = 2 * time.Second
+)
+
+// DrainNode 排空節點：先停止排程，再透過 Eviction API 驅逐節點上的 Pod (遵守 PodDisruptionBudget)
+// 存在阻擋條件 (沒有控制器的 Pod、使用 emptyDir 的 Pod 等) 時不驅逐任何 Pod，節點維持停止排程
+// 驅逐進度以 ReportProgress 回報
+func (s *Service) DrainNode(ctx context.Context, options DrainOptions) (*NodeDrain, error) {
+	if err := s.ensureWriteEnabled(); err != nil {
+		return nil, err
+	}
+	if options.Confirm != options.NodeName {
+		return nil, fmt.Errorf("確認參數不符，請將 confirm 設為要排空的節點名稱 %q", options.NodeName)
+	}
+	if options.Timeout <= 0 {
+		options.Timeout = defaultDrainTimeout
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(ctx, options.Timeout)
+	defer cancel()
+
+	result := &NodeDrain{
+		NodeName: options.NodeName,
+		Evicted:  []string{},
+	}
+
+	if _, err := s.updateNodeUnschedulable(ctx, options.NodeName, true); err != nil {
+		return nil, err
+	}
+	result.Cordoned = true
+	s.auditWrite("排空節點 %s", options.NodeName)
+
+	pods, err := s.clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", options.NodeName).String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("無法取得節點上的 Pod: %w", err)
+	}
+
+	// 決定哪些 Pod 需要驅逐
+	var targets []corev1.Pod
+	for _, pod := range pods.Items {
+		key := pod.Namespace + "/" + pod.Name
+		skip, block := drainFilter(&pod, options)
+		switch {
+		case block != "":
+			result.Blocked = append(result.Blocked, DrainPodNote{Pod: key, Reason: block})
+		case skip != "":
+			result.Skipped = append(result.Skipped, DrainPodNote{Pod: key, Reason: skip})
+		default:
+			targets = append(targets, pod)
+		}
+	}
+
+	if len(result.Blocked) > 0 {
+		result.Duration = time.Since(start).Round(time.Second).String()
+		result.Message = fmt.Sprintf("節點已停止排程，但有 %d 個 Pod 阻擋排空，未驅逐任何 Pod", len(result.Blocked))
+		return result, nil
+	}
+
+	total := len(targets)
+	ReportProgress(ctx, 0, total, fmt.Sprintf("節點 %s 已停止排程，開始驅逐 %d 個 Pod", options.NodeName, total))
+
+	// 與 kubectl drain 相同，同時驅逐所有 Pod，各自等待 PDB 允許與 Pod 移除
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	done := 0
+	for _, pod := range targets {
+		wg.Add(1)
+		go func(pod corev1.Pod) {
+			defer wg.Done()
+			key := pod.Namespace + "/" + pod.Name
+
+			err := s.evictAndWait(ctx, &pod, options.GracePeriodSeconds)
+
+			mu.Lock()
+			defer mu.Unlock()
+			done++
+			if err != nil {
+				result.Failed = append(result.Failed, DrainPodNote{Pod: key, Reason: err.Error()})
+				ReportProgress(ctx, done, total, fmt.Sprintf("驅逐 %s 失敗: %v", key, err))
+				return
+			}
+			result.Evicted = append(result.Evicted, key)
+			ReportProgress(ctx, done, total, fmt.Sprintf("已驅逐 %s", key))
+		}(pod)
+	}
+	wg.Wait()
+
+	sort.Strings(result.Evicted)
+	sort.Slice(result.Failed, func(i, j int) bool { return result.Failed[i].Pod < result.Failed[j].Pod })
+
+	result.Completed = len(result.Failed) == 0
+	result.Duration = time.Since(start).Round(time.Second).String()
+	if result.Completed {
+		result.Message = fmt.Sprintf("節點 %s 已排空，共驅逐 %d 個 Pod", options.NodeName, len(result.Evicted))
+	} else {
+		result.Message = fmt.Sprintf("節點 %s 排空未完成，%d 個 Pod 驅逐失敗", options.NodeName, len(result.Failed))
+	}
+
+	return result, nil
+}
+
+// drainFilter 判斷 Pod 在排空時應略過或阻擋排空，回傳空字串表示需要驅逐
+func drainFilter(pod *corev1.Pod, options DrainOptions) (skip string, block string) {
+	if _, ok := pod.Annotations[mirrorPodAnnotation]; ok {
+		return "靜態 Pod (mirror pod)", ""
+	}
+	if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		// 已終止的 Pod 不佔用資源，驅逐即可直接刪除
+		return "", ""
+	}
+
+	controller := metav1.GetControllerOf(pod)
+	if controller != nil && controller.Kind == "DaemonSet" {
+		if options.IgnoreDaemonSets {
+			return "DaemonSet 管理的 Pod", ""
+		}
+		return "", "DaemonSet 管理的 Pod (使用 ignoreDaemonSets 略過)"
+	}
+	if controller == nil && !options.Force {
+		return "", "沒有控制器管理的 Pod，驅逐後不會重建 (使用 force 強制驅逐)"
+	}
+
+	for _, volume := range pod.Spec.Volumes {
+		if volume.EmptyDir != nil && !options.DeleteEmptyDirData {
+			return "", fmt.Sprintf("使用 emptyDir 卷 %s，驅逐後資料會遺失 (使用 deleteEmptyDirData 允許)", volume.Name)
+		}
+	}
+
+	return "", ""
+}
+
+// evictAndWait 驅逐 Pod 並等待其移除，PodDisruptionBudget 拒絕時持續重試直到逾時
+func (s *Service) evictAndWait(ctx context.Context, pod *corev1.Pod, gracePeriodSeconds *int64) error {
+	for {
+		err := s.evictPod(ctx, pod.Namespace, pod.Name, gracePeriodSeconds)
+		if err == nil || apierrors.IsNotFound(err) {
+			break
+		}
+		if !apierrors.IsTooManyRequests(err) {
+			return err
+		}
+
+		// 429 代表驅逐會違反 PodDisruptionBudget，等待其他 Pod 就緒後重試
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("等待 PodDisruptionBudget 允許驅逐逾時: %v", err)
+		case <-time.After(evictionRetryInterval):
+		}
+	}
+
+	return s.waitForPodDeletion(ctx, pod.Namespace, pod.Name, pod.UID)
+}
+
+// evictPod 透過 policy/v1 Eviction 子資源驅逐 Pod
+func (s *Service) evictPod(ctx context.Context, namespace, podName string, gracePeriodSeconds *int64) error {
+	eviction := &policyv1.Eviction{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      podName,
+			Namespace: namespace,
+		},
+	}
+	if gracePeriodSeconds != nil {
+		eviction.DeleteOptions = &metav1.DeleteOptions{GracePeriodSeconds: gracePeriodSeconds}
+	}
+
+	return s.clientset.PolicyV1().Evictions(namespace).Evict(ctx, eviction)
+}
+
+// waitForPodDeletion 等待 Pod 移除 (找不到 Pod 或 UID 已改變)
+func (s *Service) waitForPodDeletion(ctx context.Context, namespace, podName string, uid types.UID) error {
+	for {
+		pod, err := s.clientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) || (err == nil && pod.UID != uid) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("等待 Pod 移除逾時")
+		case <-time.After(podDeletionPollInterval):
+		}
+	}
+}
+
+// EvictPod 透過 Eviction API 驅逐單一 Pod，與直接刪除不同，驅逐會遵守 PodDisruptionBudget
+func (s *Service) EvictPod(ctx context.Context, podName, namespace string, gracePeriodSeconds *int64) (*PodEviction, error) {
+	if err := s.ensureWriteEnabled(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if namespace == "" {
+		namespace = s.defaultNamespace
+	}
+
+	pod, err := s.clientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("無法取得 Pod 資訊: %w", err)
+	}
+
+	result := &PodEviction{
+		PodName:   podName,
+		Namespace: namespace,
+	}
+
+	pdbs, err := s.getPodDisruptionBudgets(ctx, pod)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Printf("警告: 無法取得 PodDisruptionBudget: %v", err)
+		}
+	}
+	result.PodDisruptionBudgets = pdbs
+
+	err = s.evictPod(ctx, namespace, podName, gracePeriodSeconds)
+	switch {
+	case err == nil:
+		result.Evicted = true
+		result.Message = fmt.Sprintf("已驅逐 Pod %s", podName)
+		s.auditWrite("驅逐 Pod %s/%s", namespace, podName)
+	case apierrors.IsTooManyRequests(err):
+		result.BlockedByPDB = true
+		result.Message = fmt.Sprintf("驅逐會違反 PodDisruptionBudget，已被拒絕: %v", err)
+	default:
+		return nil, fmt.Errorf("無法驅逐 Pod: %w", err)
+	}
+
+	return result, nil
+}
+
+// getPodDisruptionBudgets 取得選取指定 Pod 的 PodDisruptionBudget
+func (s *Service) getPodDisruptionBudgets(ctx context.Context, pod *corev1.Pod) ([]PDBStatus, error) {
+	pdbs, err := s.clientset.PolicyV1().PodDisruptionBudgets(pod.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var result []PDBStatus
+	for _, pdb := range pdbs.Items {
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil || selector.Empty() || !selector.Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+		result = append(result, convertPDBStatus(&pdb))
+	}
+
+	return result, nil
+}
+
+// convertPDBStatus 轉換 PodDisruptionBudget 的設定與狀態
+func convertPDBStatus(pdb *policyv1.PodDisruptionBudget) PDBStatus {
+	status := PDBStatus{
+		Name:               pdb.Name,
+		CurrentHealthy:     pdb.Status.CurrentHealthy,
+		DesiredHealthy:     pdb.Status.DesiredHealthy,
+		ExpectedPods:       pdb.Status.ExpectedPods,
+		DisruptionsAllowed: pdb.Status.DisruptionsAllowed,
+	}
+	if pdb.Spec.MinAvailable != nil {
+		status.MinAvailable = pdb.Spec.MinAvailable.String()
+	}
+	if pdb.Spec.MaxUnavailable != nil {
+		status.MaxUnavailable = pdb.Spec.MaxUnavailable.String()
+	}
+	return status
+}
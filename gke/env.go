@@ -0,0 +1,173 @@
+package gke
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetPodEnv 取得 Pod 各容器的環境變數，ConfigMap 來源的值會解析為實際值，Secret 來源的值一律遮蔽
+func (s *Service) GetPodEnv(ctx context.Context, podName, namespace string) (*PodEnv, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if namespace == "" {
+		namespace = s.defaultNamespace
+	}
+
+	pod, err := s.clientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("無法取得 Pod 資訊: %w", err)
+	}
+
+	result := &PodEnv{
+		PodName:   pod.Name,
+		Namespace: pod.Namespace,
+	}
+
+	// ConfigMap 快取，同一 Pod 中常有多個容器引用相同的 ConfigMap
+	resolver := &configMapResolver{service: s, namespace: namespace, cache: make(map[string]*corev1.ConfigMap), errs: make(map[string]error)}
+
+	for _, container := range pod.Spec.InitContainers {
+		env := s.resolveContainerEnv(ctx, container, resolver)
+		env.Init = true
+		result.Containers = append(result.Containers, env)
+	}
+	for _, container := range pod.Spec.Containers {
+		result.Containers = append(result.Containers, s.resolveContainerEnv(ctx, container, resolver))
+	}
+
+	return result, nil
+}
+
+// configMapResolver 查詢並快取 ConfigMap
+type configMapResolver struct {
+	service   *Service
+	namespace string
+	cache     map[string]*corev1.ConfigMap
+	errs      map[string]error
+}
+
+// get 取得 ConfigMap，查詢失敗時回傳錯誤 (結果會被快取)
+func (r *configMapResolver) get(ctx context.Context, name string) (*corev1.ConfigMap, error) {
+	if configMap, ok := r.cache[name]; ok {
+		return configMap, nil
+	}
+	if err, ok := r.errs[name]; ok {
+		return nil, err
+	}
+
+	configMap, err := r.service.clientset.CoreV1().ConfigMaps(r.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		r.errs[name] = err
+		return nil, err
+	}
+	r.cache[name] = configMap
+	return configMap, nil
+}
+
+// resolveContainerEnv 解析單一容器的環境變數 (envFrom 先於 env，與 kubelet 的覆寫順序一致)
+func (s *Service) resolveContainerEnv(ctx context.Context, container corev1.Container, resolver *configMapResolver) ContainerEnv {
+	result := ContainerEnv{
+		Container: container.Name,
+		Variables: []EnvVar{},
+	}
+
+	for _, envFrom := range container.EnvFrom {
+		switch {
+		case envFrom.ConfigMapRef != nil:
+			name := envFrom.ConfigMapRef.Name
+			configMap, err := resolver.get(ctx, name)
+			if err != nil {
+				if !isOptional(envFrom.ConfigMapRef.Optional) {
+					result.Warnings = append(result.Warnings, describeConfigMapError(name, err))
+				}
+				continue
+			}
+
+			keys := make([]string, 0, len(configMap.Data))
+			for key := range configMap.Data {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				result.Variables = append(result.Variables, EnvVar{
+					Name:   envFrom.Prefix + key,
+					Value:  configMap.Data[key],
+					Source: "configMap",
+					From:   name + "/" + key,
+				})
+			}
+		case envFrom.SecretRef != nil:
+			// 不讀取 Secret 內容，僅標示整個 Secret 被匯入
+			name := envFrom.SecretRef.Name
+			result.Variables = append(result.Variables, EnvVar{
+				Name:   envFrom.Prefix + "*",
+				Value:  fmt.Sprintf("<secret:%s/*>", name),
+				Source: "secret",
+				From:   name,
+			})
+		}
+	}
+
+	for _, env := range container.Env {
+		variable := EnvVar{
+			Name:   env.Name,
+			Value:  env.Value,
+			Source: "value",
+		}
+
+		if env.ValueFrom != nil {
+			switch {
+			case env.ValueFrom.SecretKeyRef != nil:
+				ref := env.ValueFrom.SecretKeyRef
+				variable.Source = "secret"
+				variable.From = ref.Name + "/" + ref.Key
+				variable.Value = fmt.Sprintf("<secret:%s/%s>", ref.Name, ref.Key)
+			case env.ValueFrom.ConfigMapKeyRef != nil:
+				ref := env.ValueFrom.ConfigMapKeyRef
+				variable.Source = "configMap"
+				variable.From = ref.Name + "/" + ref.Key
+				configMap, err := resolver.get(ctx, ref.Name)
+				if err != nil {
+					if !isOptional(ref.Optional) {
+						result.Warnings = append(result.Warnings, fmt.Sprintf("環境變數 %s: %s", env.Name, describeConfigMapError(ref.Name, err)))
+					}
+				} else if value, ok := configMap.Data[ref.Key]; ok {
+					variable.Value = value
+				} else if !isOptional(ref.Optional) {
+					result.Warnings = append(result.Warnings, fmt.Sprintf("環境變數 %s: ConfigMap %s 中沒有鍵 %s", env.Name, ref.Name, ref.Key))
+				}
+			case env.ValueFrom.FieldRef != nil:
+				variable.Source = "fieldRef"
+				variable.From = env.ValueFrom.FieldRef.FieldPath
+				variable.Value = fmt.Sprintf("<fieldRef:%s>", env.ValueFrom.FieldRef.FieldPath)
+			case env.ValueFrom.ResourceFieldRef != nil:
+				variable.Source = "resourceFieldRef"
+				variable.From = env.ValueFrom.ResourceFieldRef.Resource
+				variable.Value = fmt.Sprintf("<resourceFieldRef:%s>", env.ValueFrom.ResourceFieldRef.Resource)
+			}
+		}
+
+		result.Variables = append(result.Variables, variable)
+	}
+
+	return result
+}
+
+// isOptional 判斷引用是否標記為可選
+func isOptional(optional *bool) bool {
+	return optional != nil && *optional
+}
+
+// describeConfigMapError 將 ConfigMap 查詢錯誤轉為可讀的警告訊息
+func describeConfigMapError(name string, err error) string {
+	if apierrors.IsNotFound(err) {
+		return fmt.Sprintf("ConfigMap %s 不存在", name)
+	}
+	return fmt.Sprintf("無法讀取 ConfigMap %s: %v", name, err)
+}
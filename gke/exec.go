@@ -0,0 +1,333 @@
+package gke
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
+)
+
+const (
+	// maxExecOutputBytes exec 輸出的緩衝上限，避免讀取大型檔案時耗盡記憶體
+	maxExecOutputBytes = 1024 * 1024
+
+	// maxExecResultBytes exec_in_pod 回傳給客戶端的輸出上限
+	maxExecResultBytes = 64 * 1024
+
+	// defaultExecTimeout exec_in_pod 的預設逾時時間
+	defaultExecTimeout = 30 * time.Second
+)
+
+// limitedBuffer 只保留前 limit 個位元組的輸出緩衝
+type limitedBuffer struct {
+	buf       bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+// Write 寫入資料，超過上限的部分會被捨棄 (仍回報寫入成功，避免中斷串流)
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	remaining := b.limit - b.buf.Len()
+	if remaining <= 0 {
+		b.truncated = b.truncated || len(p) > 0
+		return len(p), nil
+	}
+	if len(p) > remaining {
+		b.buf.Write(p[:remaining])
+		b.truncated = true
+		return len(p), nil
+	}
+	return b.buf.Write(p)
+}
+
+// execInContainer 在容器內執行指令並回傳標準輸出與標準錯誤
+func (s *Service) execInContainer(ctx context.Context, namespace, podName, container string, command []string) (string, string, error) {
+	if s.restConfig == nil {
+		return "", "", fmt.Errorf("缺少 Kubernetes 連線配置，無法執行指令")
+	}
+
+	req := s.clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(podName).
+		Namespace(namespace).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: container,
+			Command:   command,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(s.restConfig, "POST", req.URL())
+	if err != nil {
+		return "", "", fmt.Errorf("無法建立 exec 連線: %w", err)
+	}
+
+	stdout := &limitedBuffer{limit: maxExecOutputBytes}
+	stderr := &limitedBuffer{limit: maxExecOutputBytes}
+	err = executor.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdout: stdout,
+		Stderr: stderr,
+	})
+
+	return stdout.buf.String(), stderr.buf.String(), err
+}
+
+// resolveContainerName 取得要操作的容器名稱，未指定時使用第一個容器
+func (s *Service) resolveContainerName(pod *corev1.Pod, container string) (string, error) {
+	if container == "" {
+		if len(pod.Spec.Containers) == 0 {
+			return "", fmt.Errorf("Pod %s 沒有容器", pod.Name)
+		}
+		return pod.Spec.Containers[0].Name, nil
+	}
+
+	for _, spec := range pod.Spec.Containers {
+		if spec.Name == container {
+			return container, nil
+		}
+	}
+	return "", fmt.Errorf("Pod %s 中找不到容器 %s", pod.Name, container)
+}
+
+// isValidConnectivityTarget 目標是否為 IP 位址 (IPv6 可加上中括號) 或 DNS-1123 主機名稱
+// 主機名稱不可能以 - 開頭，避免目標被 nc、curl 等工具當成選項
+func isValidConnectivityTarget(host string) bool {
+	if ip := net.ParseIP(strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")); ip != nil {
+		return true
+	}
+	return len(validation.IsDNS1123Subdomain(strings.ToLower(host))) == 0
+}
+
+// shellQuote 以單引號包裹字串供 sh 使用
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'"'"'`) + "'"
+}
+
+// CheckPodConnectivity 在 Pod 內執行 TCP/HTTP 連線檢查
+func (s *Service) CheckPodConnectivity(ctx context.Context, podName, namespace, container, protocol, host string, port int, path string, timeoutSeconds int) (*ConnectivityCheck, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if namespace == "" {
+		namespace = s.defaultNamespace
+	}
+	if timeoutSeconds <= 0 {
+		timeoutSeconds = 5
+	}
+
+	protocol = strings.ToUpper(protocol)
+	if protocol == "" {
+		protocol = "TCP"
+		if path != "" {
+			protocol = "HTTP"
+		}
+	}
+	if protocol != "TCP" && protocol != "HTTP" {
+		return nil, fmt.Errorf("不支援的協定 %q，可用值: TCP, HTTP", protocol)
+	}
+	if !isValidConnectivityTarget(host) {
+		return nil, fmt.Errorf("無效的目標主機 %q，必須是 IP 位址或 DNS 主機名稱", host)
+	}
+	if port <= 0 || port > 65535 {
+		return nil, fmt.Errorf("無效的連接埠 %d", port)
+	}
+	if path == "" {
+		path = "/"
+	}
+	if !strings.HasPrefix(path, "/") {
+		return nil, fmt.Errorf("HTTP 路徑必須以 / 開頭")
+	}
+
+	pod, err := s.clientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("無法取得 Pod 資訊: %w", err)
+	}
+
+	container, err = s.resolveContainerName(pod, container)
+	if err != nil {
+		return nil, err
+	}
+
+	hostPort := host + ":" + strconv.Itoa(port)
+	result := &ConnectivityCheck{
+		PodName:   podName,
+		Namespace: namespace,
+		Container: container,
+		Protocol:  protocol,
+		Target:    hostPort,
+	}
+
+	timeout := strconv.Itoa(timeoutSeconds)
+	var script string
+	if protocol == "HTTP" {
+		url := shellQuote("http://" + hostPort + path)
+		result.Target = "http://" + hostPort + path
+		script = "if command -v curl >/dev/null 2>&1; then " +
+			"curl -s -o /dev/null -m " + timeout + " -w 'tool=curl status=%{http_code} time=%{time_total}' -- " + url + "; " +
+			"elif command -v wget >/dev/null 2>&1; then " +
+			"wget -q -O /dev/null -T " + timeout + " -- " + url + " && echo 'tool=wget status=ok' || { echo 'tool=wget status=fail'; exit 1; }; " +
+			"else echo 'tool=none'; exit 127; fi"
+	} else {
+		quotedHost := shellQuote(host)
+		script = "if command -v nc >/dev/null 2>&1; then " +
+			"nc -z -w " + timeout + " -- " + quotedHost + " " + strconv.Itoa(port) + " && echo 'tool=nc status=open' || { echo 'tool=nc status=closed'; exit 1; }; " +
+			"elif command -v bash >/dev/null 2>&1; then " +
+			"timeout " + timeout + " bash -c " + shellQuote("</dev/tcp/"+host+"/"+strconv.Itoa(port)) + " && echo 'tool=bash status=open' || { echo 'tool=bash status=closed'; exit 1; }; " +
+			"else echo 'tool=none'; exit 127; fi"
+	}
+
+	execCtx, cancel := context.WithTimeout(ctx, time.Duration(timeoutSeconds+10)*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	stdout, stderr, execErr := s.execInContainer(execCtx, namespace, podName, container, []string{"sh", "-c", script})
+	elapsed := time.Since(start)
+
+	fields := parseKeyValueOutput(stdout)
+	result.Tool = fields["tool"]
+	result.Output = strings.TrimSpace(stdout + stderr)
+	result.LatencyMs = float64(elapsed.Microseconds()) / 1000
+	result.LatencySource = "exec-roundtrip"
+
+	if result.Tool == "none" {
+		result.Error = "容器內沒有可用的檢查工具 (curl, wget, nc, bash)"
+		return result, nil
+	}
+
+	switch result.Tool {
+	case "curl":
+		statusCode, _ := strconv.Atoi(fields["status"])
+		result.StatusCode = statusCode
+		result.Reachable = statusCode > 0
+		if seconds, err := strconv.ParseFloat(fields["time"], 64); err == nil {
+			result.LatencyMs = seconds * 1000
+			result.LatencySource = "curl"
+		}
+	default:
+		result.Reachable = execErr == nil && (fields["status"] == "ok" || fields["status"] == "open")
+	}
+
+	if execErr != nil && !result.Reachable {
+		result.Error = execErr.Error()
+	}
+
+	return result, nil
+}
+
+// parseKeyValueOutput 解析 "key=value key2=value2" 格式的輸出
+func parseKeyValueOutput(output string) map[string]string {
+	result := make(map[string]string)
+	for _, field := range strings.Fields(output) {
+		if key, value, ok := strings.Cut(field, "="); ok {
+			result[key] = value
+		}
+	}
+	return result
+}
+
+// ExecInPod 在容器內執行允許清單中的指令 (不經過 shell，參數不會被展開)
+func (s *Service) ExecInPod(ctx context.Context, podName, namespace, container string, command []string, timeout time.Duration) (*ExecResult, error) {
+	if len(s.config.ExecAllowlist) == 0 {
+		return nil, fmt.Errorf("exec_in_pod 未啟用，請在 config.json 的 exec.allowedCommands 中設定允許的指令")
+	}
+	if len(command) == 0 || command[0] == "" {
+		return nil, fmt.Errorf("必須提供要執行的指令")
+	}
+
+	program := command[0]
+	if !isCommandAllowed(program, s.config.ExecAllowlist) {
+		return nil, fmt.Errorf("指令 %q 不在允許清單中，允許的指令: %s", program, strings.Join(s.config.ExecAllowlist, ", "))
+	}
+	if timeout <= 0 {
+		timeout = defaultExecTimeout
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if namespace == "" {
+		namespace = s.defaultNamespace
+	}
+
+	pod, err := s.clientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("無法取得 Pod 資訊: %w", err)
+	}
+
+	container, err = s.resolveContainerName(pod, container)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.logger != nil {
+		s.logger.Printf("執行指令: %s/%s [%s] %s", namespace, podName, container, strings.Join(command, " "))
+	}
+
+	execCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	stdout, stderr, execErr := s.execInContainer(execCtx, namespace, podName, container, command)
+
+	result := &ExecResult{
+		PodName:    podName,
+		Namespace:  namespace,
+		Container:  container,
+		Command:    command,
+		DurationMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	result.Stdout, result.Truncated = truncateOutput(stdout, maxExecResultBytes)
+	var stderrTruncated bool
+	result.Stderr, stderrTruncated = truncateOutput(stderr, maxExecResultBytes)
+	result.Truncated = result.Truncated || stderrTruncated
+
+	if execErr != nil {
+		var exitErr utilexec.ExitError
+		if errors.As(execErr, &exitErr) {
+			result.ExitCode = exitErr.ExitStatus()
+		} else if execCtx.Err() != nil {
+			result.ExitCode = -1
+			result.Error = fmt.Sprintf("指令執行逾時 (%s)", timeout)
+		} else {
+			return nil, fmt.Errorf("執行指令失敗: %w", execErr)
+		}
+	}
+
+	return result, nil
+}
+
+// isCommandAllowed 判斷指令是否在允許清單中
+// 程式名稱必須與清單完全相同：清單中的 "ls" 只允許 "ls"，不允許 /tmp/x/ls 這類工作負載寫入的同名程式
+// 包含 "/" 的程式必須是清單中列出的絕對路徑
+func isCommandAllowed(program string, allowlist []string) bool {
+	if strings.Contains(program, "/") && !path.IsAbs(program) {
+		return false
+	}
+	for _, allowed := range allowlist {
+		if program == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// truncateOutput 截斷過長的輸出
+func truncateOutput(output string, limit int) (string, bool) {
+	if len(output) <= limit {
+		return output, false
+	}
+	return output[:limit], true
+}
@@ -0,0 +1,63 @@
+package gke
+
+import "testing"
+
+func TestIsCommandAllowed(t *testing.T) {
+	allowlist := []string{"ls", "cat", "/usr/bin/env"}
+
+	tests := []struct {
+		name    string
+		command []string
+		want    bool
+	}{
+		// 完全相同
+		{name: "exact match", command: []string{"ls"}, want: true},
+		{name: "exact absolute path", command: []string{"/usr/bin/env"}, want: true},
+		{name: "not in allowlist", command: []string{"rm"}, want: false},
+		{name: "empty program", command: []string{""}, want: false},
+
+		// 前綴與後綴
+		{name: "allowed name as prefix", command: []string{"lsof"}, want: false},
+		{name: "allowed name as suffix", command: []string{"els"}, want: false},
+		{name: "different case", command: []string{"LS"}, want: false},
+		{name: "leading space", command: []string{" ls"}, want: false},
+		{name: "trailing newline", command: []string{"ls\n"}, want: false},
+
+		// 路徑
+		{name: "same base name in writable directory", command: []string{"/tmp/x/ls"}, want: false},
+		{name: "relative path", command: []string{"./ls"}, want: false},
+		{name: "relative path to allowed absolute", command: []string{"usr/bin/env"}, want: false},
+		{name: "absolute path of allowed base name", command: []string{"/bin/ls"}, want: false},
+		{name: "traversal to allowed path", command: []string{"/tmp/../usr/bin/env"}, want: false},
+		{name: "double slash", command: []string{"/usr/bin//env"}, want: false},
+
+		// 額外參數：指令不經過 shell 執行，參數不影響允許清單的判斷
+		{name: "extra arguments", command: []string{"ls", "-la", "/etc"}, want: true},
+		{name: "metacharacters in arguments", command: []string{"cat", "/etc/hosts;", "rm", "-rf", "/"}, want: true},
+		{name: "arguments inside program", command: []string{"ls -la"}, want: false},
+
+		// shell 特殊字元
+		{name: "command separator", command: []string{"ls;id"}, want: false},
+		{name: "and operator", command: []string{"ls&&id"}, want: false},
+		{name: "pipe", command: []string{"ls|sh"}, want: false},
+		{name: "command substitution", command: []string{"$(ls)"}, want: false},
+		{name: "backticks", command: []string{"`ls`"}, want: false},
+		{name: "glob", command: []string{"l*"}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isCommandAllowed(tt.command[0], allowlist); got != tt.want {
+				t.Errorf("isCommandAllowed(%q) = %v, want %v", tt.command[0], got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsCommandAllowedEmptyAllowlist(t *testing.T) {
+	for _, program := range []string{"ls", "/bin/sh", ""} {
+		if isCommandAllowed(program, nil) {
+			t.Errorf("isCommandAllowed(%q, nil) = true, want false", program)
+		}
+	}
+}
@@ -0,0 +1,325 @@
+package gke
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+const (
+	// defaultForecastWindow 擬合趨勢使用的歷史時間範圍
+	defaultForecastWindow = 7 * 24 * time.Hour
+
+	// defaultForecastHorizon 預測的時間長度
+	defaultForecastHorizon = 30 * 24 * time.Hour
+
+	// minForecastPoints 擬合趨勢最少需要的資料點數
+	minForecastPoints = 6
+)
+
+// ForecastUsage 以線性迴歸擬合命名空間或節點的使用量趨勢，預測何時會超過目前的請求量與可分配容量
+// 命名空間的容量為整個叢集的可分配資源；節點使用量需要啟用背景指標收集
+func (s *Service) ForecastUsage(ctx context.Context, scope, name, source string, window, horizon time.Duration) (*UsageForecast, error) {
+	if window <= 0 {
+		window = defaultForecastWindow
+	}
+	if horizon <= 0 {
+		horizon = defaultForecastHorizon
+	}
+
+	scope = strings.ToLower(scope)
+	if scope == "" {
+		scope = "namespace"
+	}
+
+	var series []MetricSample
+	var requests, allocatable corev1.ResourceList
+	var err error
+	switch scope {
+	case "namespace":
+		if name == "" {
+			name = s.defaultNamespace
+		}
+		series, source, requests, allocatable, err = s.namespaceUsageSeries(ctx, name, source, window)
+	case "node":
+		if name == "" {
+			return nil, fmt.Errorf("必須提供節點名稱")
+		}
+		series, requests, allocatable, err = s.nodeUsageSeries(ctx, name, window)
+		source = UsageSourceCollector
+	default:
+		return nil, fmt.Errorf("不支援的範圍 %q，可用值: namespace, node", scope)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(series) < minForecastPoints {
+		return nil, fmt.Errorf("資料點不足 (%d 個，至少需要 %d 個)，請延長時間範圍或等待更多樣本", len(series), minForecastPoints)
+	}
+
+	forecast := &UsageForecast{
+		Scope:   scope,
+		Name:    name,
+		Source:  source,
+		Window:  window.String(),
+		Horizon: horizon.String(),
+		Points:  len(series),
+	}
+
+	now := time.Now()
+	cpu := make([]SeriesPoint, len(series))
+	memory := make([]SeriesPoint, len(series))
+	for i, sample := range series {
+		cpu[i] = SeriesPoint{Timestamp: sample.Timestamp, Value: float64(sample.CPUMillicores)}
+		memory[i] = SeriesPoint{Timestamp: sample.Timestamp, Value: float64(sample.MemoryBytes)}
+	}
+
+	forecast.CPU = forecastResource(cpu, float64(requests.Cpu().MilliValue()), float64(allocatable.Cpu().MilliValue()), now, horizon)
+	forecast.Memory = forecastResource(memory, float64(requests.Memory().Value()), float64(allocatable.Memory().Value()), now, horizon)
+	forecast.CPU.Message = describeForecast("CPU", forecast.CPU, func(v float64) string { return fmt.Sprintf("%.0fm", v) })
+	forecast.Memory.Message = describeForecast("記憶體", forecast.Memory, func(v float64) string { return fmt.Sprintf("%.0fMi", v/(1024*1024)) })
+
+	return forecast, nil
+}
+
+// namespaceUsageSeries 取得命名空間內目前執行中 Pod 的使用量總和時間序列，以及命名空間的請求量與叢集可分配容量
+func (s *Service) namespaceUsageSeries(ctx context.Context, namespace, source string, window time.Duration) ([]MetricSample, string, corev1.ResourceList, corev1.ResourceList, error) {
+	s.mu.RLock()
+	podList, err := s.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("status.phase", string(corev1.PodRunning)).String(),
+	})
+	if err != nil {
+		s.mu.RUnlock()
+		return nil, "", nil, nil, fmt.Errorf("無法取得 Pod 列表: %w", err)
+	}
+	nodes, err := s.clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	s.mu.RUnlock()
+	if err != nil {
+		return nil, "", nil, nil, fmt.Errorf("無法取得節點列表: %w", err)
+	}
+	if len(podList.Items) == 0 {
+		return nil, "", nil, nil, fmt.Errorf("命名空間 %s 中沒有執行中的 Pod", namespace)
+	}
+
+	requests := corev1.ResourceList{}
+	pods := make([]string, 0, len(podList.Items))
+	for i := range podList.Items {
+		podRequests, _ := podRequestsAndLimits(&podList.Items[i])
+		addResourceList(requests, podRequests)
+		pods = append(pods, podList.Items[i].Name)
+	}
+
+	allocatable := corev1.ResourceList{}
+	for _, node := range nodes.Items {
+		addResourceList(allocatable, node.Status.Allocatable)
+	}
+
+	samples, source, err := s.podUsageSamples(ctx, source, namespace, pods, window)
+	if err != nil {
+		return nil, "", nil, nil, err
+	}
+
+	return sumByBucket(samples, forecastBucket(window)), source, requests, allocatable, nil
+}
+
+// nodeUsageSeries 取得節點的使用量時間序列 (來自背景指標收集器)，以及節點上 Pod 的請求量與可分配容量
+func (s *Service) nodeUsageSeries(ctx context.Context, nodeName string, window time.Duration) ([]MetricSample, corev1.ResourceList, corev1.ResourceList, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.collector == nil {
+		return nil, nil, nil, fmt.Errorf("節點使用量預測需要啟用背景指標收集")
+	}
+
+	node, err := s.clientset.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("無法取得節點資訊: %w", err)
+	}
+	pods, err := s.clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", nodeName).String(),
+	})
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("無法取得節點上的 Pod: %w", err)
+	}
+
+	requests := corev1.ResourceList{}
+	for i := range pods.Items {
+		if pods.Items[i].Status.Phase == corev1.PodSucceeded || pods.Items[i].Status.Phase == corev1.PodFailed {
+			continue
+		}
+		podRequests, _ := podRequestsAndLimits(&pods.Items[i])
+		addResourceList(requests, podRequests)
+	}
+
+	samples, ok := s.collector.history("node", nodeName, time.Now().Add(-window))
+	if !ok {
+		return nil, nil, nil, fmt.Errorf("找不到節點 %s 的指標紀錄", nodeName)
+	}
+
+	series := sumByBucket(map[string][]MetricSample{nodeName: samples}, forecastBucket(window))
+	return series, requests, node.Status.Allocatable, nil
+}
+
+// forecastBucket 依時間範圍決定彙總間隔，避免資料點過多或過少
+func forecastBucket(window time.Duration) time.Duration {
+	if window >= 48*time.Hour {
+		return time.Hour
+	}
+	return 5 * time.Minute
+}
+
+// sumByBucket 將各時間序列依彙總間隔取平均後再加總，得到整體使用量的時間序列
+func sumByBucket(samples map[string][]MetricSample, bucket time.Duration) []MetricSample {
+	totals := make(map[time.Time]*MetricSample)
+	for _, series := range samples {
+		type accumulator struct {
+			cpu, memory int64
+			count       int64
+		}
+		buckets := make(map[time.Time]*accumulator)
+		for _, sample := range series {
+			key := sample.Timestamp.Truncate(bucket)
+			acc, ok := buckets[key]
+			if !ok {
+				acc = &accumulator{}
+				buckets[key] = acc
+			}
+			acc.cpu += sample.CPUMillicores
+			acc.memory += sample.MemoryBytes
+			acc.count++
+		}
+
+		for key, acc := range buckets {
+			total, ok := totals[key]
+			if !ok {
+				total = &MetricSample{Timestamp: key}
+				totals[key] = total
+			}
+			total.CPUMillicores += acc.cpu / acc.count
+			total.MemoryBytes += acc.memory / acc.count
+		}
+	}
+
+	result := sortedSamples(totals)
+	// 最後一個區間通常尚未收集完整，捨棄以免拉低趨勢
+	if len(result) > 1 {
+		result = result[:len(result)-1]
+	}
+	return result
+}
+
+// linearTrend 最小平方法擬合的線性趨勢，x 軸為距離第一個資料點的小時數
+type linearTrend struct {
+	origin       time.Time
+	slopePerHour float64
+	intercept    float64
+	rSquared     float64 // 決定係數 R²，代表線性趨勢對資料的解釋程度
+}
+
+// at 取得趨勢線在指定時間的值
+func (t linearTrend) at(timestamp time.Time) float64 {
+	return t.intercept + t.slopePerHour*timestamp.Sub(t.origin).Hours()
+}
+
+// fitLinearTrend 以最小平方法擬合線性趨勢，points 需依時間排序且不可為空
+func fitLinearTrend(points []SeriesPoint) linearTrend {
+	origin := points[0].Timestamp
+	var sumX, sumY, sumXY, sumXX float64
+	n := float64(len(points))
+	for _, point := range points {
+		x := point.Timestamp.Sub(origin).Hours()
+		sumX += x
+		sumY += point.Value
+		sumXY += x * point.Value
+		sumXX += x * x
+	}
+
+	slope := 0.0
+	if denominator := n*sumXX - sumX*sumX; denominator != 0 {
+		slope = (n*sumXY - sumX*sumY) / denominator
+	}
+	intercept := (sumY - slope*sumX) / n
+
+	mean := sumY / n
+	var ssTotal, ssResidual float64
+	for _, point := range points {
+		x := point.Timestamp.Sub(origin).Hours()
+		predicted := intercept + slope*x
+		ssTotal += (point.Value - mean) * (point.Value - mean)
+		ssResidual += (point.Value - predicted) * (point.Value - predicted)
+	}
+	rSquared := 0.0
+	if ssTotal > 0 {
+		rSquared = 1 - ssResidual/ssTotal
+	}
+
+	return linearTrend{origin: origin, slopePerHour: slope, intercept: intercept, rSquared: rSquared}
+}
+
+// forecastResource 以最小平方法擬合線性趨勢並預測超過請求量與可分配容量的時間
+func forecastResource(points []SeriesPoint, requests, allocatable float64, now time.Time, horizon time.Duration) ResourceForecast {
+	sort.Slice(points, func(i, j int) bool {
+		return points[i].Timestamp.Before(points[j].Timestamp)
+	})
+
+	trend := fitLinearTrend(points)
+	current := trend.at(now)
+	projected := trend.at(now.Add(horizon))
+
+	forecast := ResourceForecast{
+		Current:     math.Max(current, 0),
+		SlopePerDay: trend.slopePerHour * 24,
+		RSquared:    math.Round(trend.rSquared*1000) / 1000,
+		Projected:   math.Max(projected, 0),
+		Requests:    requests,
+		Allocatable: allocatable,
+	}
+	forecast.RequestsExceededAt = projectCrossing(current, trend.slopePerHour, requests, now, horizon)
+	forecast.AllocatableExceededAt = projectCrossing(current, trend.slopePerHour, allocatable, now, horizon)
+
+	return forecast
+}
+
+// projectCrossing 計算趨勢線在預測期間內超過門檻的時間，已超過時回傳目前時間，不會超過時回傳 nil
+func projectCrossing(current, slopePerHour, threshold float64, now time.Time, horizon time.Duration) *time.Time {
+	if threshold <= 0 {
+		return nil
+	}
+	if current >= threshold {
+		return &now
+	}
+	if slopePerHour <= 0 {
+		return nil
+	}
+
+	hours := (threshold - current) / slopePerHour
+	if hours > horizon.Hours() {
+		return nil
+	}
+	crossing := now.Add(time.Duration(hours * float64(time.Hour)))
+	return &crossing
+}
+
+// describeForecast 產生預測結果的說明
+func describeForecast(resource string, forecast ResourceForecast, format func(float64) string) string {
+	trend := fmt.Sprintf("%s 目前約 %s，每日變化 %s", resource, format(forecast.Current), format(forecast.SlopePerDay))
+	if forecast.RSquared < 0.3 {
+		trend += fmt.Sprintf("（R²=%.2f，趨勢不明顯，預測僅供參考）", forecast.RSquared)
+	}
+
+	switch {
+	case forecast.AllocatableExceededAt != nil:
+		return trend + fmt.Sprintf("；預計於 %s 超過可分配容量 %s", forecast.AllocatableExceededAt.Format("2006-01-02"), format(forecast.Allocatable))
+	case forecast.RequestsExceededAt != nil:
+		return trend + fmt.Sprintf("；預計於 %s 超過目前的請求量 %s", forecast.RequestsExceededAt.Format("2006-01-02"), format(forecast.Requests))
+	default:
+		return trend + "；預測期間內不會超過請求量或可分配容量"
+	}
+}
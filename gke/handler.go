@@ -5,6 +5,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 )
@@ -19,6 +22,158 @@ func NewHandler(service *Service) *Handler {
 	}
 }
 
+// parseTimeArgument 解析時間參數，支援 RFC3339 絕對時間或相對時間 (例如 "30m", "1h", "7d" 表示多久以前)
+func parseTimeArgument(value string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	duration, err := ParseDuration(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("時間格式必須為 RFC3339 或相對時間 (例如 30m, 1h, 7d): %q", value)
+	}
+
+	return now.Add(-duration), nil
+}
+
+// ParseDuration 解析時間長度，額外支援以 "d" 表示天數 (例如 7d)
+func ParseDuration(value string) (time.Duration, error) {
+	if strings.HasSuffix(value, "d") {
+		days, err := strconv.ParseFloat(strings.TrimSuffix(value, "d"), 64)
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(days * float64(24*time.Hour)), nil
+	}
+	return time.ParseDuration(value)
+}
+
+// parseStringList 解析字串列表參數，支援 JSON 陣列或以逗號分隔的字串
+func parseStringList(value interface{}) []string {
+	var result []string
+
+	switch v := value.(type) {
+	case []interface{}:
+		for _, item := range v {
+			if str, ok := item.(string); ok && strings.TrimSpace(str) != "" {
+				result = append(result, strings.TrimSpace(str))
+			}
+		}
+	case string:
+		for _, item := range strings.Split(v, ",") {
+			if strings.TrimSpace(item) != "" {
+				result = append(result, strings.TrimSpace(item))
+			}
+		}
+	}
+
+	return result
+}
+
+// parsePageOptions 從請求中解析分頁參數
+func parsePageOptions(arguments map[string]interface{}) PageOptions {
+	page := PageOptions{}
+
+	if limit, ok := arguments["limit"].(float64); ok && limit > 0 {
+		page.Limit = int64(limit)
+	}
+
+	if cont, ok := arguments["continue"].(string); ok {
+		page.Continue = cont
+	}
+
+	return page
+}
+
+// paginated 判斷請求是否使用分頁
+func (p PageOptions) paginated() bool {
+	return p.Limit > 0 || p.Continue != ""
+}
+
+// sortedCursorPrefix 排序結果的 continue 游標前綴，游標為 offset:<已回傳筆數>
+const sortedCursorPrefix = "offset:"
+
+// sortedPage 排序完整的 Pod 列表後依 offset 游標取出一頁
+// API 伺服器只能依名稱分頁，排序必須在取出單頁之前套用到所有結果，否則只會排序該頁
+func sortedPage(pods []Pod, sortBy, order string, page PageOptions) (*PodList, error) {
+	if err := SortPods(pods, sortBy, order); err != nil {
+		return nil, err
+	}
+	if !page.paginated() {
+		return &PodList{Items: pods}, nil
+	}
+
+	offset := 0
+	if page.Continue != "" {
+		value, found := strings.CutPrefix(page.Continue, sortedCursorPrefix)
+		parsed, err := strconv.Atoi(value)
+		if !found || err != nil || parsed < 0 {
+			return nil, fmt.Errorf("無效的 continue 游標 %q，指定 sortBy 時必須使用同樣排序的上一頁回傳的游標", page.Continue)
+		}
+		offset = min(parsed, len(pods))
+	}
+
+	end := len(pods)
+	if page.Limit > 0 && int64(end-offset) > page.Limit {
+		end = offset + int(page.Limit)
+	}
+
+	result := &PodList{Items: pods[offset:end]}
+	if end < len(pods) {
+		remaining := int64(len(pods) - end)
+		result.Continue = sortedCursorPrefix + strconv.Itoa(end)
+		result.RemainingItemCount = &remaining
+	}
+	return result, nil
+}
+
+// parseFields 從請求中解析欄位投影參數 (以逗號分隔)
+func parseFields(arguments map[string]interface{}) ([]string, error) {
+	raw, ok := arguments["fields"].(string)
+	if !ok || strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+
+	var fields []string
+	for _, field := range strings.Split(raw, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		if !isValidPodField(field) {
+			return nil, fmt.Errorf("不支援的欄位 %q，可用欄位: %s", field, strings.Join(podFieldNames(), ", "))
+		}
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+// marshalPodList 序列化 Pod 列表，未使用分頁時維持原本的陣列格式，指定欄位時僅輸出投影後的欄位
+func marshalPodList(podList *PodList, page PageOptions, fields []string) ([]byte, error) {
+	var items interface{} = podList.Items
+	if len(fields) > 0 {
+		projected, err := projectPods(podList.Items, fields)
+		if err != nil {
+			return nil, err
+		}
+		items = projected
+	}
+
+	if page.paginated() {
+		return json.Marshal(struct {
+			Items              interface{} `json:"items"`
+			Continue           string      `json:"continue,omitempty"`
+			RemainingItemCount *int64      `json:"remainingItemCount,omitempty"`
+		}{
+			Items:              items,
+			Continue:           podList.Continue,
+			RemainingItemCount: podList.RemainingItemCount,
+		})
+	}
+	return json.Marshal(items)
+}
+
 // GetAllPods 取得所有 Pod
 func (h *Handler) GetAllPods(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	// 從請求中獲取命名空間參數
@@ -27,12 +182,33 @@ func (h *Handler) GetAllPods(ctx context.Context, request mcp.CallToolRequest) (
 		namespace = ns
 	}
 
-	pods, err := h.service.GetAllPods(namespace)
+	page := parsePageOptions(request.Params.Arguments)
+
+	fields, err := parseFields(request.Params.Arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	// 指定排序時取得所有 Pod 並在排序後分頁
+	sortBy, _ := request.Params.Arguments["sortBy"].(string)
+	order, _ := request.Params.Arguments["order"].(string)
+	listPage := page
+	if sortBy != "" {
+		listPage = PageOptions{}
+	}
+
+	pods, err := h.service.ListPods(ctx, namespace, listPage)
 	if err != nil {
 		return nil, fmt.Errorf("取得 Pod 列表失敗: %w", err)
 	}
 
-	podsJSON, err := json.Marshal(pods)
+	if sortBy != "" {
+		if pods, err = sortedPage(pods.Items, sortBy, order, page); err != nil {
+			return nil, err
+		}
+	}
+
+	podsJSON, err := marshalPodList(pods, page, fields)
 	if err != nil {
 		return nil, fmt.Errorf("序列化 Pod 資料失敗: %w", err)
 	}
@@ -49,6 +225,8 @@ func (h *Handler) SearchPods(ctx context.Context, request mcp.CallToolRequest) (
 		criteria.Namespace = namespace
 	}
 
+	criteria.Namespaces = parseStringList(request.Params.Arguments["namespaces"])
+
 	if labelSelector, ok := request.Params.Arguments["labelSelector"].(string); ok && labelSelector != "" {
 		criteria.LabelSelector = labelSelector
 	}
@@ -61,12 +239,63 @@ func (h *Handler) SearchPods(ctx context.Context, request mcp.CallToolRequest) (
 		criteria.Status = status
 	}
 
-	pods, err := h.service.SearchPods(criteria)
+	if nodeName, ok := request.Params.Arguments["nodeName"].(string); ok && nodeName != "" {
+		criteria.NodeName = nodeName
+	}
+
+	if qosClass, ok := request.Params.Arguments["qosClass"].(string); ok && qosClass != "" {
+		criteria.QOSClass = qosClass
+	}
+
+	if namePattern, ok := request.Params.Arguments["namePattern"].(string); ok && namePattern != "" {
+		criteria.NamePattern = namePattern
+	}
+
+	if patternType, ok := request.Params.Arguments["patternType"].(string); ok && patternType != "" {
+		criteria.PatternType = patternType
+	}
+
+	timeFilters := map[string]*time.Time{
+		"createdAfter":   &criteria.CreatedAfter,
+		"createdBefore":  &criteria.CreatedBefore,
+		"restartedSince": &criteria.RestartedSince,
+	}
+	for name, target := range timeFilters {
+		if value, ok := request.Params.Arguments[name].(string); ok && value != "" {
+			parsed, err := parseTimeArgument(value, time.Now())
+			if err != nil {
+				return nil, fmt.Errorf("無效的 %s 參數: %w", name, err)
+			}
+			*target = parsed
+		}
+	}
+
+	// 指定排序時搜尋所有符合條件的 Pod 並在排序後分頁
+	page := parsePageOptions(request.Params.Arguments)
+	sortBy, _ := request.Params.Arguments["sortBy"].(string)
+	order, _ := request.Params.Arguments["order"].(string)
+	if sortBy == "" {
+		criteria.Limit = page.Limit
+		criteria.Continue = page.Continue
+	}
+
+	fields, err := parseFields(request.Params.Arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := h.service.SearchPods(ctx, criteria)
 	if err != nil {
 		return nil, fmt.Errorf("搜尋 Pod 失敗: %w", err)
 	}
 
-	podsJSON, err := json.Marshal(pods)
+	if sortBy != "" {
+		if pods, err = sortedPage(pods.Items, sortBy, order, page); err != nil {
+			return nil, err
+		}
+	}
+
+	podsJSON, err := marshalPodList(pods, page, fields)
 	if err != nil {
 		return nil, fmt.Errorf("序列化 Pod 資料失敗: %w", err)
 	}
@@ -88,7 +317,7 @@ func (h *Handler) GetPodCPUUsage(ctx context.Context, request mcp.CallToolReques
 		namespace = ns
 	}
 
-	usage, err := h.service.GetPodResourceUsage(podName, namespace)
+	usage, err := h.service.GetPodResourceUsage(ctx, podName, namespace)
 	if err != nil {
 		return nil, fmt.Errorf("取得 Pod 資源使用狀況失敗: %w", err)
 	}
@@ -130,7 +359,7 @@ func (h *Handler) GetPodMemoryUsage(ctx context.Context, request mcp.CallToolReq
 		namespace = ns
 	}
 
-	usage, err := h.service.GetPodResourceUsage(podName, namespace)
+	usage, err := h.service.GetPodResourceUsage(ctx, podName, namespace)
 	if err != nil {
 		return nil, fmt.Errorf("取得 Pod 資源使用狀況失敗: %w", err)
 	}
@@ -172,7 +401,7 @@ func (h *Handler) GetPodDiskUsage(ctx context.Context, request mcp.CallToolReque
 		namespace = ns
 	}
 
-	usage, err := h.service.GetPodResourceUsage(podName, namespace)
+	usage, err := h.service.GetPodResourceUsage(ctx, podName, namespace)
 	if err != nil {
 		return nil, fmt.Errorf("取得 Pod 資源使用狀況失敗: %w", err)
 	}
@@ -212,7 +441,7 @@ func (h *Handler) GetPodDetails(ctx context.Context, request mcp.CallToolRequest
 		namespace = ns
 	}
 
-	details, err := h.service.GetPodDetails(podName, namespace)
+	details, err := h.service.GetPodDetails(ctx, podName, namespace)
 	if err != nil {
 		return nil, fmt.Errorf("取得 Pod 詳細資訊失敗: %w", err)
 	}
@@ -239,3 +468,1126 @@ func (h *Handler) GetPodDetails(ctx context.Context, request mcp.CallToolRequest
 
 	return mcp.NewToolResultText(string(detailsJSON)), nil
 }
+
+// GetPodDistribution 取得 Pod 在節點與可用區上的分佈狀況
+func (h *Handler) GetPodDistribution(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	namespace := ""
+	if ns, ok := request.Params.Arguments["namespace"].(string); ok {
+		namespace = ns
+	}
+
+	labelSelector := ""
+	if selector, ok := request.Params.Arguments["labelSelector"].(string); ok {
+		labelSelector = selector
+	}
+
+	distribution, err := h.service.GetPodDistribution(ctx, namespace, labelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("取得 Pod 分佈狀況失敗: %w", err)
+	}
+
+	distributionJSON, err := json.Marshal(distribution)
+	if err != nil {
+		return nil, fmt.Errorf("序列化 Pod 分佈資料失敗: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(distributionJSON)), nil
+}
+
+// GetPodProbes 取得 Pod 的探針設定
+func (h *Handler) GetPodProbes(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// Pod 名稱是必要參數
+	podName, ok := request.Params.Arguments["podName"].(string)
+	if !ok || podName == "" {
+		return nil, errors.New("必須提供有效的 Pod 名稱")
+	}
+
+	// 命名空間是可選參數
+	namespace := ""
+	if ns, ok := request.Params.Arguments["namespace"].(string); ok {
+		namespace = ns
+	}
+
+	probes, err := h.service.GetPodProbes(ctx, podName, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("取得 Pod 探針設定失敗: %w", err)
+	}
+
+	probesJSON, err := json.Marshal(probes)
+	if err != nil {
+		return nil, fmt.Errorf("序列化探針設定失敗: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(probesJSON)), nil
+}
+
+// AggregatePodsByLabel 依標籤分組統計 Pod
+func (h *Handler) AggregatePodsByLabel(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// 標籤鍵是必要參數
+	labelKey, ok := request.Params.Arguments["labelKey"].(string)
+	if !ok || labelKey == "" {
+		return nil, errors.New("必須提供有效的標籤鍵")
+	}
+
+	namespace := ""
+	if ns, ok := request.Params.Arguments["namespace"].(string); ok {
+		namespace = ns
+	}
+
+	aggregation, err := h.service.AggregatePodsByLabel(ctx, namespace, labelKey)
+	if err != nil {
+		return nil, fmt.Errorf("依標籤分組統計 Pod 失敗: %w", err)
+	}
+
+	aggregationJSON, err := json.Marshal(aggregation)
+	if err != nil {
+		return nil, fmt.Errorf("序列化分組統計資料失敗: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(aggregationJSON)), nil
+}
+
+// GetServicesForPod 取得選取指定 Pod 的 Service
+func (h *Handler) GetServicesForPod(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// Pod 名稱是必要參數
+	podName, ok := request.Params.Arguments["podName"].(string)
+	if !ok || podName == "" {
+		return nil, errors.New("必須提供有效的 Pod 名稱")
+	}
+
+	// 命名空間是可選參數
+	namespace := ""
+	if ns, ok := request.Params.Arguments["namespace"].(string); ok {
+		namespace = ns
+	}
+
+	services, err := h.service.GetServicesForPod(ctx, podName, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("取得 Pod 對應的 Service 失敗: %w", err)
+	}
+
+	servicesJSON, err := json.Marshal(services)
+	if err != nil {
+		return nil, fmt.Errorf("序列化 Service 資料失敗: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(servicesJSON)), nil
+}
+
+// CheckPodConnectivity 在 Pod 內執行 TCP/HTTP 連線檢查
+func (h *Handler) CheckPodConnectivity(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// Pod 名稱是必要參數
+	podName, ok := request.Params.Arguments["podName"].(string)
+	if !ok || podName == "" {
+		return nil, errors.New("必須提供有效的 Pod 名稱")
+	}
+
+	// 目標主機與連接埠是必要參數
+	host, ok := request.Params.Arguments["host"].(string)
+	if !ok || host == "" {
+		return nil, errors.New("必須提供有效的目標主機")
+	}
+
+	port, ok := request.Params.Arguments["port"].(float64)
+	if !ok {
+		return nil, errors.New("必須提供有效的目標連接埠")
+	}
+
+	namespace, _ := request.Params.Arguments["namespace"].(string)
+	container, _ := request.Params.Arguments["container"].(string)
+	protocol, _ := request.Params.Arguments["protocol"].(string)
+	path, _ := request.Params.Arguments["path"].(string)
+
+	timeoutSeconds := 0
+	if timeout, ok := request.Params.Arguments["timeoutSeconds"].(float64); ok {
+		timeoutSeconds = int(timeout)
+	}
+
+	check, err := h.service.CheckPodConnectivity(ctx, podName, namespace, container, protocol, host, int(port), path, timeoutSeconds)
+	if err != nil {
+		return nil, fmt.Errorf("連線檢查失敗: %w", err)
+	}
+
+	checkJSON, err := json.Marshal(check)
+	if err != nil {
+		return nil, fmt.Errorf("序列化連線檢查結果失敗: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(checkJSON)), nil
+}
+
+// DescribePod 取得 Pod 的綜合描述
+func (h *Handler) DescribePod(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// Pod 名稱是必要參數
+	podName, ok := request.Params.Arguments["podName"].(string)
+	if !ok || podName == "" {
+		return nil, errors.New("必須提供有效的 Pod 名稱")
+	}
+
+	// 命名空間是可選參數
+	namespace := ""
+	if ns, ok := request.Params.Arguments["namespace"].(string); ok {
+		namespace = ns
+	}
+
+	description, err := h.service.DescribePod(ctx, podName, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("取得 Pod 綜合描述失敗: %w", err)
+	}
+
+	descriptionJSON, err := json.Marshal(description)
+	if err != nil {
+		return nil, fmt.Errorf("序列化 Pod 綜合描述失敗: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(descriptionJSON)), nil
+}
+
+// DescribeNode 取得節點的綜合描述
+func (h *Handler) DescribeNode(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// 節點名稱是必要參數
+	nodeName, ok := request.Params.Arguments["nodeName"].(string)
+	if !ok || nodeName == "" {
+		return nil, errors.New("必須提供有效的節點名稱")
+	}
+
+	description, err := h.service.DescribeNode(ctx, nodeName)
+	if err != nil {
+		return nil, fmt.Errorf("取得節點綜合描述失敗: %w", err)
+	}
+
+	descriptionJSON, err := json.Marshal(description)
+	if err != nil {
+		return nil, fmt.Errorf("序列化節點綜合描述失敗: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(descriptionJSON)), nil
+}
+
+// GetPodEnv 取得 Pod 的環境變數
+func (h *Handler) GetPodEnv(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// Pod 名稱是必要參數
+	podName, ok := request.Params.Arguments["podName"].(string)
+	if !ok || podName == "" {
+		return nil, errors.New("必須提供有效的 Pod 名稱")
+	}
+
+	// 命名空間是可選參數
+	namespace := ""
+	if ns, ok := request.Params.Arguments["namespace"].(string); ok {
+		namespace = ns
+	}
+
+	env, err := h.service.GetPodEnv(ctx, podName, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("取得 Pod 環境變數失敗: %w", err)
+	}
+
+	envJSON, err := json.Marshal(env)
+	if err != nil {
+		return nil, fmt.Errorf("序列化環境變數失敗: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(envJSON)), nil
+}
+
+// GetPodVolumes 取得 Pod 的卷與掛載資訊
+func (h *Handler) GetPodVolumes(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// Pod 名稱是必要參數
+	podName, ok := request.Params.Arguments["podName"].(string)
+	if !ok || podName == "" {
+		return nil, errors.New("必須提供有效的 Pod 名稱")
+	}
+
+	// 命名空間是可選參數
+	namespace := ""
+	if ns, ok := request.Params.Arguments["namespace"].(string); ok {
+		namespace = ns
+	}
+
+	volumes, err := h.service.GetPodVolumes(ctx, podName, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("取得 Pod 卷資訊失敗: %w", err)
+	}
+
+	volumesJSON, err := json.Marshal(volumes)
+	if err != nil {
+		return nil, fmt.Errorf("序列化卷資訊失敗: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(volumesJSON)), nil
+}
+
+// DiagnoseImagePull 診斷 Pod 的映像檔拉取失敗
+func (h *Handler) DiagnoseImagePull(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// Pod 名稱是必要參數
+	podName, ok := request.Params.Arguments["podName"].(string)
+	if !ok || podName == "" {
+		return nil, errors.New("必須提供有效的 Pod 名稱")
+	}
+
+	// 命名空間是可選參數
+	namespace := ""
+	if ns, ok := request.Params.Arguments["namespace"].(string); ok {
+		namespace = ns
+	}
+
+	diagnosis, err := h.service.DiagnoseImagePull(ctx, podName, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("診斷映像檔拉取失敗: %w", err)
+	}
+
+	diagnosisJSON, err := json.Marshal(diagnosis)
+	if err != nil {
+		return nil, fmt.Errorf("序列化診斷結果失敗: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(diagnosisJSON)), nil
+}
+
+// GetTerminatedPods 取得已完成、失敗及近期刪除的 Pod
+func (h *Handler) GetTerminatedPods(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	namespace := ""
+	if ns, ok := request.Params.Arguments["namespace"].(string); ok {
+		namespace = ns
+	}
+
+	var since time.Time
+	if value, ok := request.Params.Arguments["since"].(string); ok && value != "" {
+		parsed, err := parseTimeArgument(value, time.Now())
+		if err != nil {
+			return nil, fmt.Errorf("無效的 since 參數: %w", err)
+		}
+		since = parsed
+	}
+
+	// 預設包含近期刪除的 Pod
+	includeDeleted := true
+	if value, ok := request.Params.Arguments["includeDeleted"].(bool); ok {
+		includeDeleted = value
+	}
+
+	pods, err := h.service.GetTerminatedPods(ctx, namespace, since, includeDeleted)
+	if err != nil {
+		return nil, fmt.Errorf("取得已終止的 Pod 失敗: %w", err)
+	}
+
+	podsJSON, err := json.Marshal(pods)
+	if err != nil {
+		return nil, fmt.Errorf("序列化 Pod 資料失敗: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(podsJSON)), nil
+}
+
+// GetNodeEvents 取得節點事件
+func (h *Handler) GetNodeEvents(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// 節點名稱是可選參數，未提供時查詢所有節點
+	nodeName, _ := request.Params.Arguments["nodeName"].(string)
+	eventType, _ := request.Params.Arguments["type"].(string)
+
+	// 預設查詢最近 24 小時的事件
+	since := time.Now().Add(-24 * time.Hour)
+	if value, ok := request.Params.Arguments["since"].(string); ok && value != "" {
+		parsed, err := parseTimeArgument(value, time.Now())
+		if err != nil {
+			return nil, fmt.Errorf("無效的 since 參數: %w", err)
+		}
+		since = parsed
+	}
+
+	events, err := h.service.GetNodeEvents(ctx, nodeName, since, eventType)
+	if err != nil {
+		return nil, fmt.Errorf("取得節點事件失敗: %w", err)
+	}
+
+	eventsJSON, err := json.Marshal(events)
+	if err != nil {
+		return nil, fmt.Errorf("序列化節點事件失敗: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(eventsJSON)), nil
+}
+
+// RestartWorkload 滾動重啟工作負載
+func (h *Handler) RestartWorkload(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// 工作負載類型與名稱是必要參數
+	kind, ok := request.Params.Arguments["kind"].(string)
+	if !ok || kind == "" {
+		return nil, errors.New("必須提供有效的工作負載類型")
+	}
+
+	name, ok := request.Params.Arguments["name"].(string)
+	if !ok || name == "" {
+		return nil, errors.New("必須提供有效的工作負載名稱")
+	}
+
+	namespace := ""
+	if ns, ok := request.Params.Arguments["namespace"].(string); ok {
+		namespace = ns
+	}
+
+	result, err := h.service.RestartWorkload(ctx, kind, name, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("重啟工作負載失敗: %w", err)
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("序列化重啟結果失敗: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// ScaleWorkload 設定工作負載的副本數
+func (h *Handler) ScaleWorkload(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// 工作負載類型、名稱與副本數是必要參數
+	kind, ok := request.Params.Arguments["kind"].(string)
+	if !ok || kind == "" {
+		return nil, errors.New("必須提供有效的工作負載類型")
+	}
+
+	name, ok := request.Params.Arguments["name"].(string)
+	if !ok || name == "" {
+		return nil, errors.New("必須提供有效的工作負載名稱")
+	}
+
+	replicas, ok := request.Params.Arguments["replicas"].(float64)
+	if !ok || replicas != float64(int32(replicas)) {
+		return nil, errors.New("必須提供有效的副本數")
+	}
+
+	namespace := ""
+	if ns, ok := request.Params.Arguments["namespace"].(string); ok {
+		namespace = ns
+	}
+
+	result, err := h.service.ScaleWorkload(ctx, kind, name, namespace, int32(replicas))
+	if err != nil {
+		return nil, fmt.Errorf("擴縮工作負載失敗: %w", err)
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("序列化擴縮結果失敗: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// DeletePod 刪除 Pod (需提供確認參數)
+func (h *Handler) DeletePod(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// Pod 名稱與確認參數是必要參數
+	podName, ok := request.Params.Arguments["podName"].(string)
+	if !ok || podName == "" {
+		return nil, errors.New("必須提供有效的 Pod 名稱")
+	}
+
+	confirm, ok := request.Params.Arguments["confirm"].(string)
+	if !ok || confirm == "" {
+		return nil, errors.New("必須提供確認參數 confirm (值為 Pod 名稱)")
+	}
+
+	namespace := ""
+	if ns, ok := request.Params.Arguments["namespace"].(string); ok {
+		namespace = ns
+	}
+
+	var gracePeriodSeconds *int64
+	if value, ok := request.Params.Arguments["gracePeriodSeconds"].(float64); ok {
+		seconds := int64(value)
+		gracePeriodSeconds = &seconds
+	}
+
+	result, err := h.service.DeletePod(ctx, podName, namespace, confirm, gracePeriodSeconds)
+	if err != nil {
+		return nil, fmt.Errorf("刪除 Pod 失敗: %w", err)
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("序列化刪除結果失敗: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// CordonNode 將節點標記為不可排程
+func (h *Handler) CordonNode(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return h.setNodeSchedulable(ctx, request, true)
+}
+
+// UncordonNode 將節點恢復為可排程
+func (h *Handler) UncordonNode(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return h.setNodeSchedulable(ctx, request, false)
+}
+
+// setNodeSchedulable 處理 cordon/uncordon 請求
+func (h *Handler) setNodeSchedulable(ctx context.Context, request mcp.CallToolRequest, unschedulable bool) (*mcp.CallToolResult, error) {
+	// 節點名稱是必要參數
+	nodeName, ok := request.Params.Arguments["nodeName"].(string)
+	if !ok || nodeName == "" {
+		return nil, errors.New("必須提供有效的節點名稱")
+	}
+
+	var result *NodeSchedulingChange
+	var err error
+	if unschedulable {
+		result, err = h.service.CordonNode(ctx, nodeName)
+	} else {
+		result, err = h.service.UncordonNode(ctx, nodeName)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("更新節點排程狀態失敗: %w", err)
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("序列化節點排程狀態失敗: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// DrainNode 排空節點
+func (h *Handler) DrainNode(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// 節點名稱與確認參數是必要參數
+	nodeName, ok := request.Params.Arguments["nodeName"].(string)
+	if !ok || nodeName == "" {
+		return nil, errors.New("必須提供有效的節點名稱")
+	}
+
+	confirm, ok := request.Params.Arguments["confirm"].(string)
+	if !ok || confirm == "" {
+		return nil, errors.New("必須提供確認參數 confirm (值為節點名稱)")
+	}
+
+	options := DrainOptions{
+		NodeName:         nodeName,
+		Confirm:          confirm,
+		IgnoreDaemonSets: true,
+	}
+	if value, ok := request.Params.Arguments["ignoreDaemonSets"].(bool); ok {
+		options.IgnoreDaemonSets = value
+	}
+	if value, ok := request.Params.Arguments["deleteEmptyDirData"].(bool); ok {
+		options.DeleteEmptyDirData = value
+	}
+	if value, ok := request.Params.Arguments["force"].(bool); ok {
+		options.Force = value
+	}
+	if value, ok := request.Params.Arguments["gracePeriodSeconds"].(float64); ok {
+		seconds := int64(value)
+		options.GracePeriodSeconds = &seconds
+	}
+	if value, ok := request.Params.Arguments["timeoutSeconds"].(float64); ok && value > 0 {
+		options.Timeout = time.Duration(value) * time.Second
+	}
+
+	result, err := h.service.DrainNode(ctx, options)
+	if err != nil {
+		return nil, fmt.Errorf("排空節點失敗: %w", err)
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("序列化排空結果失敗: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// EvictPod 透過 Eviction API 驅逐 Pod
+func (h *Handler) EvictPod(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// Pod 名稱是必要參數
+	podName, ok := request.Params.Arguments["podName"].(string)
+	if !ok || podName == "" {
+		return nil, errors.New("必須提供有效的 Pod 名稱")
+	}
+
+	namespace := ""
+	if ns, ok := request.Params.Arguments["namespace"].(string); ok {
+		namespace = ns
+	}
+
+	var gracePeriodSeconds *int64
+	if value, ok := request.Params.Arguments["gracePeriodSeconds"].(float64); ok {
+		seconds := int64(value)
+		gracePeriodSeconds = &seconds
+	}
+
+	result, err := h.service.EvictPod(ctx, podName, namespace, gracePeriodSeconds)
+	if err != nil {
+		return nil, fmt.Errorf("驅逐 Pod 失敗: %w", err)
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("序列化驅逐結果失敗: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// GetRolloutHistory 取得 Deployment 的版本歷史
+func (h *Handler) GetRolloutHistory(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// Deployment 名稱是必要參數
+	name, ok := request.Params.Arguments["name"].(string)
+	if !ok || name == "" {
+		return nil, errors.New("必須提供有效的 Deployment 名稱")
+	}
+
+	namespace := ""
+	if ns, ok := request.Params.Arguments["namespace"].(string); ok {
+		namespace = ns
+	}
+
+	history, err := h.service.GetRolloutHistory(ctx, name, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("取得版本歷史失敗: %w", err)
+	}
+
+	historyJSON, err := json.Marshal(history)
+	if err != nil {
+		return nil, fmt.Errorf("序列化版本歷史失敗: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(historyJSON)), nil
+}
+
+// RollbackDeployment 將 Deployment 回滾到指定版本
+func (h *Handler) RollbackDeployment(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// Deployment 名稱是必要參數
+	name, ok := request.Params.Arguments["name"].(string)
+	if !ok || name == "" {
+		return nil, errors.New("必須提供有效的 Deployment 名稱")
+	}
+
+	namespace := ""
+	if ns, ok := request.Params.Arguments["namespace"].(string); ok {
+		namespace = ns
+	}
+
+	var toRevision int64
+	if value, ok := request.Params.Arguments["toRevision"].(float64); ok {
+		toRevision = int64(value)
+	}
+
+	result, err := h.service.RollbackDeployment(ctx, name, namespace, toRevision)
+	if err != nil {
+		return nil, fmt.Errorf("回滾 Deployment 失敗: %w", err)
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("序列化回滾結果失敗: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// ExecInPod 處理在容器內執行允許清單指令的請求
+func (h *Handler) ExecInPod(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// Pod 名稱是必要參數
+	podName, ok := request.Params.Arguments["podName"].(string)
+	if !ok || podName == "" {
+		return nil, errors.New("必須提供有效的 Pod 名稱")
+	}
+
+	// 指令可以是字串陣列，或以空白分隔的字串 (不支援引號與 shell 語法)
+	var command []string
+	switch v := request.Params.Arguments["command"].(type) {
+	case []interface{}:
+		for _, item := range v {
+			if str, ok := item.(string); ok {
+				command = append(command, str)
+			}
+		}
+	case string:
+		command = strings.Fields(v)
+	}
+	if len(command) == 0 {
+		return nil, errors.New("必須提供有效的指令")
+	}
+
+	namespace, _ := request.Params.Arguments["namespace"].(string)
+	container, _ := request.Params.Arguments["container"].(string)
+
+	var timeout time.Duration
+	if seconds, ok := request.Params.Arguments["timeoutSeconds"].(float64); ok && seconds > 0 {
+		timeout = time.Duration(seconds * float64(time.Second))
+	}
+
+	result, err := h.service.ExecInPod(ctx, podName, namespace, container, command, timeout)
+	if err != nil {
+		return nil, fmt.Errorf("執行容器指令失敗: %w", err)
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("序列化指令執行結果失敗: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// ProbePodEndpoint 處理透過 API 伺服器 proxy 探測 Pod HTTP 端點的請求
+func (h *Handler) ProbePodEndpoint(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// Pod 名稱是必要參數
+	podName, ok := request.Params.Arguments["podName"].(string)
+	if !ok || podName == "" {
+		return nil, errors.New("必須提供有效的 Pod 名稱")
+	}
+
+	namespace, _ := request.Params.Arguments["namespace"].(string)
+	container, _ := request.Params.Arguments["container"].(string)
+	scheme, _ := request.Params.Arguments["scheme"].(string)
+	path, _ := request.Params.Arguments["path"].(string)
+
+	port := 0
+	if value, ok := request.Params.Arguments["port"].(float64); ok {
+		port = int(value)
+	}
+
+	var timeout time.Duration
+	if seconds, ok := request.Params.Arguments["timeoutSeconds"].(float64); ok && seconds > 0 {
+		timeout = time.Duration(seconds * float64(time.Second))
+	}
+
+	probe, err := h.service.ProbePodEndpoint(ctx, podName, namespace, container, scheme, port, path, timeout)
+	if err != nil {
+		return nil, fmt.Errorf("探測 Pod 端點失敗: %w", err)
+	}
+
+	probeJSON, err := json.Marshal(probe)
+	if err != nil {
+		return nil, fmt.Errorf("序列化端點探測結果失敗: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(probeJSON)), nil
+}
+
+// ApplyPatch 處理修補資源的請求，預設僅進行 dry-run
+func (h *Handler) ApplyPatch(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// 資源類型與名稱是必要參數
+	resource, ok := request.Params.Arguments["resource"].(string)
+	if !ok || resource == "" {
+		return nil, errors.New("必須提供有效的資源類型")
+	}
+
+	name, ok := request.Params.Arguments["name"].(string)
+	if !ok || name == "" {
+		return nil, errors.New("必須提供有效的資源名稱")
+	}
+
+	// 修補內容可以是 JSON 字串，或直接傳入 JSON 物件/陣列
+	var patch []byte
+	switch v := request.Params.Arguments["patch"].(type) {
+	case string:
+		patch = []byte(v)
+	case map[string]interface{}, []interface{}:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("序列化修補內容失敗: %w", err)
+		}
+		patch = data
+	}
+	if len(patch) == 0 {
+		return nil, errors.New("必須提供有效的修補內容")
+	}
+
+	namespace, _ := request.Params.Arguments["namespace"].(string)
+	patchType, _ := request.Params.Arguments["patchType"].(string)
+	commit, _ := request.Params.Arguments["commit"].(bool)
+
+	result, err := h.service.ApplyPatch(ctx, resource, name, namespace, patchType, patch, commit)
+	if err != nil {
+		return nil, fmt.Errorf("修補資源失敗: %w", err)
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("序列化修補結果失敗: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// GetMetricsHistory 處理取得使用量歷史時間序列的請求
+func (h *Handler) GetMetricsHistory(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// 名稱是必要參數
+	name, ok := request.Params.Arguments["name"].(string)
+	if !ok || name == "" {
+		return nil, errors.New("必須提供有效的 Pod 或節點名稱")
+	}
+
+	kind, _ := request.Params.Arguments["kind"].(string)
+	namespace, _ := request.Params.Arguments["namespace"].(string)
+	container, _ := request.Params.Arguments["container"].(string)
+
+	var duration time.Duration
+	if value, ok := request.Params.Arguments["duration"].(string); ok && value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("無效的時間範圍 %q (例如 30m、6h): %w", value, err)
+		}
+		duration = parsed
+	}
+
+	history, err := h.service.GetMetricsHistory(kind, name, namespace, container, duration)
+	if err != nil {
+		return nil, fmt.Errorf("取得使用量歷史失敗: %w", err)
+	}
+
+	historyJSON, err := json.Marshal(history)
+	if err != nil {
+		return nil, fmt.Errorf("序列化使用量歷史失敗: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(historyJSON)), nil
+}
+
+// GetHistoricalUsage 處理從 Cloud Monitoring 取得歷史使用量的請求
+func (h *Handler) GetHistoricalUsage(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// Pod 名稱是必要參數
+	podName, ok := request.Params.Arguments["podName"].(string)
+	if !ok || podName == "" {
+		return nil, errors.New("必須提供有效的 Pod 名稱")
+	}
+
+	namespace, _ := request.Params.Arguments["namespace"].(string)
+
+	var duration, alignment time.Duration
+	if value, ok := request.Params.Arguments["duration"].(string); ok && value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("無效的時間範圍 %q (例如 24h、168h): %w", value, err)
+		}
+		duration = parsed
+	}
+	if value, ok := request.Params.Arguments["alignment"].(string); ok && value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("無效的對齊間隔 %q (例如 5m、1h): %w", value, err)
+		}
+		alignment = parsed
+	}
+
+	usage, err := h.service.GetHistoricalUsage(ctx, podName, namespace, duration, alignment)
+	if err != nil {
+		return nil, fmt.Errorf("取得歷史使用量失敗: %w", err)
+	}
+
+	usageJSON, err := json.Marshal(usage)
+	if err != nil {
+		return nil, fmt.Errorf("序列化歷史使用量失敗: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(usageJSON)), nil
+}
+
+// QueryPrometheus 處理執行 PromQL 查詢的請求
+func (h *Handler) QueryPrometheus(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// 查詢語句是必要參數
+	query, ok := request.Params.Arguments["query"].(string)
+	if !ok || query == "" {
+		return nil, errors.New("必須提供有效的 PromQL 查詢")
+	}
+
+	var duration, step time.Duration
+	if value, ok := request.Params.Arguments["duration"].(string); ok && value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("無效的時間範圍 %q (例如 1h、24h): %w", value, err)
+		}
+		duration = parsed
+	}
+	if value, ok := request.Params.Arguments["step"].(string); ok && value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("無效的解析度 %q (例如 1m、5m): %w", value, err)
+		}
+		step = parsed
+	}
+
+	result, err := h.service.QueryPrometheus(ctx, query, duration, step)
+	if err != nil {
+		return nil, fmt.Errorf("執行 PromQL 查詢失敗: %w", err)
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("序列化查詢結果失敗: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// GetPrometheusUsage 處理從 Prometheus 取得 Pod 使用量的請求
+func (h *Handler) GetPrometheusUsage(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// Pod 名稱是必要參數
+	podName, ok := request.Params.Arguments["podName"].(string)
+	if !ok || podName == "" {
+		return nil, errors.New("必須提供有效的 Pod 名稱")
+	}
+
+	namespace, _ := request.Params.Arguments["namespace"].(string)
+
+	var duration, step time.Duration
+	if value, ok := request.Params.Arguments["duration"].(string); ok && value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("無效的時間範圍 %q (例如 1h、24h): %w", value, err)
+		}
+		duration = parsed
+	}
+	if value, ok := request.Params.Arguments["step"].(string); ok && value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("無效的解析度 %q (例如 1m、5m): %w", value, err)
+		}
+		step = parsed
+	}
+
+	usage, err := h.service.GetPrometheusUsage(ctx, podName, namespace, duration, step)
+	if err != nil {
+		return nil, fmt.Errorf("取得 Prometheus 使用量失敗: %w", err)
+	}
+
+	usageJSON, err := json.Marshal(usage)
+	if err != nil {
+		return nil, fmt.Errorf("序列化 Prometheus 使用量失敗: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(usageJSON)), nil
+}
+
+// GetUsagePercentiles 處理計算使用量百分位數的請求
+func (h *Handler) GetUsagePercentiles(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// 名稱是必要參數
+	name, ok := request.Params.Arguments["name"].(string)
+	if !ok || name == "" {
+		return nil, errors.New("必須提供有效的 Pod 或工作負載名稱")
+	}
+
+	kind, _ := request.Params.Arguments["kind"].(string)
+	namespace, _ := request.Params.Arguments["namespace"].(string)
+	source, _ := request.Params.Arguments["source"].(string)
+
+	var window time.Duration
+	if value, ok := request.Params.Arguments["window"].(string); ok && value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("無效的時間範圍 %q (例如 24h、168h): %w", value, err)
+		}
+		window = parsed
+	}
+
+	percentiles, err := h.service.GetUsagePercentiles(ctx, kind, name, namespace, source, window)
+	if err != nil {
+		return nil, fmt.Errorf("計算使用量百分位數失敗: %w", err)
+	}
+
+	percentilesJSON, err := json.Marshal(percentiles)
+	if err != nil {
+		return nil, fmt.Errorf("序列化使用量百分位數失敗: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(percentilesJSON)), nil
+}
+
+// DetectAnomalies 處理使用量異常偵測的請求
+func (h *Handler) DetectAnomalies(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	namespace, _ := request.Params.Arguments["namespace"].(string)
+	source, _ := request.Params.Arguments["source"].(string)
+
+	var baseline, recent time.Duration
+	if value, ok := request.Params.Arguments["baseline"].(string); ok && value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("無效的基準時間範圍 %q (例如 24h): %w", value, err)
+		}
+		baseline = parsed
+	}
+	if value, ok := request.Params.Arguments["recent"].(string); ok && value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("無效的最近時間範圍 %q (例如 30m): %w", value, err)
+		}
+		recent = parsed
+	}
+
+	threshold := 0.0
+	if value, ok := request.Params.Arguments["threshold"].(float64); ok {
+		threshold = value
+	}
+
+	report, err := h.service.DetectAnomalies(ctx, namespace, source, baseline, recent, threshold)
+	if err != nil {
+		return nil, fmt.Errorf("偵測使用量異常失敗: %w", err)
+	}
+
+	reportJSON, err := json.Marshal(report)
+	if err != nil {
+		return nil, fmt.Errorf("序列化異常偵測報告失敗: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(reportJSON)), nil
+}
+
+// ForecastUsage 處理使用量趨勢預測的請求
+func (h *Handler) ForecastUsage(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	scope, _ := request.Params.Arguments["scope"].(string)
+	name, _ := request.Params.Arguments["name"].(string)
+	source, _ := request.Params.Arguments["source"].(string)
+
+	var window, horizon time.Duration
+	if value, ok := request.Params.Arguments["window"].(string); ok && value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("無效的時間範圍 %q (例如 168h): %w", value, err)
+		}
+		window = parsed
+	}
+	if value, ok := request.Params.Arguments["horizonDays"].(float64); ok && value > 0 {
+		horizon = time.Duration(value * 24 * float64(time.Hour))
+	}
+
+	forecast, err := h.service.ForecastUsage(ctx, scope, name, source, window, horizon)
+	if err != nil {
+		return nil, fmt.Errorf("預測使用量趨勢失敗: %w", err)
+	}
+
+	forecastJSON, err := json.Marshal(forecast)
+	if err != nil {
+		return nil, fmt.Errorf("序列化使用量預測失敗: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(forecastJSON)), nil
+}
+
+// GetOOMEvents 處理 OOM 事件彙總的請求
+func (h *Handler) GetOOMEvents(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	namespace, _ := request.Params.Arguments["namespace"].(string)
+
+	var since time.Time
+	if value, ok := request.Params.Arguments["since"].(string); ok && value != "" {
+		parsed, err := parseTimeArgument(value, time.Now())
+		if err != nil {
+			return nil, fmt.Errorf("無效的 since 參數: %w", err)
+		}
+		since = parsed
+	}
+
+	report, err := h.service.GetOOMEvents(ctx, namespace, since)
+	if err != nil {
+		return nil, fmt.Errorf("取得 OOM 事件失敗: %w", err)
+	}
+
+	reportJSON, err := json.Marshal(report)
+	if err != nil {
+		return nil, fmt.Errorf("序列化 OOM 事件失敗: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(reportJSON)), nil
+}
+
+// GetNodeCommitment 處理節點請求量承諾比例報告的請求
+func (h *Handler) GetNodeCommitment(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	nodePool, _ := request.Params.Arguments["nodePool"].(string)
+
+	report, err := h.service.GetNodeCommitment(ctx, nodePool)
+	if err != nil {
+		return nil, fmt.Errorf("取得節點承諾比例失敗: %w", err)
+	}
+
+	reportJSON, err := json.Marshal(report)
+	if err != nil {
+		return nil, fmt.Errorf("序列化節點承諾比例失敗: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(reportJSON)), nil
+}
+
+// GetCapacityPlan 處理工作負載副本容量規劃的請求
+func (h *Handler) GetCapacityPlan(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	kind, ok := request.Params.Arguments["kind"].(string)
+	if !ok || kind == "" {
+		return nil, errors.New("必須提供有效的工作負載類型")
+	}
+
+	name, ok := request.Params.Arguments["name"].(string)
+	if !ok || name == "" {
+		return nil, errors.New("必須提供有效的工作負載名稱")
+	}
+
+	namespace, _ := request.Params.Arguments["namespace"].(string)
+	nodePool, _ := request.Params.Arguments["nodePool"].(string)
+
+	plan, err := h.service.GetCapacityPlan(ctx, kind, name, namespace, nodePool)
+	if err != nil {
+		return nil, fmt.Errorf("規劃副本容量失敗: %w", err)
+	}
+
+	planJSON, err := json.Marshal(plan)
+	if err != nil {
+		return nil, fmt.Errorf("序列化容量規劃失敗: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(planJSON)), nil
+}
+
+// DetectMemoryLeaks 處理記憶體洩漏偵測的請求
+func (h *Handler) DetectMemoryLeaks(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	namespace, _ := request.Params.Arguments["namespace"].(string)
+	source, _ := request.Params.Arguments["source"].(string)
+
+	var window time.Duration
+	if value, ok := request.Params.Arguments["window"].(string); ok && value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("無效的時間範圍 %q (例如 24h): %w", value, err)
+		}
+		window = parsed
+	}
+
+	report, err := h.service.DetectMemoryLeaks(ctx, namespace, source, window)
+	if err != nil {
+		return nil, fmt.Errorf("偵測記憶體洩漏失敗: %w", err)
+	}
+
+	reportJSON, err := json.Marshal(report)
+	if err != nil {
+		return nil, fmt.Errorf("序列化記憶體洩漏報告失敗: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(reportJSON)), nil
+}
+
+// DetectOOMRisks 處理 OOM 風險偵測的請求
+func (h *Handler) DetectOOMRisks(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	namespace, _ := request.Params.Arguments["namespace"].(string)
+	source, _ := request.Params.Arguments["source"].(string)
+
+	var window time.Duration
+	if value, ok := request.Params.Arguments["window"].(string); ok && value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("無效的時間範圍 %q (例如 6h): %w", value, err)
+		}
+		window = parsed
+	}
+
+	report, err := h.service.DetectOOMRisks(ctx, namespace, source, window)
+	if err != nil {
+		return nil, fmt.Errorf("偵測 OOM 風險失敗: %w", err)
+	}
+
+	reportJSON, err := json.Marshal(report)
+	if err != nil {
+		return nil, fmt.Errorf("序列化 OOM 風險報告失敗: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(reportJSON)), nil
+}
@@ -0,0 +1,483 @@
+package gke
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+)
+
+const (
+	// defaultMetricsInterval 預設的指標取樣間隔
+	defaultMetricsInterval = time.Minute
+
+	// defaultMetricsRetention 預設的指標保存時間
+	defaultMetricsRetention = 24 * time.Hour
+
+	// defaultHistoryDuration get_metrics_history 預設查詢的時間範圍
+	defaultHistoryDuration = time.Hour
+)
+
+// sampleSeries 依時間排序的樣本，隨取樣逐步成長而不預先配置容量，超過容量或保存時間的樣本會被捨棄
+// 捨棄舊樣本時只移動切片的起點，下次 append 重新配置底層陣列時才釋放被捨棄的樣本
+type sampleSeries struct {
+	samples  []MetricSample
+	capacity int
+}
+
+// newSampleSeries 建立最多保存 capacity 個樣本的時間序列
+func newSampleSeries(capacity int) *sampleSeries {
+	return &sampleSeries{capacity: capacity}
+}
+
+// add 新增樣本，超過容量時捨棄最舊的樣本
+func (s *sampleSeries) add(sample MetricSample) {
+	s.samples = append(s.samples, sample)
+	if len(s.samples) > s.capacity {
+		s.samples = s.samples[len(s.samples)-s.capacity:]
+	}
+}
+
+// latest 取得最新的樣本
+func (s *sampleSeries) latest() (MetricSample, bool) {
+	if len(s.samples) == 0 {
+		return MetricSample{}, false
+	}
+	return s.samples[len(s.samples)-1], true
+}
+
+// trim 捨棄 cutoff 之前的樣本，回傳剩餘的樣本數
+func (s *sampleSeries) trim(cutoff time.Time) int {
+	s.samples = s.samples[s.index(cutoff):]
+	if len(s.samples) == 0 {
+		s.samples = nil
+	}
+	return len(s.samples)
+}
+
+// index 第一個不早於 t 的樣本位置
+func (s *sampleSeries) index(t time.Time) int {
+	return sort.Search(len(s.samples), func(i int) bool {
+		return !s.samples[i].Timestamp.Before(t)
+	})
+}
+
+// since 依時間由舊到新取得 since 之後的樣本
+func (s *sampleSeries) since(since time.Time) []MetricSample {
+	samples := s.samples[s.index(since):]
+	result := make([]MetricSample, len(samples))
+	copy(result, samples)
+	return result
+}
+
+// MetricsCollector 背景指標收集器，定期從 Metrics API 取樣所有 Pod 與節點的使用量並保存在記憶體中
+type MetricsCollector struct {
+	service   *Service
+	interval  time.Duration
+	retention time.Duration
+	capacity  int
+
+	mu      sync.RWMutex
+	pods    map[string]*sampleSeries // 以 namespace/name 為鍵
+	nodes   map[string]*sampleSeries
+	lastRun time.Time
+	store   *historyStore // 可選的持久化儲存
+}
+
+// MetricsCollectorConfig 指標收集器配置
+type MetricsCollectorConfig struct {
+	Interval  time.Duration // 取樣間隔
+	Retention time.Duration // 每個時間序列的保存時間
+	StorePath string        // 持久化檔案路徑，空字串表示只保存在記憶體中
+}
+
+// newMetricsCollector 建立指標收集器，每個時間序列最多保存 retention/interval 個樣本
+func newMetricsCollector(service *Service, interval, retention time.Duration) *MetricsCollector {
+	if interval <= 0 {
+		interval = defaultMetricsInterval
+	}
+	if retention <= 0 {
+		retention = defaultMetricsRetention
+	}
+
+	capacity := int(retention / interval)
+	if capacity < 1 {
+		capacity = 1
+	}
+
+	return &MetricsCollector{
+		service:   service,
+		interval:  interval,
+		retention: retention,
+		capacity:  capacity,
+		pods:      make(map[string]*sampleSeries),
+		nodes:     make(map[string]*sampleSeries),
+	}
+}
+
+// StartMetricsCollector 啟動背景指標收集，ctx 結束時停止
+// 設定持久化檔案時會先載入檔案中仍在保存時間內的樣本
+func (s *Service) StartMetricsCollector(ctx context.Context, config MetricsCollectorConfig) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.metricsClientset == nil {
+		return fmt.Errorf("Metrics API 不可用，無法啟動指標收集")
+	}
+	if s.collector != nil {
+		return fmt.Errorf("指標收集已啟動")
+	}
+
+	collector := newMetricsCollector(s, config.Interval, config.Retention)
+	if config.StorePath != "" {
+		store, err := openHistoryStore(config.StorePath)
+		if err != nil {
+			return err
+		}
+		collector.store = store
+		if err := collector.restore(); err != nil {
+			store.close()
+			return err
+		}
+	}
+
+	s.collector = collector
+	go s.collector.run(ctx)
+
+	return nil
+}
+
+// restore 從持久化檔案載入樣本並刪除過期的樣本
+func (c *MetricsCollector) restore() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	loaded := 0
+	skipped, err := c.store.load(time.Now().Add(-c.retention), func(record historyRecord) {
+		series := c.pods
+		if record.Kind == "node" {
+			series = c.nodes
+		}
+		if c.record(series, record.Key, record.Sample) {
+			loaded++
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	if c.service.logger != nil {
+		c.service.logger.Printf("已從指標歷史檔案載入 %d 筆樣本", loaded)
+		if skipped > 0 {
+			c.service.logger.Printf("警告: 指標歷史檔案中有 %d 筆紀錄無法解析，已略過", skipped)
+		}
+	}
+
+	return c.expireStore()
+}
+
+// expireStore 刪除持久化檔案中超過保存時間的樣本
+// 持久化儲存只由收集器的 goroutine 存取，不需要持有 c.mu，查詢使用量歷史不會因檔案 I/O 而等待
+func (c *MetricsCollector) expireStore() error {
+	removed, err := c.store.removeExpired(time.Now().Add(-c.retention))
+	if err != nil {
+		return err
+	}
+	c.store.lastExpired = time.Now()
+	if removed > 0 && c.service.logger != nil {
+		c.service.logger.Printf("已刪除 %d 筆過期的指標歷史", removed)
+	}
+	return nil
+}
+
+// run 定期取樣直到 ctx 結束
+func (c *MetricsCollector) run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	if c.store != nil {
+		defer c.store.close()
+	}
+
+	for {
+		if err := c.sample(ctx); err != nil && c.service.logger != nil {
+			c.service.logger.Printf("警告: 指標取樣失敗: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sample 取樣一次所有 Pod 與節點的使用量
+func (c *MetricsCollector) sample(ctx context.Context) error {
+	c.service.mu.RLock()
+	metricsClient := c.service.metricsClientset.MetricsV1beta1()
+	c.service.mu.RUnlock()
+
+	podMetrics, err := metricsClient.PodMetricses("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("無法取得 Pod metrics: %w", err)
+	}
+
+	nodeMetrics, err := metricsClient.NodeMetricses().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("無法取得節點 metrics: %w", err)
+	}
+
+	added := c.recordMetrics(podMetrics.Items, nodeMetrics.Items)
+
+	// 寫入檔案時不持有 c.mu
+	if c.store != nil {
+		if err := c.store.append(added); err != nil {
+			return err
+		}
+		if time.Since(c.store.lastExpired) >= historyExpireInterval {
+			return c.expireStore()
+		}
+	}
+
+	return nil
+}
+
+// recordMetrics 將一次取樣的結果加入時間序列，回傳新增的紀錄
+func (c *MetricsCollector) recordMetrics(podMetrics []metricsv1beta1.PodMetrics, nodeMetrics []metricsv1beta1.NodeMetrics) []historyRecord {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var added []historyRecord
+	for _, metrics := range podMetrics {
+		sample := MetricSample{Timestamp: metrics.Timestamp.Time}
+		for _, container := range metrics.Containers {
+			cpu := container.Usage.Cpu().MilliValue()
+			memory := container.Usage.Memory().Value()
+			sample.CPUMillicores += cpu
+			sample.MemoryBytes += memory
+			sample.Containers = append(sample.Containers, ContainerSample{
+				Name:          container.Name,
+				CPUMillicores: cpu,
+				MemoryBytes:   memory,
+			})
+		}
+		key := metrics.Namespace + "/" + metrics.Name
+		if c.record(c.pods, key, sample) {
+			added = append(added, historyRecord{Kind: "pod", Key: key, Sample: sample})
+		}
+	}
+
+	for _, metrics := range nodeMetrics {
+		sample := MetricSample{
+			Timestamp:     metrics.Timestamp.Time,
+			CPUMillicores: metrics.Usage.Cpu().MilliValue(),
+			MemoryBytes:   metrics.Usage.Memory().Value(),
+		}
+		if c.record(c.nodes, metrics.Name, sample) {
+			added = append(added, historyRecord{Kind: "node", Key: metrics.Name, Sample: sample})
+		}
+	}
+
+	c.lastRun = time.Now()
+	c.prune(c.pods)
+	c.prune(c.nodes)
+
+	return added
+}
+
+// record 將樣本加入時間序列；Metrics API 尚未更新 (時間戳相同) 時略過並回傳 false
+func (c *MetricsCollector) record(series map[string]*sampleSeries, key string, sample MetricSample) bool {
+	samples, ok := series[key]
+	if !ok {
+		samples = newSampleSeries(c.capacity)
+		series[key] = samples
+	}
+	if latest, ok := samples.latest(); ok && !sample.Timestamp.After(latest.Timestamp) {
+		return false
+	}
+	samples.add(sample)
+	return true
+}
+
+// prune 捨棄超過保存時間的樣本，並移除超過保存時間都沒有新樣本的時間序列 (例如已刪除的 Pod)
+func (c *MetricsCollector) prune(series map[string]*sampleSeries) {
+	cutoff := time.Now().Add(-c.retention)
+	for key, samples := range series {
+		if samples.trim(cutoff) == 0 {
+			delete(series, key)
+		}
+	}
+}
+
+// history 取得指定時間序列在 since 之後的樣本
+func (c *MetricsCollector) history(kind, key string, since time.Time) ([]MetricSample, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	series := c.pods
+	if kind == "node" {
+		series = c.nodes
+	}
+
+	samples, ok := series[key]
+	if !ok {
+		return nil, false
+	}
+	return samples.since(since), true
+}
+
+// namespacePodHistories 取得命名空間內所有 Pod 的使用量樣本，以 namespace/name 為鍵，namespace 為空時包含所有命名空間
+func (c *MetricsCollector) namespacePodHistories(namespace string, since time.Time) map[string][]MetricSample {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	result := make(map[string][]MetricSample)
+	for key, series := range c.pods {
+		if namespace != "" && !strings.HasPrefix(key, namespace+"/") {
+			continue
+		}
+		if samples := series.since(since); len(samples) > 0 {
+			result[key] = samples
+		}
+	}
+	return result
+}
+
+// GetMetricsHistory 取得 Pod 或節點在指定時間範圍內的使用量時間序列
+// container 不為空時只回傳該容器的使用量
+func (s *Service) GetMetricsHistory(kind, name, namespace, container string, duration time.Duration) (*MetricsHistory, error) {
+	s.mu.RLock()
+	collector := s.collector
+	if namespace == "" {
+		namespace = s.defaultNamespace
+	}
+	s.mu.RUnlock()
+
+	if collector == nil {
+		return nil, fmt.Errorf("指標收集未啟用，請在 config.json 中設定 \"metrics\": {\"enabled\": true}")
+	}
+
+	kind = strings.ToLower(kind)
+	if kind == "" {
+		kind = "pod"
+	}
+	if kind != "pod" && kind != "node" {
+		return nil, fmt.Errorf("不支援的類型 %q，可用值: pod, node", kind)
+	}
+	if duration <= 0 {
+		duration = defaultHistoryDuration
+	}
+
+	key := namespace + "/" + name
+	if kind == "node" {
+		key = name
+		namespace = ""
+		container = ""
+	}
+
+	since := time.Now().Add(-duration)
+	samples, ok := collector.history(kind, key, since)
+	if !ok {
+		return nil, fmt.Errorf("找不到 %s 的指標紀錄，可能尚未取樣或已超過保存時間", key)
+	}
+
+	history := &MetricsHistory{
+		Kind:            kind,
+		Name:            name,
+		Namespace:       namespace,
+		Container:       container,
+		Since:           since,
+		IntervalSeconds: int(collector.interval.Seconds()),
+		Samples:         make([]MetricSample, 0, len(samples)),
+	}
+
+	for _, sample := range samples {
+		if container != "" {
+			found := false
+			for _, containerSample := range sample.Containers {
+				if containerSample.Name == container {
+					sample.CPUMillicores = containerSample.CPUMillicores
+					sample.MemoryBytes = containerSample.MemoryBytes
+					found = true
+					break
+				}
+			}
+			if !found {
+				continue
+			}
+		}
+		sample.Containers = nil
+		history.Samples = append(history.Samples, sample)
+	}
+
+	history.SampleCount = len(history.Samples)
+	history.Summary = summarizeSamples(history.Samples)
+
+	return history, nil
+}
+
+// summarizeSamples 計算時間序列的最小值、最大值、平均值與最新值，以及資料點涵蓋的時間範圍
+func summarizeSamples(samples []MetricSample) *MetricsSummary {
+	if len(samples) == 0 {
+		return nil
+	}
+
+	summary := &MetricsSummary{Samples: len(samples)}
+	cpu := make([]int64, len(samples))
+	memory := make([]int64, len(samples))
+	for i, sample := range samples {
+		cpu[i] = sample.CPUMillicores
+		memory[i] = sample.MemoryBytes
+		if summary.FirstSeen.IsZero() || sample.Timestamp.Before(summary.FirstSeen) {
+			summary.FirstSeen = sample.Timestamp
+		}
+		if sample.Timestamp.After(summary.LastSeen) {
+			summary.LastSeen = sample.Timestamp
+		}
+	}
+
+	summary.CPUMillicores = summarizeSeries(cpu)
+	summary.MemoryBytes = summarizeSeries(memory)
+	return summary
+}
+
+// summarizeSeries 計算單一數列的統計值
+func summarizeSeries(values []int64) SeriesStats {
+	stats := SeriesStats{
+		Min:    values[0],
+		Max:    values[0],
+		Latest: values[len(values)-1],
+	}
+
+	var total int64
+	sorted := make([]float64, len(values))
+	for i, value := range values {
+		total += value
+		sorted[i] = float64(value)
+		if value < stats.Min {
+			stats.Min = value
+		}
+		if value > stats.Max {
+			stats.Max = value
+		}
+	}
+	stats.Avg = float64(total) / float64(len(values))
+
+	var variance float64
+	for _, value := range values {
+		variance += (float64(value) - stats.Avg) * (float64(value) - stats.Avg)
+	}
+	stats.StdDev = math.Sqrt(variance / float64(len(values)))
+
+	sort.Float64s(sorted)
+	stats.P95 = int64(math.Round(percentile(sorted, 95)))
+
+	return stats
+}
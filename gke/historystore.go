@@ -0,0 +1,158 @@
+package gke
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	// historyExpireInterval 刪除過期樣本的間隔
+	historyExpireInterval = time.Hour
+
+	// historyExpireBatch 每個交易最多刪除的樣本數，避免單一交易占用過多記憶體
+	historyExpireBatch = 10000
+)
+
+// historyBucket 保存樣本的 bucket
+var historyBucket = []byte("samples")
+
+// historyRecord 持久化儲存中的一筆紀錄，以 JSON 保存
+type historyRecord struct {
+	Kind   string       `json:"kind"` // pod 或 node
+	Key    string       `json:"key"`
+	Sample MetricSample `json:"sample"`
+}
+
+// historyStore 以 BoltDB 保存指標樣本，讓使用量歷史在服務重新啟動後仍可使用
+// 鍵以樣本時間 (big-endian 的 Unix 奈秒) 開頭，載入時從保存時間的起點開始讀取，刪除過期樣本時只需從最舊的鍵開始刪除
+type historyStore struct {
+	db          *bolt.DB
+	lastExpired time.Time // 上次刪除過期樣本的時間
+}
+
+// openHistoryStore 開啟 (或建立) BoltDB 檔案
+func openHistoryStore(path string) (*historyStore, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("無法建立指標歷史目錄: %w", err)
+	}
+
+	// 檔案被其他程序開啟時 BoltDB 會等待檔案鎖，設定逾時避免啟動時卡住
+	db, err := bolt.Open(path, 0o644, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("無法開啟指標歷史檔案: %w", err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(historyBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("無法初始化指標歷史檔案: %w", err)
+	}
+	return &historyStore{db: db}, nil
+}
+
+// historyKey 紀錄的鍵：樣本時間、類型與時間序列的鍵，同一時間序列在同一時間只會有一筆
+func historyKey(record historyRecord) []byte {
+	key := historyTimeKey(record.Sample.Timestamp)
+	key = append(key, record.Kind...)
+	key = append(key, 0)
+	return append(key, record.Key...)
+}
+
+// historyTimeKey 時間 t 的鍵前綴，1970 年以前的時間視為最早的鍵
+func historyTimeKey(t time.Time) []byte {
+	key := make([]byte, 8)
+	if t.After(time.Unix(0, 0)) {
+		binary.BigEndian.PutUint64(key, uint64(t.UnixNano()))
+	}
+	return key
+}
+
+// load 依時間順序讀取 since 之後的紀錄，回傳略過的無效紀錄數
+func (st *historyStore) load(since time.Time, fn func(record historyRecord)) (int, error) {
+	skipped := 0
+	err := st.db.View(func(tx *bolt.Tx) error {
+		cursor := tx.Bucket(historyBucket).Cursor()
+		for key, value := cursor.Seek(historyTimeKey(since)); key != nil; key, value = cursor.Next() {
+			var record historyRecord
+			if err := json.Unmarshal(value, &record); err != nil {
+				skipped++
+				continue
+			}
+			fn(record)
+		}
+		return nil
+	})
+	if err != nil {
+		return skipped, fmt.Errorf("讀取指標歷史檔案失敗: %w", err)
+	}
+	return skipped, nil
+}
+
+// append 寫入紀錄
+func (st *historyStore) append(records []historyRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	err := st.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(historyBucket)
+		for _, record := range records {
+			value, err := json.Marshal(record)
+			if err != nil {
+				return err
+			}
+			if err := bucket.Put(historyKey(record), value); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("寫入指標歷史失敗: %w", err)
+	}
+	return nil
+}
+
+// removeExpired 刪除 cutoff 之前的紀錄，回傳刪除的紀錄數
+// 每個交易最多刪除 historyExpireBatch 筆，不會重寫仍保存中的紀錄
+func (st *historyStore) removeExpired(cutoff time.Time) (int, error) {
+	cutoffKey := historyTimeKey(cutoff)
+	removed := 0
+	for {
+		var expired [][]byte
+		err := st.db.Update(func(tx *bolt.Tx) error {
+			bucket := tx.Bucket(historyBucket)
+			cursor := bucket.Cursor()
+			for key, _ := cursor.First(); key != nil && bytes.Compare(key, cutoffKey) < 0 && len(expired) < historyExpireBatch; key, _ = cursor.Next() {
+				expired = append(expired, append([]byte(nil), key...))
+			}
+			for _, key := range expired {
+				if err := bucket.Delete(key); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return removed, fmt.Errorf("無法刪除過期的指標歷史: %w", err)
+		}
+		removed += len(expired)
+		if len(expired) < historyExpireBatch {
+			return removed, nil
+		}
+	}
+}
+
+// close 關閉 BoltDB 檔案
+func (st *historyStore) close() error {
+	return st.db.Close()
+}
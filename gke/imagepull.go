@@ -0,0 +1,190 @@
+package gke
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// 映像檔拉取失敗分類
+const (
+	ImagePullAuth        = "AUTH"
+	ImagePullNotFound    = "NOT_FOUND"
+	ImagePullRateLimit   = "RATE_LIMIT"
+	ImagePullNetwork     = "NETWORK"
+	ImagePullInvalidName = "INVALID_NAME"
+	ImagePullUnknown     = "UNKNOWN"
+)
+
+// imagePullFailureReasons 代表映像檔拉取失敗的容器等待原因
+var imagePullFailureReasons = map[string]bool{
+	"ErrImagePull":      true,
+	"ImagePullBackOff":  true,
+	"InvalidImageName":  true,
+	"ErrImageNeverPull": true,
+}
+
+// imagePullPatterns 依錯誤訊息關鍵字分類拉取失敗原因 (依序比對，先比對到者優先)
+// 不比對純數字狀態碼，避免與映像檔 digest 誤判
+var imagePullPatterns = []struct {
+	category string
+	keywords []string
+}{
+	{ImagePullRateLimit, []string{"toomanyrequests", "too many requests", "rate limit", "pull rate"}},
+	{ImagePullAuth, []string{"unauthorized", "authentication required", "forbidden", "denied", "permission", "no basic auth credentials"}},
+	{ImagePullNotFound, []string{"not found", "manifest unknown", "does not exist", "name unknown"}},
+	{ImagePullNetwork, []string{"i/o timeout", "no such host", "connection refused", "timeout", "tls handshake", "network is unreachable", "dial tcp"}},
+}
+
+// DiagnoseImagePull 檢查 Pod 中映像檔拉取失敗的容器，結合事件與 registry 分類失敗原因
+func (s *Service) DiagnoseImagePull(ctx context.Context, podName, namespace string) (*ImagePullDiagnosis, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if namespace == "" {
+		namespace = s.defaultNamespace
+	}
+
+	pod, err := s.clientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("無法取得 Pod 資訊: %w", err)
+	}
+
+	events, err := s.getPodEvents(ctx, podName, namespace)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Printf("警告: 無法取得 Pod 事件: %v", err)
+		}
+	}
+
+	result := &ImagePullDiagnosis{
+		PodName:   pod.Name,
+		Namespace: pod.Namespace,
+		Issues:    []ImagePullIssue{},
+	}
+	for _, secret := range pod.Spec.ImagePullSecrets {
+		result.ImagePullSecrets = append(result.ImagePullSecrets, secret.Name)
+	}
+
+	statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		if status.State.Waiting == nil || !imagePullFailureReasons[status.State.Waiting.Reason] {
+			continue
+		}
+
+		image := status.Image
+		if spec := findContainerSpec(pod, status.Name); spec != nil {
+			image = spec.Image
+		}
+
+		issue := ImagePullIssue{
+			Container: status.Name,
+			Image:     image,
+			Registry:  ImageRegistry(image),
+			State:     status.State.Waiting.Reason,
+			Message:   status.State.Waiting.Message,
+		}
+
+		// 事件訊息通常包含 registry 回傳的完整錯誤
+		for _, event := range events {
+			if event.Type == corev1.EventTypeWarning && strings.Contains(event.Message, image) {
+				issue.Events = append(issue.Events, event.Message)
+			}
+		}
+
+		issue.Category = classifyImagePullFailure(issue.State, append([]string{issue.Message}, issue.Events...))
+		if issue.Message == "" && len(issue.Events) > 0 {
+			issue.Message = issue.Events[len(issue.Events)-1]
+		}
+		issue.Suggestions = imagePullSuggestions(issue, len(result.ImagePullSecrets) > 0)
+
+		result.Issues = append(result.Issues, issue)
+	}
+
+	result.HasFailures = len(result.Issues) > 0
+	return result, nil
+}
+
+// findContainerSpec 依名稱取得容器規格 (含初始化容器)
+func findContainerSpec(pod *corev1.Pod, name string) *corev1.Container {
+	for _, container := range podContainers(pod) {
+		if container.Name == name {
+			return &container
+		}
+	}
+	return nil
+}
+
+// classifyImagePullFailure 依容器等待原因與錯誤訊息分類拉取失敗原因
+func classifyImagePullFailure(reason string, messages []string) string {
+	if reason == "InvalidImageName" {
+		return ImagePullInvalidName
+	}
+
+	combined := strings.ToLower(strings.Join(messages, "\n"))
+	for _, pattern := range imagePullPatterns {
+		for _, keyword := range pattern.keywords {
+			if strings.Contains(combined, keyword) {
+				return pattern.category
+			}
+		}
+	}
+	return ImagePullUnknown
+}
+
+// ImageRegistry 從映像檔參照中解析 registry 主機，未指定時為 Docker Hub
+func ImageRegistry(image string) string {
+	slash := strings.Index(image, "/")
+	if slash < 0 {
+		return "docker.io"
+	}
+
+	host := image[:slash]
+	// 第一段包含 "." 或 ":" 或為 localhost 時才是 registry 主機，否則屬於 Docker Hub 的命名空間
+	if strings.ContainsAny(host, ".:") || host == "localhost" {
+		return host
+	}
+	return "docker.io"
+}
+
+// imagePullSuggestions 依失敗分類與 registry 提供處理建議
+func imagePullSuggestions(issue ImagePullIssue, hasPullSecrets bool) []string {
+	isGoogleRegistry := strings.HasSuffix(issue.Registry, "gcr.io") || strings.HasSuffix(issue.Registry, "-docker.pkg.dev")
+
+	var suggestions []string
+	switch issue.Category {
+	case ImagePullAuth:
+		if isGoogleRegistry {
+			suggestions = append(suggestions, "確認節點使用的服務帳戶 (或 Workload Identity) 具有 roles/artifactregistry.reader 權限")
+			suggestions = append(suggestions, "若映像檔位於其他專案，需在該專案授予節點服務帳戶讀取權限")
+		} else if hasPullSecrets {
+			suggestions = append(suggestions, "檢查 imagePullSecrets 中的憑證是否正確且未過期")
+		} else {
+			suggestions = append(suggestions, fmt.Sprintf("私有 registry %s 需要在 Pod 或 ServiceAccount 上設定 imagePullSecrets", issue.Registry))
+		}
+	case ImagePullNotFound:
+		suggestions = append(suggestions, fmt.Sprintf("確認映像檔 %s 的名稱與 tag 正確，且已推送到 %s", issue.Image, issue.Registry))
+		suggestions = append(suggestions, "私有 registry 在權限不足時也可能回傳 not found，請一併確認存取權限")
+	case ImagePullRateLimit:
+		if issue.Registry == "docker.io" {
+			suggestions = append(suggestions, "Docker Hub 對匿名拉取有次數限制，建議設定 Docker Hub 帳號的 imagePullSecrets 或改用 Artifact Registry 的 remote repository 快取")
+		} else {
+			suggestions = append(suggestions, fmt.Sprintf("%s 回傳請求過多，請稍後重試或降低同時拉取的節點數量", issue.Registry))
+		}
+	case ImagePullNetwork:
+		suggestions = append(suggestions, fmt.Sprintf("確認節點可以連線到 %s (防火牆規則、Cloud NAT、私有叢集的 Private Google Access)", issue.Registry))
+	case ImagePullInvalidName:
+		suggestions = append(suggestions, "映像檔名稱格式不正確，請檢查是否有大寫字母、多餘空白或錯誤的 tag/digest")
+	default:
+		suggestions = append(suggestions, "請檢查 Pod 事件中的完整錯誤訊息")
+	}
+
+	if issue.State == "ErrImageNeverPull" {
+		suggestions = append(suggestions, "imagePullPolicy 為 Never 但節點上沒有此映像檔，請改用 IfNotPresent 或預先載入映像檔")
+	}
+
+	return suggestions
+}
@@ -0,0 +1,61 @@
+package gke
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetImageSizes 從各節點已下載的映像檔清單取得映像檔大小 (bytes)，以映像檔名稱 (含 tag 或 digest) 為鍵
+// 節點回報的名稱是完整參照 (例如 docker.io/library/nginx:1.25)，查詢時請使用 ImageSize
+func (s *Service) GetImageSizes(ctx context.Context) (map[string]int64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	nodes, err := s.clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("無法取得節點列表: %w", err)
+	}
+
+	sizes := make(map[string]int64)
+	for _, node := range nodes.Items {
+		for _, image := range node.Status.Images {
+			for _, name := range image.Names {
+				sizes[name] = max(sizes[name], image.SizeBytes)
+			}
+		}
+	}
+	return sizes, nil
+}
+
+// ImageSize 查詢容器映像檔的大小，容器規格中的簡寫 (例如 nginx:1.25) 會補上 Docker Hub 的完整路徑，未指定 tag 時為 latest
+func ImageSize(sizes map[string]int64, image string) (int64, bool) {
+	if ImageTag(image) == "" && !strings.Contains(image, "@") {
+		image += ":latest"
+	}
+
+	candidates := []string{image}
+	if ImageRegistry(image) == "docker.io" && !strings.HasPrefix(image, "docker.io/") {
+		candidates = append(candidates, "docker.io/"+image, "docker.io/library/"+image)
+	}
+	for _, candidate := range candidates {
+		if size, ok := sizes[candidate]; ok {
+			return size, true
+		}
+	}
+	return 0, false
+}
+
+// ImageTag 取得映像檔參照的 tag，未指定 tag 時回傳空字串
+func ImageTag(image string) string {
+	if at := strings.Index(image, "@"); at >= 0 {
+		image = image[:at]
+	}
+	name := image[strings.LastIndex(image, "/")+1:]
+	if colon := strings.LastIndex(name, ":"); colon >= 0 {
+		return name[colon+1:]
+	}
+	return ""
+}
@@ -0,0 +1,314 @@
+package gke
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// defaultLeakWindow 偵測記憶體洩漏預設的時間範圍
+	defaultLeakWindow = 24 * time.Hour
+
+	// leakProjectionHorizon 預測 OOM 時間的最長範圍
+	leakProjectionHorizon = 30 * 24 * time.Hour
+
+	// minLeakSamples 判斷趨勢最少需要的樣本數
+	minLeakSamples = 12
+
+	// maxLeakSamples 計算 Kendall tau 時使用的最大樣本數，超過時等間隔抽樣
+	maxLeakSamples = 500
+
+	// leakMinTau Kendall tau 門檻，代表使用量接近單調遞增
+	leakMinTau = 0.6
+
+	// leakMinRSquared 線性趨勢的 R² 門檻
+	leakMinRSquared = 0.7
+
+	// leakMinGrowth 時間範圍內的最小成長比例，避免把小幅波動視為洩漏
+	leakMinGrowth = 0.1
+
+	// restartDropRatio 記憶體下降超過此比例時視為容器重啟，只分析最後一次重啟之後的資料
+	restartDropRatio = 0.5
+)
+
+// DetectMemoryLeaks 分析命名空間內各容器在時間範圍內的記憶體使用量，找出持續單調成長的容器
+// 以 Kendall tau 判斷單調性、以線性趨勢計算成長率，並依記憶體限制預測 OOM 時間
+func (s *Service) DetectMemoryLeaks(ctx context.Context, namespace, source string, window time.Duration) (*MemoryLeakReport, error) {
+	if window <= 0 {
+		window = defaultLeakWindow
+	}
+
+	source, err := s.resolveUsageSource(source)
+	if err != nil {
+		return nil, err
+	}
+
+	namespace, containers, warnings, err := s.runningContainerMemory(ctx, namespace, source, window)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &MemoryLeakReport{
+		Namespace:          namespace,
+		Source:             source,
+		Window:             window.String(),
+		ContainersAnalyzed: len(containers),
+		Leaks:              []MemoryLeak{},
+		Warnings:           warnings,
+	}
+
+	now := time.Now()
+	for _, container := range containers {
+		leak := detectMemoryLeak(container.points, container.limit, now)
+		if leak == nil {
+			continue
+		}
+		kind, workload := podWorkload(container.pod)
+		leak.Namespace = container.pod.Namespace
+		leak.PodName = container.pod.Name
+		leak.Container = container.name
+		leak.WorkloadKind = kind
+		leak.WorkloadName = workload
+		report.Leaks = append(report.Leaks, *leak)
+	}
+
+	// 越快 OOM 的排在前面，沒有預測時間的依成長率排序
+	sort.Slice(report.Leaks, func(i, j int) bool {
+		a, b := report.Leaks[i], report.Leaks[j]
+		if (a.ProjectedOOMAt == nil) != (b.ProjectedOOMAt == nil) {
+			return a.ProjectedOOMAt != nil
+		}
+		if a.ProjectedOOMAt != nil && !a.ProjectedOOMAt.Equal(*b.ProjectedOOMAt) {
+			return a.ProjectedOOMAt.Before(*b.ProjectedOOMAt)
+		}
+		return a.GrowthPercentPerDay > b.GrowthPercentPerDay
+	})
+
+	return report, nil
+}
+
+// containerMemory 單一容器的記憶體時間序列與記憶體限制
+type containerMemory struct {
+	pod    *corev1.Pod
+	name   string
+	points []SeriesPoint
+	limit  float64 // 記憶體限制 (bytes)，未設定時為 0
+}
+
+// runningContainerMemory 取得命名空間內 Running Pod 各容器在時間範圍內的記憶體時間序列，回傳解析後的命名空間
+// 單一命名空間查詢失敗時記錄為警告並繼續處理其他命名空間
+func (s *Service) runningContainerMemory(ctx context.Context, namespace, source string, window time.Duration) (string, []containerMemory, []string, error) {
+	s.mu.RLock()
+	namespace = s.resolveListNamespace(namespace)
+	podList, err := s.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	s.mu.RUnlock()
+	if err != nil {
+		return namespace, nil, nil, fmt.Errorf("無法取得 Pod 列表: %w", err)
+	}
+
+	podsByNamespace := make(map[string][]*corev1.Pod)
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if pod.Status.Phase == corev1.PodRunning {
+			podsByNamespace[pod.Namespace] = append(podsByNamespace[pod.Namespace], pod)
+		}
+	}
+
+	var containers []containerMemory
+	var warnings []string
+	for podNamespace, pods := range podsByNamespace {
+		names := make([]string, len(pods))
+		for i, pod := range pods {
+			names[i] = pod.Name
+		}
+
+		series, err := s.containerMemorySeries(ctx, source, podNamespace, names, window)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("%s: %v", podNamespace, err))
+			continue
+		}
+
+		for _, pod := range pods {
+			for container, points := range series[pod.Name] {
+				var limit float64
+				for _, spec := range pod.Spec.Containers {
+					if spec.Name == container {
+						limit = spec.Resources.Limits.Memory().AsApproximateFloat64()
+					}
+				}
+				containers = append(containers, containerMemory{pod: pod, name: container, points: points, limit: limit})
+			}
+		}
+	}
+	return namespace, containers, warnings, nil
+}
+
+// containerMemorySeries 從歷史資料來源取得各容器的記憶體時間序列，以 Pod 名稱與容器名稱為鍵
+func (s *Service) containerMemorySeries(ctx context.Context, source, namespace string, pods []string, window time.Duration) (map[string]map[string][]SeriesPoint, error) {
+	end := time.Now()
+	start := end.Add(-window)
+	result := make(map[string]map[string][]SeriesPoint, len(pods))
+	add := func(pod, container string, point SeriesPoint) {
+		if result[pod] == nil {
+			result[pod] = make(map[string][]SeriesPoint)
+		}
+		result[pod][container] = append(result[pod][container], point)
+	}
+
+	switch source {
+	case UsageSourceCloudMonitoring:
+		for _, pod := range pods {
+			usage, err := s.GetHistoricalUsage(ctx, pod, namespace, window, 0)
+			if err != nil {
+				return nil, err
+			}
+			for _, container := range usage.Containers {
+				for _, sample := range container.Samples {
+					add(pod, container.Name, SeriesPoint{Timestamp: sample.Timestamp, Value: float64(sample.MemoryBytes)})
+				}
+			}
+		}
+	case UsageSourcePrometheus:
+		s.mu.RLock()
+		client := s.prometheus
+		s.mu.RUnlock()
+
+		quoted := make([]string, len(pods))
+		for i, pod := range pods {
+			quoted[i] = regexp.QuoteMeta(pod)
+		}
+		selector := fmt.Sprintf(`namespace=%q,pod=~%q,container!="",container!="POD"`, namespace, strings.Join(quoted, "|"))
+		series, err := client.queryRange(ctx, fmt.Sprintf(`sum by (pod, container) (container_memory_working_set_bytes{%s})`, selector), start, end, defaultPrometheusStep)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range series {
+			for _, point := range item.Points {
+				add(item.Labels["pod"], item.Labels["container"], point)
+			}
+		}
+	case UsageSourceCollector:
+		s.mu.RLock()
+		collector := s.collector
+		s.mu.RUnlock()
+
+		for _, pod := range pods {
+			samples, ok := collector.history("pod", namespace+"/"+pod, start)
+			if !ok {
+				continue
+			}
+			for _, sample := range samples {
+				for _, container := range sample.Containers {
+					add(pod, container.Name, SeriesPoint{Timestamp: sample.Timestamp, Value: float64(container.MemoryBytes)})
+				}
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// detectMemoryLeak 判斷記憶體時間序列是否呈現持續成長，不像洩漏時回傳 nil
+func detectMemoryLeak(points []SeriesPoint, limit float64, now time.Time) *MemoryLeak {
+	points = sinceLastRestart(points)
+	if len(points) < minLeakSamples {
+		return nil
+	}
+
+	trend := fitLinearTrend(points)
+	startValue := trend.at(points[0].Timestamp)
+	endValue := trend.at(points[len(points)-1].Timestamp)
+	if trend.slopePerHour <= 0 || startValue <= 0 || (endValue-startValue)/startValue < leakMinGrowth {
+		return nil
+	}
+
+	tau := kendallTau(points)
+	if tau < leakMinTau || trend.rSquared < leakMinRSquared {
+		return nil
+	}
+
+	current := points[len(points)-1].Value
+	leak := &MemoryLeak{
+		SegmentStart:        points[0].Timestamp,
+		SampleCount:         len(points),
+		StartBytes:          int64(points[0].Value),
+		CurrentBytes:        int64(current),
+		GrowthBytesPerHour:  int64(trend.slopePerHour),
+		GrowthPercentPerDay: math.Round(trend.slopePerHour*24/startValue*1000) / 10,
+		KendallTau:          math.Round(tau*1000) / 1000,
+		RSquared:            math.Round(trend.rSquared*1000) / 1000,
+		Confidence:          "medium",
+	}
+	if tau >= 0.8 && trend.rSquared >= 0.9 {
+		leak.Confidence = "high"
+	}
+
+	if limit > 0 {
+		leak.MemoryLimitBytes = int64(limit)
+		leak.ProjectedOOMAt = projectCrossing(current, trend.slopePerHour, limit, now, leakProjectionHorizon)
+		if leak.ProjectedOOMAt != nil {
+			leak.Description = fmt.Sprintf("記憶體每小時成長約 %dMi，預計於 %s 達到限制 %dMi 而被 OOMKilled",
+				leak.GrowthBytesPerHour/(1024*1024), leak.ProjectedOOMAt.Format(time.RFC3339), leak.MemoryLimitBytes/(1024*1024))
+		} else {
+			leak.Description = fmt.Sprintf("記憶體每小時成長約 %dMi，%d 天內不會達到限制 %dMi",
+				leak.GrowthBytesPerHour/(1024*1024), int(leakProjectionHorizon.Hours()/24), leak.MemoryLimitBytes/(1024*1024))
+		}
+	} else {
+		leak.Description = fmt.Sprintf("記憶體每小時成長約 %dMi，容器未設定記憶體限制，持續成長將耗盡節點記憶體", leak.GrowthBytesPerHour/(1024*1024))
+	}
+
+	return leak
+}
+
+// sinceLastRestart 依時間排序記憶體時間序列，容器重啟後記憶體會大幅下降，只保留最後一次重啟之後的資料
+func sinceLastRestart(points []SeriesPoint) []SeriesPoint {
+	sort.Slice(points, func(i, j int) bool {
+		return points[i].Timestamp.Before(points[j].Timestamp)
+	})
+
+	segment := 0
+	for i := 1; i < len(points); i++ {
+		if points[i].Value < points[i-1].Value*(1-restartDropRatio) {
+			segment = i
+		}
+	}
+	return points[segment:]
+}
+
+// kendallTau 計算時間序列的 Kendall tau (1 代表嚴格遞增，-1 代表嚴格遞減)，樣本過多時等間隔抽樣
+func kendallTau(points []SeriesPoint) float64 {
+	values := make([]float64, 0, maxLeakSamples)
+	step := float64(len(points)) / maxLeakSamples
+	if step < 1 {
+		step = 1
+	}
+	for i := 0.0; int(i) < len(points); i += step {
+		values = append(values, points[int(i)].Value)
+	}
+
+	concordant, discordant := 0, 0
+	for i := 0; i < len(values); i++ {
+		for j := i + 1; j < len(values); j++ {
+			switch {
+			case values[j] > values[i]:
+				concordant++
+			case values[j] < values[i]:
+				discordant++
+			}
+		}
+	}
+
+	pairs := len(values) * (len(values) - 1) / 2
+	if pairs == 0 {
+		return 0
+	}
+	return float64(concordant-discordant) / float64(pairs)
+}
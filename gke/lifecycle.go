@@ -0,0 +1,107 @@
+package gke
+
+import (
+	"context"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetWorkloadLifecycle 取得可能已不再使用的工作負載狀態：副本數為 0 的 Deployment、所有 Job，以及沒有控制器的 ReplicaSet
+func (s *Service) GetWorkloadLifecycle(ctx context.Context, namespace string) (*WorkloadLifecycle, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	listNamespace := s.resolveListNamespace(namespace)
+	result := &WorkloadLifecycle{}
+
+	deployments, err := s.clientset.AppsV1().Deployments(listNamespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("無法取得 Deployment 列表: %w", err)
+	}
+	for _, deployment := range deployments.Items {
+		if deployment.Spec.Replicas == nil || *deployment.Spec.Replicas != 0 {
+			continue
+		}
+		// 縮減為 0 時 Deployment 的狀態條件會更新，以最後更新時間估算縮減的時間
+		lastUpdate := deployment.CreationTimestamp.Time
+		for _, condition := range deployment.Status.Conditions {
+			if condition.LastUpdateTime.After(lastUpdate) {
+				lastUpdate = condition.LastUpdateTime.Time
+			}
+		}
+		result.ScaledDownDeployments = append(result.ScaledDownDeployments, ScaledDownDeployment{
+			Name:       deployment.Name,
+			Namespace:  deployment.Namespace,
+			LastUpdate: lastUpdate,
+		})
+	}
+
+	jobs, err := s.clientset.BatchV1().Jobs(listNamespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("無法取得 Job 列表: %w", err)
+	}
+	for _, job := range jobs.Items {
+		result.Jobs = append(result.Jobs, convertJobState(&job))
+	}
+
+	replicaSets, err := s.clientset.AppsV1().ReplicaSets(listNamespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("無法取得 ReplicaSet 列表: %w", err)
+	}
+	for _, replicaSet := range replicaSets.Items {
+		if metav1.GetControllerOf(&replicaSet) != nil || replicaSet.Status.Replicas == 0 {
+			continue
+		}
+		result.OrphanedReplicaSets = append(result.OrphanedReplicaSets, OrphanedReplicaSet{
+			Name:      replicaSet.Name,
+			Namespace: replicaSet.Namespace,
+			Replicas:  replicaSet.Status.Replicas,
+			CreatedAt: replicaSet.CreationTimestamp.Time,
+		})
+	}
+
+	return result, nil
+}
+
+// convertJobState 將 Job 轉換為完成狀態摘要
+func convertJobState(job *batchv1.Job) JobState {
+	state := JobState{
+		Name:                    job.Name,
+		Namespace:               job.Namespace,
+		Active:                  job.Status.Active,
+		Succeeded:               job.Status.Succeeded,
+		Failed:                  job.Status.Failed,
+		TTLSecondsAfterFinished: job.Spec.TTLSecondsAfterFinished,
+		ActiveDeadlineSeconds:   job.Spec.ActiveDeadlineSeconds,
+		CreatedAt:               job.CreationTimestamp.Time,
+	}
+	if owner := metav1.GetControllerOf(job); owner != nil && owner.Kind == "CronJob" {
+		state.CronJob = owner.Name
+	}
+	if job.Spec.BackoffLimit != nil {
+		state.BackoffLimit = *job.Spec.BackoffLimit
+	}
+	if job.Status.CompletionTime != nil {
+		state.FinishedAt = job.Status.CompletionTime.Time
+	}
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			state.Complete = true
+		case batchv1.JobFailed:
+			state.FailedPermanently = true
+		default:
+			continue
+		}
+		if state.FinishedAt.IsZero() {
+			state.FinishedAt = condition.LastTransitionTime.Time
+		}
+	}
+	return state
+}
@@ -0,0 +1,116 @@
+package gke
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// DeletePod 刪除 Pod，confirm 必須與 Pod 名稱相同以避免誤刪
+// gracePeriodSeconds 為 nil 時使用 Pod 自身的 terminationGracePeriodSeconds
+func (s *Service) DeletePod(ctx context.Context, podName, namespace, confirm string, gracePeriodSeconds *int64) (*PodDeletion, error) {
+	if err := s.ensureWriteEnabled(); err != nil {
+		return nil, err
+	}
+	if confirm != podName {
+		return nil, fmt.Errorf("確認參數不符，請將 confirm 設為要刪除的 Pod 名稱 %q", podName)
+	}
+	if gracePeriodSeconds != nil && *gracePeriodSeconds < 0 {
+		return nil, fmt.Errorf("寬限期不可為負數")
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if namespace == "" {
+		namespace = s.defaultNamespace
+	}
+
+	pod, err := s.clientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("無法取得 Pod 資訊: %w", err)
+	}
+
+	// 以 UID 作為前置條件，確保刪除的是剛才檢查的 Pod，而不是同名的新 Pod
+	err = s.clientset.CoreV1().Pods(namespace).Delete(ctx, podName, metav1.DeleteOptions{
+		GracePeriodSeconds: gracePeriodSeconds,
+		Preconditions:      &metav1.Preconditions{UID: &pod.UID},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("無法刪除 Pod: %w", err)
+	}
+
+	s.auditWrite("刪除 Pod %s/%s (狀態: %s, 原因: %s)", namespace, podName, pod.Status.Phase, pod.Status.Reason)
+
+	result := &PodDeletion{
+		PodName:            podName,
+		Namespace:          namespace,
+		PreviousStatus:     string(pod.Status.Phase),
+		PreviousReason:     pod.Status.Reason,
+		GracePeriodSeconds: gracePeriodSeconds,
+		Message:            fmt.Sprintf("已送出刪除 Pod %s 的請求", podName),
+	}
+	if gracePeriodSeconds == nil {
+		result.GracePeriodSeconds = pod.Spec.TerminationGracePeriodSeconds
+	}
+
+	return result, nil
+}
+
+// CordonNode 將節點標記為不可排程
+func (s *Service) CordonNode(ctx context.Context, nodeName string) (*NodeSchedulingChange, error) {
+	return s.setNodeUnschedulable(ctx, nodeName, true)
+}
+
+// UncordonNode 將節點恢復為可排程
+func (s *Service) UncordonNode(ctx context.Context, nodeName string) (*NodeSchedulingChange, error) {
+	return s.setNodeUnschedulable(ctx, nodeName, false)
+}
+
+// setNodeUnschedulable 設定節點的 spec.unschedulable
+func (s *Service) setNodeUnschedulable(ctx context.Context, nodeName string, unschedulable bool) (*NodeSchedulingChange, error) {
+	if err := s.ensureWriteEnabled(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.updateNodeUnschedulable(ctx, nodeName, unschedulable)
+}
+
+// updateNodeUnschedulable 更新節點的 spec.unschedulable (呼叫端需持有讀鎖)
+func (s *Service) updateNodeUnschedulable(ctx context.Context, nodeName string, unschedulable bool) (*NodeSchedulingChange, error) {
+	node, err := s.clientset.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("無法取得節點資訊: %w", err)
+	}
+
+	action := "恢復排程"
+	if unschedulable {
+		action = "停止排程"
+	}
+
+	result := &NodeSchedulingChange{
+		NodeName:      nodeName,
+		Unschedulable: unschedulable,
+	}
+	if node.Spec.Unschedulable == unschedulable {
+		result.Message = fmt.Sprintf("節點 %s 已是%s狀態，未做變更", nodeName, action)
+		return result, nil
+	}
+
+	patch := fmt.Sprintf(`{"spec":{"unschedulable":%t}}`, unschedulable)
+	_, err = s.clientset.CoreV1().Nodes().Patch(ctx, nodeName, types.StrategicMergePatchType, []byte(patch), metav1.PatchOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("無法更新節點排程狀態: %w", err)
+	}
+
+	s.auditWrite("節點 %s %s", nodeName, action)
+
+	result.Changed = true
+	result.Message = fmt.Sprintf("節點 %s 已%s", nodeName, action)
+	return result, nil
+}
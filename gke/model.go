@@ -4,25 +4,77 @@ import "time"
 
 // Pod 基本資訊
 type Pod struct {
-	Name       string            `json:"name"`
-	Namespace  string            `json:"namespace"`
-	Status     string            `json:"status"`
-	NodeName   string            `json:"nodeName"`
-	PodIP      string            `json:"podIP"`
-	HostIP     string            `json:"hostIP"`
-	Labels     map[string]string `json:"labels"`
-	CreatedAt  time.Time         `json:"createdAt"`
-	Ready      bool              `json:"ready"`
-	Containers []Container       `json:"containers"`
+	Name        string            `json:"name"`
+	Namespace   string            `json:"namespace"`
+	Status      string            `json:"status"`
+	NodeName    string            `json:"nodeName"`
+	PodIP       string            `json:"podIP"`
+	HostIP      string            `json:"hostIP"`
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	CreatedAt   time.Time         `json:"createdAt"`
+	Ready       bool              `json:"ready"`
+	Reason      string            `json:"reason,omitempty"`  // Pod 層級原因 (例如 Evicted, Preempting)
+	Message     string            `json:"message,omitempty"` // Pod 層級訊息
+	QOSClass    string            `json:"qosClass"`          // Guaranteed, Burstable, BestEffort
+	Containers  []Container       `json:"containers"`
+
+	InitContainers      []Container `json:"initContainers,omitempty"`
+	EphemeralContainers []Container `json:"ephemeralContainers,omitempty"` // 臨時除錯容器
+
+	Conditions      []PodCondition   `json:"conditions,omitempty"`
+	OwnerReferences []OwnerReference `json:"ownerReferences,omitempty"`
+}
+
+// Pod 狀態條件 (例如 PodScheduled, ContainersReady)
+type PodCondition struct {
+	Type               string    `json:"type"`
+	Status             string    `json:"status"`
+	Reason             string    `json:"reason,omitempty"`
+	Message            string    `json:"message,omitempty"`
+	LastTransitionTime time.Time `json:"lastTransitionTime"`
+}
+
+// 擁有者參照 (例如 ReplicaSet, StatefulSet, Job)
+type OwnerReference struct {
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	Controller bool   `json:"controller"`
 }
 
 // 容器資訊
 type Container struct {
+	Name              string             `json:"name"`
+	Image             string             `json:"image"`
+	Status            string             `json:"status"`
+	Ready             bool               `json:"ready"`
+	Restart           int32              `json:"restartCount"`
+	Reason            string             `json:"reason,omitempty"`  // 等待或終止原因 (例如: CrashLoopBackOff, Error)
+	Message           string             `json:"message,omitempty"` // 等待或終止訊息
+	ExitCode          *int32             `json:"exitCode,omitempty"`
+	LastRestartAt     *time.Time         `json:"lastRestartAt,omitempty"`     // 最近一次重啟 (上次終止) 時間
+	ExtendedResources []ExtendedResource `json:"extendedResources,omitempty"` // 擴充資源 (例如 nvidia.com/gpu)
+
+	Resources ResourceRequirements `json:"resources"` // CPU 與記憶體的請求量與限制量
+
+	LivenessProbe  *ProbeInfo `json:"livenessProbe,omitempty"`
+	ReadinessProbe *ProbeInfo `json:"readinessProbe,omitempty"`
+	StartupProbe   *ProbeInfo `json:"startupProbe,omitempty"`
+}
+
+// ResourceRequirements 容器的 CPU 與記憶體請求量與限制量，未設定的欄位為空字串
+type ResourceRequirements struct {
+	CPURequest    string `json:"cpuRequest,omitempty"`
+	CPULimit      string `json:"cpuLimit,omitempty"`
+	MemoryRequest string `json:"memoryRequest,omitempty"`
+	MemoryLimit   string `json:"memoryLimit,omitempty"`
+}
+
+// 擴充資源的請求量與限制量
+type ExtendedResource struct {
 	Name    string `json:"name"`
-	Image   string `json:"image"`
-	Status  string `json:"status"`
-	Ready   bool   `json:"ready"`
-	Restart int32  `json:"restartCount"`
+	Request string `json:"request"`
+	Limit   string `json:"limit"`
 }
 
 // 資源使用狀況
@@ -32,6 +84,7 @@ type ResourceUsage struct {
 	CPU        CPUUsage         `json:"cpu"`
 	Memory     MemoryUsage      `json:"memory"`
 	Disk       DiskUsage        `json:"disk"`
+	GPU        *GPUUsage        `json:"gpu,omitempty"` // 僅在 Pod 請求 GPU 時提供
 	Timestamp  time.Time        `json:"timestamp"`
 	Containers []ContainerUsage `json:"containers"`
 }
@@ -42,6 +95,21 @@ type CPUUsage struct {
 	Percentage float64 `json:"percentage"` // 使用百分比
 	Limit      string  `json:"limit"`      // 限制量
 	Request    string  `json:"request"`    // 請求量
+
+	// Throttling CPU 節流狀況，Pod 層級為節流最嚴重的容器；無法取得節流指標時為 nil
+	Throttling *CPUThrottling `json:"throttling,omitempty"`
+}
+
+// CPUThrottling CPU CFS 節流狀況
+// 平均使用率低但節流比例高代表 CPU 限制過低，突發負載被限制住
+type CPUThrottling struct {
+	Container           string  `json:"container,omitempty"`        // 僅在 Pod 層級提供
+	Source              string  `json:"source"`                     // prometheus 或 cadvisor
+	Window              string  `json:"window"`                     // 1h (prometheus) 或 sinceContainerStart (cadvisor 累計值)
+	Periods             int64   `json:"periods,omitempty"`          // CFS 週期數 (僅 cadvisor)
+	ThrottledPeriods    int64   `json:"throttledPeriods,omitempty"` // 被節流的 CFS 週期數 (僅 cadvisor)
+	ThrottledSeconds    float64 `json:"throttledSeconds"`           // 被節流的總秒數
+	ThrottledPercentage float64 `json:"throttledPercentage"`        // 被節流的 CFS 週期比例 (%)
 }
 
 // 記憶體使用狀況
@@ -52,12 +120,22 @@ type MemoryUsage struct {
 	Request    string  `json:"request"`    // 請求量
 }
 
+// GPU 使用狀況
+type GPUUsage struct {
+	Resource          string  `json:"resource"`          // 資源名稱 (例如: "nvidia.com/gpu")
+	Request           string  `json:"request"`           // 請求量
+	Limit             string  `json:"limit"`             // 限制量
+	Utilization       float64 `json:"utilization"`       // GPU 使用率百分比 (來自 DCGM)
+	MetricsAvailable  bool    `json:"metricsAvailable"`  // 是否取得 DCGM 使用率資料
+	UtilizationSource string  `json:"utilizationSource"` // 使用率資料來源
+}
+
 // 磁碟使用狀況
 type DiskUsage struct {
-	Used      string            `json:"used"`      // 已使用空間
+	Used      string            `json:"used"`      // ephemeral storage 已使用空間
 	Available string            `json:"available"` // 可用空間
-	Total     string            `json:"total"`     // 總空間
-	Volumes   map[string]Volume `json:"volumes"`   // 各個掛載點的使用狀況
+	Total     string            `json:"total"`     // ephemeral-storage limit 總和，未設定時為已使用加上節點磁碟剩餘空間
+	Volumes   map[string]Volume `json:"volumes"`   // 各個卷的使用狀況
 }
 
 // 磁碟卷資訊
@@ -67,7 +145,7 @@ type Volume struct {
 	MountPath string `json:"mountPath"`
 	Used      string `json:"used"`
 	Available string `json:"available"`
-	Total     string `json:"total"`
+	Total     string `json:"total"` // 獨立的總量 (PVC 容量或 emptyDir sizeLimit)，與節點共用磁碟的卷為空
 }
 
 // 容器資源使用狀況
@@ -87,18 +165,1191 @@ type PodDetails struct {
 
 // Pod 事件
 type Event struct {
-	Type      string    `json:"type"`
-	Reason    string    `json:"reason"`
-	Message   string    `json:"message"`
-	Timestamp time.Time `json:"timestamp"`
-	Source    string    `json:"source"`
+	Type      string     `json:"type"`
+	Reason    string     `json:"reason"`
+	Message   string     `json:"message"`
+	Timestamp time.Time  `json:"timestamp"`
+	Source    string     `json:"source"`
+	Count     int32      `json:"count,omitempty"`    // 事件重複發生次數
+	LastSeen  *time.Time `json:"lastSeen,omitempty"` // 最後一次發生時間
 }
 
 // 搜尋條件
 type SearchCriteria struct {
-	Namespace     string            `json:"namespace"`
-	LabelSelector string            `json:"labelSelector"`
-	FieldSelector string            `json:"fieldSelector"`
-	Status        string            `json:"status"`
+	Namespace      string            `json:"namespace"`
+	Namespaces     []string          `json:"namespaces"` // 多命名空間搜尋，優先於 Namespace
+	LabelSelector  string            `json:"labelSelector"`
+	FieldSelector  string            `json:"fieldSelector"`
+	Status         string            `json:"status"`
+	NodeName       string            `json:"nodeName"`
+	QOSClass       string            `json:"qosClass"`
+	NamePattern    string            `json:"namePattern"`    // Pod 名稱比對模式
+	PatternType    string            `json:"patternType"`    // glob (預設) 或 regex
+	CreatedAfter   time.Time         `json:"createdAfter"`   // 零值表示不過濾
+	CreatedBefore  time.Time         `json:"createdBefore"`  // 零值表示不過濾
+	RestartedSince time.Time         `json:"restartedSince"` // 零值表示不過濾
+	Labels         map[string]string `json:"labels"`
+	Limit          int64             `json:"limit"`
+	Continue       string            `json:"continue"`
+}
+
+// 分頁參數
+type PageOptions struct {
+	Limit    int64  `json:"limit"`    // 每頁最大筆數，0 表示不限制
+	Continue string `json:"continue"` // 上一頁回傳的 continue 游標
+}
+
+// Pod 列表 (含分頁資訊)
+type PodList struct {
+	Items              []Pod  `json:"items"`
+	Continue           string `json:"continue,omitempty"`           // 下一頁游標，空字串表示沒有更多資料
+	RemainingItemCount *int64 `json:"remainingItemCount,omitempty"` // 剩餘筆數 (僅在 API 伺服器提供時)
+}
+
+// Pod 分佈狀況 (依節點與可用區分組)
+type PodDistribution struct {
+	Namespace     string              `json:"namespace"`
+	LabelSelector string              `json:"labelSelector"`
+	TotalPods     int                 `json:"totalPods"`
+	ByNode        map[string][]string `json:"byNode"`     // 節點名稱 -> Pod 名稱列表
+	ByZone        map[string][]string `json:"byZone"`     // 可用區 -> Pod 名稱列表
+	SingleNode    bool                `json:"singleNode"` // 所有 Pod 集中在單一節點
+	SingleZone    bool                `json:"singleZone"` // 所有 Pod 集中在單一可用區
+	Warnings      []string            `json:"warnings,omitempty"`
+}
+
+// Pod 探針設定
+type PodProbes struct {
+	PodName    string            `json:"podName"`
+	Namespace  string            `json:"namespace"`
+	Containers []ContainerProbes `json:"containers"`
+}
+
+// 容器探針設定
+type ContainerProbes struct {
+	Container     string     `json:"container"`
+	Liveness      *ProbeInfo `json:"liveness,omitempty"`
+	Readiness     *ProbeInfo `json:"readiness,omitempty"`
+	Startup       *ProbeInfo `json:"startup,omitempty"`
+	MissingProbes []string   `json:"missingProbes,omitempty"` // 未設定的探針類型 (liveness, readiness, startup)
+}
+
+// 探針資訊
+type ProbeInfo struct {
+	Type                string   `json:"type"` // HTTP, TCP, Exec, gRPC
+	Path                string   `json:"path,omitempty"`
+	Port                string   `json:"port,omitempty"`
+	Scheme              string   `json:"scheme,omitempty"`
+	Command             []string `json:"command,omitempty"`
+	InitialDelaySeconds int32    `json:"initialDelaySeconds"`
+	PeriodSeconds       int32    `json:"periodSeconds"`
+	TimeoutSeconds      int32    `json:"timeoutSeconds"`
+	SuccessThreshold    int32    `json:"successThreshold"`
+	FailureThreshold    int32    `json:"failureThreshold"`
+}
+
+// 依標籤分組的 Pod 統計
+type LabelAggregation struct {
+	Namespace        string       `json:"namespace"`
+	LabelKey         string       `json:"labelKey"`
+	MetricsAvailable bool         `json:"metricsAvailable"` // 是否取得資源使用量
+	Groups           []LabelGroup `json:"groups"`
+}
+
+// 標籤分組統計
+type LabelGroup struct {
+	Value         string   `json:"value"` // 標籤值，未設定標籤的 Pod 歸類於 "<none>"
+	PodCount      int      `json:"podCount"`
+	ReadyCount    int      `json:"readyCount"`
+	ReadyRatio    float64  `json:"readyRatio"` // 0-1
+	TotalRestarts int32    `json:"totalRestarts"`
+	CPUUsage      string   `json:"cpuUsage"`    // 加總 CPU 使用量 (例如: "250m")
+	MemoryUsage   string   `json:"memoryUsage"` // 加總記憶體使用量 (例如: "512Mi")
+	Pods          []string `json:"pods"`
+}
+
+// 選取 Pod 的 Service 資訊
+type PodServices struct {
+	PodName   string        `json:"podName"`
+	Namespace string        `json:"namespace"`
+	Services  []ServiceInfo `json:"services"`
+}
+
+// Service 基本資訊
+type ServiceInfo struct {
+	Name      string            `json:"name"`
+	Type      string            `json:"type"`
+	ClusterIP string            `json:"clusterIP"`
+	Selector  map[string]string `json:"selector"`
+	Ports     []ServicePort     `json:"ports"`
+}
+
+// Service 連接埠
+type ServicePort struct {
+	Name       string `json:"name,omitempty"`
+	Protocol   string `json:"protocol"`
+	Port       int32  `json:"port"`
+	TargetPort string `json:"targetPort"`
+	NodePort   int32  `json:"nodePort,omitempty"`
+}
+
+// Pod 內部連線檢查結果
+type ConnectivityCheck struct {
+	PodName       string  `json:"podName"`
+	Namespace     string  `json:"namespace"`
+	Container     string  `json:"container"`
+	Protocol      string  `json:"protocol"` // TCP 或 HTTP
+	Target        string  `json:"target"`
+	Reachable     bool    `json:"reachable"`
+	StatusCode    int     `json:"statusCode,omitempty"` // HTTP 狀態碼 (僅 HTTP 且容器內有 curl 時)
+	LatencyMs     float64 `json:"latencyMs"`
+	LatencySource string  `json:"latencySource"` // curl: 容器內量測; exec-roundtrip: 包含 exec 往返時間
+	Tool          string  `json:"tool"`          // 容器內使用的檢查工具 (curl, wget, nc, bash)
+	Output        string  `json:"output,omitempty"`
+	Error         string  `json:"error,omitempty"`
+}
+
+// Pod 綜合描述 (類似 kubectl describe pod)
+type PodDescription struct {
+	Pod               Pod               `json:"pod"` // 基本資訊、狀態條件與容器狀態
+	StartTime         *time.Time        `json:"startTime,omitempty"`
+	ServiceAccount    string            `json:"serviceAccount"`
+	PriorityClassName string            `json:"priorityClassName,omitempty"`
+	RestartPolicy     string            `json:"restartPolicy"`
+	NodeSelector      map[string]string `json:"nodeSelector,omitempty"`
+	Tolerations       []Toleration      `json:"tolerations,omitempty"`
+	ContainerSpecs    []ContainerSpec   `json:"containerSpecs"`
+	Volumes           []PodVolume       `json:"volumes,omitempty"`
+	Events            []Event           `json:"events"`
+}
+
+// 容器規格
+type ContainerSpec struct {
+	Name         string            `json:"name"`
+	Image        string            `json:"image"`
+	Command      []string          `json:"command,omitempty"`
+	Args         []string          `json:"args,omitempty"`
+	Ports        []ContainerPort   `json:"ports,omitempty"`
+	Requests     map[string]string `json:"requests,omitempty"`
+	Limits       map[string]string `json:"limits,omitempty"`
+	VolumeMounts []VolumeMount     `json:"volumeMounts,omitempty"`
+}
+
+// 容器連接埠
+type ContainerPort struct {
+	Name          string `json:"name,omitempty"`
+	ContainerPort int32  `json:"containerPort"`
+	Protocol      string `json:"protocol"`
+}
+
+// 容器的卷掛載
+type VolumeMount struct {
+	Name      string `json:"name"`
+	MountPath string `json:"mountPath"`
+	SubPath   string `json:"subPath,omitempty"`
+	ReadOnly  bool   `json:"readOnly"`
+}
+
+// Pod 卷定義
+type PodVolume struct {
+	Name     string           `json:"name"`
+	Type     string           `json:"type"`               // EmptyDir, PVC, ConfigMap, Secret...
+	Source   string           `json:"source,omitempty"`   // 來源名稱 (例如 PVC 名稱、ConfigMap 名稱)
+	ReadOnly bool             `json:"readOnly,omitempty"` // 卷來源本身設定為唯讀 (例如 PVC readOnly)
+	Medium   string           `json:"medium,omitempty"`   // EmptyDir 儲存媒介 (例如 Memory)
+	Mounts   []VolumeMountRef `json:"mounts,omitempty"`   // 掛載此卷的容器
+	Claim    *ClaimInfo       `json:"claim,omitempty"`    // PVC 詳細資訊 (僅 get_pod_volumes 提供)
+}
+
+// 容器對卷的掛載
+type VolumeMountRef struct {
+	Container string `json:"container"`
+	MountPath string `json:"mountPath"`
+	SubPath   string `json:"subPath,omitempty"`
+	ReadOnly  bool   `json:"readOnly"`
+}
+
+// PersistentVolumeClaim 資訊
+type ClaimInfo struct {
+	Phase        string   `json:"phase"`
+	VolumeName   string   `json:"volumeName,omitempty"`
+	StorageClass string   `json:"storageClass,omitempty"`
+	Capacity     string   `json:"capacity,omitempty"`
+	Requested    string   `json:"requested,omitempty"`
+	AccessModes  []string `json:"accessModes,omitempty"`
+	Error        string   `json:"error,omitempty"` // 無法取得 PVC 時的錯誤訊息
+}
+
+// Pod 卷與掛載資訊
+type PodVolumes struct {
+	PodName   string      `json:"podName"`
+	Namespace string      `json:"namespace"`
+	Volumes   []PodVolume `json:"volumes"`
+	Warnings  []string    `json:"warnings,omitempty"` // 例如掛載了未定義的卷、PVC 尚未綁定
+}
+
+// 容忍設定
+type Toleration struct {
+	Key               string `json:"key,omitempty"`
+	Operator          string `json:"operator,omitempty"`
+	Value             string `json:"value,omitempty"`
+	Effect            string `json:"effect,omitempty"`
+	TolerationSeconds *int64 `json:"tolerationSeconds,omitempty"`
+}
+
+// 節點綜合描述 (類似 kubectl describe node)
+type NodeDescription struct {
+	Name          string            `json:"name"`
 	Labels        map[string]string `json:"labels"`
+	Annotations   map[string]string `json:"annotations,omitempty"`
+	CreatedAt     time.Time         `json:"createdAt"`
+	Zone          string            `json:"zone"`
+	InstanceType  string            `json:"instanceType,omitempty"`
+	Unschedulable bool              `json:"unschedulable"`
+	Taints        []Taint           `json:"taints,omitempty"`
+	Conditions    []NodeCondition   `json:"conditions"`
+	Addresses     []NodeAddress     `json:"addresses"`
+	Capacity      map[string]string `json:"capacity"`
+	Allocatable   map[string]string `json:"allocatable"`
+	SystemInfo    NodeSystemInfo    `json:"systemInfo"`
+	PodCount      int               `json:"podCount"` // 未終止的 Pod 數量
+	Pods          []NodePodResource `json:"pods"`     // 未終止的 Pod 及其請求量/限制量
+
+	AllocatedResources AllocatedResources `json:"allocatedResources"`
+
+	Events []Event `json:"events"`
+}
+
+// 節點上單一 Pod 的資源請求量與限制量 (類似 kubectl describe node 的 Non-terminated Pods)
+type NodePodResource struct {
+	Namespace         string  `json:"namespace"`
+	Name              string  `json:"name"`
+	CPURequests       string  `json:"cpuRequests"`
+	CPULimits         string  `json:"cpuLimits"`
+	MemoryRequests    string  `json:"memoryRequests"`
+	MemoryLimits      string  `json:"memoryLimits"`
+	CPURequestsPct    float64 `json:"cpuRequestsPct"` // 佔節點可分配量的百分比
+	CPULimitsPct      float64 `json:"cpuLimitsPct"`
+	MemoryRequestsPct float64 `json:"memoryRequestsPct"`
+	MemoryLimitsPct   float64 `json:"memoryLimitsPct"`
+}
+
+// 節點已分配資源 (類似 kubectl describe node 的 Allocated resources)
+type AllocatedResources struct {
+	CPURequests       string               `json:"cpuRequests"`
+	CPULimits         string               `json:"cpuLimits"`
+	MemoryRequests    string               `json:"memoryRequests"`
+	MemoryLimits      string               `json:"memoryLimits"`
+	CPURequestsPct    float64              `json:"cpuRequestsPct"` // 佔節點可分配量的百分比，限制量可能超過 100% (超額配置)
+	CPULimitsPct      float64              `json:"cpuLimitsPct"`
+	MemoryRequestsPct float64              `json:"memoryRequestsPct"`
+	MemoryLimitsPct   float64              `json:"memoryLimitsPct"`
+	Extended          []ExtendedAllocation `json:"extended,omitempty"` // 擴充資源 (例如 nvidia.com/gpu、ephemeral-storage)
+}
+
+// 擴充資源已分配量
+type ExtendedAllocation struct {
+	Name        string `json:"name"`
+	Requests    string `json:"requests"`
+	Limits      string `json:"limits"`
+	Allocatable string `json:"allocatable"`
+}
+
+// 節點污點
+type Taint struct {
+	Key    string `json:"key"`
+	Value  string `json:"value,omitempty"`
+	Effect string `json:"effect"`
+}
+
+// 節點狀態條件 (例如 Ready, MemoryPressure, DiskPressure)
+type NodeCondition struct {
+	Type               string    `json:"type"`
+	Status             string    `json:"status"`
+	Reason             string    `json:"reason,omitempty"`
+	Message            string    `json:"message,omitempty"`
+	LastTransitionTime time.Time `json:"lastTransitionTime"`
+}
+
+// 節點位址
+type NodeAddress struct {
+	Type    string `json:"type"`
+	Address string `json:"address"`
+}
+
+// 節點系統資訊
+type NodeSystemInfo struct {
+	KubeletVersion          string `json:"kubeletVersion"`
+	OSImage                 string `json:"osImage"`
+	KernelVersion           string `json:"kernelVersion"`
+	ContainerRuntimeVersion string `json:"containerRuntimeVersion"`
+	Architecture            string `json:"architecture"`
+}
+
+// Pod 環境變數
+type PodEnv struct {
+	PodName    string         `json:"podName"`
+	Namespace  string         `json:"namespace"`
+	Containers []ContainerEnv `json:"containers"`
+}
+
+// 容器環境變數
+type ContainerEnv struct {
+	Container string   `json:"container"`
+	Init      bool     `json:"init,omitempty"` // 是否為初始化容器
+	Variables []EnvVar `json:"variables"`
+	Warnings  []string `json:"warnings,omitempty"` // 引用不存在的 ConfigMap 或鍵等設定問題
+}
+
+// 環境變數
+type EnvVar struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`          // Secret 來源的值會遮蔽為 "<secret:name/key>"
+	Source string `json:"source"`         // value, configMap, secret, fieldRef, resourceFieldRef
+	From   string `json:"from,omitempty"` // 來源參照 (例如 ConfigMap 名稱/鍵、欄位路徑)
+}
+
+// 映像檔拉取失敗診斷結果
+type ImagePullDiagnosis struct {
+	PodName          string           `json:"podName"`
+	Namespace        string           `json:"namespace"`
+	HasFailures      bool             `json:"hasFailures"`
+	ImagePullSecrets []string         `json:"imagePullSecrets,omitempty"`
+	Issues           []ImagePullIssue `json:"issues"`
+}
+
+// 單一容器的映像檔拉取問題
+type ImagePullIssue struct {
+	Container   string   `json:"container"`
+	Image       string   `json:"image"`
+	Registry    string   `json:"registry"` // 映像檔所在的 registry (例如 docker.io, gcr.io, asia-east1-docker.pkg.dev)
+	State       string   `json:"state"`    // ErrImagePull, ImagePullBackOff, InvalidImageName...
+	Category    string   `json:"category"` // AUTH, NOT_FOUND, RATE_LIMIT, NETWORK, INVALID_NAME, UNKNOWN
+	Message     string   `json:"message"`  // 容器狀態或事件中的錯誤訊息
+	Events      []string `json:"events,omitempty"`
+	Suggestions []string `json:"suggestions"`
+}
+
+// 已終止及近期刪除的 Pod
+type TerminatedPods struct {
+	Namespace string       `json:"namespace"`
+	Since     time.Time    `json:"since"`
+	Succeeded []Pod        `json:"succeeded"`
+	Failed    []Pod        `json:"failed"` // 包含被驅逐 (Evicted) 的 Pod
+	Deleted   []DeletedPod `json:"deleted,omitempty"`
+}
+
+// 近期刪除的 Pod (由事件推斷，受事件保留時間限制，GKE 預設約 1 小時)
+type DeletedPod struct {
+	Name      string    `json:"name"`
+	Namespace string    `json:"namespace"`
+	Reason    string    `json:"reason"` // 例如 Evicted, Preempted, Killing, SuccessfulDelete
+	Message   string    `json:"message"`
+	Source    string    `json:"source"` // 事件來源 (例如 kubelet, replicaset-controller)
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// 節點事件 (依節點分組)
+type NodeEvents struct {
+	Since       time.Time        `json:"since"`
+	TotalEvents int              `json:"totalEvents"`
+	Nodes       []NodeEventGroup `json:"nodes"`
+}
+
+// 單一節點的事件
+type NodeEventGroup struct {
+	NodeName     string           `json:"nodeName"`
+	ReasonCounts map[string]int32 `json:"reasonCounts"` // 事件原因 -> 發生次數 (含重複次數)
+	Events       []Event          `json:"events"`
+}
+
+// 工作負載重啟結果
+type WorkloadRestart struct {
+	Kind        string    `json:"kind"` // Deployment, StatefulSet, DaemonSet
+	Name        string    `json:"name"`
+	Namespace   string    `json:"namespace"`
+	RestartedAt time.Time `json:"restartedAt"`
+	Message     string    `json:"message"`
+}
+
+// 工作負載擴縮結果
+type WorkloadScale struct {
+	Kind             string `json:"kind"` // Deployment, StatefulSet
+	Name             string `json:"name"`
+	Namespace        string `json:"namespace"`
+	PreviousReplicas int32  `json:"previousReplicas"`
+	Replicas         int32  `json:"replicas"`
+	Message          string `json:"message"`
+}
+
+// Pod 刪除結果
+type PodDeletion struct {
+	PodName            string `json:"podName"`
+	Namespace          string `json:"namespace"`
+	PreviousStatus     string `json:"previousStatus"`           // 刪除前的狀態 (例如 Failed)
+	PreviousReason     string `json:"previousReason,omitempty"` // 刪除前的原因 (例如 Evicted)
+	GracePeriodSeconds *int64 `json:"gracePeriodSeconds,omitempty"`
+	Message            string `json:"message"`
+}
+
+// 節點可排程狀態變更結果
+type NodeSchedulingChange struct {
+	NodeName      string `json:"nodeName"`
+	Unschedulable bool   `json:"unschedulable"` // 變更後是否不可排程
+	Changed       bool   `json:"changed"`       // 狀態是否有變更 (已是目標狀態時為 false)
+	Message       string `json:"message"`
+}
+
+// 節點排空選項
+type DrainOptions struct {
+	NodeName           string        `json:"nodeName"`
+	Confirm            string        `json:"confirm"`            // 必須與節點名稱相同
+	IgnoreDaemonSets   bool          `json:"ignoreDaemonSets"`   // 略過 DaemonSet 管理的 Pod (否則視為阻擋)
+	DeleteEmptyDirData bool          `json:"deleteEmptyDirData"` // 允許驅逐使用 emptyDir 的 Pod (資料會遺失)
+	Force              bool          `json:"force"`              // 允許驅逐沒有控制器管理的 Pod (不會被重建)
+	GracePeriodSeconds *int64        `json:"gracePeriodSeconds,omitempty"`
+	Timeout            time.Duration `json:"timeout"` // 整體逾時時間
+}
+
+// 節點排空結果
+type NodeDrain struct {
+	NodeName  string         `json:"nodeName"`
+	Cordoned  bool           `json:"cordoned"`
+	Completed bool           `json:"completed"` // 所有需驅逐的 Pod 都已移除
+	Evicted   []string       `json:"evicted"`   // 已驅逐並移除的 Pod (namespace/name)
+	Skipped   []DrainPodNote `json:"skipped,omitempty"`
+	Blocked   []DrainPodNote `json:"blocked,omitempty"` // 阻擋排空的 Pod (未驅逐任何 Pod)
+	Failed    []DrainPodNote `json:"failed,omitempty"`  // 驅逐失敗或逾時的 Pod
+	Duration  string         `json:"duration"`
+	Message   string         `json:"message"`
+}
+
+// 排空過程中單一 Pod 的說明
+type DrainPodNote struct {
+	Pod    string `json:"pod"` // namespace/name
+	Reason string `json:"reason"`
+}
+
+// Pod 驅逐結果
+type PodEviction struct {
+	PodName              string      `json:"podName"`
+	Namespace            string      `json:"namespace"`
+	Evicted              bool        `json:"evicted"`
+	BlockedByPDB         bool        `json:"blockedByPDB"` // 驅逐會違反 PodDisruptionBudget 而被拒絕
+	Message              string      `json:"message"`
+	PodDisruptionBudgets []PDBStatus `json:"podDisruptionBudgets,omitempty"` // 選取此 Pod 的 PDB
+}
+
+// PodDisruptionBudget 狀態
+type PDBStatus struct {
+	Name               string `json:"name"`
+	MinAvailable       string `json:"minAvailable,omitempty"`
+	MaxUnavailable     string `json:"maxUnavailable,omitempty"`
+	CurrentHealthy     int32  `json:"currentHealthy"`
+	DesiredHealthy     int32  `json:"desiredHealthy"`
+	ExpectedPods       int32  `json:"expectedPods"`
+	DisruptionsAllowed int32  `json:"disruptionsAllowed"`
+}
+
+// Deployment 版本歷史
+type RolloutHistory struct {
+	Name            string            `json:"name"`
+	Namespace       string            `json:"namespace"`
+	CurrentRevision int64             `json:"currentRevision"`
+	Revisions       []RolloutRevision `json:"revisions"` // 依版本由新到舊排序
+}
+
+// Deployment 單一版本 (對應一個 ReplicaSet)
+type RolloutRevision struct {
+	Revision      int64     `json:"revision"`
+	ReplicaSet    string    `json:"replicaSet"`
+	CreatedAt     time.Time `json:"createdAt"`
+	ChangeCause   string    `json:"changeCause,omitempty"`
+	Images        []string  `json:"images"`
+	Replicas      int32     `json:"replicas"`
+	ReadyReplicas int32     `json:"readyReplicas"`
+	Current       bool      `json:"current"`
+}
+
+// Deployment 回滾結果
+type RolloutUndo struct {
+	Name         string   `json:"name"`
+	Namespace    string   `json:"namespace"`
+	FromRevision int64    `json:"fromRevision"`
+	ToRevision   int64    `json:"toRevision"` // 回滾的來源版本，回滾後會產生新的版本號
+	Images       []string `json:"images"`
+	Message      string   `json:"message"`
+}
+
+// 容器內指令執行結果
+type ExecResult struct {
+	PodName    string   `json:"podName"`
+	Namespace  string   `json:"namespace"`
+	Container  string   `json:"container"`
+	Command    []string `json:"command"`
+	ExitCode   int      `json:"exitCode"`
+	Stdout     string   `json:"stdout"`
+	Stderr     string   `json:"stderr"`
+	Truncated  bool     `json:"truncated"` // 輸出超過上限而被截斷
+	DurationMs float64  `json:"durationMs"`
+	Error      string   `json:"error,omitempty"`
+}
+
+// Pod HTTP 端點探測結果
+type PodEndpointProbe struct {
+	PodName    string  `json:"podName"`
+	Namespace  string  `json:"namespace"`
+	Source     string  `json:"source"` // 端點來源: request、readinessProbe 或 livenessProbe
+	Scheme     string  `json:"scheme"`
+	Port       int     `json:"port"`
+	Path       string  `json:"path"`
+	StatusCode int     `json:"statusCode,omitempty"`
+	Healthy    bool    `json:"healthy"` // 狀態碼為 200-399
+	LatencyMs  float64 `json:"latencyMs"`
+	Body       string  `json:"body,omitempty"`
+	Truncated  bool    `json:"truncated"`
+	Error      string  `json:"error,omitempty"`
+}
+
+// 資源修補結果
+type PatchResult struct {
+	Kind            string        `json:"kind"`
+	APIVersion      string        `json:"apiVersion"`
+	Name            string        `json:"name"`
+	Namespace       string        `json:"namespace,omitempty"`
+	PatchType       string        `json:"patchType"`
+	DryRun          bool          `json:"dryRun"` // true 表示僅預覽，未變更叢集狀態
+	ResourceVersion string        `json:"resourceVersion"`
+	Changes         []FieldChange `json:"changes"`
+	Message         string        `json:"message"`
+}
+
+// 修補造成的欄位變更
+type FieldChange struct {
+	Path   string      `json:"path"` // 例如 spec.template.spec.containers[0].resources.limits.memory
+	Before interface{} `json:"before,omitempty"`
+	After  interface{} `json:"after,omitempty"`
+}
+
+// 指標樣本
+type MetricSample struct {
+	Timestamp     time.Time         `json:"timestamp"`
+	CPUMillicores int64             `json:"cpuMillicores"`
+	MemoryBytes   int64             `json:"memoryBytes"`
+	Containers    []ContainerSample `json:"containers,omitempty"`
+}
+
+// 容器指標樣本
+type ContainerSample struct {
+	Name          string `json:"name"`
+	CPUMillicores int64  `json:"cpuMillicores"`
+	MemoryBytes   int64  `json:"memoryBytes"`
+}
+
+// 使用量歷史時間序列
+type MetricsHistory struct {
+	Kind            string          `json:"kind"` // pod 或 node
+	Name            string          `json:"name"`
+	Namespace       string          `json:"namespace,omitempty"`
+	Container       string          `json:"container,omitempty"`
+	Since           time.Time       `json:"since"`
+	IntervalSeconds int             `json:"intervalSeconds"`
+	SampleCount     int             `json:"sampleCount"`
+	Summary         *MetricsSummary `json:"summary,omitempty"`
+	Samples         []MetricSample  `json:"samples"`
+}
+
+// 時間序列統計摘要
+type MetricsSummary struct {
+	CPUMillicores SeriesStats `json:"cpuMillicores"`
+	MemoryBytes   SeriesStats `json:"memoryBytes"`
+
+	Samples   int       `json:"samples"`             // 資料點數量
+	FirstSeen time.Time `json:"firstSeen,omitempty"` // 最早的資料點時間
+	LastSeen  time.Time `json:"lastSeen,omitempty"`  // 最新的資料點時間
+}
+
+// Coverage 資料點涵蓋的時間長度，只有單一取樣時為 0
+func (m *MetricsSummary) Coverage() time.Duration {
+	if m == nil {
+		return 0
+	}
+	return m.LastSeen.Sub(m.FirstSeen)
+}
+
+// 數列統計值
+type SeriesStats struct {
+	Min    int64   `json:"min"`
+	Max    int64   `json:"max"`
+	Avg    float64 `json:"avg"`
+	P95    int64   `json:"p95"`
+	StdDev float64 `json:"stdDev"` // 標準差，用來判斷使用量的波動程度
+	Latest int64   `json:"latest"`
+}
+
+// Cloud Monitoring 歷史使用量
+type HistoricalUsage struct {
+	PodName          string             `json:"podName"`
+	Namespace        string             `json:"namespace"`
+	Source           string             `json:"source"`
+	Start            time.Time          `json:"start"`
+	End              time.Time          `json:"end"`
+	AlignmentSeconds int                `json:"alignmentSeconds"` // 每個資料點代表的時間間隔
+	Summary          *MetricsSummary    `json:"summary,omitempty"`
+	Containers       []ContainerHistory `json:"containers"`
+	Samples          []MetricSample     `json:"samples"` // Pod 總使用量 (各容器加總)
+}
+
+// 單一容器的歷史使用量
+type ContainerHistory struct {
+	Name    string          `json:"name"`
+	Summary *MetricsSummary `json:"summary,omitempty"`
+	Samples []MetricSample  `json:"samples"`
+}
+
+// 時間序列資料點
+type SeriesPoint struct {
+	Timestamp time.Time `json:"timestamp"`
+	Value     float64   `json:"value"`
+}
+
+// 資料點統計值
+type PointStats struct {
+	Min    float64 `json:"min"`
+	Max    float64 `json:"max"`
+	Avg    float64 `json:"avg"`
+	Latest float64 `json:"latest"`
+}
+
+// Prometheus 時間序列
+type PrometheusSeries struct {
+	Labels map[string]string `json:"labels"`
+	Points []SeriesPoint     `json:"points"`
+}
+
+// PromQL 查詢結果
+type PrometheusQueryResult struct {
+	Query       string             `json:"query"`
+	Start       time.Time          `json:"start,omitempty"`
+	End         time.Time          `json:"end,omitempty"`
+	StepSeconds int                `json:"stepSeconds,omitempty"` // 僅範圍查詢
+	Series      []PrometheusSeries `json:"series"`
+}
+
+// Prometheus 使用量
+type PrometheusUsage struct {
+	PodName     string        `json:"podName"`
+	Namespace   string        `json:"namespace"`
+	Source      string        `json:"source"`
+	Start       time.Time     `json:"start"`
+	End         time.Time     `json:"end"`
+	StepSeconds int           `json:"stepSeconds"`
+	Series      []UsageSeries `json:"series"`
+	Warnings    []string      `json:"warnings,omitempty"` // 無法取得的指標
+}
+
+// 使用量時間序列
+type UsageSeries struct {
+	Metric    string        `json:"metric"`              // cpuMillicores、memoryBytes、cpuThrottledRatio、networkReceiveBytesPerSecond、networkTransmitBytesPerSecond
+	Container string        `json:"container,omitempty"` // 網路指標為 Pod 層級
+	Summary   *PointStats   `json:"summary,omitempty"`
+	Points    []SeriesPoint `json:"points"`
+}
+
+// 使用量百分位數
+type UsagePercentiles struct {
+	Kind          string      `json:"kind"` // Pod、Deployment、StatefulSet 或 DaemonSet
+	Name          string      `json:"name"`
+	Namespace     string      `json:"namespace"`
+	Source        string      `json:"source"` // cloud-monitoring、prometheus 或 collector
+	Window        string      `json:"window"`
+	Pods          []string    `json:"pods"` // 有樣本的 Pod
+	SampleCount   int         `json:"sampleCount"`
+	CPUMillicores Percentiles `json:"cpuMillicores"`
+	MemoryBytes   Percentiles `json:"memoryBytes"`
+}
+
+// 百分位數
+type Percentiles struct {
+	P50 float64 `json:"p50"`
+	P90 float64 `json:"p90"`
+	P95 float64 `json:"p95"`
+	P99 float64 `json:"p99"`
+	Max float64 `json:"max"`
+}
+
+// 使用量異常偵測報告
+type AnomalyReport struct {
+	Namespace      string         `json:"namespace"`
+	Source         string         `json:"source,omitempty"` // 使用量資料來源
+	BaselineWindow string         `json:"baselineWindow"`
+	RecentWindow   string         `json:"recentWindow"`
+	Threshold      float64        `json:"threshold"` // robust z-score 門檻
+	PodsAnalyzed   int            `json:"podsAnalyzed"`
+	Anomalies      []UsageAnomaly `json:"anomalies"`
+	Warnings       []string       `json:"warnings,omitempty"`
+}
+
+// 使用量異常
+type UsageAnomaly struct {
+	PodName        string  `json:"podName"`
+	Namespace      string  `json:"namespace"`
+	Metric         string  `json:"metric"`         // cpu、memory 或 restarts
+	Direction      string  `json:"direction"`      // spike 或 drop
+	Recent         float64 `json:"recent"`         // 最近的平均值 (restarts 為累計重啟次數)
+	BaselineMedian float64 `json:"baselineMedian"` // 基準中位數 (restarts 為平均每小時重啟次數)
+	Score          float64 `json:"score,omitempty"`
+	Message        string  `json:"message"`
+}
+
+// 使用量趨勢預測
+type UsageForecast struct {
+	Scope   string           `json:"scope"` // namespace 或 node
+	Name    string           `json:"name"`
+	Source  string           `json:"source"`
+	Window  string           `json:"window"`  // 擬合使用的歷史時間範圍
+	Horizon string           `json:"horizon"` // 預測的時間長度
+	Points  int              `json:"points"`
+	CPU     ResourceForecast `json:"cpu"`    // 單位為毫核
+	Memory  ResourceForecast `json:"memory"` // 單位為位元組
+}
+
+// 單一資源的趨勢預測
+type ResourceForecast struct {
+	Current               float64    `json:"current"`     // 趨勢線在目前時間的值
+	SlopePerDay           float64    `json:"slopePerDay"` // 每日變化量
+	RSquared              float64    `json:"rSquared"`    // 趨勢線的決定係數 (0-1)
+	Projected             float64    `json:"projected"`   // 預測期間結束時的值
+	Requests              float64    `json:"requests"`    // 目前的請求量總和
+	Allocatable           float64    `json:"allocatable"` // 可分配容量
+	RequestsExceededAt    *time.Time `json:"requestsExceededAt,omitempty"`
+	AllocatableExceededAt *time.Time `json:"allocatableExceededAt,omitempty"`
+	Message               string     `json:"message"`
+}
+
+// OOM 事件報告
+type OOMReport struct {
+	Namespace     string        `json:"namespace"`
+	Since         time.Time     `json:"since"`
+	TotalOOMKills int           `json:"totalOOMKills"`
+	Workloads     []WorkloadOOM `json:"workloads"` // 依 OOMKilled 次數由多到少排序
+	Nodes         []NodeOOM     `json:"nodes"`     // 節點層級 OOM (SystemOOM/OOMKilling 事件)
+}
+
+// 工作負載的 OOMKilled 彙總
+type WorkloadOOM struct {
+	Kind       string    `json:"kind"`
+	Name       string    `json:"name"`
+	Namespace  string    `json:"namespace"`
+	OOMKills   int       `json:"oomKills"`
+	LastOOMAt  time.Time `json:"lastOOMAt"`
+	Pods       []string  `json:"pods"`
+	Containers []string  `json:"containers"`
+	Kills      []OOMKill `json:"kills"`
+}
+
+// 單次 OOMKilled 終止
+type OOMKill struct {
+	PodName      string    `json:"podName"`
+	Container    string    `json:"container"`
+	FinishedAt   time.Time `json:"finishedAt"`
+	MemoryLimit  string    `json:"memoryLimit,omitempty"`
+	RestartCount int32     `json:"restartCount"`
+	NodeName     string    `json:"nodeName,omitempty"`
+}
+
+// 節點層級 OOM 彙總
+type NodeOOM struct {
+	NodeName string    `json:"nodeName"`
+	Count    int       `json:"count"`
+	LastSeen time.Time `json:"lastSeen"`
+	Messages []string  `json:"messages"` // 例如 "System OOM encountered, victim process: java, pid: 1234"
+}
+
+// NodeCommitmentReport 節點請求量承諾比例報告
+type NodeCommitmentReport struct {
+	NodePool string               `json:"nodePool,omitempty"`
+	Total    NodePoolCommitment   `json:"total"`
+	Pools    []NodePoolCommitment `json:"pools"`
+	Nodes    []NodeCommitment     `json:"nodes"` // 依閒置容量由多到少排序
+}
+
+// NodePoolCommitment 節點池 (或整個叢集) 的承諾比例
+type NodePoolCommitment struct {
+	Name      string             `json:"name,omitempty"`
+	NodeCount int                `json:"nodeCount"`
+	CPU       ResourceCommitment `json:"cpu"`
+	Memory    ResourceCommitment `json:"memory"`
+	Pods      ResourceCommitment `json:"pods"`
+}
+
+// NodeCommitment 單一節點的承諾比例
+type NodeCommitment struct {
+	NodeName        string             `json:"nodeName"`
+	NodePool        string             `json:"nodePool,omitempty"`
+	Unschedulable   bool               `json:"unschedulable,omitempty"`
+	CPU             ResourceCommitment `json:"cpu"`
+	Memory          ResourceCommitment `json:"memory"`
+	Pods            ResourceCommitment `json:"pods"`
+	BindingResource string             `json:"bindingResource,omitempty"` // 承諾比例最高、最先耗盡的資源
+}
+
+// ResourceCommitment 單一資源的請求量與可分配量比較
+type ResourceCommitment struct {
+	Allocatable  string  `json:"allocatable"`
+	Requested    string  `json:"requested"`
+	Free         string  `json:"free"`
+	Stranded     string  `json:"stranded"` // 最先耗盡的資源用盡後仍無法被排程使用的量
+	CommittedPct float64 `json:"committedPct"`
+	StrandedPct  float64 `json:"strandedPct"`
+}
+
+// NodeUtilization 節點的可分配量、Pod 請求量總和與目前使用量 (CPU 為 millicores，記憶體為 bytes)
+type NodeUtilization struct {
+	NodeName          string    `json:"nodeName"`
+	NodePool          string    `json:"nodePool,omitempty"`
+	Zone              string    `json:"zone"`
+	Unschedulable     bool      `json:"unschedulable,omitempty"`
+	CPUAllocatable    int64     `json:"cpuAllocatable"`
+	CPURequested      int64     `json:"cpuRequested"`
+	CPUUsage          int64     `json:"cpuUsage"`
+	MemoryAllocatable int64     `json:"memoryAllocatable"`
+	MemoryRequested   int64     `json:"memoryRequested"`
+	MemoryUsage       int64     `json:"memoryUsage"`
+	PodCapacity       int64     `json:"podCapacity"`
+	MetricsAvailable  bool      `json:"metricsAvailable"` // 是否取得 Metrics API 的使用量
+	Pods              []NodePod `json:"pods"`
+}
+
+// NodePod 節點上執行中的 Pod 與其請求量
+type NodePod struct {
+	Name          string `json:"name"`
+	Namespace     string `json:"namespace"`
+	OwnerKind     string `json:"ownerKind,omitempty"`
+	OwnerName     string `json:"ownerName,omitempty"`
+	CPURequest    int64  `json:"cpuRequest"`    // millicores
+	MemoryRequest int64  `json:"memoryRequest"` // bytes
+	DaemonSet     bool   `json:"daemonSet,omitempty"`
+	Mirror        bool   `json:"mirror,omitempty"` // 靜態 Pod 的鏡像 Pod
+
+	labels       map[string]string
+	antiAffinity []hostAntiAffinityTerm
+}
+
+// CapacityPlan 工作負載副本容量規劃
+type CapacityPlan struct {
+	Kind               string               `json:"kind"`
+	Name               string               `json:"name"`
+	Namespace          string               `json:"namespace"`
+	NodePool           string               `json:"nodePool,omitempty"`
+	CurrentReplicas    int32                `json:"currentReplicas"`
+	PerReplica         map[string]string    `json:"perReplica"`         // 每個副本的有效請求量
+	AdditionalReplicas int                  `json:"additionalReplicas"` // 還能增加的副本數
+	MaxReplicas        int32                `json:"maxReplicas"`        // 目前副本數加上可增加的副本數
+	LimitingFactor     string               `json:"limitingFactor"`     // resources、podAntiAffinity(拓撲鍵)、topologySpread(拓撲鍵) 或 noEligibleNodes
+	EligibleNodes      int                  `json:"eligibleNodes"`
+	Constraints        []CapacityConstraint `json:"constraints"`
+	Nodes              []CapacityPlanNode   `json:"nodes"`
+	Warnings           []string             `json:"warnings,omitempty"`
+}
+
+// CapacityConstraint 限制副本數的排程限制
+type CapacityConstraint struct {
+	Type               string `json:"type"` // podAntiAffinity 或 topologySpread
+	TopologyKey        string `json:"topologyKey"`
+	MaxSkew            int32  `json:"maxSkew,omitempty"`
+	AdditionalReplicas int    `json:"additionalReplicas"` // 僅考慮此限制時可增加的副本數
+}
+
+// CapacityPlanNode 單一節點可容納的副本數
+type CapacityPlanNode struct {
+	NodeName         string `json:"nodeName"`
+	NodePool         string `json:"nodePool,omitempty"`
+	Zone             string `json:"zone,omitempty"`
+	ExistingReplicas int    `json:"existingReplicas"`
+	Fits             int    `json:"fits"`                       // 節點剩餘資源可容納的副本數
+	LimitingResource string `json:"limitingResource,omitempty"` // 最先不足的資源
+	Excluded         string `json:"excluded,omitempty"`         // 無法排程到此節點的原因
+}
+
+// MemoryLeakReport 記憶體洩漏偵測報告
+type MemoryLeakReport struct {
+	Namespace          string       `json:"namespace"`
+	Source             string       `json:"source"`
+	Window             string       `json:"window"`
+	ContainersAnalyzed int          `json:"containersAnalyzed"`
+	Leaks              []MemoryLeak `json:"leaks"` // 依預測 OOM 時間由近到遠排序
+	Warnings           []string     `json:"warnings,omitempty"`
+}
+
+// MemoryLeak 記憶體持續成長的容器
+type MemoryLeak struct {
+	Namespace           string     `json:"namespace"`
+	PodName             string     `json:"podName"`
+	Container           string     `json:"container"`
+	WorkloadKind        string     `json:"workloadKind"`
+	WorkloadName        string     `json:"workloadName"`
+	SegmentStart        time.Time  `json:"segmentStart"` // 分析的起始時間 (時間範圍開始或最後一次重啟)
+	SampleCount         int        `json:"sampleCount"`
+	StartBytes          int64      `json:"startBytes"`
+	CurrentBytes        int64      `json:"currentBytes"`
+	GrowthBytesPerHour  int64      `json:"growthBytesPerHour"`
+	GrowthPercentPerDay float64    `json:"growthPercentPerDay"`
+	KendallTau          float64    `json:"kendallTau"` // 單調性，1 代表嚴格遞增
+	RSquared            float64    `json:"rSquared"`
+	Confidence          string     `json:"confidence"` // high 或 medium
+	MemoryLimitBytes    int64      `json:"memoryLimitBytes,omitempty"`
+	ProjectedOOMAt      *time.Time `json:"projectedOomAt,omitempty"` // 依趨勢預測達到記憶體限制的時間
+	Description         string     `json:"description"`
+}
+
+// OOMRiskReport OOM 風險偵測報告
+type OOMRiskReport struct {
+	Namespace          string    `json:"namespace"`
+	Source             string    `json:"source"`
+	Window             string    `json:"window"`
+	ContainersAnalyzed int       `json:"containersAnalyzed"`
+	Risks              []OOMRisk `json:"risks"` // 依預測 OOM 時間由近到遠排序
+	Warnings           []string  `json:"warnings,omitempty"`
+}
+
+// OOMRisk 記憶體使用量接近限制且仍在成長的容器
+type OOMRisk struct {
+	Namespace          string     `json:"namespace"`
+	PodName            string     `json:"podName"`
+	Container          string     `json:"container"`
+	WorkloadKind       string     `json:"workloadKind"`
+	WorkloadName       string     `json:"workloadName"`
+	CurrentBytes       int64      `json:"currentBytes"`
+	MemoryLimitBytes   int64      `json:"memoryLimitBytes"`
+	UsagePercent       float64    `json:"usagePercent"` // 目前使用量佔記憶體限制的比例
+	GrowthBytesPerHour int64      `json:"growthBytesPerHour"`
+	ProjectedOOMAt     *time.Time `json:"projectedOomAt,omitempty"` // 依趨勢預測達到記憶體限制的時間
+	Description        string     `json:"description"`
+}
+
+// WorkloadLifecycle 可能已不再使用的工作負載狀態，用於找出需要清理的工作負載
+type WorkloadLifecycle struct {
+	ScaledDownDeployments []ScaledDownDeployment `json:"scaledDownDeployments"`
+	Jobs                  []JobState             `json:"jobs"`
+	OrphanedReplicaSets   []OrphanedReplicaSet   `json:"orphanedReplicaSets"`
+}
+
+// ScaledDownDeployment 副本數為 0 的 Deployment
+type ScaledDownDeployment struct {
+	Name       string    `json:"name"`
+	Namespace  string    `json:"namespace"`
+	LastUpdate time.Time `json:"lastUpdate"` // 狀態條件的最後更新時間，約為縮減為 0 的時間
+}
+
+// JobState Job 的完成狀態
+type JobState struct {
+	Name                    string    `json:"name"`
+	Namespace               string    `json:"namespace"`
+	CronJob                 string    `json:"cronJob,omitempty"` // 由 CronJob 建立時為 CronJob 名稱
+	Active                  int32     `json:"active"`
+	Succeeded               int32     `json:"succeeded"`
+	Failed                  int32     `json:"failed"`
+	BackoffLimit            int32     `json:"backoffLimit"`
+	Complete                bool      `json:"complete"`
+	FailedPermanently       bool      `json:"failedPermanently"` // 已達 backoffLimit 或期限而停止重試
+	TTLSecondsAfterFinished *int32    `json:"ttlSecondsAfterFinished,omitempty"`
+	ActiveDeadlineSeconds   *int64    `json:"activeDeadlineSeconds,omitempty"`
+	CreatedAt               time.Time `json:"createdAt"`
+	FinishedAt              time.Time `json:"finishedAt,omitempty"`
+}
+
+// OrphanedReplicaSet 沒有控制器 (Deployment 已刪除) 但仍有 Pod 的 ReplicaSet
+type OrphanedReplicaSet struct {
+	Name      string    `json:"name"`
+	Namespace string    `json:"namespace"`
+	Replicas  int32     `json:"replicas"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// CrashDiagnosis 重啟過的容器上一次執行的日誌摘要與 Pod 最近的 Warning 事件
+type CrashDiagnosis struct {
+	PodName    string           `json:"podName"`
+	Namespace  string           `json:"namespace"`
+	Containers []ContainerCrash `json:"containers"` // 依重啟次數由多到少排序
+	Events     []Event          `json:"events"`     // 最近的 Warning 事件
+
+	// RestartCauses 依原因彙總的重啟次數，各容器的重啟次數以最近一次終止的原因估計
+	RestartCauses map[string]int32 `json:"restartCauses"`
+}
+
+// ContainerCrash 單一容器最近一次終止的原因與日誌摘要
+type ContainerCrash struct {
+	Container    string     `json:"container"`
+	RestartCount int32      `json:"restartCount"`
+	Reason       string     `json:"reason,omitempty"` // 上一次終止的原因 (例如 Error, OOMKilled)
+	ExitCode     int32      `json:"exitCode"`
+	Cause        string     `json:"cause"` // 重啟原因分類: oom-killed、liveness-probe、node-preemption、error-exit、completed、unknown
+	Message      string     `json:"message,omitempty"`
+	TerminatedAt *time.Time `json:"terminatedAt,omitempty"`
+	Excerpt      []string   `json:"excerpt,omitempty"`  // 上一次執行的日誌中含有錯誤關鍵字的行，沒有時為最後幾行
+	LogError     string     `json:"logError,omitempty"` // 無法取得日誌的原因
+}
+
+// NamespaceQuotas 命名空間的 ResourceQuota 與 LimitRange
+type NamespaceQuotas struct {
+	ResourceQuotas []ResourceQuotaStatus `json:"resourceQuotas"`
+	LimitRanges    []ContainerLimitRange `json:"limitRanges"`
+}
+
+// ResourceQuotaStatus ResourceQuota 的上限與目前用量，鍵為資源名稱 (例如 requests.cpu、limits.memory、pods)
+type ResourceQuotaStatus struct {
+	Name      string            `json:"name"`
+	Namespace string            `json:"namespace"`
+	Hard      map[string]string `json:"hard"`
+	Used      map[string]string `json:"used"`
+}
+
+// ContainerLimitRange LimitRange 中 Container 類型的限制，鍵為資源名稱 (cpu、memory)
+type ContainerLimitRange struct {
+	Name           string            `json:"name"`
+	Namespace      string            `json:"namespace"`
+	Min            map[string]string `json:"min,omitempty"`
+	Max            map[string]string `json:"max,omitempty"`
+	Default        map[string]string `json:"default,omitempty"`        // 未設定 limits 時套用的值
+	DefaultRequest map[string]string `json:"defaultRequest,omitempty"` // 未設定 requests 時套用的值
+}
+
+// PersistentVolumeClaimUsage PVC 的容量、磁碟類型與實際使用量
+type PersistentVolumeClaimUsage struct {
+	Name         string    `json:"name"`
+	Namespace    string    `json:"namespace"`
+	Phase        string    `json:"phase"` // Pending、Bound 或 Lost
+	StorageClass string    `json:"storageClass,omitempty"`
+	DiskType     string    `json:"diskType,omitempty"` // Compute Engine 永久磁碟類型 (pd-standard、pd-balanced、pd-ssd 等)，非永久磁碟時為空
+	VolumeName   string    `json:"volumeName,omitempty"`
+	Capacity     string    `json:"capacity"`            // 已繫結時為 PV 的容量，否則為請求的容量
+	Used         string    `json:"used,omitempty"`      // 沒有執行中的 Pod 掛載時無法取得
+	MountedBy    []string  `json:"mountedBy,omitempty"` // 掛載此 PVC 的 Pod
+	CreatedAt    time.Time `json:"createdAt"`
+
+	CapacityBytes int64  `json:"-"`
+	UsedBytes     *int64 `json:"-"`
+}
+
+// PodPlatformProfile Pod 對節點平台的需求：特權與存取節點的設定、節點選擇條件，以及各容器的 requests
+type PodPlatformProfile struct {
+	Name         string `json:"name"`
+	Namespace    string `json:"namespace"`
+	WorkloadKind string `json:"workloadKind"` // 沒有控制器時為 Pod
+	WorkloadName string `json:"workloadName"`
+
+	HostNetwork          bool             `json:"hostNetwork,omitempty"`
+	HostPID              bool             `json:"hostPID,omitempty"`
+	HostIPC              bool             `json:"hostIPC,omitempty"`
+	PrivilegedContainers []string         `json:"privilegedContainers,omitempty"`
+	AddedCapabilities    []string         `json:"addedCapabilities,omitempty"` // 容器名稱: capability
+	HostPathVolumes      []HostPathVolume `json:"hostPathVolumes,omitempty"`
+	HostPorts            []string         `json:"hostPorts,omitempty"` // 容器名稱:port
+
+	// NodeSelectors nodeSelector 與 requiredDuringScheduling 節點親和性使用的節點標籤
+	NodeSelectors []string `json:"nodeSelectors,omitempty"`
+
+	Containers     []ContainerRequests `json:"containers"`
+	InitContainers []ContainerRequests `json:"initContainers,omitempty"`
+}
+
+// HostPathVolume hostPath 卷與掛載方式
+type HostPathVolume struct {
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	ReadOnly bool   `json:"readOnly"` // 所有掛載都是唯讀
+}
+
+// PodSecurityProfile Pod 與各容器的安全設定，用於依 Pod Security Standards 評估
+type PodSecurityProfile struct {
+	Name         string `json:"name"`
+	Namespace    string `json:"namespace"`
+	WorkloadKind string `json:"workloadKind"` // 沒有控制器時為 Pod
+	WorkloadName string `json:"workloadName"`
+
+	HostNetwork bool             `json:"hostNetwork,omitempty"`
+	HostPID     bool             `json:"hostPID,omitempty"`
+	HostIPC     bool             `json:"hostIPC,omitempty"`
+	Sysctls     []string         `json:"sysctls,omitempty"`
+	Volumes     []SecurityVolume `json:"volumes,omitempty"`
+
+	// SecuritySettings Pod 層級的設定，容器未設定的欄位沿用
+	SecuritySettings
+
+	Containers []ContainerSecurity `json:"containers"` // 包含 init 容器
+}
+
+// SecuritySettings Pod 與容器共有的安全設定，未設定的欄位為空值
+type SecuritySettings struct {
+	RunAsNonRoot *bool           `json:"runAsNonRoot,omitempty"`
+	RunAsUser    *int64          `json:"runAsUser,omitempty"`
+	Seccomp      string          `json:"seccomp,omitempty"`  // RuntimeDefault、Localhost 或 Unconfined
+	AppArmor     string          `json:"appArmor,omitempty"` // RuntimeDefault、Localhost 或 Unconfined
+	SELinux      *SELinuxOptions `json:"seLinux,omitempty"`
+	HostProcess  bool            `json:"hostProcess,omitempty"` // Windows HostProcess 容器
+}
+
+// SELinuxOptions SELinux 設定
+type SELinuxOptions struct {
+	User string `json:"user,omitempty"`
+	Role string `json:"role,omitempty"`
+	Type string `json:"type,omitempty"`
+}
+
+// ContainerSecurity 容器的安全設定
+type ContainerSecurity struct {
+	Name string `json:"name"`
+	Init bool   `json:"init,omitempty"`
+
+	SecuritySettings
+
+	Privileged               bool     `json:"privileged,omitempty"`
+	AllowPrivilegeEscalation *bool    `json:"allowPrivilegeEscalation,omitempty"`
+	ProcMount                string   `json:"procMount,omitempty"`
+	AddedCapabilities        []string `json:"addedCapabilities,omitempty"`
+	DroppedCapabilities      []string `json:"droppedCapabilities,omitempty"`
+	HostPorts                []int32  `json:"hostPorts,omitempty"`
+}
+
+// SecurityVolume Pod 的卷與類型
+type SecurityVolume struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`               // Pod 規格中的欄位名稱，例如 hostPath、configMap、nfs
+	HostPath string `json:"hostPath,omitempty"` // hostPath 卷掛載的節點路徑
+}
+
+// NamespacePodSecurity 命名空間以 pod-security.kubernetes.io 標籤設定的 Pod Security Admission 等級，未設定時為空字串
+type NamespacePodSecurity struct {
+	Namespace string `json:"namespace"`
+	Enforce   string `json:"enforce,omitempty"`
+	Audit     string `json:"audit,omitempty"`
+	Warn      string `json:"warn,omitempty"`
+}
+
+// ContainerRequests 容器的 requests，未設定的資源為 0
+type ContainerRequests struct {
+	Name             string `json:"name"`
+	CPU              int64  `json:"cpu"`              // millicores
+	Memory           int64  `json:"memory"`           // bytes
+	EphemeralStorage int64  `json:"ephemeralStorage"` // bytes
+}
+
+// CloudRecommendation Google Cloud Recommender 對叢集節點、節點池或磁碟的建議
+type CloudRecommendation struct {
+	Name         string `json:"name"`        // 建議的完整資源名稱，可用於在 Recommender API 標記建議狀態
+	Recommender  string `json:"recommender"` // 例如 google.compute.instance.MachineTypeRecommender
+	Subtype      string `json:"subtype"`     // 例如 CHANGE_MACHINE_TYPE、STOP_VM、SNAPSHOT_AND_DELETE_DISK
+	Description  string `json:"description"`
+	Priority     string `json:"priority"`     // P1 (最高) 到 P4
+	ResourceType string `json:"resourceType"` // Instance、InstanceGroupManager 或 Disk
+	Resource     string `json:"resource"`     // 目標資源的完整名稱，例如 //compute.googleapis.com/projects/p/zones/z/instances/n
+	ResourceName string `json:"resourceName"` // 節點、代管執行個體群組或磁碟名稱
+	Zone         string `json:"zone"`
+	NodePool     string `json:"nodePool,omitempty"`
+
+	SuggestedMachineType string  `json:"suggestedMachineType,omitempty"`
+	MonthlySavings       float64 `json:"monthlySavings"` // 依 costProjection 換算的每月節省成本，負值表示增加的成本
+	Currency             string  `json:"currency,omitempty"`
+	LastRefreshTime      string  `json:"lastRefreshTime,omitempty"`
 }
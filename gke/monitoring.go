@@ -0,0 +1,321 @@
+package gke
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2/google"
+	monitoring "google.golang.org/api/monitoring/v3"
+	"google.golang.org/api/option"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// cpuUsageMetric GKE 系統指標: 容器累計使用的 CPU 核心秒數
+	cpuUsageMetric = "kubernetes.io/container/cpu/core_usage_time"
+
+	// memoryUsageMetric GKE 系統指標: 容器使用的記憶體位元組數
+	memoryUsageMetric = "kubernetes.io/container/memory/used_bytes"
+
+	// defaultHistoricalDuration get_historical_usage 預設查詢的時間範圍
+	defaultHistoricalDuration = 24 * time.Hour
+
+	// defaultAlignmentPeriod 預設的資料對齊間隔
+	defaultAlignmentPeriod = 5 * time.Minute
+
+	// UsageSourceCloudMonitoring 使用量資料來自 Cloud Monitoring
+	UsageSourceCloudMonitoring = "cloud-monitoring"
+)
+
+// newMonitoringService 使用服務帳戶凭证建立 Cloud Monitoring 客戶端
+func newMonitoringService(config ServiceConfig) (*monitoring.Service, error) {
+	credentialsBytes, err := os.ReadFile(config.CredentialsFile)
+	if err != nil {
+		return nil, fmt.Errorf("無法讀取凭证文件: %w", err)
+	}
+
+	googleCredentials, err := google.CredentialsFromJSON(context.Background(), credentialsBytes, monitoring.MonitoringReadScope)
+	if err != nil {
+		return nil, fmt.Errorf("無法建立 Google 凭证: %w", err)
+	}
+
+	monitoringService, err := monitoring.NewService(context.Background(), option.WithCredentials(googleCredentials))
+	if err != nil {
+		return nil, fmt.Errorf("無法建立 Cloud Monitoring 客戶端: %w", err)
+	}
+
+	return monitoringService, nil
+}
+
+// HistoricalUsageAvailable 是否可以從 Cloud Monitoring 查詢歷史使用量
+func (s *Service) HistoricalUsageAvailable() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.monitoringService != nil
+}
+
+// GetHistoricalUsage 從 Cloud Monitoring 取得 Pod 各容器在指定時間範圍內的 CPU 與記憶體使用量
+func (s *Service) GetHistoricalUsage(ctx context.Context, podName, namespace string, duration, alignment time.Duration) (*HistoricalUsage, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.monitoringService == nil {
+		return nil, fmt.Errorf("Cloud Monitoring 不可用，需使用 Google Cloud 凭证連線")
+	}
+	if namespace == "" {
+		namespace = s.defaultNamespace
+	}
+	if duration <= 0 {
+		duration = defaultHistoricalDuration
+	}
+	if alignment <= 0 {
+		alignment = defaultAlignmentPeriod
+	}
+
+	if err := validateFilterNames(namespace, podName); err != nil {
+		return nil, err
+	}
+
+	end := time.Now()
+	start := end.Add(-duration)
+	resourceFilter := fmt.Sprintf(`resource.labels.namespace_name="%s" AND resource.labels.pod_name="%s"`, namespace, podName)
+
+	// 每個容器一個時間序列，以時間戳為鍵合併 CPU 與記憶體
+	containers := make(map[string]map[time.Time]*MetricSample)
+	collect := func(series *monitoring.TimeSeries, apply func(sample *MetricSample, value float64)) {
+		name := series.Resource.Labels["container_name"]
+		if containers[name] == nil {
+			containers[name] = make(map[time.Time]*MetricSample)
+		}
+		for _, point := range series.Points {
+			timestamp, value, ok := parseMonitoringPoint(point)
+			if !ok {
+				continue
+			}
+			sample, exists := containers[name][timestamp]
+			if !exists {
+				sample = &MetricSample{Timestamp: timestamp}
+				containers[name][timestamp] = sample
+			}
+			apply(sample, value)
+		}
+	}
+
+	err := s.queryTimeSeries(ctx, cpuUsageMetric, resourceFilter, "ALIGN_RATE", start, end, alignment, nil, func(series *monitoring.TimeSeries) {
+		collect(series, func(sample *MetricSample, value float64) {
+			sample.CPUMillicores += int64(value * 1000)
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.queryTimeSeries(ctx, memoryUsageMetric, resourceFilter+` AND metric.labels.memory_type="non-evictable"`, "ALIGN_MEAN", start, end, alignment, nil, func(series *monitoring.TimeSeries) {
+		collect(series, func(sample *MetricSample, value float64) {
+			sample.MemoryBytes += int64(value)
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	usage := &HistoricalUsage{
+		PodName:          podName,
+		Namespace:        namespace,
+		Source:           UsageSourceCloudMonitoring,
+		Start:            start,
+		End:              end,
+		AlignmentSeconds: int(alignment.Seconds()),
+		Containers:       []ContainerHistory{},
+		Samples:          []MetricSample{},
+	}
+
+	totals := make(map[time.Time]*MetricSample)
+	for name, samples := range containers {
+		history := ContainerHistory{Name: name, Samples: sortedSamples(samples)}
+		history.Summary = summarizeSamples(history.Samples)
+		usage.Containers = append(usage.Containers, history)
+
+		for timestamp, sample := range samples {
+			total, ok := totals[timestamp]
+			if !ok {
+				total = &MetricSample{Timestamp: timestamp}
+				totals[timestamp] = total
+			}
+			total.CPUMillicores += sample.CPUMillicores
+			total.MemoryBytes += sample.MemoryBytes
+		}
+	}
+	sort.Slice(usage.Containers, func(i, j int) bool {
+		return usage.Containers[i].Name < usage.Containers[j].Name
+	})
+
+	usage.Samples = sortedSamples(totals)
+	usage.Summary = summarizeSamples(usage.Samples)
+
+	return usage, nil
+}
+
+// GetNamespaceUsagePeaks 從 Cloud Monitoring 取得命名空間內各 Pod 在指定時間範圍內的使用量統計，以 namespace/pod 為鍵
+// 一次查詢整個命名空間，供產生優化報告時使用
+func (s *Service) GetNamespaceUsagePeaks(ctx context.Context, namespace string, duration time.Duration) (map[string]*MetricsSummary, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.monitoringService == nil {
+		return nil, fmt.Errorf("Cloud Monitoring 不可用，需使用 Google Cloud 凭证連線")
+	}
+	if duration <= 0 {
+		duration = defaultHistoricalDuration
+	}
+
+	end := time.Now()
+	start := end.Add(-duration)
+	resourceFilter := ""
+	if namespace != "" && namespace != AllNamespaces {
+		if err := validateFilterNames(namespace, ""); err != nil {
+			return nil, err
+		}
+		resourceFilter = fmt.Sprintf(`resource.labels.namespace_name="%s"`, namespace)
+	}
+
+	// 在伺服器端依 Pod 加總各容器的使用量
+	groupBy := []string{"resource.labels.namespace_name", "resource.labels.pod_name"}
+	pods := make(map[string]map[time.Time]*MetricSample)
+	collect := func(series *monitoring.TimeSeries, apply func(sample *MetricSample, value float64)) {
+		key := series.Resource.Labels["namespace_name"] + "/" + series.Resource.Labels["pod_name"]
+		if pods[key] == nil {
+			pods[key] = make(map[time.Time]*MetricSample)
+		}
+		for _, point := range series.Points {
+			timestamp, value, ok := parseMonitoringPoint(point)
+			if !ok {
+				continue
+			}
+			sample, exists := pods[key][timestamp]
+			if !exists {
+				sample = &MetricSample{Timestamp: timestamp}
+				pods[key][timestamp] = sample
+			}
+			apply(sample, value)
+		}
+	}
+
+	err := s.queryTimeSeries(ctx, cpuUsageMetric, resourceFilter, "ALIGN_RATE", start, end, defaultAlignmentPeriod, groupBy, func(series *monitoring.TimeSeries) {
+		collect(series, func(sample *MetricSample, value float64) {
+			sample.CPUMillicores += int64(value * 1000)
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	memoryFilter := `metric.labels.memory_type="non-evictable"`
+	if resourceFilter != "" {
+		memoryFilter = resourceFilter + " AND " + memoryFilter
+	}
+	err = s.queryTimeSeries(ctx, memoryUsageMetric, memoryFilter, "ALIGN_MEAN", start, end, defaultAlignmentPeriod, groupBy, func(series *monitoring.TimeSeries) {
+		collect(series, func(sample *MetricSample, value float64) {
+			sample.MemoryBytes += int64(value)
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]*MetricsSummary, len(pods))
+	for key, samples := range pods {
+		if summary := summarizeSamples(sortedSamples(samples)); summary != nil {
+			result[key] = summary
+		}
+	}
+
+	return result, nil
+}
+
+// validateFilterNames 確認命名空間與 Pod 名稱是合法的 Kubernetes 名稱 (DNS-1123)，再放進 Cloud Monitoring 篩選條件
+// 避免參數中的引號改變篩選條件，podName 為空時只檢查命名空間
+func validateFilterNames(namespace, podName string) error {
+	if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+		return fmt.Errorf("無效的命名空間名稱 %q: %s", namespace, strings.Join(errs, "; "))
+	}
+	if podName == "" {
+		return nil
+	}
+	if errs := validation.IsDNS1123Subdomain(podName); len(errs) > 0 {
+		return fmt.Errorf("無效的 Pod 名稱 %q: %s", podName, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// queryTimeSeries 查詢目前叢集的容器指標時間序列，groupBy 不為空時在伺服器端加總
+func (s *Service) queryTimeSeries(ctx context.Context, metricType, resourceFilter, aligner string, start, end time.Time, alignment time.Duration, groupBy []string, fn func(series *monitoring.TimeSeries)) error {
+	filter := fmt.Sprintf(`metric.type="%s" AND resource.type="k8s_container"`, metricType)
+	if s.config.ClusterName != "" {
+		filter += fmt.Sprintf(` AND resource.labels.cluster_name="%s"`, s.config.ClusterName)
+	}
+	if s.config.Location != "" {
+		filter += fmt.Sprintf(` AND resource.labels.location="%s"`, s.config.Location)
+	}
+	if resourceFilter != "" {
+		filter += " AND " + resourceFilter
+	}
+
+	call := s.monitoringService.Projects.TimeSeries.List("projects/" + s.config.ProjectID).
+		Filter(filter).
+		IntervalStartTime(start.UTC().Format(time.RFC3339)).
+		IntervalEndTime(end.UTC().Format(time.RFC3339)).
+		AggregationAlignmentPeriod(fmt.Sprintf("%ds", int(alignment.Seconds()))).
+		AggregationPerSeriesAligner(aligner)
+	if len(groupBy) > 0 {
+		call = call.AggregationCrossSeriesReducer("REDUCE_SUM").AggregationGroupByFields(groupBy...)
+	}
+
+	err := call.Pages(ctx, func(response *monitoring.ListTimeSeriesResponse) error {
+		for _, series := range response.TimeSeries {
+			fn(series)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("查詢 Cloud Monitoring 指標 %s 失敗: %w", metricType, err)
+	}
+	return nil
+}
+
+// parseMonitoringPoint 取得資料點的時間與數值
+func parseMonitoringPoint(point *monitoring.Point) (time.Time, float64, bool) {
+	if point.Interval == nil || point.Value == nil {
+		return time.Time{}, 0, false
+	}
+
+	timestamp, err := time.Parse(time.RFC3339, point.Interval.EndTime)
+	if err != nil {
+		return time.Time{}, 0, false
+	}
+
+	switch {
+	case point.Value.DoubleValue != nil:
+		return timestamp, *point.Value.DoubleValue, true
+	case point.Value.Int64Value != nil:
+		return timestamp, float64(*point.Value.Int64Value), true
+	default:
+		return time.Time{}, 0, false
+	}
+}
+
+// sortedSamples 將以時間戳為鍵的樣本依時間由舊到新排序
+func sortedSamples(samples map[time.Time]*MetricSample) []MetricSample {
+	result := make([]MetricSample, 0, len(samples))
+	for _, sample := range samples {
+		result = append(result, *sample)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Timestamp.Before(result[j].Timestamp)
+	})
+	return result
+}
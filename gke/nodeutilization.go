@@ -0,0 +1,145 @@
+package gke
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// GetNodeUtilization 取得所有節點的可分配量、Pod 請求量總和、目前使用量與執行中的 Pod
+// Metrics API 不可用時只提供請求量，MetricsAvailable 為 false
+func (s *Service) GetNodeUtilization(ctx context.Context) ([]NodeUtilization, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	nodes, err := s.clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("無法取得節點列表: %w", err)
+	}
+
+	pods, err := s.clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("無法取得 Pod 列表: %w", err)
+	}
+
+	// 依節點整理執行中的 Pod，已結束的 Pod 不佔用排程容量
+	podsByNode := make(map[string][]NodePod)
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Spec.NodeName == "" || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+
+		requests, _ := podRequestsAndLimits(pod)
+		nodePod := NodePod{
+			Name:          pod.Name,
+			Namespace:     pod.Namespace,
+			CPURequest:    requests.Cpu().MilliValue(),
+			MemoryRequest: requests.Memory().Value(),
+			Mirror:        pod.Annotations[mirrorPodAnnotation] != "",
+			labels:        pod.Labels,
+			antiAffinity:  hostAntiAffinityTerms(pod),
+		}
+		if owner := metav1.GetControllerOf(pod); owner != nil {
+			nodePod.OwnerKind = owner.Kind
+			nodePod.OwnerName = owner.Name
+			nodePod.DaemonSet = owner.Kind == "DaemonSet"
+		}
+		podsByNode[pod.Spec.NodeName] = append(podsByNode[pod.Spec.NodeName], nodePod)
+	}
+
+	usage := make(map[string]corev1.ResourceList)
+	if s.metricsClientset != nil {
+		nodeMetrics, err := s.metricsClientset.MetricsV1beta1().NodeMetricses().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			if s.logger != nil {
+				s.logger.Printf("警告: 無法取得節點 metrics: %v", err)
+			}
+		} else {
+			for _, metrics := range nodeMetrics.Items {
+				usage[metrics.Name] = metrics.Usage
+			}
+		}
+	}
+
+	result := make([]NodeUtilization, 0, len(nodes.Items))
+	for _, node := range nodes.Items {
+		utilization := NodeUtilization{
+			NodeName:          node.Name,
+			NodePool:          node.Labels[NodePoolLabel],
+			Zone:              nodeZoneFromLabels(node.Labels),
+			Unschedulable:     node.Spec.Unschedulable,
+			CPUAllocatable:    node.Status.Allocatable.Cpu().MilliValue(),
+			MemoryAllocatable: node.Status.Allocatable.Memory().Value(),
+			PodCapacity:       node.Status.Allocatable.Pods().Value(),
+			Pods:              podsByNode[node.Name],
+		}
+		for _, pod := range utilization.Pods {
+			utilization.CPURequested += pod.CPURequest
+			utilization.MemoryRequested += pod.MemoryRequest
+		}
+		if nodeUsage, ok := usage[node.Name]; ok {
+			utilization.MetricsAvailable = true
+			utilization.CPUUsage = nodeUsage.Cpu().MilliValue()
+			utilization.MemoryUsage = nodeUsage.Memory().Value()
+		}
+		result = append(result, utilization)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].NodeName < result[j].NodeName
+	})
+	return result, nil
+}
+
+// hostAntiAffinityTerm 以節點為拓撲網域的必要 Pod 反親和性
+type hostAntiAffinityTerm struct {
+	selector   labels.Selector
+	namespaces map[string]bool
+}
+
+// hostAntiAffinityTerms 取得 Pod 以 kubernetes.io/hostname 為拓撲網域的必要反親和性，未指定命名空間時為 Pod 所在的命名空間
+func hostAntiAffinityTerms(pod *corev1.Pod) []hostAntiAffinityTerm {
+	if pod.Spec.Affinity == nil || pod.Spec.Affinity.PodAntiAffinity == nil {
+		return nil
+	}
+
+	var terms []hostAntiAffinityTerm
+	for _, term := range pod.Spec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution {
+		if term.TopologyKey != hostnameLabel {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(term.LabelSelector)
+		if err != nil {
+			continue
+		}
+		namespaces := map[string]bool{pod.Namespace: true}
+		if len(term.Namespaces) > 0 {
+			namespaces = make(map[string]bool, len(term.Namespaces))
+			for _, namespace := range term.Namespaces {
+				namespaces[namespace] = true
+			}
+		}
+		terms = append(terms, hostAntiAffinityTerm{selector: selector, namespaces: namespaces})
+	}
+	return terms
+}
+
+// ConflictsWith 判斷兩個 Pod 是否因必要的反親和性 (kubernetes.io/hostname) 而不能在同一個節點上
+func (p NodePod) ConflictsWith(other NodePod) bool {
+	return p.repels(other) || other.repels(p)
+}
+
+// repels 判斷 Pod 的反親和性是否排斥另一個 Pod
+func (p NodePod) repels(other NodePod) bool {
+	for _, term := range p.antiAffinity {
+		if term.namespaces[other.Namespace] && term.selector.Matches(labels.Set(other.labels)) {
+			return true
+		}
+	}
+	return false
+}
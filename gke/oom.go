@@ -0,0 +1,172 @@
+package gke
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// oomKilledReason 容器因超過記憶體限制被終止的原因
+	oomKilledReason = "OOMKilled"
+
+	// defaultOOMWindow 預設查詢的時間範圍
+	defaultOOMWindow = 24 * time.Hour
+)
+
+// nodeOOMReasons 代表節點層級 OOM 的事件原因 (kubelet 的 SystemOOM 與 node-problem-detector 的 OOMKilling)
+var nodeOOMReasons = map[string]bool{
+	"SystemOOM":  true,
+	"OOMKilling": true,
+}
+
+// GetOOMEvents 依工作負載彙總指定時間範圍內的 OOMKilled 終止，並依節點彙總 SystemOOM 事件
+// 容器狀態只保留最近一次終止，因此每個容器最多計算一次 OOMKilled；restartCount 可作為實際次數的參考
+func (s *Service) GetOOMEvents(ctx context.Context, namespace string, since time.Time) (*OOMReport, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if since.IsZero() {
+		since = time.Now().Add(-defaultOOMWindow)
+	}
+	namespace = s.resolveListNamespace(namespace)
+
+	pods, err := s.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("無法取得 Pod 列表: %w", err)
+	}
+
+	report := &OOMReport{
+		Namespace: namespace,
+		Since:     since,
+		Workloads: []WorkloadOOM{},
+		Nodes:     []NodeOOM{},
+	}
+
+	workloads := make(map[string]*WorkloadOOM)
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		kills := podOOMKills(pod, since)
+		if len(kills) == 0 {
+			continue
+		}
+
+		kind, name := podWorkload(pod)
+		key := pod.Namespace + "/" + kind + "/" + name
+		workload, ok := workloads[key]
+		if !ok {
+			workload = &WorkloadOOM{Kind: kind, Name: name, Namespace: pod.Namespace}
+			workloads[key] = workload
+		}
+
+		workload.OOMKills += len(kills)
+		workload.Pods = appendUnique(workload.Pods, pod.Name)
+		for _, kill := range kills {
+			workload.Containers = appendUnique(workload.Containers, kill.Container)
+			if kill.FinishedAt.After(workload.LastOOMAt) {
+				workload.LastOOMAt = kill.FinishedAt
+			}
+		}
+		workload.Kills = append(workload.Kills, kills...)
+	}
+
+	for _, workload := range workloads {
+		sort.Slice(workload.Kills, func(i, j int) bool {
+			return workload.Kills[i].FinishedAt.After(workload.Kills[j].FinishedAt)
+		})
+		report.Workloads = append(report.Workloads, *workload)
+		report.TotalOOMKills += workload.OOMKills
+	}
+	sort.Slice(report.Workloads, func(i, j int) bool {
+		if report.Workloads[i].OOMKills != report.Workloads[j].OOMKills {
+			return report.Workloads[i].OOMKills > report.Workloads[j].OOMKills
+		}
+		return report.Workloads[i].LastOOMAt.After(report.Workloads[j].LastOOMAt)
+	})
+
+	// 節點事件不屬於特定命名空間，查詢失敗時只記錄警告
+	events, err := s.listNodeEvents(ctx, "", since, corev1.EventTypeWarning)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Printf("警告: 無法取得節點事件: %v", err)
+		}
+		return report, nil
+	}
+
+	nodes := make(map[string]*NodeOOM)
+	for _, event := range events {
+		if !nodeOOMReasons[event.Reason] {
+			continue
+		}
+		node, ok := nodes[event.InvolvedObject.Name]
+		if !ok {
+			node = &NodeOOM{NodeName: event.InvolvedObject.Name}
+			nodes[event.InvolvedObject.Name] = node
+		}
+
+		count := int(event.Count)
+		if count == 0 {
+			count = 1
+		}
+		node.Count += count
+		timestamp := eventTimestamp(event)
+		if timestamp.After(node.LastSeen) {
+			node.LastSeen = timestamp
+		}
+		node.Messages = appendUnique(node.Messages, event.Message)
+	}
+	for _, node := range nodes {
+		report.Nodes = append(report.Nodes, *node)
+	}
+	sort.Slice(report.Nodes, func(i, j int) bool {
+		return report.Nodes[i].Count > report.Nodes[j].Count
+	})
+
+	return report, nil
+}
+
+// podOOMKills 取得 Pod 中在 since 之後因 OOMKilled 終止的容器
+func podOOMKills(pod *corev1.Pod, since time.Time) []OOMKill {
+	limits := make(map[string]string)
+	for _, container := range podContainers(pod) {
+		if limit, ok := container.Resources.Limits[corev1.ResourceMemory]; ok {
+			limits[container.Name] = limit.String()
+		}
+	}
+
+	var kills []OOMKill
+	statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		// 目前狀態 (尚未重啟) 與上次終止狀態都可能是 OOMKilled
+		for _, terminated := range []*corev1.ContainerStateTerminated{status.State.Terminated, status.LastTerminationState.Terminated} {
+			if terminated == nil || terminated.Reason != oomKilledReason || terminated.FinishedAt.Time.Before(since) {
+				continue
+			}
+			kills = append(kills, OOMKill{
+				PodName:      pod.Name,
+				Container:    status.Name,
+				FinishedAt:   terminated.FinishedAt.Time,
+				MemoryLimit:  limits[status.Name],
+				RestartCount: status.RestartCount,
+				NodeName:     pod.Spec.NodeName,
+			})
+			break
+		}
+	}
+
+	return kills
+}
+
+// appendUnique 加入不重複的字串
+func appendUnique(values []string, value string) []string {
+	for _, existing := range values {
+		if existing == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
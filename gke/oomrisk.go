@@ -0,0 +1,114 @@
+package gke
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	// defaultOOMRiskWindow 偵測 OOM 風險預設的時間範圍，只看近期的趨勢
+	defaultOOMRiskWindow = 6 * time.Hour
+
+	// oomRiskUsagePercent 記憶體使用量達到限制的此比例 (%) 且仍在成長時視為即將 OOM
+	oomRiskUsagePercent = 85.0
+
+	// minOOMRiskSamples 判斷趨勢最少需要的樣本數
+	minOOMRiskSamples = 6
+)
+
+// DetectOOMRisks 找出記憶體使用量接近限制且仍在成長的容器，在被 OOMKilled 之前提出警告
+// 與記憶體洩漏偵測不同，不要求長期單調成長，只看最後一次重啟之後的近期趨勢；未設定記憶體限制的容器不列入
+func (s *Service) DetectOOMRisks(ctx context.Context, namespace, source string, window time.Duration) (*OOMRiskReport, error) {
+	if window <= 0 {
+		window = defaultOOMRiskWindow
+	}
+
+	source, err := s.resolveUsageSource(source)
+	if err != nil {
+		return nil, err
+	}
+
+	namespace, containers, warnings, err := s.runningContainerMemory(ctx, namespace, source, window)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &OOMRiskReport{
+		Namespace:          namespace,
+		Source:             source,
+		Window:             window.String(),
+		ContainersAnalyzed: len(containers),
+		Risks:              []OOMRisk{},
+		Warnings:           warnings,
+	}
+
+	now := time.Now()
+	for _, container := range containers {
+		risk := detectOOMRisk(container.points, container.limit, now)
+		if risk == nil {
+			continue
+		}
+		kind, workload := podWorkload(container.pod)
+		risk.Namespace = container.pod.Namespace
+		risk.PodName = container.pod.Name
+		risk.Container = container.name
+		risk.WorkloadKind = kind
+		risk.WorkloadName = workload
+		report.Risks = append(report.Risks, *risk)
+	}
+
+	// 越快 OOM 的排在前面，沒有預測時間的依使用比例排序
+	sort.Slice(report.Risks, func(i, j int) bool {
+		a, b := report.Risks[i], report.Risks[j]
+		if (a.ProjectedOOMAt == nil) != (b.ProjectedOOMAt == nil) {
+			return a.ProjectedOOMAt != nil
+		}
+		if a.ProjectedOOMAt != nil && !a.ProjectedOOMAt.Equal(*b.ProjectedOOMAt) {
+			return a.ProjectedOOMAt.Before(*b.ProjectedOOMAt)
+		}
+		return a.UsagePercent > b.UsagePercent
+	})
+
+	return report, nil
+}
+
+// detectOOMRisk 判斷容器記憶體是否接近限制且仍在成長，沒有風險時回傳 nil
+func detectOOMRisk(points []SeriesPoint, limit float64, now time.Time) *OOMRisk {
+	if limit <= 0 {
+		return nil
+	}
+	points = sinceLastRestart(points)
+	if len(points) < minOOMRiskSamples {
+		return nil
+	}
+
+	current := points[len(points)-1].Value
+	usagePercent := current / limit * 100
+	if usagePercent < oomRiskUsagePercent {
+		return nil
+	}
+	trend := fitLinearTrend(points)
+	if trend.slopePerHour <= 0 {
+		return nil
+	}
+
+	risk := &OOMRisk{
+		CurrentBytes:       int64(current),
+		MemoryLimitBytes:   int64(limit),
+		UsagePercent:       math.Round(usagePercent*10) / 10,
+		GrowthBytesPerHour: int64(trend.slopePerHour),
+		ProjectedOOMAt:     projectCrossing(current, trend.slopePerHour, limit, now, leakProjectionHorizon),
+	}
+	if risk.ProjectedOOMAt != nil {
+		risk.Description = fmt.Sprintf("記憶體使用量 %dMi 已達限制 %dMi 的 %.1f%%，每小時成長約 %dMi，預計於 %s 被 OOMKilled",
+			risk.CurrentBytes/(1024*1024), risk.MemoryLimitBytes/(1024*1024), risk.UsagePercent,
+			risk.GrowthBytesPerHour/(1024*1024), risk.ProjectedOOMAt.Format(time.RFC3339))
+	} else {
+		risk.Description = fmt.Sprintf("記憶體使用量 %dMi 已達限制 %dMi 的 %.1f%% 且仍在成長",
+			risk.CurrentBytes/(1024*1024), risk.MemoryLimitBytes/(1024*1024), risk.UsagePercent)
+	}
+	return risk
+}
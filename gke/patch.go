@@ -0,0 +1,251 @@
+package gke
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// patchIgnoredFields 比對修補前後差異時忽略的欄位 (每次更新都會變動或由控制器維護)
+var patchIgnoredFields = map[string]bool{
+	"metadata.managedFields":   true,
+	"metadata.resourceVersion": true,
+	"metadata.generation":      true,
+	"status":                   true,
+}
+
+// applyFieldManager 伺服器端套用 (server-side apply) 使用的欄位管理者名稱
+const applyFieldManager = "mcp-gke-monitor"
+
+// parsePatchType 轉換修補類型名稱
+func parsePatchType(patchType string) (types.PatchType, error) {
+	switch strings.ToLower(patchType) {
+	case "", "strategic":
+		return types.StrategicMergePatchType, nil
+	case "merge":
+		return types.MergePatchType, nil
+	case "json":
+		return types.JSONPatchType, nil
+	case "apply":
+		return types.ApplyPatchType, nil
+	default:
+		return "", fmt.Errorf("不支援的修補類型 %q，可用值: strategic, merge, json, apply", patchType)
+	}
+}
+
+// ApplyPatch 對指定資源套用 strategic merge、merge、JSON 修補或伺服器端套用 (apply)
+// apply 的內容需包含 apiVersion、kind 與 metadata.name，並會強制取得所列欄位的擁有權
+// commit 為 false 時只進行伺服器端 dry-run，不會變更叢集狀態；commit 為 true 時需啟用寫入模式
+// 修補 Secret 時差異與稽核日誌中的值會被遮蔽
+func (s *Service) ApplyPatch(ctx context.Context, resource, name, namespace, patchType string, patch []byte, commit bool) (*PatchResult, error) {
+	if commit {
+		if err := s.ensureWriteEnabled(); err != nil {
+			return nil, err
+		}
+	}
+
+	pt, err := parsePatchType(patchType)
+	if err != nil {
+		return nil, err
+	}
+	if !json.Valid(patch) {
+		return nil, fmt.Errorf("修補內容不是有效的 JSON")
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	mapping, err := s.resolveResource(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		if namespace == "" {
+			namespace = s.defaultNamespace
+		}
+	} else {
+		namespace = ""
+	}
+
+	// 命名空間為空時會使用叢集層級的資源路徑
+	client := s.dynamicClient.Resource(mapping.Resource).Namespace(namespace)
+
+	before, err := client.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("無法取得 %s %s: %w", mapping.GroupVersionKind.Kind, name, err)
+	}
+
+	options := metav1.PatchOptions{}
+	if pt == types.ApplyPatchType {
+		force := true
+		options.FieldManager = applyFieldManager
+		options.Force = &force
+	}
+	if !commit {
+		options.DryRun = []string{metav1.DryRunAll}
+	}
+
+	after, err := client.Patch(ctx, name, pt, patch, options)
+	if err != nil {
+		return nil, fmt.Errorf("套用修補失敗: %w", err)
+	}
+
+	secret := isSecret(mapping.GroupVersionKind)
+	if commit {
+		content := string(patch)
+		if secret {
+			content = "<內容已遮蔽>"
+		}
+		s.auditWrite("修補 %s %s/%s (%s): %s", mapping.GroupVersionKind.Kind, namespace, name, pt, content)
+	}
+
+	result := &PatchResult{
+		Kind:            mapping.GroupVersionKind.Kind,
+		APIVersion:      mapping.GroupVersionKind.GroupVersion().String(),
+		Name:            name,
+		Namespace:       namespace,
+		PatchType:       string(pt),
+		DryRun:          !commit,
+		ResourceVersion: after.GetResourceVersion(),
+		Changes:         patchChanges(mapping.GroupVersionKind, name, before.Object, after.Object),
+	}
+
+	switch {
+	case len(result.Changes) == 0:
+		result.Message = "修補不會造成任何變更"
+	case !commit:
+		result.Message = "僅為 dry-run 預覽，未變更叢集狀態；確認後以 commit=true 套用"
+	default:
+		result.Message = "修補已套用"
+	}
+
+	return result, nil
+}
+
+// resolveResource 將資源名稱 (例如 deployment、deployments.apps、hpa) 解析為 API 資源
+func (s *Service) resolveResource(resource string) (*meta.RESTMapping, error) {
+	if s.dynamicClient == nil || s.restMapper == nil {
+		return nil, fmt.Errorf("缺少 Kubernetes 動態客戶端，無法修補資源")
+	}
+
+	gvr, err := s.restMapper.ResourceFor(schema.ParseGroupResource(strings.ToLower(resource)).WithVersion(""))
+	if err != nil {
+		return nil, fmt.Errorf("無法識別資源類型 %q: %w", resource, err)
+	}
+
+	gvk, err := s.restMapper.KindFor(gvr)
+	if err != nil {
+		return nil, fmt.Errorf("無法識別資源類型 %q: %w", resource, err)
+	}
+
+	mapping, err := s.restMapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, fmt.Errorf("無法識別資源類型 %q: %w", resource, err)
+	}
+	return mapping, nil
+}
+
+// diffObjects 遞迴比對兩個物件並記錄有差異的欄位路徑
+func diffObjects(path string, before, after interface{}, changes *[]FieldChange) {
+	if patchIgnoredFields[path] {
+		return
+	}
+
+	beforeMap, beforeIsMap := before.(map[string]interface{})
+	afterMap, afterIsMap := after.(map[string]interface{})
+	if beforeIsMap && afterIsMap {
+		keys := make(map[string]bool)
+		for key := range beforeMap {
+			keys[key] = true
+		}
+		for key := range afterMap {
+			keys[key] = true
+		}
+		for key := range keys {
+			diffObjects(joinFieldPath(path, key), beforeMap[key], afterMap[key], changes)
+		}
+		return
+	}
+
+	beforeList, beforeIsList := before.([]interface{})
+	afterList, afterIsList := after.([]interface{})
+	if beforeIsList && afterIsList && len(beforeList) == len(afterList) {
+		for i := range beforeList {
+			diffObjects(fmt.Sprintf("%s[%d]", path, i), beforeList[i], afterList[i], changes)
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(before, after) {
+		*changes = append(*changes, FieldChange{Path: path, Before: before, After: after})
+	}
+}
+
+// patchChanges 比對修補前後的物件，依路徑排序列出變更的欄位，Secret 的值會被遮蔽
+func patchChanges(gvk schema.GroupVersionKind, name string, before, after map[string]interface{}) []FieldChange {
+	changes := []FieldChange{}
+	diffObjects("", before, after, &changes)
+	if isSecret(gvk) {
+		redactSecretChanges(name, changes)
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+	return changes
+}
+
+// isSecret 資源是否為 core/v1 的 Secret
+func isSecret(gvk schema.GroupVersionKind) bool {
+	return gvk.Group == "" && gvk.Kind == "Secret"
+}
+
+// redactSecretChanges 與 get_pod_env 相同，將 Secret 的 data、stringData 與 last-applied 註解 (包含完整內容) 的值遮蔽為 "<secret:name/key>"
+// 未啟用寫入模式時也可以 dry-run 修補 Secret，不遮蔽會從差異中洩漏原本的值
+func redactSecretChanges(name string, changes []FieldChange) {
+	for i := range changes {
+		key, ok := secretValueKey(changes[i].Path)
+		if !ok {
+			continue
+		}
+		masked := fmt.Sprintf("<secret:%s/%s>", name, key)
+		if changes[i].Before != nil {
+			changes[i].Before = masked
+		}
+		if changes[i].After != nil {
+			changes[i].After = masked
+		}
+	}
+}
+
+// secretValueKey 差異路徑是否包含 Secret 的值，回傳遮蔽時顯示的 key (整個 data 區塊或註解為 "*")
+func secretValueKey(path string) (string, bool) {
+	if path == "metadata.annotations" || path == "metadata.annotations.kubectl.kubernetes.io/last-applied-configuration" {
+		return "*", true
+	}
+	for _, field := range []string{"data", "stringData"} {
+		if path == field {
+			return "*", true
+		}
+		if key, found := strings.CutPrefix(path, field+"."); found {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+// joinFieldPath 組合欄位路徑
+func joinFieldPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,177 @@
+package gke
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var (
+	secretGVK    = schema.GroupVersionKind{Version: "v1", Kind: "Secret"}
+	configMapGVK = schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+)
+
+// testObject 以 metadata 與其他欄位組成物件
+func testObject(metadata map[string]interface{}, fields map[string]interface{}) map[string]interface{} {
+	object := map[string]interface{}{"metadata": metadata}
+	for key, value := range fields {
+		object[key] = value
+	}
+	return object
+}
+
+func TestPatchChangesRedactsSecret(t *testing.T) {
+	lastApplied := `{"data":{"password":"b2xk"}}`
+
+	tests := []struct {
+		name   string
+		before map[string]interface{}
+		after  map[string]interface{}
+		want   []FieldChange
+	}{
+		{
+			name:   "changed data key",
+			before: testObject(map[string]interface{}{}, map[string]interface{}{"data": map[string]interface{}{"password": "b2xk"}}),
+			after:  testObject(map[string]interface{}{}, map[string]interface{}{"data": map[string]interface{}{"password": "bmV3"}}),
+			want:   []FieldChange{{Path: "data.password", Before: "<secret:db/password>", After: "<secret:db/password>"}},
+		},
+		{
+			name:   "changed stringData key",
+			before: testObject(map[string]interface{}{}, map[string]interface{}{"stringData": map[string]interface{}{"token": "old"}}),
+			after:  testObject(map[string]interface{}{}, map[string]interface{}{"stringData": map[string]interface{}{"token": "new"}}),
+			want:   []FieldChange{{Path: "stringData.token", Before: "<secret:db/token>", After: "<secret:db/token>"}},
+		},
+		{
+			name:   "added key",
+			before: testObject(map[string]interface{}{}, map[string]interface{}{"data": map[string]interface{}{"user": "YWRtaW4="}}),
+			after: testObject(map[string]interface{}{}, map[string]interface{}{
+				"data":       map[string]interface{}{"user": "YWRtaW4=", "password": "bmV3"},
+				"stringData": map[string]interface{}{"token": "new"},
+			}),
+			want: []FieldChange{
+				{Path: "data.password", Before: nil, After: "<secret:db/password>"},
+				{Path: "stringData", Before: nil, After: "<secret:db/*>"},
+			},
+		},
+		{
+			name:   "removed key",
+			before: testObject(map[string]interface{}{}, map[string]interface{}{"data": map[string]interface{}{"user": "YWRtaW4=", "password": "b2xk"}}),
+			after:  testObject(map[string]interface{}{}, map[string]interface{}{"data": map[string]interface{}{"user": "YWRtaW4="}}),
+			want:   []FieldChange{{Path: "data.password", Before: "<secret:db/password>", After: nil}},
+		},
+		{
+			name:   "added data block",
+			before: testObject(map[string]interface{}{}, nil),
+			after:  testObject(map[string]interface{}{}, map[string]interface{}{"data": map[string]interface{}{"password": "bmV3"}}),
+			want:   []FieldChange{{Path: "data", Before: nil, After: "<secret:db/*>"}},
+		},
+		{
+			name: "last-applied annotation",
+			before: testObject(map[string]interface{}{"annotations": map[string]interface{}{
+				"kubectl.kubernetes.io/last-applied-configuration": lastApplied,
+			}}, nil),
+			after: testObject(map[string]interface{}{"annotations": map[string]interface{}{
+				"kubectl.kubernetes.io/last-applied-configuration": `{"data":{"password":"bmV3"}}`,
+			}}, nil),
+			want: []FieldChange{{
+				Path:   "metadata.annotations.kubectl.kubernetes.io/last-applied-configuration",
+				Before: "<secret:db/*>",
+				After:  "<secret:db/*>",
+			}},
+		},
+		{
+			name:   "added annotations block",
+			before: testObject(map[string]interface{}{}, nil),
+			after: testObject(map[string]interface{}{"annotations": map[string]interface{}{
+				"kubectl.kubernetes.io/last-applied-configuration": lastApplied,
+			}}, nil),
+			want: []FieldChange{{Path: "metadata.annotations", Before: nil, After: "<secret:db/*>"}},
+		},
+		{
+			name:   "labels and type are not redacted",
+			before: testObject(map[string]interface{}{"labels": map[string]interface{}{"app": "db"}}, map[string]interface{}{"type": "Opaque"}),
+			after:  testObject(map[string]interface{}{"labels": map[string]interface{}{"app": "api"}}, map[string]interface{}{"type": "kubernetes.io/basic-auth"}),
+			want: []FieldChange{
+				{Path: "metadata.labels.app", Before: "db", After: "api"},
+				{Path: "type", Before: "Opaque", After: "kubernetes.io/basic-auth"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := patchChanges(secretGVK, "db", tt.before, tt.after)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("patchChanges() = %#v, want %#v", got, tt.want)
+			}
+
+			// 變更內容中不可出現任何原始值
+			data, err := json.Marshal(got)
+			if err != nil {
+				t.Fatalf("無法編碼變更: %v", err)
+			}
+			for _, value := range []string{"b2xk", "bmV3", "old", "new", lastApplied} {
+				if strings.Contains(string(data), value) {
+					t.Errorf("變更內容包含原始值 %q: %s", value, data)
+				}
+			}
+		})
+	}
+}
+
+func TestPatchChangesNonSecretUnchanged(t *testing.T) {
+	before := testObject(map[string]interface{}{}, map[string]interface{}{"data": map[string]interface{}{"level": "info"}})
+	after := testObject(map[string]interface{}{}, map[string]interface{}{
+		"data":       map[string]interface{}{"level": "debug", "format": "json"},
+		"stringData": map[string]interface{}{"token": "new"},
+	})
+	want := []FieldChange{
+		{Path: "data.format", Before: nil, After: "json"},
+		{Path: "data.level", Before: "info", After: "debug"},
+		{Path: "stringData", Before: nil, After: map[string]interface{}{"token": "new"}},
+	}
+
+	for _, gvk := range []schema.GroupVersionKind{
+		configMapGVK,
+		// 其他 API 群組中同名的 Secret 不是 core/v1 的 Secret
+		{Group: "example.com", Version: "v1", Kind: "Secret"},
+	} {
+		t.Run(gvk.String(), func(t *testing.T) {
+			got := patchChanges(gvk, "app", before, after)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("patchChanges() = %#v, want %#v", got, want)
+			}
+		})
+	}
+}
+
+func TestSecretValueKey(t *testing.T) {
+	tests := []struct {
+		path   string
+		want   string
+		wantOK bool
+	}{
+		{path: "data", want: "*", wantOK: true},
+		{path: "stringData", want: "*", wantOK: true},
+		{path: "data.password", want: "password", wantOK: true},
+		{path: "stringData.tls.key", want: "tls.key", wantOK: true},
+		{path: "metadata.annotations", want: "*", wantOK: true},
+		{path: "metadata.annotations.kubectl.kubernetes.io/last-applied-configuration", want: "*", wantOK: true},
+		{path: "metadata.annotations.owner", wantOK: false},
+		{path: "metadata.labels.app", wantOK: false},
+		{path: "dataSource", wantOK: false},
+		{path: "type", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, ok := secretValueKey(tt.path)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("secretValueKey(%q) = (%q, %v), want (%q, %v)", tt.path, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
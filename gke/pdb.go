@@ -0,0 +1,47 @@
+package gke
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// PodDisruptionBudget 命名空間內的 PodDisruptionBudget
+type PodDisruptionBudget struct {
+	PDBStatus
+	Namespace string `json:"namespace"`
+
+	selector labels.Selector
+}
+
+// Matches 判斷 PDB 是否選取帶有指定標籤的 Pod
+func (p PodDisruptionBudget) Matches(podLabels map[string]string) bool {
+	return p.selector != nil && p.selector.Matches(labels.Set(podLabels))
+}
+
+// ListPodDisruptionBudgets 取得命名空間內的 PodDisruptionBudget 與其選擇器，選擇器無效或為空的 PDB 不列入
+func (s *Service) ListPodDisruptionBudgets(ctx context.Context, namespace string) ([]PodDisruptionBudget, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	pdbs, err := s.clientset.PolicyV1().PodDisruptionBudgets(s.resolveListNamespace(namespace)).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("無法取得 PodDisruptionBudget 列表: %w", err)
+	}
+
+	result := make([]PodDisruptionBudget, 0, len(pdbs.Items))
+	for _, pdb := range pdbs.Items {
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil || selector.Empty() {
+			continue
+		}
+		result = append(result, PodDisruptionBudget{
+			PDBStatus: convertPDBStatus(&pdb),
+			Namespace: pdb.Namespace,
+			selector:  selector,
+		})
+	}
+	return result, nil
+}
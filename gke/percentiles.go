@@ -0,0 +1,207 @@
+package gke
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// UsageSourceCollector 使用量資料來自背景指標收集器
+	UsageSourceCollector = "collector"
+
+	// defaultPercentileWindow 計算百分位數預設的時間範圍
+	defaultPercentileWindow = 7 * 24 * time.Hour
+)
+
+// usageSources 自動選擇資料來源時的優先順序 (保存時間較長的來源優先)
+var usageSources = []string{UsageSourceCloudMonitoring, UsageSourcePrometheus, UsageSourceCollector}
+
+// usageSourceAvailable 判斷資料來源是否可用
+func (s *Service) usageSourceAvailable(source string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	switch source {
+	case UsageSourceCloudMonitoring:
+		return s.monitoringService != nil
+	case UsageSourcePrometheus:
+		return s.prometheus != nil
+	case UsageSourceCollector:
+		return s.collector != nil
+	default:
+		return false
+	}
+}
+
+// resolveUsageSource 確認資料來源可用；source 為空時依序選擇第一個可用的來源
+func (s *Service) resolveUsageSource(source string) (string, error) {
+	if source == "" {
+		for _, candidate := range usageSources {
+			if s.usageSourceAvailable(candidate) {
+				return candidate, nil
+			}
+		}
+		return "", fmt.Errorf("沒有可用的歷史資料來源，請啟用指標收集、Prometheus 或使用 Google Cloud 凭证連線")
+	}
+	if !s.usageSourceAvailable(source) {
+		return "", fmt.Errorf("資料來源 %s 不可用，可用值: %s", source, strings.Join(usageSources, ", "))
+	}
+	return source, nil
+}
+
+// UsageHistoryAvailable 判斷是否有任何可用的歷史使用量資料來源
+func (s *Service) UsageHistoryAvailable() bool {
+	_, err := s.resolveUsageSource("")
+	return err == nil
+}
+
+// podUsageSamples 從指定的歷史資料來源取得各 Pod 的使用量樣本，以 Pod 名稱為鍵
+// source 為空時依序使用第一個可用的來源，並回傳實際使用的來源
+func (s *Service) podUsageSamples(ctx context.Context, source, namespace string, pods []string, window time.Duration) (map[string][]MetricSample, string, error) {
+	source, err := s.resolveUsageSource(source)
+	if err != nil {
+		return nil, "", err
+	}
+
+	end := time.Now()
+	start := end.Add(-window)
+	result := make(map[string][]MetricSample, len(pods))
+
+	switch source {
+	case UsageSourceCloudMonitoring:
+		for _, pod := range pods {
+			usage, err := s.GetHistoricalUsage(ctx, pod, namespace, window, 0)
+			if err != nil {
+				return nil, source, err
+			}
+			result[pod] = usage.Samples
+		}
+	case UsageSourcePrometheus:
+		s.mu.RLock()
+		client := s.prometheus
+		s.mu.RUnlock()
+
+		samples, err := client.podSamples(ctx, namespace, pods, start, end, defaultPrometheusStep)
+		if err != nil {
+			return nil, source, err
+		}
+		result = samples
+	case UsageSourceCollector:
+		s.mu.RLock()
+		collector := s.collector
+		s.mu.RUnlock()
+
+		for _, pod := range pods {
+			if samples, ok := collector.history("pod", namespace+"/"+pod, start); ok {
+				result[pod] = samples
+			}
+		}
+	}
+
+	return result, source, nil
+}
+
+// GetUsagePercentiles 計算 Pod 或工作負載 (所有 Pod 的樣本合併) 在指定時間範圍內的 CPU 與記憶體百分位數
+func (s *Service) GetUsagePercentiles(ctx context.Context, kind, name, namespace, source string, window time.Duration) (*UsagePercentiles, error) {
+	if namespace == "" {
+		namespace = s.defaultNamespace
+	}
+	if window <= 0 {
+		window = defaultPercentileWindow
+	}
+
+	pods := []string{name}
+	if kind != "" && !strings.EqualFold(kind, "pod") {
+		workloadKind, err := normalizeWorkloadKind(kind)
+		if err != nil {
+			return nil, err
+		}
+		kind = workloadKind
+
+		s.mu.RLock()
+		workloadPods, err := s.getWorkloadPods(ctx, kind, name, namespace)
+		s.mu.RUnlock()
+		if err != nil {
+			return nil, err
+		}
+		if len(workloadPods) == 0 {
+			return nil, fmt.Errorf("%s %s 目前沒有任何 Pod", kind, name)
+		}
+
+		pods = pods[:0]
+		for _, pod := range workloadPods {
+			pods = append(pods, pod.Name)
+		}
+	} else {
+		kind = "Pod"
+	}
+
+	samples, source, err := s.podUsageSamples(ctx, source, namespace, pods, window)
+	if err != nil {
+		return nil, err
+	}
+
+	var cpu, memory []float64
+	podsWithData := []string{}
+	for _, pod := range pods {
+		if len(samples[pod]) == 0 {
+			continue
+		}
+		podsWithData = append(podsWithData, pod)
+		for _, sample := range samples[pod] {
+			cpu = append(cpu, float64(sample.CPUMillicores))
+			memory = append(memory, float64(sample.MemoryBytes))
+		}
+	}
+	if len(cpu) == 0 {
+		return nil, fmt.Errorf("資料來源 %s 中沒有 %s %s 在最近 %s 內的使用量樣本", source, kind, name, window)
+	}
+
+	return &UsagePercentiles{
+		Kind:          kind,
+		Name:          name,
+		Namespace:     namespace,
+		Source:        source,
+		Window:        window.String(),
+		Pods:          podsWithData,
+		SampleCount:   len(cpu),
+		CPUMillicores: computePercentiles(cpu),
+		MemoryBytes:   computePercentiles(memory),
+	}, nil
+}
+
+// computePercentiles 計算 p50/p90/p95/p99 與最大值
+func computePercentiles(values []float64) Percentiles {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	return Percentiles{
+		P50: percentile(sorted, 50),
+		P90: percentile(sorted, 90),
+		P95: percentile(sorted, 95),
+		P99: percentile(sorted, 99),
+		Max: sorted[len(sorted)-1],
+	}
+}
+
+// percentile 以線性內插計算已排序數列的百分位數
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	if len(sorted) == 1 {
+		return sorted[0]
+	}
+
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	if lower == upper {
+		return sorted[lower]
+	}
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower))
+}
@@ -0,0 +1,105 @@
+package gke
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetPodPlatformProfiles 取得命名空間中執行中與等待中的 Pod 對節點平台的需求，用於評估工作負載能否在其他平台 (例如 GKE Autopilot) 執行
+func (s *Service) GetPodPlatformProfiles(ctx context.Context, namespace string) ([]PodPlatformProfile, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	namespace = s.resolveListNamespace(namespace)
+
+	pods, err := s.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("無法取得 Pod 列表: %w", err)
+	}
+
+	profiles := make([]PodPlatformProfile, 0, len(pods.Items))
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		profiles = append(profiles, buildPlatformProfile(pod))
+	}
+	return profiles, nil
+}
+
+// buildPlatformProfile 從 Pod 規格整理平台需求，主機存取相關的設定取自 buildSecurityProfile
+func buildPlatformProfile(pod *corev1.Pod) PodPlatformProfile {
+	security := buildSecurityProfile(pod)
+	profile := PodPlatformProfile{
+		Name:         security.Name,
+		Namespace:    security.Namespace,
+		WorkloadKind: security.WorkloadKind,
+		WorkloadName: security.WorkloadName,
+		HostNetwork:  security.HostNetwork,
+		HostPID:      security.HostPID,
+		HostIPC:      security.HostIPC,
+	}
+
+	for _, container := range security.Containers {
+		if container.Privileged {
+			profile.PrivilegedContainers = append(profile.PrivilegedContainers, container.Name)
+		}
+		for _, capability := range container.AddedCapabilities {
+			profile.AddedCapabilities = append(profile.AddedCapabilities, fmt.Sprintf("%s: %s", container.Name, capability))
+		}
+		for _, port := range container.HostPorts {
+			profile.HostPorts = append(profile.HostPorts, fmt.Sprintf("%s:%d", container.Name, port))
+		}
+	}
+
+	for _, volume := range security.Volumes {
+		if volume.HostPath == "" {
+			continue
+		}
+		readOnly := true
+		for _, mount := range getVolumeMounts(pod, volume.Name) {
+			readOnly = readOnly && mount.ReadOnly
+		}
+		profile.HostPathVolumes = append(profile.HostPathVolumes, HostPathVolume{
+			Name:     volume.Name,
+			Path:     volume.HostPath,
+			ReadOnly: readOnly,
+		})
+	}
+
+	for key, value := range pod.Spec.NodeSelector {
+		profile.NodeSelectors = append(profile.NodeSelectors, key+"="+value)
+	}
+	sort.Strings(profile.NodeSelectors)
+	if affinity := pod.Spec.Affinity; affinity != nil && affinity.NodeAffinity != nil && affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution != nil {
+		for _, term := range affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+			for _, expression := range term.MatchExpressions {
+				profile.NodeSelectors = appendUnique(profile.NodeSelectors, fmt.Sprintf("%s %s %v", expression.Key, expression.Operator, expression.Values))
+			}
+		}
+	}
+
+	for _, container := range pod.Spec.Containers {
+		profile.Containers = append(profile.Containers, containerRequests(container))
+	}
+	for _, container := range pod.Spec.InitContainers {
+		profile.InitContainers = append(profile.InitContainers, containerRequests(container))
+	}
+	return profile
+}
+
+// containerRequests 取得容器的 CPU、記憶體與 ephemeral storage requests
+func containerRequests(container corev1.Container) ContainerRequests {
+	requests := container.Resources.Requests
+	return ContainerRequests{
+		Name:             container.Name,
+		CPU:              requests.Cpu().MilliValue(),
+		Memory:           requests.Memory().Value(),
+		EphemeralStorage: requests.StorageEphemeral().Value(),
+	}
+}
@@ -0,0 +1,219 @@
+package gke
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// podSecurityLabelPrefix Pod Security Admission 設定命名空間等級的標籤前綴
+const podSecurityLabelPrefix = "pod-security.kubernetes.io/"
+
+// GetPodSecurityProfiles 取得命名空間中執行中與等待中的 Pod 的安全設定，用於依 Pod Security Standards 評估
+func (s *Service) GetPodSecurityProfiles(ctx context.Context, namespace string) ([]PodSecurityProfile, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	namespace = s.resolveListNamespace(namespace)
+
+	pods, err := s.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("無法取得 Pod 列表: %w", err)
+	}
+
+	profiles := make([]PodSecurityProfile, 0, len(pods.Items))
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		profiles = append(profiles, buildSecurityProfile(pod))
+	}
+	return profiles, nil
+}
+
+// GetNamespacePodSecurity 取得命名空間以 Pod Security Admission 標籤設定的等級，namespace 為 all 時列出所有命名空間
+func (s *Service) GetNamespacePodSecurity(ctx context.Context, namespace string) ([]NamespacePodSecurity, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var namespaces []corev1.Namespace
+	if listNamespace := s.resolveListNamespace(namespace); listNamespace == metav1.NamespaceAll {
+		list, err := s.clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("無法取得命名空間列表: %w", err)
+		}
+		namespaces = list.Items
+	} else {
+		ns, err := s.clientset.CoreV1().Namespaces().Get(ctx, listNamespace, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("無法取得命名空間 %s: %w", listNamespace, err)
+		}
+		namespaces = []corev1.Namespace{*ns}
+	}
+
+	result := make([]NamespacePodSecurity, 0, len(namespaces))
+	for _, ns := range namespaces {
+		result = append(result, NamespacePodSecurity{
+			Namespace: ns.Name,
+			Enforce:   ns.Labels[podSecurityLabelPrefix+"enforce"],
+			Audit:     ns.Labels[podSecurityLabelPrefix+"audit"],
+			Warn:      ns.Labels[podSecurityLabelPrefix+"warn"],
+		})
+	}
+	return result, nil
+}
+
+// buildSecurityProfile 從 Pod 規格整理 Pod 與各容器的安全設定，get_pod_security_profiles 與 get_platform_profiles 共用
+func buildSecurityProfile(pod *corev1.Pod) PodSecurityProfile {
+	kind, name := podWorkload(pod)
+	profile := PodSecurityProfile{
+		Name:         pod.Name,
+		Namespace:    pod.Namespace,
+		WorkloadKind: kind,
+		WorkloadName: name,
+		HostNetwork:  pod.Spec.HostNetwork,
+		HostPID:      pod.Spec.HostPID,
+		HostIPC:      pod.Spec.HostIPC,
+	}
+
+	if podContext := pod.Spec.SecurityContext; podContext != nil {
+		profile.SecuritySettings = SecuritySettings{
+			RunAsNonRoot: podContext.RunAsNonRoot,
+			RunAsUser:    podContext.RunAsUser,
+			Seccomp:      seccompType(podContext.SeccompProfile),
+			AppArmor:     appArmorType(podContext.AppArmorProfile),
+			SELinux:      seLinuxOptions(podContext.SELinuxOptions),
+			HostProcess:  hostProcess(podContext.WindowsOptions),
+		}
+		for _, sysctl := range podContext.Sysctls {
+			profile.Sysctls = append(profile.Sysctls, sysctl.Name)
+		}
+	}
+
+	for i := range pod.Spec.Volumes {
+		volume := &pod.Spec.Volumes[i]
+		securityVolume := SecurityVolume{
+			Name: volume.Name,
+			Type: volumeSourceType(volume.VolumeSource),
+		}
+		if volume.HostPath != nil {
+			securityVolume.HostPath = volume.HostPath.Path
+		}
+		profile.Volumes = append(profile.Volumes, securityVolume)
+	}
+
+	for _, container := range pod.Spec.InitContainers {
+		profile.Containers = append(profile.Containers, containerSecurity(pod, container, true))
+	}
+	for _, container := range pod.Spec.Containers {
+		profile.Containers = append(profile.Containers, containerSecurity(pod, container, false))
+	}
+	return profile
+}
+
+// containerSecurity 整理容器的安全設定，AppArmor 同時支援 securityContext 與舊版的註解
+func containerSecurity(pod *corev1.Pod, container corev1.Container, init bool) ContainerSecurity {
+	result := ContainerSecurity{
+		Name: container.Name,
+		Init: init,
+	}
+
+	if securityContext := container.SecurityContext; securityContext != nil {
+		result.SecuritySettings = SecuritySettings{
+			RunAsNonRoot: securityContext.RunAsNonRoot,
+			RunAsUser:    securityContext.RunAsUser,
+			Seccomp:      seccompType(securityContext.SeccompProfile),
+			AppArmor:     appArmorType(securityContext.AppArmorProfile),
+			SELinux:      seLinuxOptions(securityContext.SELinuxOptions),
+			HostProcess:  hostProcess(securityContext.WindowsOptions),
+		}
+		result.Privileged = securityContext.Privileged != nil && *securityContext.Privileged
+		result.AllowPrivilegeEscalation = securityContext.AllowPrivilegeEscalation
+		if securityContext.ProcMount != nil {
+			result.ProcMount = string(*securityContext.ProcMount)
+		}
+		if capabilities := securityContext.Capabilities; capabilities != nil {
+			for _, capability := range capabilities.Add {
+				result.AddedCapabilities = append(result.AddedCapabilities, string(capability))
+			}
+			for _, capability := range capabilities.Drop {
+				result.DroppedCapabilities = append(result.DroppedCapabilities, string(capability))
+			}
+		}
+	}
+
+	if result.AppArmor == "" {
+		switch annotation := pod.Annotations[corev1.DeprecatedAppArmorBetaContainerAnnotationKeyPrefix+container.Name]; {
+		case annotation == corev1.DeprecatedAppArmorBetaProfileNameUnconfined:
+			result.AppArmor = string(corev1.AppArmorProfileTypeUnconfined)
+		case annotation == corev1.DeprecatedAppArmorBetaProfileRuntimeDefault:
+			result.AppArmor = string(corev1.AppArmorProfileTypeRuntimeDefault)
+		case strings.HasPrefix(annotation, corev1.DeprecatedAppArmorBetaProfileNamePrefix):
+			result.AppArmor = string(corev1.AppArmorProfileTypeLocalhost)
+		}
+	}
+
+	for _, port := range container.Ports {
+		if port.HostPort != 0 {
+			result.HostPorts = append(result.HostPorts, port.HostPort)
+		}
+	}
+	return result
+}
+
+// volumeSourceType 卷的類型，即 Pod 規格中的欄位名稱 (例如 hostPath、configMap、nfs)
+func volumeSourceType(source corev1.VolumeSource) string {
+	data, err := json.Marshal(source)
+	if err != nil {
+		return "unknown"
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil || len(fields) == 0 {
+		return "unknown"
+	}
+	types := make([]string, 0, len(fields))
+	for field := range fields {
+		types = append(types, field)
+	}
+	sort.Strings(types)
+	return types[0]
+}
+
+// seccompType seccomp profile 的類型，未設定時為空字串
+func seccompType(profile *corev1.SeccompProfile) string {
+	if profile == nil {
+		return ""
+	}
+	return string(profile.Type)
+}
+
+// appArmorType AppArmor profile 的類型，未設定時為空字串
+func appArmorType(profile *corev1.AppArmorProfile) string {
+	if profile == nil {
+		return ""
+	}
+	return string(profile.Type)
+}
+
+// seLinuxOptions 複製 SELinux 設定，未設定時為 nil
+func seLinuxOptions(options *corev1.SELinuxOptions) *SELinuxOptions {
+	if options == nil {
+		return nil
+	}
+	return &SELinuxOptions{
+		User: options.User,
+		Role: options.Role,
+		Type: options.Type,
+	}
+}
+
+// hostProcess Windows 容器是否以 HostProcess 執行
+func hostProcess(options *corev1.WindowsSecurityContextOptions) bool {
+	return options != nil && options.HostProcess != nil && *options.HostProcess
+}
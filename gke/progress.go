@@ -0,0 +1,58 @@
+package gke
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+)
+
+// ProgressFunc 回報長時間操作的進度
+type ProgressFunc func(done, total int, message string)
+
+// progressKey 進度回報函數在 context 中的鍵
+type progressKey struct{}
+
+// ProgressMiddleware 客戶端在請求中提供 progressToken 時，在工具的 context 中加入以 notifications/progress 傳送進度的回報函數
+func ProgressMiddleware(next mcpserver.ToolHandlerFunc) mcpserver.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if progress := newProgressReporter(ctx, request); progress != nil {
+			ctx = context.WithValue(ctx, progressKey{}, progress)
+		}
+		return next(ctx, request)
+	}
+}
+
+// WithoutProgress 移除 context 中的進度回報函數，用於同時執行多個會回報進度的操作，避免各自的進度交錯
+func WithoutProgress(ctx context.Context) context.Context {
+	return context.WithValue(ctx, progressKey{}, ProgressFunc(nil))
+}
+
+// ReportProgress 回報長時間操作的進度，done 必須逐次遞增；客戶端沒有提供 progressToken 時不做任何事
+func ReportProgress(ctx context.Context, done, total int, message string) {
+	if progress, _ := ctx.Value(progressKey{}).(ProgressFunc); progress != nil {
+		progress(done, total, message)
+	}
+}
+
+// newProgressReporter 建立進度回報函數，客戶端在請求中提供 progressToken 時以 notifications/progress 傳送進度
+func newProgressReporter(ctx context.Context, request mcp.CallToolRequest) ProgressFunc {
+	if request.Params.Meta == nil || request.Params.Meta.ProgressToken == nil {
+		return nil
+	}
+	srv := mcpserver.ServerFromContext(ctx)
+	if srv == nil {
+		return nil
+	}
+
+	token := request.Params.Meta.ProgressToken
+	return func(done, total int, message string) {
+		// 通知傳送失敗 (例如通道已滿) 不影響操作本身
+		_ = srv.SendNotificationToClient(ctx, "notifications/progress", map[string]any{
+			"progressToken": token,
+			"progress":      done,
+			"total":         total,
+			"message":       message,
+		})
+	}
+}
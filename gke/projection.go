@@ -0,0 +1,75 @@
+package gke
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// restartCountField 衍生欄位：所有容器重啟次數總和
+const restartCountField = "restartCount"
+
+// podFieldNames 取得 Pod 可投影的欄位名稱 (JSON 名稱)
+func podFieldNames() []string {
+	podType := reflect.TypeOf(Pod{})
+	names := []string{restartCountField}
+	for i := 0; i < podType.NumField(); i++ {
+		tag := podType.Field(i).Tag.Get("json")
+		name := strings.Split(tag, ",")[0]
+		if name != "" && name != "-" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// isValidPodField 檢查欄位名稱是否可投影
+func isValidPodField(field string) bool {
+	for _, name := range podFieldNames() {
+		if name == field {
+			return true
+		}
+	}
+	return false
+}
+
+// projectPods 將 Pod 列表投影為僅包含指定欄位的精簡格式
+func projectPods(pods []Pod, fields []string) ([]map[string]interface{}, error) {
+	result := make([]map[string]interface{}, 0, len(pods))
+
+	for _, pod := range pods {
+		podJSON, err := json.Marshal(pod)
+		if err != nil {
+			return nil, fmt.Errorf("序列化 Pod 資料失敗: %w", err)
+		}
+
+		var full map[string]interface{}
+		if err := json.Unmarshal(podJSON, &full); err != nil {
+			return nil, fmt.Errorf("解析 Pod 資料失敗: %w", err)
+		}
+
+		projected := make(map[string]interface{}, len(fields))
+		for _, field := range fields {
+			if field == restartCountField {
+				projected[field] = podRestartCount(pod)
+				continue
+			}
+			projected[field] = full[field]
+		}
+		result = append(result, projected)
+	}
+
+	return result, nil
+}
+
+// podRestartCount 計算 Pod 所有容器的重啟次數總和
+func podRestartCount(pod Pod) int32 {
+	total := int32(0)
+	for _, container := range pod.Containers {
+		total += container.Restart
+	}
+	return total
+}
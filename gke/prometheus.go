@@ -0,0 +1,402 @@
+package gke
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2/google"
+	monitoring "google.golang.org/api/monitoring/v3"
+)
+
+const (
+	// defaultPrometheusStep 範圍查詢預設的解析度
+	defaultPrometheusStep = 5 * time.Minute
+
+	// maxPrometheusResponseBytes 查詢回應的大小上限
+	maxPrometheusResponseBytes = 16 * 1024 * 1024
+
+	// UsageSourcePrometheus 使用量資料來自 Prometheus
+	UsageSourcePrometheus = "prometheus"
+)
+
+// prometheusClient Prometheus HTTP API 客戶端 (相容於 Google Cloud Managed Service for Prometheus)
+type prometheusClient struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// prometheusResponse Prometheus HTTP API 的回應格式
+type prometheusResponse struct {
+	Status    string `json:"status"`
+	ErrorType string `json:"errorType"`
+	Error     string `json:"error"`
+	Data      struct {
+		ResultType string `json:"resultType"`
+		Result     []struct {
+			Metric map[string]string `json:"metric"`
+			Value  []interface{}     `json:"value"`  // instant 查詢: [時間戳, "數值"]
+			Values [][]interface{}   `json:"values"` // 範圍查詢
+		} `json:"result"`
+	} `json:"data"`
+}
+
+// newPrometheusClient 建立 Prometheus 客戶端；useGoogleCredentials 為 true 時以服務帳戶凭证存取 Managed Prometheus
+func newPrometheusClient(config ServiceConfig) (*prometheusClient, error) {
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+
+	switch {
+	case config.PrometheusUseGoogleAuth:
+		credentialsBytes, err := os.ReadFile(config.CredentialsFile)
+		if err != nil {
+			return nil, fmt.Errorf("無法讀取凭证文件: %w", err)
+		}
+		googleCredentials, err := google.CredentialsFromJSON(context.Background(), credentialsBytes, monitoring.MonitoringReadScope)
+		if err != nil {
+			return nil, fmt.Errorf("無法建立 Google 凭证: %w", err)
+		}
+		httpClient.Transport = &tokenRefreshTransport{
+			base:        http.DefaultTransport,
+			tokenSource: googleCredentials.TokenSource,
+		}
+	case config.PrometheusBearerToken != "":
+		httpClient.Transport = &bearerTokenTransport{
+			base:  http.DefaultTransport,
+			token: config.PrometheusBearerToken,
+		}
+	}
+
+	return &prometheusClient{
+		baseURL:    strings.TrimSuffix(config.PrometheusURL, "/"),
+		httpClient: httpClient,
+	}, nil
+}
+
+// bearerTokenTransport 在請求中加入固定的 Bearer token
+type bearerTokenTransport struct {
+	base  http.RoundTripper
+	token string
+}
+
+func (t *bearerTokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+t.token)
+	return t.base.RoundTrip(req)
+}
+
+// query 執行 instant 查詢
+func (c *prometheusClient) query(ctx context.Context, promql string, at time.Time) ([]PrometheusSeries, error) {
+	params := url.Values{}
+	params.Set("query", promql)
+	if !at.IsZero() {
+		params.Set("time", formatPrometheusTime(at))
+	}
+	return c.do(ctx, "/api/v1/query", params)
+}
+
+// queryRange 執行範圍查詢
+func (c *prometheusClient) queryRange(ctx context.Context, promql string, start, end time.Time, step time.Duration) ([]PrometheusSeries, error) {
+	params := url.Values{}
+	params.Set("query", promql)
+	params.Set("start", formatPrometheusTime(start))
+	params.Set("end", formatPrometheusTime(end))
+	params.Set("step", strconv.FormatFloat(step.Seconds(), 'f', -1, 64))
+	return c.do(ctx, "/api/v1/query_range", params)
+}
+
+// do 送出查詢並解析結果
+func (c *prometheusClient) do(ctx context.Context, path string, params url.Values) ([]PrometheusSeries, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, strings.NewReader(params.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("無法建立 Prometheus 請求: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("Prometheus 請求失敗: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxPrometheusResponseBytes))
+	if err != nil {
+		return nil, fmt.Errorf("讀取 Prometheus 回應失敗: %w", err)
+	}
+
+	var response prometheusResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("Prometheus 回應格式錯誤 (HTTP %d): %s", resp.StatusCode, truncateString(string(body), 200))
+	}
+	if response.Status != "success" {
+		return nil, fmt.Errorf("Prometheus 查詢失敗 (%s): %s", response.ErrorType, response.Error)
+	}
+
+	var series []PrometheusSeries
+	for _, result := range response.Data.Result {
+		item := PrometheusSeries{Labels: result.Metric, Points: []SeriesPoint{}}
+		if result.Value != nil {
+			if point, ok := parsePrometheusPoint(result.Value); ok {
+				item.Points = append(item.Points, point)
+			}
+		}
+		for _, value := range result.Values {
+			if point, ok := parsePrometheusPoint(value); ok {
+				item.Points = append(item.Points, point)
+			}
+		}
+		series = append(series, item)
+	}
+
+	return series, nil
+}
+
+// parsePrometheusPoint 解析 [時間戳, "數值"] 格式的資料點
+func parsePrometheusPoint(value []interface{}) (SeriesPoint, bool) {
+	if len(value) != 2 {
+		return SeriesPoint{}, false
+	}
+	timestamp, ok := value[0].(float64)
+	if !ok {
+		return SeriesPoint{}, false
+	}
+	text, ok := value[1].(string)
+	if !ok {
+		return SeriesPoint{}, false
+	}
+	// NaN/Inf (例如分母為 0 的比例) 無法序列化為 JSON，直接略過
+	number, err := strconv.ParseFloat(text, 64)
+	if err != nil || math.IsNaN(number) || math.IsInf(number, 0) {
+		return SeriesPoint{}, false
+	}
+
+	seconds := int64(timestamp)
+	nanos := int64((timestamp - float64(seconds)) * 1e9)
+	return SeriesPoint{Timestamp: time.Unix(seconds, nanos).UTC(), Value: number}, true
+}
+
+// formatPrometheusTime 轉換為 Prometheus API 使用的 Unix 秒數
+func formatPrometheusTime(t time.Time) string {
+	return strconv.FormatFloat(float64(t.UnixNano())/1e9, 'f', 3, 64)
+}
+
+// truncateString 截斷過長的字串
+func truncateString(value string, limit int) string {
+	if len(value) <= limit {
+		return value
+	}
+	return value[:limit] + "..."
+}
+
+// PrometheusAvailable 是否已設定 Prometheus 資料來源
+func (s *Service) PrometheusAvailable() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.prometheus != nil
+}
+
+// QueryPrometheus 執行任意 PromQL 查詢；duration 大於 0 時執行範圍查詢 (最近 duration 的資料)
+func (s *Service) QueryPrometheus(ctx context.Context, promql string, duration, step time.Duration) (*PrometheusQueryResult, error) {
+	s.mu.RLock()
+	client := s.prometheus
+	s.mu.RUnlock()
+
+	if client == nil {
+		return nil, fmt.Errorf("未設定 Prometheus 資料來源，請在 config.json 中設定 prometheus.url")
+	}
+
+	result := &PrometheusQueryResult{Query: promql}
+	var err error
+	if duration > 0 {
+		if step <= 0 {
+			step = defaultPrometheusStep
+		}
+		end := time.Now()
+		result.Start = end.Add(-duration)
+		result.End = end
+		result.StepSeconds = int(step.Seconds())
+		result.Series, err = client.queryRange(ctx, promql, result.Start, end, step)
+	} else {
+		result.Series, err = client.query(ctx, promql, time.Time{})
+	}
+	if err != nil {
+		return nil, err
+	}
+	if result.Series == nil {
+		result.Series = []PrometheusSeries{}
+	}
+
+	return result, nil
+}
+
+// GetPrometheusUsage 從 Prometheus 的 cAdvisor 指標取得 Pod 各容器的 CPU、記憶體、CPU 節流比例與網路流量
+func (s *Service) GetPrometheusUsage(ctx context.Context, podName, namespace string, duration, step time.Duration) (*PrometheusUsage, error) {
+	s.mu.RLock()
+	client := s.prometheus
+	if namespace == "" {
+		namespace = s.defaultNamespace
+	}
+	s.mu.RUnlock()
+
+	if client == nil {
+		return nil, fmt.Errorf("未設定 Prometheus 資料來源，請在 config.json 中設定 prometheus.url")
+	}
+	if duration <= 0 {
+		duration = defaultHistoricalDuration
+	}
+	if step <= 0 {
+		step = defaultPrometheusStep
+	}
+
+	end := time.Now()
+	start := end.Add(-duration)
+	selector := fmt.Sprintf(`namespace=%q,pod=%q`, namespace, podName)
+	containerSelector := selector + `,container!="",container!="POD"`
+
+	queries := []struct {
+		metric string
+		promql string
+		scale  float64
+	}{
+		{"cpuMillicores", fmt.Sprintf(`sum by (container) (rate(container_cpu_usage_seconds_total{%s}[5m]))`, containerSelector), 1000},
+		{"memoryBytes", fmt.Sprintf(`sum by (container) (container_memory_working_set_bytes{%s})`, containerSelector), 1},
+		{"cpuThrottledRatio", fmt.Sprintf(`sum by (container) (rate(container_cpu_cfs_throttled_periods_total{%[1]s}[5m])) / sum by (container) (rate(container_cpu_cfs_periods_total{%[1]s}[5m]))`, containerSelector), 1},
+		{"networkReceiveBytesPerSecond", fmt.Sprintf(`sum (rate(container_network_receive_bytes_total{%s}[5m]))`, selector), 1},
+		{"networkTransmitBytesPerSecond", fmt.Sprintf(`sum (rate(container_network_transmit_bytes_total{%s}[5m]))`, selector), 1},
+	}
+
+	usage := &PrometheusUsage{
+		PodName:     podName,
+		Namespace:   namespace,
+		Source:      UsageSourcePrometheus,
+		Start:       start,
+		End:         end,
+		StepSeconds: int(step.Seconds()),
+		Series:      []UsageSeries{},
+	}
+
+	for _, query := range queries {
+		series, err := client.queryRange(ctx, query.promql, start, end, step)
+		if err != nil {
+			// 個別指標不存在時 (例如未收集網路指標) 不影響其他指標
+			usage.Warnings = append(usage.Warnings, fmt.Sprintf("%s: %v", query.metric, err))
+			continue
+		}
+		sort.Slice(series, func(i, j int) bool {
+			return series[i].Labels["container"] < series[j].Labels["container"]
+		})
+		for _, item := range series {
+			for i := range item.Points {
+				item.Points[i].Value *= query.scale
+			}
+			usage.Series = append(usage.Series, UsageSeries{
+				Metric:    query.metric,
+				Container: item.Labels["container"],
+				Summary:   summarizePoints(item.Points),
+				Points:    item.Points,
+			})
+		}
+	}
+
+	return usage, nil
+}
+
+// summarizePoints 計算資料點的最小值、最大值、平均值與最新值
+func summarizePoints(points []SeriesPoint) *PointStats {
+	if len(points) == 0 {
+		return nil
+	}
+
+	stats := &PointStats{
+		Min:    points[0].Value,
+		Max:    points[0].Value,
+		Latest: points[len(points)-1].Value,
+	}
+	total := 0.0
+	for _, point := range points {
+		total += point.Value
+		if point.Value < stats.Min {
+			stats.Min = point.Value
+		}
+		if point.Value > stats.Max {
+			stats.Max = point.Value
+		}
+	}
+	stats.Avg = total / float64(len(points))
+
+	return stats
+}
+
+// podSamples 以一次查詢取得多個 Pod 的總 CPU 與記憶體使用量，以 Pod 名稱為鍵
+func (c *prometheusClient) podSamples(ctx context.Context, namespace string, pods []string, start, end time.Time, step time.Duration) (map[string][]MetricSample, error) {
+	quoted := make([]string, len(pods))
+	for i, pod := range pods {
+		quoted[i] = regexp.QuoteMeta(pod)
+	}
+	selector := fmt.Sprintf(`namespace=%q,pod=~%q,container!="",container!="POD"`, namespace, strings.Join(quoted, "|"))
+
+	samples, err := c.usageSamples(ctx, selector, start, end, step)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string][]MetricSample, len(samples))
+	for key, podSamples := range samples {
+		result[strings.TrimPrefix(key, namespace+"/")] = podSamples
+	}
+	return result, nil
+}
+
+// usageSamples 取得符合選擇器的各 Pod 使用量樣本，以 namespace/pod 為鍵
+func (c *prometheusClient) usageSamples(ctx context.Context, selector string, start, end time.Time, step time.Duration) (map[string][]MetricSample, error) {
+	samples := make(map[string]map[time.Time]*MetricSample)
+	collect := func(promql string, apply func(sample *MetricSample, value float64)) error {
+		series, err := c.queryRange(ctx, promql, start, end, step)
+		if err != nil {
+			return err
+		}
+		for _, item := range series {
+			key := item.Labels["namespace"] + "/" + item.Labels["pod"]
+			if samples[key] == nil {
+				samples[key] = make(map[time.Time]*MetricSample)
+			}
+			for _, point := range item.Points {
+				sample, ok := samples[key][point.Timestamp]
+				if !ok {
+					sample = &MetricSample{Timestamp: point.Timestamp}
+					samples[key][point.Timestamp] = sample
+				}
+				apply(sample, point.Value)
+			}
+		}
+		return nil
+	}
+
+	err := collect(fmt.Sprintf(`sum by (namespace, pod) (rate(container_cpu_usage_seconds_total{%s}[5m]))`, selector), func(sample *MetricSample, value float64) {
+		sample.CPUMillicores = int64(value * 1000)
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = collect(fmt.Sprintf(`sum by (namespace, pod) (container_memory_working_set_bytes{%s})`, selector), func(sample *MetricSample, value float64) {
+		sample.MemoryBytes = int64(value)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string][]MetricSample, len(samples))
+	for key, podSamples := range samples {
+		result[key] = sortedSamples(podSamples)
+	}
+	return result, nil
+}
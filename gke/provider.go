@@ -0,0 +1,180 @@
+package gke
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// UsageSourceMetricsAPI 使用量資料來自 Metrics API (metrics-server) 的目前取樣
+	UsageSourceMetricsAPI = "metrics-api"
+
+	// usageSourceAuto 自動選擇資料來源
+	usageSourceAuto = "auto"
+)
+
+// MetricsProvider 使用量資料來源，優化分析透過此介面取得使用量，不需要知道資料來自何處
+type MetricsProvider interface {
+	// Name 資料來源名稱，會顯示在分析結果的 usageSource 欄位
+	Name() string
+
+	// NamespaceUsage 取得命名空間內各 Pod 在時間範圍內的使用量統計，以 namespace/pod 為鍵
+	// namespace 為空或 "all" 時包含所有命名空間；只提供目前使用量的資料來源會忽略 window
+	NamespaceUsage(ctx context.Context, namespace string, window time.Duration) (map[string]*MetricsSummary, error)
+}
+
+// NewMetricsProvider 依名稱建立使用量資料來源 (auto、metrics-api、cloud-monitoring、prometheus、collector)
+// 名稱為空或 auto 時依序使用第一個可用的歷史資料來源，都不可用時使用 Metrics API
+func (s *Service) NewMetricsProvider(name string) (MetricsProvider, error) {
+	switch strings.ToLower(name) {
+	case "", usageSourceAuto:
+		source, err := s.resolveUsageSource("")
+		if err != nil {
+			return &metricsAPIProvider{service: s}, nil
+		}
+		return s.NewMetricsProvider(source)
+	case UsageSourceMetricsAPI, "metrics-server":
+		return &metricsAPIProvider{service: s}, nil
+	}
+
+	source, err := s.resolveUsageSource(strings.ToLower(name))
+	if err != nil {
+		return nil, err
+	}
+	switch source {
+	case UsageSourceCloudMonitoring:
+		return &cloudMonitoringProvider{service: s}, nil
+	case UsageSourcePrometheus:
+		return &prometheusProvider{service: s}, nil
+	default:
+		return &collectorProvider{service: s}, nil
+	}
+}
+
+// metricsAPIProvider 以 Metrics API 的目前取樣作為使用量，統計值的最小、最大與平均值相同
+type metricsAPIProvider struct {
+	service *Service
+}
+
+func (p *metricsAPIProvider) Name() string {
+	return UsageSourceMetricsAPI
+}
+
+func (p *metricsAPIProvider) NamespaceUsage(ctx context.Context, namespace string, window time.Duration) (map[string]*MetricsSummary, error) {
+	s := p.service
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.metricsClientset == nil {
+		return nil, fmt.Errorf("Metrics API 不可用")
+	}
+
+	podMetrics, err := s.metricsClientset.MetricsV1beta1().PodMetricses(s.resolveListNamespace(namespace)).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("無法取得 Pod metrics: %w", err)
+	}
+
+	result := make(map[string]*MetricsSummary, len(podMetrics.Items))
+	for _, metrics := range podMetrics.Items {
+		sample := MetricSample{Timestamp: metrics.Timestamp.Time}
+		for _, container := range metrics.Containers {
+			sample.CPUMillicores += container.Usage.Cpu().MilliValue()
+			sample.MemoryBytes += container.Usage.Memory().Value()
+		}
+		result[metrics.Namespace+"/"+metrics.Name] = summarizeSamples([]MetricSample{sample})
+	}
+	return result, nil
+}
+
+// cloudMonitoringProvider 以 Cloud Monitoring 的歷史使用量作為使用量
+type cloudMonitoringProvider struct {
+	service *Service
+}
+
+func (p *cloudMonitoringProvider) Name() string {
+	return UsageSourceCloudMonitoring
+}
+
+func (p *cloudMonitoringProvider) NamespaceUsage(ctx context.Context, namespace string, window time.Duration) (map[string]*MetricsSummary, error) {
+	p.service.mu.RLock()
+	namespace = p.service.resolveListNamespace(namespace)
+	p.service.mu.RUnlock()
+
+	return p.service.GetNamespaceUsagePeaks(ctx, namespace, window)
+}
+
+// prometheusProvider 以 Prometheus 的 cAdvisor 指標作為使用量
+type prometheusProvider struct {
+	service *Service
+}
+
+func (p *prometheusProvider) Name() string {
+	return UsageSourcePrometheus
+}
+
+func (p *prometheusProvider) NamespaceUsage(ctx context.Context, namespace string, window time.Duration) (map[string]*MetricsSummary, error) {
+	s := p.service
+	s.mu.RLock()
+	client := s.prometheus
+	namespace = s.resolveListNamespace(namespace)
+	s.mu.RUnlock()
+
+	if window <= 0 {
+		window = defaultHistoricalDuration
+	}
+
+	selector := `container!="",container!="POD"`
+	if namespace != "" {
+		selector = fmt.Sprintf(`namespace=%q,`, namespace) + selector
+	}
+
+	// 時間範圍較長時放大解析度，避免單次查詢的資料點過多
+	step := defaultPrometheusStep
+	if window/step > 2000 {
+		step = window / 2000
+	}
+
+	end := time.Now()
+	samples, err := client.usageSamples(ctx, selector, end.Add(-window), end, step)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]*MetricsSummary, len(samples))
+	for key, podSamples := range samples {
+		result[key] = summarizeSamples(podSamples)
+	}
+	return result, nil
+}
+
+// collectorProvider 以背景指標收集器的紀錄作為使用量
+type collectorProvider struct {
+	service *Service
+}
+
+func (p *collectorProvider) Name() string {
+	return UsageSourceCollector
+}
+
+func (p *collectorProvider) NamespaceUsage(ctx context.Context, namespace string, window time.Duration) (map[string]*MetricsSummary, error) {
+	s := p.service
+	s.mu.RLock()
+	collector := s.collector
+	namespace = s.resolveListNamespace(namespace)
+	s.mu.RUnlock()
+
+	if window <= 0 {
+		window = defaultHistoricalDuration
+	}
+
+	histories := collector.namespacePodHistories(namespace, time.Now().Add(-window))
+	result := make(map[string]*MetricsSummary, len(histories))
+	for key, samples := range histories {
+		result[key] = summarizeSamples(samples)
+	}
+	return result, nil
+}
@@ -0,0 +1,182 @@
+package gke
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// maxProbeBodyBytes 回傳的回應內容上限
+	maxProbeBodyBytes = 2048
+
+	// defaultProbeTimeout 探測端點的預設逾時時間
+	defaultProbeTimeout = 5 * time.Second
+)
+
+// ProbePodEndpoint 透過 API 伺服器的 Pod proxy 子資源對 Pod 的 HTTP 端點發出 GET 請求
+// 未指定連接埠時使用容器的 readiness 探針 (其次為 liveness 探針) 的 HTTP 設定
+func (s *Service) ProbePodEndpoint(ctx context.Context, podName, namespace, container, scheme string, port int, path string, timeout time.Duration) (*PodEndpointProbe, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if namespace == "" {
+		namespace = s.defaultNamespace
+	}
+	if timeout <= 0 {
+		timeout = defaultProbeTimeout
+	}
+
+	pod, err := s.clientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("無法取得 Pod 資訊: %w", err)
+	}
+
+	result := &PodEndpointProbe{
+		PodName:   podName,
+		Namespace: namespace,
+		Source:    "request",
+	}
+
+	if port == 0 {
+		spec, source, err := findHTTPProbe(pod, container)
+		if err != nil {
+			return nil, err
+		}
+		port, err = resolveContainerPort(pod, spec.Port)
+		if err != nil {
+			return nil, err
+		}
+		if path == "" {
+			path = spec.Path
+		}
+		if scheme == "" {
+			scheme = string(spec.Scheme)
+		}
+		result.Source = source
+	}
+	if port <= 0 || port > 65535 {
+		return nil, fmt.Errorf("無效的連接埠 %d", port)
+	}
+
+	scheme = strings.ToLower(scheme)
+	if scheme == "" {
+		scheme = "http"
+	}
+	if scheme != "http" && scheme != "https" {
+		return nil, fmt.Errorf("不支援的協定 %q，可用值: http, https", scheme)
+	}
+	if path == "" {
+		path = "/"
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	result.Scheme = scheme
+	result.Port = port
+	result.Path = path
+
+	if pod.Status.Phase != corev1.PodRunning || pod.Status.PodIP == "" {
+		result.Error = fmt.Sprintf("Pod 目前狀態為 %s，無法透過 proxy 連線", pod.Status.Phase)
+		return result, nil
+	}
+
+	probeCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// 路徑中的查詢字串需要拆開，否則會被編碼成路徑的一部分
+	requestPath, rawQuery, _ := strings.Cut(path, "?")
+
+	// 名稱格式為 scheme:name:port，由 API 伺服器轉送到 Pod
+	request := s.clientset.CoreV1().RESTClient().Get().
+		Namespace(namespace).
+		Resource("pods").
+		Name(fmt.Sprintf("%s:%s:%d", scheme, podName, port)).
+		SubResource("proxy").
+		Suffix(requestPath)
+	if rawQuery != "" {
+		query, err := url.ParseQuery(rawQuery)
+		if err != nil {
+			return nil, fmt.Errorf("無效的查詢字串 %q: %w", rawQuery, err)
+		}
+		for key, values := range query {
+			for _, value := range values {
+				request = request.Param(key, value)
+			}
+		}
+	}
+
+	start := time.Now()
+	response := request.Do(probeCtx)
+	result.LatencyMs = float64(time.Since(start).Microseconds()) / 1000
+
+	var statusCode int
+	response.StatusCode(&statusCode)
+	body, err := response.Raw()
+
+	result.StatusCode = statusCode
+	result.Body, result.Truncated = truncateOutput(string(body), maxProbeBodyBytes)
+	// 與 kubelet 相同，200-399 視為健康
+	result.Healthy = statusCode >= http.StatusOK && statusCode < http.StatusBadRequest
+
+	if err != nil && statusCode == 0 {
+		if probeCtx.Err() != nil {
+			result.Error = fmt.Sprintf("請求逾時 (%s)", timeout)
+		} else {
+			result.Error = err.Error()
+		}
+	}
+
+	return result, nil
+}
+
+// findHTTPProbe 取得容器的 HTTP 探針設定，優先使用 readiness 探針
+func findHTTPProbe(pod *corev1.Pod, container string) (*corev1.HTTPGetAction, string, error) {
+	for _, spec := range pod.Spec.Containers {
+		if container != "" && spec.Name != container {
+			continue
+		}
+		if spec.ReadinessProbe != nil && spec.ReadinessProbe.HTTPGet != nil {
+			return spec.ReadinessProbe.HTTPGet, "readinessProbe", nil
+		}
+		if spec.LivenessProbe != nil && spec.LivenessProbe.HTTPGet != nil {
+			return spec.LivenessProbe.HTTPGet, "livenessProbe", nil
+		}
+		if container != "" {
+			return nil, "", fmt.Errorf("容器 %s 沒有 HTTP 探針，請指定連接埠", container)
+		}
+	}
+
+	if container != "" {
+		return nil, "", fmt.Errorf("Pod %s 中找不到容器 %s", pod.Name, container)
+	}
+	return nil, "", fmt.Errorf("Pod %s 沒有 HTTP 探針，請指定連接埠", pod.Name)
+}
+
+// resolveContainerPort 將具名連接埠轉換為數字
+func resolveContainerPort(pod *corev1.Pod, port intstr.IntOrString) (int, error) {
+	if port.Type == intstr.Int {
+		return port.IntValue(), nil
+	}
+
+	if number, err := strconv.Atoi(port.StrVal); err == nil {
+		return number, nil
+	}
+	for _, spec := range pod.Spec.Containers {
+		for _, containerPort := range spec.Ports {
+			if containerPort.Name == port.StrVal {
+				return int(containerPort.ContainerPort), nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("Pod %s 中找不到名為 %s 的連接埠", pod.Name, port.StrVal)
+}
@@ -0,0 +1,155 @@
+package gke
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// defaultStorageClassAnnotation 標記預設 StorageClass 的 annotation
+	defaultStorageClassAnnotation = "storageclass.kubernetes.io/is-default-class"
+
+	// defaultPDType Compute Engine 永久磁碟 CSI driver 未指定 type 參數時建立的磁碟類型
+	defaultPDType = "pd-standard"
+)
+
+// pdProvisioners 建立 Compute Engine 永久磁碟的 provisioner
+var pdProvisioners = map[string]bool{
+	"pd.csi.storage.gke.io": true,
+	"kubernetes.io/gce-pd":  true,
+}
+
+// GetPersistentVolumeClaims 取得命名空間中的 PVC 與其磁碟類型、掛載的 Pod，以及從 kubelet stats 取得的實際使用量
+// 只有被執行中的 Pod 掛載的 PVC 才有使用量；StorageClass 無法取得時不提供磁碟類型
+func (s *Service) GetPersistentVolumeClaims(ctx context.Context, namespace string) ([]PersistentVolumeClaimUsage, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	namespace = s.resolveListNamespace(namespace)
+
+	claims, err := s.clientset.CoreV1().PersistentVolumeClaims(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("無法取得 PVC 列表: %w", err)
+	}
+	if len(claims.Items) == 0 {
+		return []PersistentVolumeClaimUsage{}, nil
+	}
+
+	pods, err := s.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("無法取得 Pod 列表: %w", err)
+	}
+
+	classes, err := s.clientset.StorageV1().StorageClasses().List(ctx, metav1.ListOptions{})
+	if err != nil && s.logger != nil {
+		s.logger.Printf("警告: 無法取得 StorageClass 列表，不提供磁碟類型: %v", err)
+	}
+	diskTypes := make(map[string]string)
+	defaultClass := ""
+	if classes != nil {
+		for i := range classes.Items {
+			class := &classes.Items[i]
+			diskTypes[class.Name] = storageClassDiskType(class)
+			if class.Annotations[defaultStorageClassAnnotation] == "true" {
+				defaultClass = class.Name
+			}
+		}
+	}
+
+	// 每個節點只讀取一次 kubelet stats
+	mountedBy := make(map[string][]string)
+	used := make(map[string]int64)
+	summaries := make(map[string]*kubeletSummary)
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		mounts := false
+		for _, volume := range pod.Spec.Volumes {
+			if volume.PersistentVolumeClaim != nil {
+				key := pod.Namespace + "/" + volume.PersistentVolumeClaim.ClaimName
+				mountedBy[key] = append(mountedBy[key], pod.Name)
+				mounts = true
+			}
+		}
+		if !mounts || pod.Status.Phase != corev1.PodRunning || pod.Spec.NodeName == "" {
+			continue
+		}
+
+		summary, ok := summaries[pod.Spec.NodeName]
+		if !ok {
+			summary, err = s.getKubeletSummary(ctx, pod.Spec.NodeName)
+			if err != nil && s.logger != nil {
+				s.logger.Printf("警告: %v", err)
+			}
+			summaries[pod.Spec.NodeName] = summary
+		}
+		if summary == nil {
+			continue
+		}
+		for _, stats := range summary.Pods {
+			if stats.PodRef.Name != pod.Name || stats.PodRef.Namespace != pod.Namespace {
+				continue
+			}
+			for _, volume := range stats.Volumes {
+				if volume.PVCRef != nil && volume.UsedBytes != nil {
+					used[volume.PVCRef.Namespace+"/"+volume.PVCRef.Name] = *volume.UsedBytes
+				}
+			}
+		}
+	}
+
+	result := make([]PersistentVolumeClaimUsage, 0, len(claims.Items))
+	for _, claim := range claims.Items {
+		class := defaultClass
+		if claim.Spec.StorageClassName != nil {
+			class = *claim.Spec.StorageClassName
+		}
+
+		capacity, ok := claim.Status.Capacity[corev1.ResourceStorage]
+		if !ok {
+			capacity = claim.Spec.Resources.Requests[corev1.ResourceStorage]
+		}
+
+		key := claim.Namespace + "/" + claim.Name
+		usage := PersistentVolumeClaimUsage{
+			Name:          claim.Name,
+			Namespace:     claim.Namespace,
+			Phase:         string(claim.Status.Phase),
+			StorageClass:  class,
+			DiskType:      diskTypes[class],
+			VolumeName:    claim.Spec.VolumeName,
+			Capacity:      capacity.String(),
+			MountedBy:     mountedBy[key],
+			CreatedAt:     claim.CreationTimestamp.Time,
+			CapacityBytes: capacity.Value(),
+		}
+		if bytes, ok := used[key]; ok {
+			usage.Used = formatDiskBytes(bytes)
+			usage.UsedBytes = &bytes
+		}
+		result = append(result, usage)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Namespace != result[j].Namespace {
+			return result[i].Namespace < result[j].Namespace
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+// storageClassDiskType StorageClass 建立的 Compute Engine 永久磁碟類型，非永久磁碟的 provisioner 回傳空字串
+func storageClassDiskType(class *storagev1.StorageClass) string {
+	if !pdProvisioners[class.Provisioner] {
+		return ""
+	}
+	if diskType := class.Parameters["type"]; diskType != "" {
+		return diskType
+	}
+	return defaultPDType
+}
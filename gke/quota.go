@@ -0,0 +1,67 @@
+package gke
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetNamespaceQuotas 取得命名空間的 ResourceQuota 與 LimitRange 中 Container 類型的限制
+func (s *Service) GetNamespaceQuotas(ctx context.Context, namespace string) (*NamespaceQuotas, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	namespace = s.resolveListNamespace(namespace)
+
+	quotas, err := s.clientset.CoreV1().ResourceQuotas(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("無法取得 ResourceQuota 列表: %w", err)
+	}
+	limitRanges, err := s.clientset.CoreV1().LimitRanges(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("無法取得 LimitRange 列表: %w", err)
+	}
+
+	result := &NamespaceQuotas{
+		ResourceQuotas: make([]ResourceQuotaStatus, 0, len(quotas.Items)),
+		LimitRanges:    []ContainerLimitRange{},
+	}
+	for _, quota := range quotas.Items {
+		result.ResourceQuotas = append(result.ResourceQuotas, ResourceQuotaStatus{
+			Name:      quota.Name,
+			Namespace: quota.Namespace,
+			Hard:      resourceListStrings(quota.Status.Hard),
+			Used:      resourceListStrings(quota.Status.Used),
+		})
+	}
+	for _, limitRange := range limitRanges.Items {
+		for _, item := range limitRange.Spec.Limits {
+			if item.Type != corev1.LimitTypeContainer {
+				continue
+			}
+			result.LimitRanges = append(result.LimitRanges, ContainerLimitRange{
+				Name:           limitRange.Name,
+				Namespace:      limitRange.Namespace,
+				Min:            resourceListStrings(item.Min),
+				Max:            resourceListStrings(item.Max),
+				Default:        resourceListStrings(item.Default),
+				DefaultRequest: resourceListStrings(item.DefaultRequest),
+			})
+		}
+	}
+	return result, nil
+}
+
+// resourceListStrings 將資源列表轉換為以資源名稱為鍵的字串
+func resourceListStrings(list corev1.ResourceList) map[string]string {
+	if len(list) == 0 {
+		return nil
+	}
+	result := make(map[string]string, len(list))
+	for name, quantity := range list {
+		result[string(name)] = quantity.String()
+	}
+	return result
+}
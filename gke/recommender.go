@@ -0,0 +1,213 @@
+package gke
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/option"
+	recommender "google.golang.org/api/recommender/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// RecommenderInstanceMachineType VM 機器類型調整建議
+	RecommenderInstanceMachineType = "google.compute.instance.MachineTypeRecommender"
+
+	// RecommenderInstanceIdle 閒置 VM 建議
+	RecommenderInstanceIdle = "google.compute.instance.IdleResourceRecommender"
+
+	// RecommenderInstanceGroupMachineType 代管執行個體群組 (GKE 節點池) 機器類型調整建議
+	RecommenderInstanceGroupMachineType = "google.compute.instanceGroupManager.MachineTypeRecommender"
+
+	// RecommenderDiskIdle 閒置永久磁碟建議
+	RecommenderDiskIdle = "google.compute.disk.IdleResourceRecommender"
+
+	// 建議目標資源的類型
+	CloudResourceInstance = "Instance"
+	CloudResourceNodePool = "InstanceGroupManager"
+	CloudResourceDisk     = "Disk"
+
+	// cloudRecommendationFilter 只取得尚未處理的建議
+	cloudRecommendationFilter = "stateInfo.state = ACTIVE"
+
+	// cloudHoursPerMonth 將 costProjection 換算為每月成本的時數
+	cloudHoursPerMonth = 730
+)
+
+// cloudRecommenders 查詢的 recommender 與目標資源在 Compute Engine 資源路徑中的集合名稱
+var cloudRecommenders = []struct {
+	id         string
+	collection string
+	kind       string
+}{
+	{RecommenderInstanceMachineType, "instances", CloudResourceInstance},
+	{RecommenderInstanceIdle, "instances", CloudResourceInstance},
+	{RecommenderInstanceGroupMachineType, "instanceGroupManagers", CloudResourceNodePool},
+	{RecommenderDiskIdle, "disks", CloudResourceDisk},
+}
+
+// newRecommenderService 使用服務帳戶凭证建立 Recommender API 客戶端
+func newRecommenderService(config ServiceConfig) (*recommender.Service, error) {
+	credentialsBytes, err := os.ReadFile(config.CredentialsFile)
+	if err != nil {
+		return nil, fmt.Errorf("無法讀取凭证文件: %w", err)
+	}
+
+	googleCredentials, err := google.CredentialsFromJSON(context.Background(), credentialsBytes, recommender.CloudPlatformScope)
+	if err != nil {
+		return nil, fmt.Errorf("無法建立 Google 凭证: %w", err)
+	}
+
+	recommenderService, err := recommender.NewService(context.Background(), option.WithCredentials(googleCredentials))
+	if err != nil {
+		return nil, fmt.Errorf("無法建立 Recommender API 客戶端: %w", err)
+	}
+
+	return recommenderService, nil
+}
+
+// CloudRecommendationsAvailable 是否可以從 Recommender API 取得建議
+func (s *Service) CloudRecommendationsAvailable() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.recommenderService != nil && s.config.ProjectID != ""
+}
+
+// GetCloudRecommendations 從 Recommender API 取得叢集節點所在可用區的 ACTIVE 建議：節點 VM 與節點池的機器類型調整、閒置的節點 VM 與閒置的磁碟
+// 節點與節點池的建議只保留屬於此叢集的資源；磁碟建議全部回傳，由呼叫端比對 PV 名稱
+// 單一可用區或 recommender 查詢失敗 (例如缺少權限) 只記錄警告，全部失敗時回傳錯誤
+func (s *Service) GetCloudRecommendations(ctx context.Context) ([]CloudRecommendation, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.recommenderService == nil || s.config.ProjectID == "" {
+		return nil, fmt.Errorf("Recommender API 不可用，需使用 Google Cloud 凭证連線")
+	}
+
+	nodes, err := s.clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("無法取得節點列表: %w", err)
+	}
+
+	// GKE 節點名稱即 VM 名稱；節點池在每個可用區的代管執行個體群組名稱為 VM 名稱去掉最後一段再加上 -grp
+	nodePools := make(map[string]string)
+	groupPools := make(map[string]string)
+	zones := make(map[string]bool)
+	for _, node := range nodes.Items {
+		pool := node.Labels[NodePoolLabel]
+		nodePools[node.Name] = pool
+		if i := strings.LastIndex(node.Name, "-"); i > 0 {
+			groupPools[node.Name[:i]+"-grp"] = pool
+		}
+		if zone := nodeZoneFromLabels(node.Labels); zone != "unknown" {
+			zones[zone] = true
+		}
+	}
+
+	var result []CloudRecommendation
+	var queries, failures int
+	var lastErr error
+	for zone := range zones {
+		for _, source := range cloudRecommenders {
+			queries++
+			parent := fmt.Sprintf("projects/%s/locations/%s/recommenders/%s", s.config.ProjectID, zone, source.id)
+			err := s.recommenderService.Projects.Locations.Recommenders.Recommendations.List(parent).
+				Filter(cloudRecommendationFilter).
+				Pages(ctx, func(page *recommender.GoogleCloudRecommenderV1ListRecommendationsResponse) error {
+					for _, rec := range page.Recommendations {
+						recommendation, ok := buildCloudRecommendation(rec, source.id, source.collection, source.kind, zone)
+						if !ok {
+							continue
+						}
+						switch source.kind {
+						case CloudResourceInstance:
+							pool, found := nodePools[recommendation.ResourceName]
+							if !found {
+								continue
+							}
+							recommendation.NodePool = pool
+						case CloudResourceNodePool:
+							pool, found := groupPools[recommendation.ResourceName]
+							if !found {
+								continue
+							}
+							recommendation.NodePool = pool
+						}
+						result = append(result, recommendation)
+					}
+					return nil
+				})
+			if err != nil {
+				failures++
+				lastErr = err
+				if s.logger != nil {
+					s.logger.Printf("警告: 無法取得 %s 在 %s 的建議: %v", source.id, zone, err)
+				}
+			}
+		}
+	}
+	if queries > 0 && failures == queries {
+		return nil, fmt.Errorf("無法從 Recommender API 取得建議: %w", lastErr)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].MonthlySavings != result[j].MonthlySavings {
+			return result[i].MonthlySavings > result[j].MonthlySavings
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+// buildCloudRecommendation 從 Recommender API 的建議取出目標資源、建議的機器類型與每月節省的成本
+// 找不到符合集合的目標資源時回傳 false
+func buildCloudRecommendation(rec *recommender.GoogleCloudRecommenderV1Recommendation, recommenderID, collection, kind, zone string) (CloudRecommendation, bool) {
+	recommendation := CloudRecommendation{
+		Name:            rec.Name,
+		Recommender:     recommenderID,
+		Subtype:         rec.RecommenderSubtype,
+		Description:     rec.Description,
+		Priority:        rec.Priority,
+		ResourceType:    kind,
+		Zone:            zone,
+		LastRefreshTime: rec.LastRefreshTime,
+	}
+
+	if rec.Content != nil {
+		for _, group := range rec.Content.OperationGroups {
+			for _, operation := range group.Operations {
+				if recommendation.Resource == "" && strings.Contains(operation.Resource, "/"+collection+"/") {
+					recommendation.Resource = operation.Resource
+					recommendation.ResourceName = path.Base(operation.Resource)
+				}
+				if machineType, ok := operation.Value.(string); ok && strings.HasSuffix(operation.Path, "machineType") {
+					recommendation.SuggestedMachineType = path.Base(machineType)
+				}
+			}
+		}
+	}
+	if recommendation.Resource == "" {
+		return recommendation, false
+	}
+
+	// costProjection 的成本為負值表示節省，依涵蓋的期間換算為每月
+	if impact := rec.PrimaryImpact; impact != nil && impact.CostProjection != nil && impact.CostProjection.Cost != nil {
+		cost := impact.CostProjection.Cost
+		amount := float64(cost.Units) + float64(cost.Nanos)/1e9
+		duration, err := time.ParseDuration(impact.CostProjection.Duration)
+		if err != nil || duration <= 0 {
+			duration = 30 * 24 * time.Hour
+		}
+		recommendation.MonthlySavings = math.Round(-amount*cloudHoursPerMonth/duration.Hours()*100) / 100
+		recommendation.Currency = cost.CurrencyCode
+	}
+
+	return recommendation, true
+}
@@ -7,24 +7,48 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"path"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/util/homedir"
+	custommetricsv1beta1 "k8s.io/metrics/pkg/apis/custom_metrics/v1beta1"
 	metricsclientset "k8s.io/metrics/pkg/client/clientset/versioned"
 
 	// Google Cloud 相关导入
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
 	"google.golang.org/api/container/v1"
+	monitoring "google.golang.org/api/monitoring/v3"
 	"google.golang.org/api/option"
+	recommender "google.golang.org/api/recommender/v1"
+	storage "google.golang.org/api/storage/v1"
+)
+
+const (
+	// GPUResourceName NVIDIA GPU 擴充資源名稱
+	GPUResourceName corev1.ResourceName = "nvidia.com/gpu"
+
+	// dcgmGPUUtilMetric DCGM exporter 提供的 GPU 使用率指標名稱
+	dcgmGPUUtilMetric = "DCGM_FI_DEV_GPU_UTIL"
+
+	// AllNamespaces 代表查詢所有命名空間的特殊值
+	AllNamespaces = "all"
 )
 
 // Logger 接口，用於可選的日誌記錄
@@ -35,23 +59,40 @@ type Logger interface {
 
 // Service GKE 服務
 type Service struct {
-	clientset        *kubernetes.Clientset
-	metricsClientset *metricsclientset.Clientset
-	mu               sync.RWMutex
-	defaultNamespace string
-	config           ServiceConfig
-	logger           Logger // 可選的 logger
+	clientset          *kubernetes.Clientset
+	metricsClientset   *metricsclientset.Clientset
+	restConfig         *rest.Config // 用於 exec 等需要直接連線的操作
+	dynamicClient      dynamic.Interface
+	restMapper         meta.RESTMapper      // 將資源名稱解析為 API 資源，供通用修補使用
+	collector          *MetricsCollector    // 背景指標收集器，未啟用時為 nil
+	monitoringService  *monitoring.Service  // Cloud Monitoring 客戶端，僅在使用 Google Cloud 凭证時可用
+	prometheus         *prometheusClient    // Prometheus 客戶端，未設定時為 nil
+	storageService     *storage.Service     // Cloud Storage 客戶端，僅在使用 Google Cloud 凭证時可用
+	recommenderService *recommender.Service // Recommender API 客戶端，僅在使用 Google Cloud 凭证時可用
+	mu                 sync.RWMutex
+	defaultNamespace   string
+	config             ServiceConfig
+	logger             Logger // 可選的 logger
 }
 
 // ServiceConfig GKE 服務配置
 type ServiceConfig struct {
-	UseCredentials   bool
-	CredentialsFile  string
-	ProjectID        string
-	ClusterName      string
-	Location         string
-	DefaultNamespace string
-	Logger           Logger // 可選的 logger
+	UseCredentials          bool
+	CredentialsFile         string
+	ProjectID               string
+	ClusterName             string
+	Location                string
+	DefaultNamespace        string
+	KubeConfigPath          string   // 不使用 Google Cloud 凭证時的 kubeconfig 路徑，空字串表示使用預設路徑
+	KubeContext             string   // kubeconfig 中使用的 context，空字串表示使用目前的 context
+	WriteEnabled            bool     // 是否允許會變更叢集狀態的操作 (重啟、擴縮等)
+	MinReplicas             int32    // 擴縮時允許的最小副本數
+	MaxReplicas             int32    // 擴縮時允許的最大副本數，0 表示不限制
+	ExecAllowlist           []string // exec_in_pod 允許執行的指令，空清單表示停用
+	PrometheusURL           string   // Prometheus 查詢 API 位址，空字串表示不使用 Prometheus
+	PrometheusBearerToken   string   // 可選的 Prometheus Bearer token
+	PrometheusUseGoogleAuth bool     // 以 Google Cloud 凭证存取 Managed Service for Prometheus
+	Logger                  Logger   // 可選的 logger
 }
 
 // NewService 創建一個新的 GKE 服務
@@ -87,21 +128,81 @@ func NewServiceWithConfig(config ServiceConfig) (*Service, error) {
 		// 繼續執行，但 metrics 功能將不可用
 	}
 
+	// 建立動態客戶端與資源對應，供任意資源類型的修補使用
+	dynamicClient, err := dynamic.NewForConfig(kubeConfig)
+	if err != nil {
+		return nil, fmt.Errorf("無法建立 Kubernetes 動態客戶端: %w", err)
+	}
+	discoveryClient := memory.NewMemCacheClient(clientset.Discovery())
+	restMapper := restmapper.NewShortcutExpander(restmapper.NewDeferredDiscoveryRESTMapper(discoveryClient), discoveryClient, nil)
+
+	// 使用 Google Cloud 凭证時建立 Cloud Monitoring 客戶端，供查詢歷史使用量
+	var monitoringService *monitoring.Service
+	if config.UseCredentials {
+		monitoringService, err = newMonitoringService(config)
+		if err != nil {
+			if config.Logger != nil {
+				config.Logger.Printf("警告: 無法建立 Cloud Monitoring 客戶端: %v", err)
+			}
+			// 繼續執行，但歷史使用量查詢將不可用
+		}
+	}
+
+	// 使用 Google Cloud 凭证時建立 Cloud Storage 客戶端，供上傳優化報告
+	var storageService *storage.Service
+	if config.UseCredentials {
+		storageService, err = newStorageService(config)
+		if err != nil {
+			if config.Logger != nil {
+				config.Logger.Printf("警告: 無法建立 Cloud Storage 客戶端: %v", err)
+			}
+		}
+	}
+
+	// 使用 Google Cloud 凭证時建立 Recommender API 客戶端，供合併 Google Cloud 的節點與磁碟建議
+	var recommenderService *recommender.Service
+	if config.UseCredentials {
+		recommenderService, err = newRecommenderService(config)
+		if err != nil {
+			if config.Logger != nil {
+				config.Logger.Printf("警告: 無法建立 Recommender API 客戶端: %v", err)
+			}
+		}
+	}
+
+	// 設定 Prometheus 位址時建立查詢客戶端
+	var prometheus *prometheusClient
+	if config.PrometheusURL != "" {
+		prometheus, err = newPrometheusClient(config)
+		if err != nil {
+			if config.Logger != nil {
+				config.Logger.Printf("警告: 無法建立 Prometheus 客戶端: %v", err)
+			}
+		}
+	}
+
 	namespace := config.DefaultNamespace
 	if namespace == "" {
 		namespace = "default"
 	}
 
 	service := &Service{
-		clientset:        clientset,
-		metricsClientset: metricsClientset,
-		defaultNamespace: namespace,
-		config:           config,
-		logger:           config.Logger,
+		clientset:          clientset,
+		metricsClientset:   metricsClientset,
+		restConfig:         kubeConfig,
+		dynamicClient:      dynamicClient,
+		restMapper:         restMapper,
+		monitoringService:  monitoringService,
+		prometheus:         prometheus,
+		storageService:     storageService,
+		recommenderService: recommenderService,
+		defaultNamespace:   namespace,
+		config:             config,
+		logger:             config.Logger,
 	}
 
 	// 驗證連接
-	if err := service.validateConnection(); err != nil {
+	if err := service.validateConnection(context.Background()); err != nil {
 		return nil, fmt.Errorf("無法驗證 GKE 連接: %w", err)
 	}
 
@@ -109,9 +210,9 @@ func NewServiceWithConfig(config ServiceConfig) (*Service, error) {
 }
 
 // validateConnection 驗證 GKE 連接
-func (s *Service) validateConnection() error {
+func (s *Service) validateConnection(ctx context.Context) error {
 	// 嘗試獲取命名空間列表來驗證連接
-	_, err := s.clientset.CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{Limit: 1})
+	_, err := s.clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{Limit: 1})
 	if err != nil {
 		return fmt.Errorf("連接驗證失敗: %w", err)
 	}
@@ -126,7 +227,7 @@ func getKubeConfigWithCredentials(config ServiceConfig) (*rest.Config, error) {
 	if config.UseCredentials && config.CredentialsFile != "" {
 		return getKubeConfigFromGoogleCredentials(config)
 	}
-	return getKubeConfig()
+	return getKubeConfig(config)
 }
 
 // getKubeConfigFromGoogleCredentials 從 Google Cloud 凭证建立 Kubernetes 配置
@@ -218,7 +319,21 @@ func (t *tokenRefreshTransport) RoundTrip(req *http.Request) (*http.Response, er
 }
 
 // getKubeConfig 取得 Kubernetes 配置 (原有的方法，用於向後兼容)
-func getKubeConfig() (*rest.Config, error) {
+// 指定 kubeconfig 路徑或 context 時直接使用 kubeconfig，否則先嘗試 in-cluster 配置
+func getKubeConfig(serviceConfig ServiceConfig) (*rest.Config, error) {
+	if serviceConfig.KubeConfigPath != "" || serviceConfig.KubeContext != "" {
+		rules := clientcmd.NewDefaultClientConfigLoadingRules()
+		if serviceConfig.KubeConfigPath != "" {
+			rules.ExplicitPath = serviceConfig.KubeConfigPath
+		}
+		overrides := &clientcmd.ConfigOverrides{CurrentContext: serviceConfig.KubeContext}
+		config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, overrides).ClientConfig()
+		if err != nil {
+			return nil, fmt.Errorf("無法載入 kubeconfig (context %q): %w", serviceConfig.KubeContext, err)
+		}
+		return config, nil
+	}
+
 	// 嘗試使用 in-cluster 配置
 	config, err := rest.InClusterConfig()
 	if err == nil {
@@ -239,39 +354,97 @@ func getKubeConfig() (*rest.Config, error) {
 	return config, nil
 }
 
+// resolveListNamespace 解析列表查詢使用的命名空間，"all" 代表所有命名空間
+func (s *Service) resolveListNamespace(namespace string) string {
+	if namespace == "" {
+		return s.defaultNamespace
+	}
+	if strings.EqualFold(namespace, AllNamespaces) {
+		return metav1.NamespaceAll
+	}
+	return namespace
+}
+
 // GetAllPods 取得所有 Pod
-func (s *Service) GetAllPods(namespace string) ([]Pod, error) {
+func (s *Service) GetAllPods(ctx context.Context, namespace string) ([]Pod, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	if namespace == "" {
-		namespace = s.defaultNamespace
+	podList, err := s.listPods(ctx, namespace, PageOptions{})
+	if err != nil {
+		return nil, err
 	}
 
-	pods, err := s.clientset.CoreV1().Pods(namespace).List(context.TODO(), metav1.ListOptions{})
+	return podList.Items, nil
+}
+
+// ListPods 分頁取得 Pod 列表
+func (s *Service) ListPods(ctx context.Context, namespace string, page PageOptions) (*PodList, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.listPods(ctx, namespace, page)
+}
+
+// listPods 分頁取得 Pod 列表 (呼叫端需持有讀鎖)
+func (s *Service) listPods(ctx context.Context, namespace string, page PageOptions) (*PodList, error) {
+	namespace = s.resolveListNamespace(namespace)
+
+	pods, err := s.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		Limit:    page.Limit,
+		Continue: page.Continue,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("無法取得 Pod 列表: %w", err)
 	}
 
-	var result []Pod
+	result := &PodList{
+		Continue:           pods.Continue,
+		RemainingItemCount: pods.RemainingItemCount,
+	}
 	for _, pod := range pods.Items {
-		result = append(result, s.convertPod(&pod))
+		result.Items = append(result.Items, s.convertPod(&pod))
 	}
 
 	return result, nil
 }
 
 // SearchPods 根據條件搜尋 Pod
-func (s *Service) SearchPods(criteria SearchCriteria) ([]Pod, error) {
+func (s *Service) SearchPods(ctx context.Context, criteria SearchCriteria) (*PodList, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	namespace := criteria.Namespace
-	if namespace == "" {
-		namespace = s.defaultNamespace
+	nameMatcher, err := newNameMatcher(criteria.NamePattern, criteria.PatternType)
+	if err != nil {
+		return nil, err
+	}
+
+	// 多命名空間搜尋：逐一查詢後合併結果
+	if len(criteria.Namespaces) > 0 {
+		if criteria.Limit > 0 || criteria.Continue != "" {
+			return nil, fmt.Errorf("多命名空間搜尋不支援分頁參數")
+		}
+
+		result := &PodList{}
+		for _, namespace := range criteria.Namespaces {
+			podList, err := s.searchPodsInNamespace(ctx, s.resolveListNamespace(namespace), criteria, nameMatcher)
+			if err != nil {
+				return nil, fmt.Errorf("命名空間 %s: %w", namespace, err)
+			}
+			result.Items = append(result.Items, podList.Items...)
+		}
+		return result, nil
 	}
 
-	listOptions := metav1.ListOptions{}
+	return s.searchPodsInNamespace(ctx, s.resolveListNamespace(criteria.Namespace), criteria, nameMatcher)
+}
+
+// searchPodsInNamespace 在單一命名空間中根據條件搜尋 Pod (呼叫端需持有讀鎖)
+func (s *Service) searchPodsInNamespace(ctx context.Context, namespace string, criteria SearchCriteria, nameMatcher func(name string) bool) (*PodList, error) {
+	listOptions := metav1.ListOptions{
+		Limit:    criteria.Limit,
+		Continue: criteria.Continue,
+	}
 
 	// 設定標籤選擇器
 	if criteria.LabelSelector != "" {
@@ -279,16 +452,25 @@ func (s *Service) SearchPods(criteria SearchCriteria) ([]Pod, error) {
 	}
 
 	// 設定欄位選擇器
+	var fieldSelectors []string
 	if criteria.FieldSelector != "" {
-		listOptions.FieldSelector = criteria.FieldSelector
+		fieldSelectors = append(fieldSelectors, criteria.FieldSelector)
+	}
+	if criteria.NodeName != "" {
+		fieldSelectors = append(fieldSelectors, fields.OneTermEqualSelector("spec.nodeName", criteria.NodeName).String())
 	}
+	listOptions.FieldSelector = strings.Join(fieldSelectors, ",")
 
-	pods, err := s.clientset.CoreV1().Pods(namespace).List(context.TODO(), listOptions)
+	pods, err := s.clientset.CoreV1().Pods(namespace).List(ctx, listOptions)
 	if err != nil {
 		return nil, fmt.Errorf("無法搜尋 Pod: %w", err)
 	}
 
-	var result []Pod
+	// 額外過濾條件在伺服器端分頁後套用，因此每頁筆數可能少於 limit
+	result := &PodList{
+		Continue:           pods.Continue,
+		RemainingItemCount: pods.RemainingItemCount,
+	}
 	for _, pod := range pods.Items {
 		convertedPod := s.convertPod(&pod)
 
@@ -296,15 +478,67 @@ func (s *Service) SearchPods(criteria SearchCriteria) ([]Pod, error) {
 		if criteria.Status != "" && convertedPod.Status != criteria.Status {
 			continue
 		}
+		if criteria.QOSClass != "" && !strings.EqualFold(convertedPod.QOSClass, criteria.QOSClass) {
+			continue
+		}
+		if nameMatcher != nil && !nameMatcher(convertedPod.Name) {
+			continue
+		}
+		if !criteria.CreatedAfter.IsZero() && !convertedPod.CreatedAt.After(criteria.CreatedAfter) {
+			continue
+		}
+		if !criteria.CreatedBefore.IsZero() && !convertedPod.CreatedAt.Before(criteria.CreatedBefore) {
+			continue
+		}
+		if !criteria.RestartedSince.IsZero() && !podRestartedSince(convertedPod, criteria.RestartedSince) {
+			continue
+		}
 
-		result = append(result, convertedPod)
+		result.Items = append(result.Items, convertedPod)
 	}
 
 	return result, nil
 }
 
-// GetPodResourceUsage 取得 Pod 的資源使用狀況
-func (s *Service) GetPodResourceUsage(podName, namespace string) (*ResourceUsage, error) {
+// podRestartedSince 判斷 Pod 是否有容器在指定時間之後重啟
+func podRestartedSince(pod Pod, since time.Time) bool {
+	for _, container := range pod.Containers {
+		if container.LastRestartAt != nil && container.LastRestartAt.After(since) {
+			return true
+		}
+	}
+	return false
+}
+
+// newNameMatcher 建立 Pod 名稱比對函數，pattern 為空時回傳 nil
+func newNameMatcher(pattern, patternType string) (func(name string) bool, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+
+	switch strings.ToLower(patternType) {
+	case "", "glob":
+		// 預先驗證 glob 語法
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("無效的 glob 模式 %q: %w", pattern, err)
+		}
+		return func(name string) bool {
+			matched, _ := path.Match(pattern, name)
+			return matched
+		}, nil
+	case "regex":
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("無效的正規表示式 %q: %w", pattern, err)
+		}
+		return re.MatchString, nil
+	default:
+		return nil, fmt.Errorf("不支援的模式類型 %q，可用值: glob, regex", patternType)
+	}
+}
+
+// GetPodResourceUsage 取得 Pod 的資源使用狀況，ctx 取消時中止查詢
+func (s *Service) GetPodResourceUsage(ctx context.Context, podName, namespace string) (*ResourceUsage, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
@@ -317,13 +551,13 @@ func (s *Service) GetPodResourceUsage(podName, namespace string) (*ResourceUsage
 	}
 
 	// 取得 Pod metrics
-	podMetrics, err := s.metricsClientset.MetricsV1beta1().PodMetricses(namespace).Get(context.TODO(), podName, metav1.GetOptions{})
+	podMetrics, err := s.metricsClientset.MetricsV1beta1().PodMetricses(namespace).Get(ctx, podName, metav1.GetOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("無法取得 Pod metrics: %w", err)
 	}
 
 	// 取得 Pod 資訊以獲取資源限制和請求
-	pod, err := s.clientset.CoreV1().Pods(namespace).Get(context.TODO(), podName, metav1.GetOptions{})
+	pod, err := s.clientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("無法取得 Pod 資訊: %w", err)
 	}
@@ -392,35 +626,128 @@ func (s *Service) GetPodResourceUsage(podName, namespace string) (*ResourceUsage
 		containerUsages = append(containerUsages, containerUsage)
 	}
 
-	// 設定總體使用量
+	// 設定總體使用量，請求量與限制量為各容器的總和
 	usage.CPU = CPUUsage{
 		Current: fmt.Sprintf("%dm", totalCPU),
+		Request: podResourceTotal(pod, corev1.ResourceCPU, false),
+		Limit:   podResourceTotal(pod, corev1.ResourceCPU, true),
 	}
 	usage.Memory = MemoryUsage{
 		Current: fmt.Sprintf("%dMi", totalMemory/(1024*1024)),
+		Request: podResourceTotal(pod, corev1.ResourceMemory, false),
+		Limit:   podResourceTotal(pod, corev1.ResourceMemory, true),
 	}
 	usage.Containers = containerUsages
 
-	// 取得磁碟使用狀況 (模擬資料，實際需要額外的監控工具)
-	usage.Disk = s.getMockDiskUsage(pod)
+	// 取得 CPU 節流狀況，無法取得時不影響其他使用量
+	throttling, err := s.getPodCPUThrottling(ctx, pod)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Printf("無法取得 Pod %s 的 CPU 節流指標: %v", podName, err)
+		}
+	} else {
+		for i := range usage.Containers {
+			usage.Containers[i].CPU.Throttling = throttling[usage.Containers[i].Name]
+		}
+		usage.CPU.Throttling = mostThrottled(throttling)
+	}
+
+	// 取得磁碟使用狀況，無法取得時不影響其他使用量
+	disk, err := s.getPodDiskUsage(ctx, pod)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Printf("無法取得 Pod %s 的磁碟使用量: %v", podName, err)
+		}
+	} else {
+		usage.Disk = disk
+	}
+
+	// 取得 GPU 使用狀況 (僅在 Pod 請求 GPU 時)
+	usage.GPU = s.getPodGPUUsage(ctx, pod)
 
 	return usage, nil
 }
 
+// getPodGPUUsage 取得 Pod 的 GPU 請求量、限制量及 DCGM 使用率
+func (s *Service) getPodGPUUsage(ctx context.Context, pod *corev1.Pod) *GPUUsage {
+	totalRequest := int64(0)
+	totalLimit := int64(0)
+
+	for _, container := range pod.Spec.Containers {
+		if request, ok := container.Resources.Requests[GPUResourceName]; ok {
+			totalRequest += request.Value()
+		}
+		if limit, ok := container.Resources.Limits[GPUResourceName]; ok {
+			totalLimit += limit.Value()
+		}
+	}
+
+	if totalRequest == 0 && totalLimit == 0 {
+		return nil
+	}
+
+	gpuUsage := &GPUUsage{
+		Resource: string(GPUResourceName),
+		Request:  fmt.Sprintf("%d", totalRequest),
+		Limit:    fmt.Sprintf("%d", totalLimit),
+	}
+
+	utilization, err := s.getPodGPUUtilization(ctx, pod.Name, pod.Namespace)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Printf("無法取得 Pod %s 的 GPU 使用率: %v", pod.Name, err)
+		}
+		gpuUsage.UtilizationSource = "unavailable"
+		return gpuUsage
+	}
+
+	gpuUsage.Utilization = utilization
+	gpuUsage.MetricsAvailable = true
+	gpuUsage.UtilizationSource = "dcgm"
+
+	return gpuUsage
+}
+
+// getPodGPUUtilization 透過 custom metrics API 取得 DCGM GPU 使用率 (需安裝 DCGM exporter 與 metrics adapter)
+func (s *Service) getPodGPUUtilization(ctx context.Context, podName, namespace string) (float64, error) {
+	path := fmt.Sprintf("/apis/custom.metrics.k8s.io/v1beta1/namespaces/%s/pods/%s/%s", namespace, podName, dcgmGPUUtilMetric)
+	data, err := s.clientset.CoreV1().RESTClient().Get().AbsPath(path).DoRaw(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("無法查詢 DCGM 指標: %w", err)
+	}
+
+	var metrics custommetricsv1beta1.MetricValueList
+	if err := json.Unmarshal(data, &metrics); err != nil {
+		return 0, fmt.Errorf("無法解析 DCGM 指標: %w", err)
+	}
+
+	if len(metrics.Items) == 0 {
+		return 0, fmt.Errorf("沒有 DCGM 指標資料")
+	}
+
+	// 多張 GPU 時取平均使用率
+	total := 0.0
+	for _, item := range metrics.Items {
+		total += item.Value.AsApproximateFloat64()
+	}
+
+	return total / float64(len(metrics.Items)), nil
+}
+
 // GetPodDetails 取得 Pod 的詳細資訊
-func (s *Service) GetPodDetails(podName, namespace string) (*PodDetails, error) {
+func (s *Service) GetPodDetails(ctx context.Context, podName, namespace string) (*PodDetails, error) {
 	if namespace == "" {
 		namespace = s.defaultNamespace
 	}
 
 	// 取得基本資訊
-	pod, err := s.clientset.CoreV1().Pods(namespace).Get(context.TODO(), podName, metav1.GetOptions{})
+	pod, err := s.clientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("無法取得 Pod 資訊: %w", err)
 	}
 
 	// 取得資源使用狀況
-	usage, err := s.GetPodResourceUsage(podName, namespace)
+	usage, err := s.GetPodResourceUsage(ctx, podName, namespace)
 	if err != nil {
 		if s.logger != nil {
 			s.logger.Printf("警告: 無法取得資源使用狀況: %v", err)
@@ -434,7 +761,7 @@ func (s *Service) GetPodDetails(podName, namespace string) (*PodDetails, error)
 	}
 
 	// 取得事件
-	events, err := s.getPodEvents(podName, namespace)
+	events, err := s.getPodEvents(ctx, podName, namespace)
 	if err != nil {
 		if s.logger != nil {
 			s.logger.Printf("警告: 無法取得 Pod 事件: %v", err)
@@ -443,7 +770,7 @@ func (s *Service) GetPodDetails(podName, namespace string) (*PodDetails, error)
 	}
 
 	// 取得日誌 (最新 100 行)
-	logs, err := s.getPodLogs(podName, namespace, 100)
+	logs, err := s.getPodLogs(ctx, podName, namespace, 100)
 	if err != nil {
 		if s.logger != nil {
 			s.logger.Printf("警告: 無法取得 Pod 日誌: %v", err)
@@ -467,38 +794,197 @@ func (s *Service) convertPod(pod *corev1.Pod) Pod {
 	ready := true
 
 	for _, container := range pod.Spec.Containers {
-		containerStatus := s.getContainerStatus(pod, container.Name)
-		containerReady := containerStatus != nil && containerStatus.Ready
-		if !containerReady {
+		containerStatus := s.getContainerStatus(pod.Status.ContainerStatuses, container.Name)
+		convertedContainer := s.convertContainer(container.Name, container.Image, container.Resources, containerStatus)
+		if !convertedContainer.Ready {
 			ready = false
 		}
+		convertedContainer.LivenessProbe = s.convertProbe(container.LivenessProbe)
+		convertedContainer.ReadinessProbe = s.convertProbe(container.ReadinessProbe)
+		convertedContainer.StartupProbe = s.convertProbe(container.StartupProbe)
+
+		containers = append(containers, convertedContainer)
+	}
+
+	// 初始化容器
+	var initContainers []Container
+	for _, container := range pod.Spec.InitContainers {
+		containerStatus := s.getContainerStatus(pod.Status.InitContainerStatuses, container.Name)
+		initContainers = append(initContainers, s.convertContainer(container.Name, container.Image, container.Resources, containerStatus))
+	}
 
-		containers = append(containers, Container{
-			Name:    container.Name,
-			Image:   container.Image,
-			Status:  s.getContainerStatusString(containerStatus),
-			Ready:   containerReady,
-			Restart: s.getContainerRestartCount(containerStatus),
+	// 臨時除錯容器
+	var ephemeralContainers []Container
+	for _, container := range pod.Spec.EphemeralContainers {
+		containerStatus := s.getContainerStatus(pod.Status.EphemeralContainerStatuses, container.Name)
+		ephemeralContainers = append(ephemeralContainers, s.convertContainer(container.Name, container.Image, container.Resources, containerStatus))
+	}
+
+	var conditions []PodCondition
+	for _, condition := range pod.Status.Conditions {
+		conditions = append(conditions, PodCondition{
+			Type:               string(condition.Type),
+			Status:             string(condition.Status),
+			Reason:             condition.Reason,
+			Message:            condition.Message,
+			LastTransitionTime: condition.LastTransitionTime.Time,
+		})
+	}
+
+	var ownerReferences []OwnerReference
+	for _, owner := range pod.OwnerReferences {
+		ownerReferences = append(ownerReferences, OwnerReference{
+			Kind:       owner.Kind,
+			Name:       owner.Name,
+			Controller: owner.Controller != nil && *owner.Controller,
 		})
 	}
 
 	return Pod{
-		Name:       pod.Name,
-		Namespace:  pod.Namespace,
-		Status:     string(pod.Status.Phase),
-		NodeName:   pod.Spec.NodeName,
-		PodIP:      pod.Status.PodIP,
-		HostIP:     pod.Status.HostIP,
-		Labels:     pod.Labels,
-		CreatedAt:  pod.CreationTimestamp.Time,
-		Ready:      ready,
-		Containers: containers,
-	}
-}
-
-// getContainerStatus 取得容器狀態
-func (s *Service) getContainerStatus(pod *corev1.Pod, containerName string) *corev1.ContainerStatus {
-	for _, status := range pod.Status.ContainerStatuses {
+		Name:        pod.Name,
+		Namespace:   pod.Namespace,
+		Status:      string(pod.Status.Phase),
+		NodeName:    pod.Spec.NodeName,
+		PodIP:       pod.Status.PodIP,
+		HostIP:      pod.Status.HostIP,
+		Labels:      pod.Labels,
+		Annotations: pod.Annotations,
+		CreatedAt:   pod.CreationTimestamp.Time,
+		Ready:       ready,
+		Reason:      pod.Status.Reason,
+		Message:     pod.Status.Message,
+		QOSClass:    string(pod.Status.QOSClass),
+		Containers:  containers,
+
+		InitContainers:      initContainers,
+		EphemeralContainers: ephemeralContainers,
+
+		Conditions:      conditions,
+		OwnerReferences: ownerReferences,
+	}
+}
+
+// convertContainer 轉換容器規格與狀態為內部 Container 結構
+func (s *Service) convertContainer(name, image string, resources corev1.ResourceRequirements, status *corev1.ContainerStatus) Container {
+	container := Container{
+		Name:              name,
+		Image:             image,
+		Status:            s.getContainerStatusString(status),
+		Ready:             status != nil && status.Ready,
+		Restart:           s.getContainerRestartCount(status),
+		ExtendedResources: s.getExtendedResources(resources),
+		Resources:         getResourceRequirements(resources),
+	}
+
+	if status == nil {
+		return container
+	}
+
+	if status.RestartCount > 0 && status.LastTerminationState.Terminated != nil {
+		lastRestartAt := status.LastTerminationState.Terminated.FinishedAt.Time
+		container.LastRestartAt = &lastRestartAt
+	}
+
+	switch {
+	case status.State.Waiting != nil:
+		container.Reason = status.State.Waiting.Reason
+		container.Message = status.State.Waiting.Message
+	case status.State.Terminated != nil:
+		exitCode := status.State.Terminated.ExitCode
+		container.Reason = status.State.Terminated.Reason
+		container.Message = status.State.Terminated.Message
+		container.ExitCode = &exitCode
+	}
+
+	return container
+}
+
+// getResourceRequirements 取得容器的 CPU 與記憶體請求量與限制量
+func getResourceRequirements(resources corev1.ResourceRequirements) ResourceRequirements {
+	var result ResourceRequirements
+	if quantity, ok := resources.Requests[corev1.ResourceCPU]; ok {
+		result.CPURequest = quantity.String()
+	}
+	if quantity, ok := resources.Limits[corev1.ResourceCPU]; ok {
+		result.CPULimit = quantity.String()
+	}
+	if quantity, ok := resources.Requests[corev1.ResourceMemory]; ok {
+		result.MemoryRequest = quantity.String()
+	}
+	if quantity, ok := resources.Limits[corev1.ResourceMemory]; ok {
+		result.MemoryLimit = quantity.String()
+	}
+	return result
+}
+
+// podResourceTotal 計算 Pod 所有容器的請求量或限制量總和 (CPU 為 millicores，記憶體為 MiB)
+// 沒有任何容器設定時回傳空字串；計算限制量時只要有一個容器未設定，Pod 就沒有上限，同樣回傳空字串
+func podResourceTotal(pod *corev1.Pod, name corev1.ResourceName, limits bool) string {
+	var total int64
+	configured := 0
+	for _, container := range pod.Spec.Containers {
+		values := container.Resources.Requests
+		if limits {
+			values = container.Resources.Limits
+		}
+		quantity, ok := values[name]
+		if !ok {
+			continue
+		}
+		configured++
+		if name == corev1.ResourceCPU {
+			total += quantity.MilliValue()
+		} else {
+			total += quantity.Value()
+		}
+	}
+	if configured == 0 || (limits && configured < len(pod.Spec.Containers)) {
+		return ""
+	}
+
+	if name == corev1.ResourceCPU {
+		return fmt.Sprintf("%dm", total)
+	}
+	return fmt.Sprintf("%dMi", total/(1024*1024))
+}
+
+// getExtendedResources 取得容器的擴充資源 (例如 nvidia.com/gpu)
+func (s *Service) getExtendedResources(resources corev1.ResourceRequirements) []ExtendedResource {
+	names := make(map[corev1.ResourceName]bool)
+	for name := range resources.Requests {
+		names[name] = true
+	}
+	for name := range resources.Limits {
+		names[name] = true
+	}
+
+	var result []ExtendedResource
+	for name := range names {
+		// 擴充資源名稱必須帶有網域前綴，且不屬於 kubernetes.io 命名空間
+		if !strings.Contains(string(name), "/") || strings.Contains(string(name), "kubernetes.io/") {
+			continue
+		}
+
+		extended := ExtendedResource{Name: string(name)}
+		if request, ok := resources.Requests[name]; ok {
+			extended.Request = request.String()
+		}
+		if limit, ok := resources.Limits[name]; ok {
+			extended.Limit = limit.String()
+		}
+		result = append(result, extended)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result
+}
+
+// getContainerStatus 從狀態列表中取得指定容器的狀態
+func (s *Service) getContainerStatus(statuses []corev1.ContainerStatus, containerName string) *corev1.ContainerStatus {
+	for _, status := range statuses {
 		if status.Name == containerName {
 			return &status
 		}
@@ -532,9 +1018,9 @@ func (s *Service) getContainerRestartCount(status *corev1.ContainerStatus) int32
 }
 
 // getPodEvents 取得 Pod 事件
-func (s *Service) getPodEvents(podName, namespace string) ([]Event, error) {
+func (s *Service) getPodEvents(ctx context.Context, podName, namespace string) ([]Event, error) {
 	fieldSelector := fields.OneTermEqualSelector("involvedObject.name", podName).String()
-	events, err := s.clientset.CoreV1().Events(namespace).List(context.TODO(), metav1.ListOptions{
+	events, err := s.clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
 		FieldSelector: fieldSelector,
 	})
 	if err != nil {
@@ -543,26 +1029,42 @@ func (s *Service) getPodEvents(podName, namespace string) ([]Event, error) {
 
 	var result []Event
 	for _, event := range events.Items {
-		result = append(result, Event{
-			Type:      event.Type,
-			Reason:    event.Reason,
-			Message:   event.Message,
-			Timestamp: event.FirstTimestamp.Time,
-			Source:    event.Source.Component,
-		})
+		result = append(result, convertEvent(event))
 	}
 
 	return result, nil
 }
 
+// convertEvent 轉換 Kubernetes Event 為內部 Event 結構
+func convertEvent(event corev1.Event) Event {
+	converted := Event{
+		Type:      event.Type,
+		Reason:    event.Reason,
+		Message:   event.Message,
+		Timestamp: event.FirstTimestamp.Time,
+		Source:    event.Source.Component,
+		Count:     event.Count,
+	}
+
+	lastSeen := eventTimestamp(event)
+	if converted.Timestamp.IsZero() {
+		converted.Timestamp = lastSeen
+	}
+	if !lastSeen.IsZero() && !lastSeen.Equal(converted.Timestamp) {
+		converted.LastSeen = &lastSeen
+	}
+
+	return converted
+}
+
 // getPodLogs 取得 Pod 日誌
-func (s *Service) getPodLogs(podName, namespace string, tailLines int) (string, error) {
+func (s *Service) getPodLogs(ctx context.Context, podName, namespace string, tailLines int) (string, error) {
 	tailLines64 := int64(tailLines)
 	req := s.clientset.CoreV1().Pods(namespace).GetLogs(podName, &corev1.PodLogOptions{
 		TailLines: &tailLines64,
 	})
 
-	logs, err := req.Stream(context.TODO())
+	logs, err := req.Stream(ctx)
 	if err != nil {
 		return "", err
 	}
@@ -577,30 +1079,6 @@ func (s *Service) getPodLogs(podName, namespace string, tailLines int) (string,
 	return string(buf[:n]), nil
 }
 
-// getMockDiskUsage 取得模擬的磁碟使用狀況 (實際需要額外的監控工具)
-func (s *Service) getMockDiskUsage(pod *corev1.Pod) DiskUsage {
-	volumes := make(map[string]Volume)
-
-	// 模擬一些基本的磁碟使用資訊
-	for _, volume := range pod.Spec.Volumes {
-		volumes[volume.Name] = Volume{
-			Name:      volume.Name,
-			Type:      s.getVolumeType(&volume),
-			MountPath: "/data", // 模擬掛載路徑
-			Used:      "100Mi",
-			Available: "900Mi",
-			Total:     "1Gi",
-		}
-	}
-
-	return DiskUsage{
-		Used:      "500Mi",
-		Available: "1.5Gi",
-		Total:     "2Gi",
-		Volumes:   volumes,
-	}
-}
-
 // getVolumeType 取得卷類型
 func (s *Service) getVolumeType(volume *corev1.Volume) string {
 	switch {
@@ -612,7 +1090,388 @@ func (s *Service) getVolumeType(volume *corev1.Volume) string {
 		return "ConfigMap"
 	case volume.Secret != nil:
 		return "Secret"
+	case volume.HostPath != nil:
+		return "HostPath"
+	case volume.Projected != nil:
+		return "Projected"
+	case volume.DownwardAPI != nil:
+		return "DownwardAPI"
+	case volume.CSI != nil:
+		return "CSI"
+	case volume.Ephemeral != nil:
+		return "Ephemeral"
 	default:
 		return "Unknown"
 	}
 }
+
+// GetPodDistribution 取得 Pod 在節點與可用區上的分佈狀況
+func (s *Service) GetPodDistribution(ctx context.Context, namespace, labelSelector string) (*PodDistribution, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if namespace == "" {
+		namespace = s.defaultNamespace
+	}
+
+	pods, err := s.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labelSelector,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("無法取得 Pod 列表: %w", err)
+	}
+
+	distribution := &PodDistribution{
+		Namespace:     namespace,
+		LabelSelector: labelSelector,
+		ByNode:        make(map[string][]string),
+		ByZone:        make(map[string][]string),
+	}
+
+	// 節點可用區快取，避免重複查詢同一節點
+	nodeZones := make(map[string]string)
+
+	for _, pod := range pods.Items {
+		// 已終止的 Pod 不影響高可用性
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+
+		distribution.TotalPods++
+
+		nodeName := pod.Spec.NodeName
+		if nodeName == "" {
+			distribution.ByNode["unscheduled"] = append(distribution.ByNode["unscheduled"], pod.Name)
+			distribution.ByZone["unscheduled"] = append(distribution.ByZone["unscheduled"], pod.Name)
+			continue
+		}
+		distribution.ByNode[nodeName] = append(distribution.ByNode[nodeName], pod.Name)
+
+		zone, ok := nodeZones[nodeName]
+		if !ok {
+			zone = s.getNodeZone(ctx, nodeName)
+			nodeZones[nodeName] = zone
+		}
+		distribution.ByZone[zone] = append(distribution.ByZone[zone], pod.Name)
+	}
+
+	// 標記集中在單一節點或單一可用區的情況
+	if distribution.TotalPods > 1 {
+		if len(distribution.ByNode) == 1 {
+			distribution.SingleNode = true
+			distribution.Warnings = append(distribution.Warnings, "所有 Pod 都集中在同一個節點，節點故障將導致服務中斷")
+		}
+		if len(distribution.ByZone) == 1 {
+			distribution.SingleZone = true
+			distribution.Warnings = append(distribution.Warnings, "所有 Pod 都集中在同一個可用區，可用區故障將導致服務中斷")
+		}
+	}
+
+	return distribution, nil
+}
+
+// getNodeZone 取得節點所在的可用區
+func (s *Service) getNodeZone(ctx context.Context, nodeName string) string {
+	node, err := s.clientset.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Printf("警告: 無法取得節點 %s 資訊: %v", nodeName, err)
+		}
+		return "unknown"
+	}
+
+	return nodeZoneFromLabels(node.Labels)
+}
+
+// GetNodeZones 一次取得所有節點所在的可用區，以節點名稱為鍵，無法判斷時為 "unknown"
+func (s *Service) GetNodeZones(ctx context.Context) (map[string]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	nodes, err := s.clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("無法取得節點列表: %w", err)
+	}
+
+	zones := make(map[string]string, len(nodes.Items))
+	for _, node := range nodes.Items {
+		zones[node.Name] = nodeZoneFromLabels(node.Labels)
+	}
+	return zones, nil
+}
+
+// nodeZoneFromLabels 從節點標籤取得可用區，找不到時回傳 "unknown"
+func nodeZoneFromLabels(nodeLabels map[string]string) string {
+	if zone, ok := nodeLabels[corev1.LabelTopologyZone]; ok && zone != "" {
+		return zone
+	}
+	if zone, ok := nodeLabels[corev1.LabelFailureDomainBetaZone]; ok && zone != "" {
+		return zone
+	}
+	return "unknown"
+}
+
+// GetPodProbes 取得 Pod 各容器的探針設定
+func (s *Service) GetPodProbes(ctx context.Context, podName, namespace string) (*PodProbes, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if namespace == "" {
+		namespace = s.defaultNamespace
+	}
+
+	pod, err := s.clientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("無法取得 Pod 資訊: %w", err)
+	}
+
+	result := &PodProbes{
+		PodName:   pod.Name,
+		Namespace: pod.Namespace,
+	}
+
+	for _, container := range pod.Spec.Containers {
+		probes := ContainerProbes{
+			Container: container.Name,
+			Liveness:  s.convertProbe(container.LivenessProbe),
+			Readiness: s.convertProbe(container.ReadinessProbe),
+			Startup:   s.convertProbe(container.StartupProbe),
+		}
+
+		if probes.Liveness == nil {
+			probes.MissingProbes = append(probes.MissingProbes, "liveness")
+		}
+		if probes.Readiness == nil {
+			probes.MissingProbes = append(probes.MissingProbes, "readiness")
+		}
+		if probes.Startup == nil {
+			probes.MissingProbes = append(probes.MissingProbes, "startup")
+		}
+
+		result.Containers = append(result.Containers, probes)
+	}
+
+	return result, nil
+}
+
+// convertProbe 轉換 Kubernetes Probe 為內部 ProbeInfo 結構
+func (s *Service) convertProbe(probe *corev1.Probe) *ProbeInfo {
+	if probe == nil {
+		return nil
+	}
+
+	info := &ProbeInfo{
+		InitialDelaySeconds: probe.InitialDelaySeconds,
+		PeriodSeconds:       probe.PeriodSeconds,
+		TimeoutSeconds:      probe.TimeoutSeconds,
+		SuccessThreshold:    probe.SuccessThreshold,
+		FailureThreshold:    probe.FailureThreshold,
+	}
+
+	switch {
+	case probe.HTTPGet != nil:
+		info.Type = "HTTP"
+		info.Path = probe.HTTPGet.Path
+		info.Port = probe.HTTPGet.Port.String()
+		info.Scheme = string(probe.HTTPGet.Scheme)
+	case probe.TCPSocket != nil:
+		info.Type = "TCP"
+		info.Port = probe.TCPSocket.Port.String()
+	case probe.Exec != nil:
+		info.Type = "Exec"
+		info.Command = probe.Exec.Command
+	case probe.GRPC != nil:
+		info.Type = "gRPC"
+		info.Port = fmt.Sprintf("%d", probe.GRPC.Port)
+	default:
+		info.Type = "Unknown"
+	}
+
+	return info
+}
+
+// SortPods 依指定欄位排序 Pod 列表 (name, age, restartCount, status, node)，order 為 asc 或 desc
+func SortPods(pods []Pod, sortBy, order string) error {
+	if sortBy == "" {
+		return nil
+	}
+
+	var less func(a, b Pod) bool
+	switch sortBy {
+	case "name":
+		less = func(a, b Pod) bool {
+			if a.Namespace != b.Namespace {
+				return a.Namespace < b.Namespace
+			}
+			return a.Name < b.Name
+		}
+	case "age":
+		// 建立時間越早，存活時間越長
+		less = func(a, b Pod) bool { return a.CreatedAt.After(b.CreatedAt) }
+	case "restartCount":
+		less = func(a, b Pod) bool { return podRestartCount(a) < podRestartCount(b) }
+	case "status":
+		less = func(a, b Pod) bool { return a.Status < b.Status }
+	case "node":
+		less = func(a, b Pod) bool { return a.NodeName < b.NodeName }
+	default:
+		return fmt.Errorf("不支援的排序欄位 %q，可用欄位: name, age, restartCount, status, node", sortBy)
+	}
+
+	switch strings.ToLower(order) {
+	case "", "asc":
+	case "desc":
+		ascending := less
+		less = func(a, b Pod) bool { return ascending(b, a) }
+	default:
+		return fmt.Errorf("不支援的排序方向 %q，可用值: asc, desc", order)
+	}
+
+	sort.SliceStable(pods, func(i, j int) bool {
+		return less(pods[i], pods[j])
+	})
+
+	return nil
+}
+
+// AggregatePodsByLabel 依標籤鍵將 Pod 分組並統計數量、就緒比例、重啟次數與資源使用量
+func (s *Service) AggregatePodsByLabel(ctx context.Context, namespace, labelKey string) (*LabelAggregation, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	namespace = s.resolveListNamespace(namespace)
+
+	pods, err := s.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("無法取得 Pod 列表: %w", err)
+	}
+
+	// 一次取得命名空間內所有 Pod 的 metrics，避免逐一查詢
+	cpuUsage := make(map[string]int64)
+	memoryUsage := make(map[string]int64)
+	metricsAvailable := false
+	if s.metricsClientset != nil {
+		podMetrics, err := s.metricsClientset.MetricsV1beta1().PodMetricses(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			if s.logger != nil {
+				s.logger.Printf("警告: 無法取得 Pod metrics: %v", err)
+			}
+		} else {
+			metricsAvailable = true
+			for _, metrics := range podMetrics.Items {
+				key := metrics.Namespace + "/" + metrics.Name
+				for _, container := range metrics.Containers {
+					cpuUsage[key] += container.Usage.Cpu().MilliValue()
+					memoryUsage[key] += container.Usage.Memory().Value()
+				}
+			}
+		}
+	}
+
+	groups := make(map[string]*LabelGroup)
+	groupCPU := make(map[string]int64)
+	groupMemory := make(map[string]int64)
+
+	for _, pod := range pods.Items {
+		value, ok := pod.Labels[labelKey]
+		if !ok {
+			value = "<none>"
+		}
+
+		group, exists := groups[value]
+		if !exists {
+			group = &LabelGroup{Value: value}
+			groups[value] = group
+		}
+
+		convertedPod := s.convertPod(&pod)
+		group.PodCount++
+		if convertedPod.Ready {
+			group.ReadyCount++
+		}
+		group.TotalRestarts += podRestartCount(convertedPod)
+		group.Pods = append(group.Pods, pod.Name)
+
+		key := pod.Namespace + "/" + pod.Name
+		groupCPU[value] += cpuUsage[key]
+		groupMemory[value] += memoryUsage[key]
+	}
+
+	result := &LabelAggregation{
+		Namespace:        namespace,
+		LabelKey:         labelKey,
+		MetricsAvailable: metricsAvailable,
+	}
+	for value, group := range groups {
+		group.ReadyRatio = float64(group.ReadyCount) / float64(group.PodCount)
+		group.CPUUsage = fmt.Sprintf("%dm", groupCPU[value])
+		group.MemoryUsage = fmt.Sprintf("%dMi", groupMemory[value]/(1024*1024))
+		result.Groups = append(result.Groups, *group)
+	}
+
+	// 依 Pod 數量由多到少排序
+	sort.Slice(result.Groups, func(i, j int) bool {
+		if result.Groups[i].PodCount != result.Groups[j].PodCount {
+			return result.Groups[i].PodCount > result.Groups[j].PodCount
+		}
+		return result.Groups[i].Value < result.Groups[j].Value
+	})
+
+	return result, nil
+}
+
+// GetServicesForPod 取得選取指定 Pod 的 Service
+func (s *Service) GetServicesForPod(ctx context.Context, podName, namespace string) (*PodServices, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if namespace == "" {
+		namespace = s.defaultNamespace
+	}
+
+	pod, err := s.clientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("無法取得 Pod 資訊: %w", err)
+	}
+
+	services, err := s.clientset.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("無法取得 Service 列表: %w", err)
+	}
+
+	result := &PodServices{
+		PodName:   pod.Name,
+		Namespace: pod.Namespace,
+		Services:  []ServiceInfo{},
+	}
+
+	podLabels := labels.Set(pod.Labels)
+	for _, service := range services.Items {
+		// 沒有選擇器的 Service (例如 ExternalName 或手動管理 Endpoints) 不會選取任何 Pod
+		if len(service.Spec.Selector) == 0 {
+			continue
+		}
+		if !labels.SelectorFromSet(service.Spec.Selector).Matches(podLabels) {
+			continue
+		}
+
+		info := ServiceInfo{
+			Name:      service.Name,
+			Type:      string(service.Spec.Type),
+			ClusterIP: service.Spec.ClusterIP,
+			Selector:  service.Spec.Selector,
+		}
+		for _, port := range service.Spec.Ports {
+			info.Ports = append(info.Ports, ServicePort{
+				Name:       port.Name,
+				Protocol:   string(port.Protocol),
+				Port:       port.Port,
+				TargetPort: port.TargetPort.String(),
+				NodePort:   port.NodePort,
+			})
+		}
+		result.Services = append(result.Services, info)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,58 @@
+package gke
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/option"
+	storage "google.golang.org/api/storage/v1"
+)
+
+// newStorageService 使用服務帳戶凭证建立 Cloud Storage 客戶端
+func newStorageService(config ServiceConfig) (*storage.Service, error) {
+	credentialsBytes, err := os.ReadFile(config.CredentialsFile)
+	if err != nil {
+		return nil, fmt.Errorf("無法讀取凭证文件: %w", err)
+	}
+
+	googleCredentials, err := google.CredentialsFromJSON(context.Background(), credentialsBytes, storage.DevstorageReadWriteScope)
+	if err != nil {
+		return nil, fmt.Errorf("無法建立 Google 凭证: %w", err)
+	}
+
+	storageService, err := storage.NewService(context.Background(), option.WithCredentials(googleCredentials))
+	if err != nil {
+		return nil, fmt.Errorf("無法建立 Cloud Storage 客戶端: %w", err)
+	}
+
+	return storageService, nil
+}
+
+// ObjectStorageAvailable 是否可以上傳檔案到 Cloud Storage
+func (s *Service) ObjectStorageAvailable() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.storageService != nil
+}
+
+// UploadObject 將內容上傳到 Cloud Storage bucket 的 object，已存在時覆寫
+func (s *Service) UploadObject(ctx context.Context, bucket, object, contentType string, content []byte) error {
+	s.mu.RLock()
+	storageService := s.storageService
+	s.mu.RUnlock()
+	if storageService == nil {
+		return fmt.Errorf("Cloud Storage 不可用，需要使用 Google Cloud 服務帳戶凭证")
+	}
+
+	_, err := storageService.Objects.Insert(bucket, &storage.Object{Name: object, ContentType: contentType}).
+		Media(bytes.NewReader(content)).
+		Context(ctx).
+		Do()
+	if err != nil {
+		return fmt.Errorf("無法上傳 gs://%s/%s: %w", bucket, object, err)
+	}
+	return nil
+}
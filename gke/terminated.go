@@ -0,0 +1,168 @@
+package gke
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// podDeletionReasons 代表 Pod 被刪除或終止的事件原因與優先順序 (數字越大越能說明刪除原因)
+var podDeletionReasons = map[string]int{
+	"Evicted":              3,
+	"Preempted":            3,
+	"TaintManagerEviction": 3,
+	"SuccessfulDelete":     2,
+	"Killing":              1,
+}
+
+// GetTerminatedPods 取得已完成 (Succeeded) 與失敗 (Failed) 的 Pod，並可透過事件找出近期已刪除的 Pod 及刪除原因
+// since 為零值時不過濾時間
+func (s *Service) GetTerminatedPods(ctx context.Context, namespace string, since time.Time, includeDeleted bool) (*TerminatedPods, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	namespace = s.resolveListNamespace(namespace)
+
+	pods, err := s.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("無法取得 Pod 列表: %w", err)
+	}
+
+	result := &TerminatedPods{
+		Namespace: namespace,
+		Since:     since,
+		Succeeded: []Pod{},
+		Failed:    []Pod{},
+	}
+
+	existing := make(map[string]bool)
+	for _, pod := range pods.Items {
+		existing[pod.Namespace+"/"+pod.Name] = true
+
+		if pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed {
+			continue
+		}
+		if !since.IsZero() && podFinishedAt(&pod).Before(since) {
+			continue
+		}
+
+		if pod.Status.Phase == corev1.PodSucceeded {
+			result.Succeeded = append(result.Succeeded, s.convertPod(&pod))
+		} else {
+			result.Failed = append(result.Failed, s.convertPod(&pod))
+		}
+	}
+
+	if includeDeleted {
+		deleted, err := s.getDeletedPods(ctx, namespace, since, existing)
+		if err != nil {
+			if s.logger != nil {
+				s.logger.Printf("警告: 無法從事件取得已刪除的 Pod: %v", err)
+			}
+		}
+		result.Deleted = deleted
+	}
+
+	return result, nil
+}
+
+// podFinishedAt 取得 Pod 的結束時間 (容器最晚的終止時間)，無法判斷時使用建立時間
+func podFinishedAt(pod *corev1.Pod) time.Time {
+	var finishedAt time.Time
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.State.Terminated != nil && status.State.Terminated.FinishedAt.After(finishedAt) {
+			finishedAt = status.State.Terminated.FinishedAt.Time
+		}
+	}
+	if finishedAt.IsZero() {
+		return pod.CreationTimestamp.Time
+	}
+	return finishedAt
+}
+
+// getDeletedPods 從事件推斷近期刪除的 Pod：事件中出現但目前已不存在的 Pod
+func (s *Service) getDeletedPods(ctx context.Context, namespace string, since time.Time, existing map[string]bool) ([]DeletedPod, error) {
+	events, err := s.clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	deleted := make(map[string]*DeletedPod)
+	record := func(podNamespace, podName string, event corev1.Event) {
+		key := podNamespace + "/" + podName
+		if existing[key] {
+			return
+		}
+
+		timestamp := eventTimestamp(event)
+		current, ok := deleted[key]
+		if !ok {
+			deleted[key] = &DeletedPod{
+				Name:      podName,
+				Namespace: podNamespace,
+				Reason:    "Unknown",
+				Timestamp: timestamp,
+			}
+			current = deleted[key]
+		}
+
+		// 保留最能說明刪除原因的事件，優先順序相同時取較新的事件
+		priority, isDeletion := podDeletionReasons[event.Reason]
+		currentPriority := podDeletionReasons[current.Reason]
+		if isDeletion && (priority > currentPriority || (priority == currentPriority && timestamp.After(current.Timestamp))) {
+			current.Reason = event.Reason
+			current.Message = event.Message
+			current.Source = event.Source.Component
+			current.Timestamp = timestamp
+		} else if current.Reason == "Unknown" && !timestamp.Before(current.Timestamp) {
+			current.Message = event.Message
+			current.Source = event.Source.Component
+			current.Timestamp = timestamp
+		}
+	}
+
+	for _, event := range events.Items {
+		if !since.IsZero() && eventTimestamp(event).Before(since) {
+			continue
+		}
+
+		switch {
+		case event.InvolvedObject.Kind == "Pod":
+			record(event.InvolvedObject.Namespace, event.InvolvedObject.Name, event)
+		case event.Reason == "SuccessfulDelete":
+			// 控制器事件，例如 ReplicaSet 縮減副本: "Deleted pod: web-7d5b6c4f8d-abc123"
+			if podName, ok := strings.CutPrefix(event.Message, "Deleted pod: "); ok {
+				event.Message = fmt.Sprintf("%s %s 刪除: %s", event.InvolvedObject.Kind, event.InvolvedObject.Name, event.Message)
+				record(event.InvolvedObject.Namespace, strings.TrimSpace(podName), event)
+			}
+		}
+	}
+
+	var result []DeletedPod
+	for _, pod := range deleted {
+		result = append(result, *pod)
+	}
+
+	// 依時間由新到舊排序
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Timestamp.After(result[j].Timestamp)
+	})
+
+	return result, nil
+}
+
+// eventTimestamp 取得事件的最後發生時間
+func eventTimestamp(event corev1.Event) time.Time {
+	if !event.LastTimestamp.IsZero() {
+		return event.LastTimestamp.Time
+	}
+	if !event.EventTime.IsZero() {
+		return event.EventTime.Time
+	}
+	return event.FirstTimestamp.Time
+}
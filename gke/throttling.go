@@ -0,0 +1,220 @@
+package gke
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// throttlingQueryTimeout 查詢 CPU 節流指標的逾時時間
+	throttlingQueryTimeout = 10 * time.Second
+
+	// prometheusThrottlingWindow 從 Prometheus 查詢節流比例的時間範圍，取較長的範圍只反映持續的節流，不受短暫尖峰影響
+	prometheusThrottlingWindow = "1h"
+
+	// ThrottlingSourceCAdvisor 節流資料來自 kubelet 的 cAdvisor 指標 (容器啟動以來累計)
+	ThrottlingSourceCAdvisor = "cadvisor"
+
+	cadvisorPeriodsMetric          = "container_cpu_cfs_periods_total"
+	cadvisorThrottledPeriodsMetric = "container_cpu_cfs_throttled_periods_total"
+	cadvisorThrottledSecondsMetric = "container_cpu_cfs_throttled_seconds_total"
+)
+
+// getPodCPUThrottling 取得 Pod 各容器的 CPU 節流狀況，設定 Prometheus 時查詢最近 1 小時，否則讀取節點 cAdvisor 的累計值
+// 呼叫端需持有 s.mu 讀鎖
+func (s *Service) getPodCPUThrottling(ctx context.Context, pod *corev1.Pod) (map[string]*CPUThrottling, error) {
+	ctx, cancel := context.WithTimeout(ctx, throttlingQueryTimeout)
+	defer cancel()
+
+	if s.prometheus != nil {
+		return s.prometheusCPUThrottling(ctx, pod)
+	}
+	return s.cadvisorCPUThrottling(ctx, pod)
+}
+
+// prometheusCPUThrottling 從 Prometheus 查詢最近 1 小時被節流的 CFS 週期比例與節流秒數
+func (s *Service) prometheusCPUThrottling(ctx context.Context, pod *corev1.Pod) (map[string]*CPUThrottling, error) {
+	selector := fmt.Sprintf(`namespace=%q,pod=%q,container!="",container!="POD"`, pod.Namespace, pod.Name)
+
+	ratios, err := s.prometheus.query(ctx, fmt.Sprintf(`sum by (container) (rate(%s{%[2]s}[%[4]s])) / sum by (container) (rate(%[3]s{%[2]s}[%[4]s]))`, cadvisorThrottledPeriodsMetric, selector, cadvisorPeriodsMetric, prometheusThrottlingWindow), time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	seconds, err := s.prometheus.query(ctx, fmt.Sprintf(`sum by (container) (increase(%s{%s}[%s]))`, cadvisorThrottledSecondsMetric, selector, prometheusThrottlingWindow), time.Time{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]*CPUThrottling)
+	for _, series := range ratios {
+		if len(series.Points) == 0 {
+			continue
+		}
+		result[series.Labels["container"]] = &CPUThrottling{
+			Source:              UsageSourcePrometheus,
+			Window:              prometheusThrottlingWindow,
+			ThrottledPercentage: series.Points[len(series.Points)-1].Value * 100,
+		}
+	}
+	for _, series := range seconds {
+		if throttling, ok := result[series.Labels["container"]]; ok && len(series.Points) > 0 {
+			throttling.ThrottledSeconds = series.Points[len(series.Points)-1].Value
+		}
+	}
+
+	return result, nil
+}
+
+// cadvisorCPUThrottling 透過 API 伺服器的節點 proxy 讀取 kubelet cAdvisor 指標，取得容器啟動以來累計的節流狀況
+func (s *Service) cadvisorCPUThrottling(ctx context.Context, pod *corev1.Pod) (map[string]*CPUThrottling, error) {
+	if pod.Spec.NodeName == "" {
+		return nil, fmt.Errorf("Pod 尚未排程到節點")
+	}
+
+	stream, err := s.clientset.CoreV1().RESTClient().Get().
+		AbsPath("/api/v1/nodes", pod.Spec.NodeName, "proxy/metrics/cadvisor").
+		Stream(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("無法取得節點 %s 的 cAdvisor 指標: %w", pod.Spec.NodeName, err)
+	}
+	defer stream.Close()
+
+	// 節點指標包含所有 Pod，先以字串比對過濾再解析標籤
+	podLabel := fmt.Sprintf("pod=%q", pod.Name)
+	result := make(map[string]*CPUThrottling)
+
+	scanner := bufio.NewScanner(stream)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "container_cpu_cfs_") || !strings.Contains(line, podLabel) {
+			continue
+		}
+
+		name, labels, value, ok := parseMetricLine(line)
+		if !ok || labels["namespace"] != pod.Namespace || labels["pod"] != pod.Name {
+			continue
+		}
+		container := labels["container"]
+		if container == "" || container == "POD" {
+			continue
+		}
+
+		throttling, ok := result[container]
+		if !ok {
+			throttling = &CPUThrottling{Source: ThrottlingSourceCAdvisor, Window: "sinceContainerStart"}
+			result[container] = throttling
+		}
+		switch name {
+		case cadvisorPeriodsMetric:
+			throttling.Periods = int64(value)
+		case cadvisorThrottledPeriodsMetric:
+			throttling.ThrottledPeriods = int64(value)
+		case cadvisorThrottledSecondsMetric:
+			throttling.ThrottledSeconds = value
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("讀取 cAdvisor 指標失敗: %w", err)
+	}
+
+	for _, throttling := range result {
+		if throttling.Periods > 0 {
+			throttling.ThrottledPercentage = float64(throttling.ThrottledPeriods) / float64(throttling.Periods) * 100
+		}
+	}
+
+	return result, nil
+}
+
+// parseMetricLine 解析 Prometheus 文字格式的單行指標，例如 name{a="b"} 1.5 1700000000000
+func parseMetricLine(line string) (string, map[string]string, float64, bool) {
+	labels := make(map[string]string)
+
+	name := line
+	rest := ""
+	if i := strings.IndexByte(line, '{'); i >= 0 {
+		name = line[:i]
+		end, ok := parseMetricLabels(line[i+1:], labels)
+		if !ok {
+			return "", nil, 0, false
+		}
+		rest = line[i+1+end:]
+	} else if i := strings.IndexByte(line, ' '); i >= 0 {
+		name = line[:i]
+		rest = line[i:]
+	}
+
+	fields := strings.Fields(rest)
+	if len(fields) == 0 {
+		return "", nil, 0, false
+	}
+	value, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return "", nil, 0, false
+	}
+
+	return name, labels, value, true
+}
+
+// parseMetricLabels 解析大括號內的標籤，回傳右大括號之後的位置
+func parseMetricLabels(s string, labels map[string]string) (int, bool) {
+	i := 0
+	for i < len(s) {
+		switch s[i] {
+		case '}':
+			return i + 1, true
+		case ',', ' ':
+			i++
+			continue
+		}
+
+		eq := strings.IndexByte(s[i:], '=')
+		if eq < 0 || i+eq+1 >= len(s) || s[i+eq+1] != '"' {
+			return 0, false
+		}
+		key := s[i : i+eq]
+		i += eq + 2
+
+		var value strings.Builder
+		for ; i < len(s) && s[i] != '"'; i++ {
+			if s[i] == '\\' && i+1 < len(s) {
+				i++
+				switch s[i] {
+				case 'n':
+					value.WriteByte('\n')
+				default:
+					value.WriteByte(s[i])
+				}
+				continue
+			}
+			value.WriteByte(s[i])
+		}
+		if i >= len(s) {
+			return 0, false
+		}
+		labels[key] = value.String()
+		i++
+	}
+
+	return 0, false
+}
+
+// mostThrottled 取得節流比例最高的容器，作為 Pod 層級的節流狀況
+func mostThrottled(throttling map[string]*CPUThrottling) *CPUThrottling {
+	var worst *CPUThrottling
+	for container, item := range throttling {
+		if worst == nil || item.ThrottledPercentage > worst.ThrottledPercentage {
+			copied := *item
+			copied.Container = container
+			worst = &copied
+		}
+	}
+	return worst
+}
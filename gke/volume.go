@@ -0,0 +1,159 @@
+package gke
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetPodVolumes 取得 Pod 的卷定義、各容器的掛載路徑與唯讀設定，以及 PVC 的綁定狀態
+func (s *Service) GetPodVolumes(ctx context.Context, podName, namespace string) (*PodVolumes, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if namespace == "" {
+		namespace = s.defaultNamespace
+	}
+
+	pod, err := s.clientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("無法取得 Pod 資訊: %w", err)
+	}
+
+	result := &PodVolumes{
+		PodName:   pod.Name,
+		Namespace: pod.Namespace,
+		Volumes:   s.buildPodVolumes(pod),
+	}
+	if result.Volumes == nil {
+		result.Volumes = []PodVolume{}
+	}
+
+	for i := range result.Volumes {
+		volume := &result.Volumes[i]
+		if volume.Type != "PVC" {
+			continue
+		}
+
+		volume.Claim = s.getClaimInfo(ctx, volume.Source, namespace)
+		if volume.Claim.Error != "" {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("卷 %s: %s", volume.Name, volume.Claim.Error))
+		} else if volume.Claim.Phase != string(corev1.ClaimBound) {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("卷 %s 的 PVC %s 尚未綁定 (狀態: %s)", volume.Name, volume.Source, volume.Claim.Phase))
+		}
+	}
+
+	// 掛載了 Pod 未定義的卷 (通常不會通過 API 驗證，但仍提示)
+	defined := make(map[string]bool)
+	for _, volume := range pod.Spec.Volumes {
+		defined[volume.Name] = true
+	}
+	for _, container := range podContainers(pod) {
+		for _, mount := range container.VolumeMounts {
+			if !defined[mount.Name] {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("容器 %s 掛載了未定義的卷 %s", container.Name, mount.Name))
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// buildPodVolumes 依 Pod 規格建立卷定義與各容器的掛載資訊
+func (s *Service) buildPodVolumes(pod *corev1.Pod) []PodVolume {
+	var volumes []PodVolume
+	for _, volume := range pod.Spec.Volumes {
+		podVolume := PodVolume{
+			Name:   volume.Name,
+			Type:   s.getVolumeType(&volume),
+			Source: getVolumeSource(&volume),
+		}
+
+		switch {
+		case volume.PersistentVolumeClaim != nil:
+			podVolume.ReadOnly = volume.PersistentVolumeClaim.ReadOnly
+		case volume.EmptyDir != nil:
+			podVolume.Medium = string(volume.EmptyDir.Medium)
+		case volume.CSI != nil:
+			podVolume.ReadOnly = volume.CSI.ReadOnly != nil && *volume.CSI.ReadOnly
+		}
+
+		podVolume.Mounts = getVolumeMounts(pod, volume.Name)
+		volumes = append(volumes, podVolume)
+	}
+
+	return volumes
+}
+
+// getVolumeMounts 取得所有容器 (含初始化容器) 對指定卷的掛載
+func getVolumeMounts(pod *corev1.Pod, volumeName string) []VolumeMountRef {
+	var mounts []VolumeMountRef
+	for _, container := range podContainers(pod) {
+		for _, mount := range container.VolumeMounts {
+			if mount.Name != volumeName {
+				continue
+			}
+			mounts = append(mounts, VolumeMountRef{
+				Container: container.Name,
+				MountPath: mount.MountPath,
+				SubPath:   mount.SubPath,
+				ReadOnly:  mount.ReadOnly,
+			})
+		}
+	}
+	return mounts
+}
+
+// podContainers 取得 Pod 的初始化容器與一般容器規格
+func podContainers(pod *corev1.Pod) []corev1.Container {
+	containers := make([]corev1.Container, 0, len(pod.Spec.InitContainers)+len(pod.Spec.Containers))
+	containers = append(containers, pod.Spec.InitContainers...)
+	return append(containers, pod.Spec.Containers...)
+}
+
+// getVolumeSource 取得卷的來源名稱 (PVC、ConfigMap、Secret 等)
+func getVolumeSource(volume *corev1.Volume) string {
+	switch {
+	case volume.PersistentVolumeClaim != nil:
+		return volume.PersistentVolumeClaim.ClaimName
+	case volume.ConfigMap != nil:
+		return volume.ConfigMap.Name
+	case volume.Secret != nil:
+		return volume.Secret.SecretName
+	case volume.HostPath != nil:
+		return volume.HostPath.Path
+	case volume.CSI != nil:
+		return volume.CSI.Driver
+	default:
+		return ""
+	}
+}
+
+// getClaimInfo 取得 PVC 的綁定狀態、儲存類別與容量
+func (s *Service) getClaimInfo(ctx context.Context, claimName, namespace string) *ClaimInfo {
+	claim, err := s.clientset.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, claimName, metav1.GetOptions{})
+	if err != nil {
+		return &ClaimInfo{Error: fmt.Sprintf("無法取得 PVC %s: %v", claimName, err)}
+	}
+
+	info := &ClaimInfo{
+		Phase:      string(claim.Status.Phase),
+		VolumeName: claim.Spec.VolumeName,
+	}
+	if claim.Spec.StorageClassName != nil {
+		info.StorageClass = *claim.Spec.StorageClassName
+	}
+	if capacity, ok := claim.Status.Capacity[corev1.ResourceStorage]; ok {
+		info.Capacity = capacity.String()
+	}
+	if requested, ok := claim.Spec.Resources.Requests[corev1.ResourceStorage]; ok {
+		info.Requested = requested.String()
+	}
+	for _, mode := range claim.Spec.AccessModes {
+		info.AccessModes = append(info.AccessModes, string(mode))
+	}
+
+	return info
+}
@@ -0,0 +1,421 @@
+package gke
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// restartedAtAnnotation kubectl rollout restart 使用的註解，變更後會觸發滾動更新
+	restartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+	// revisionAnnotation Deployment 控制器記錄在 ReplicaSet 上的版本號
+	revisionAnnotation = "deployment.kubernetes.io/revision"
+
+	// changeCauseAnnotation 記錄變更原因的註解
+	changeCauseAnnotation = "kubernetes.io/change-cause"
+)
+
+// ErrWriteDisabled 寫入模式未啟用
+var ErrWriteDisabled = errors.New("寫入模式未啟用，請在 config.json 中設定 \"write\": {\"enabled\": true}")
+
+// ensureWriteEnabled 檢查是否允許寫入操作
+func (s *Service) ensureWriteEnabled() error {
+	if !s.config.WriteEnabled {
+		return ErrWriteDisabled
+	}
+	return nil
+}
+
+// auditWrite 記錄寫入操作
+func (s *Service) auditWrite(format string, v ...interface{}) {
+	if s.logger != nil {
+		s.logger.Printf("寫入操作: "+format, v...)
+	}
+}
+
+// normalizeWorkloadKind 正規化工作負載類型名稱
+func normalizeWorkloadKind(kind string) (string, error) {
+	switch strings.ToLower(kind) {
+	case "deployment", "deploy":
+		return "Deployment", nil
+	case "statefulset", "sts":
+		return "StatefulSet", nil
+	case "daemonset", "ds":
+		return "DaemonSet", nil
+	default:
+		return "", fmt.Errorf("不支援的工作負載類型 %q，可用值: Deployment, StatefulSet, DaemonSet", kind)
+	}
+}
+
+// RestartWorkload 以更新 restartedAt 註解的方式滾動重啟工作負載 (等同 kubectl rollout restart)
+func (s *Service) RestartWorkload(ctx context.Context, kind, name, namespace string) (*WorkloadRestart, error) {
+	if err := s.ensureWriteEnabled(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	kind, err := normalizeWorkloadKind(kind)
+	if err != nil {
+		return nil, err
+	}
+	if namespace == "" {
+		namespace = s.defaultNamespace
+	}
+
+	now := time.Now()
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"annotations": map[string]string{
+						restartedAtAnnotation: now.Format(time.RFC3339),
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("無法建立 patch: %w", err)
+	}
+
+	appsClient := s.clientset.AppsV1()
+	switch kind {
+	case "Deployment":
+		_, err = appsClient.Deployments(namespace).Patch(ctx, name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	case "StatefulSet":
+		_, err = appsClient.StatefulSets(namespace).Patch(ctx, name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	case "DaemonSet":
+		_, err = appsClient.DaemonSets(namespace).Patch(ctx, name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("無法重啟 %s %s: %w", kind, name, err)
+	}
+
+	s.auditWrite("重啟 %s %s/%s", kind, namespace, name)
+
+	return &WorkloadRestart{
+		Kind:        kind,
+		Name:        name,
+		Namespace:   namespace,
+		RestartedAt: now,
+		Message:     fmt.Sprintf("已觸發 %s %s 的滾動重啟", kind, name),
+	}, nil
+}
+
+// ScaleWorkload 設定 Deployment/StatefulSet 的副本數，副本數必須在配置的上下限之內
+func (s *Service) ScaleWorkload(ctx context.Context, kind, name, namespace string, replicas int32) (*WorkloadScale, error) {
+	if err := s.ensureWriteEnabled(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	kind, err := normalizeWorkloadKind(kind)
+	if err != nil {
+		return nil, err
+	}
+	if kind == "DaemonSet" {
+		return nil, fmt.Errorf("DaemonSet 的副本數由節點數量決定，無法擴縮")
+	}
+	if namespace == "" {
+		namespace = s.defaultNamespace
+	}
+
+	if replicas < 0 {
+		return nil, fmt.Errorf("副本數不可為負數")
+	}
+	if replicas < s.config.MinReplicas {
+		return nil, fmt.Errorf("副本數 %d 低於允許的最小值 %d (可在 config.json 的 write.minReplicas 調整)", replicas, s.config.MinReplicas)
+	}
+	if s.config.MaxReplicas > 0 && replicas > s.config.MaxReplicas {
+		return nil, fmt.Errorf("副本數 %d 超過允許的最大值 %d (可在 config.json 的 write.maxReplicas 調整)", replicas, s.config.MaxReplicas)
+	}
+
+	appsClient := s.clientset.AppsV1()
+	var scale *autoscalingv1.Scale
+	switch kind {
+	case "Deployment":
+		scale, err = appsClient.Deployments(namespace).GetScale(ctx, name, metav1.GetOptions{})
+	case "StatefulSet":
+		scale, err = appsClient.StatefulSets(namespace).GetScale(ctx, name, metav1.GetOptions{})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("無法取得 %s %s 的副本數: %w", kind, name, err)
+	}
+
+	previous := scale.Spec.Replicas
+	scale.Spec.Replicas = replicas
+
+	switch kind {
+	case "Deployment":
+		_, err = appsClient.Deployments(namespace).UpdateScale(ctx, name, scale, metav1.UpdateOptions{})
+	case "StatefulSet":
+		_, err = appsClient.StatefulSets(namespace).UpdateScale(ctx, name, scale, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("無法擴縮 %s %s: %w", kind, name, err)
+	}
+
+	s.auditWrite("擴縮 %s %s/%s: %d -> %d", kind, namespace, name, previous, replicas)
+
+	return &WorkloadScale{
+		Kind:             kind,
+		Name:             name,
+		Namespace:        namespace,
+		PreviousReplicas: previous,
+		Replicas:         replicas,
+		Message:          fmt.Sprintf("已將 %s %s 的副本數從 %d 調整為 %d", kind, name, previous, replicas),
+	}, nil
+}
+
+// GetRolloutHistory 取得 Deployment 的版本歷史 (由其擁有的 ReplicaSet 組成)
+func (s *Service) GetRolloutHistory(ctx context.Context, name, namespace string) (*RolloutHistory, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if namespace == "" {
+		namespace = s.defaultNamespace
+	}
+
+	deployment, replicaSets, err := s.getDeploymentReplicaSets(ctx, name, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	history := &RolloutHistory{
+		Name:            deployment.Name,
+		Namespace:       deployment.Namespace,
+		CurrentRevision: parseRevision(deployment.Annotations),
+		Revisions:       []RolloutRevision{},
+	}
+
+	for _, rs := range replicaSets {
+		revision := parseRevision(rs.Annotations)
+		history.Revisions = append(history.Revisions, RolloutRevision{
+			Revision:      revision,
+			ReplicaSet:    rs.Name,
+			CreatedAt:     rs.CreationTimestamp.Time,
+			ChangeCause:   rs.Annotations[changeCauseAnnotation],
+			Images:        templateImages(rs.Spec.Template.Spec),
+			Replicas:      rs.Status.Replicas,
+			ReadyReplicas: rs.Status.ReadyReplicas,
+			Current:       revision == history.CurrentRevision,
+		})
+	}
+
+	sort.Slice(history.Revisions, func(i, j int) bool {
+		return history.Revisions[i].Revision > history.Revisions[j].Revision
+	})
+
+	return history, nil
+}
+
+// RollbackDeployment 將 Deployment 回滾到指定版本 (等同 kubectl rollout undo)，toRevision 為 0 時回滾到上一個版本
+func (s *Service) RollbackDeployment(ctx context.Context, name, namespace string, toRevision int64) (*RolloutUndo, error) {
+	if err := s.ensureWriteEnabled(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if namespace == "" {
+		namespace = s.defaultNamespace
+	}
+
+	deployment, replicaSets, err := s.getDeploymentReplicaSets(ctx, name, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if deployment.Spec.Paused {
+		return nil, fmt.Errorf("Deployment %s 已暫停，請先恢復後再回滾", name)
+	}
+
+	currentRevision := parseRevision(deployment.Annotations)
+
+	// 找出目標版本：指定版本或目前版本之前最新的版本
+	var target *appsv1.ReplicaSet
+	var previousRevision int64
+	for i := range replicaSets {
+		revision := parseRevision(replicaSets[i].Annotations)
+		if toRevision > 0 {
+			if revision == toRevision {
+				target = &replicaSets[i]
+			}
+			continue
+		}
+		if revision < currentRevision && revision > previousRevision {
+			previousRevision = revision
+			target = &replicaSets[i]
+		}
+	}
+	if target == nil {
+		if toRevision > 0 {
+			return nil, fmt.Errorf("找不到 Deployment %s 的版本 %d", name, toRevision)
+		}
+		return nil, fmt.Errorf("Deployment %s 沒有可回滾的上一個版本", name)
+	}
+
+	targetRevision := parseRevision(target.Annotations)
+	if targetRevision == currentRevision {
+		return nil, fmt.Errorf("版本 %d 已是目前的版本", targetRevision)
+	}
+
+	// 使用 ReplicaSet 的 Pod 範本，並移除控制器自動加入的 pod-template-hash 標籤
+	template := target.Spec.Template.DeepCopy()
+	delete(template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+
+	patch, err := json.Marshal([]map[string]interface{}{
+		// 以 resourceVersion 作為前置條件，避免覆寫回滾期間的其他變更
+		{"op": "test", "path": "/metadata/resourceVersion", "value": deployment.ResourceVersion},
+		{"op": "replace", "path": "/spec/template", "value": template},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("無法建立 patch: %w", err)
+	}
+
+	_, err = s.clientset.AppsV1().Deployments(namespace).Patch(ctx, name, types.JSONPatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("無法回滾 Deployment %s: %w", name, err)
+	}
+
+	s.auditWrite("回滾 Deployment %s/%s: 版本 %d -> %d", namespace, name, currentRevision, targetRevision)
+
+	return &RolloutUndo{
+		Name:         name,
+		Namespace:    namespace,
+		FromRevision: currentRevision,
+		ToRevision:   targetRevision,
+		Images:       templateImages(template.Spec),
+		Message:      fmt.Sprintf("已將 Deployment %s 從版本 %d 回滾到版本 %d 的 Pod 範本", name, currentRevision, targetRevision),
+	}, nil
+}
+
+// getDeploymentReplicaSets 取得 Deployment 與其擁有的 ReplicaSet
+func (s *Service) getDeploymentReplicaSets(ctx context.Context, name, namespace string) (*appsv1.Deployment, []appsv1.ReplicaSet, error) {
+	deployment, err := s.clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("無法取得 Deployment 資訊: %w", err)
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return nil, nil, fmt.Errorf("無效的 Deployment 選擇器: %w", err)
+	}
+
+	replicaSets, err := s.clientset.AppsV1().ReplicaSets(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: selector.String(),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("無法取得 ReplicaSet 列表: %w", err)
+	}
+
+	var owned []appsv1.ReplicaSet
+	for _, rs := range replicaSets.Items {
+		if controller := metav1.GetControllerOf(&rs); controller != nil && controller.UID == deployment.UID {
+			owned = append(owned, rs)
+		}
+	}
+
+	return deployment, owned, nil
+}
+
+// parseRevision 從註解中取得 Deployment 版本號，無法解析時回傳 0
+func parseRevision(annotations map[string]string) int64 {
+	revision, err := strconv.ParseInt(annotations[revisionAnnotation], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return revision
+}
+
+// templateImages 取得 Pod 範本中所有容器的映像檔
+func templateImages(spec corev1.PodSpec) []string {
+	var images []string
+	for _, container := range spec.Containers {
+		images = append(images, container.Image)
+	}
+	return images
+}
+
+// getWorkloadPods 取得工作負載目前管理的 Pod (以工作負載的標籤選擇器比對)
+func (s *Service) getWorkloadPods(ctx context.Context, kind, name, namespace string) ([]corev1.Pod, error) {
+	var selector *metav1.LabelSelector
+	switch kind {
+	case "Deployment":
+		deployment, err := s.clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("無法取得 Deployment %s: %w", name, err)
+		}
+		selector = deployment.Spec.Selector
+	case "StatefulSet":
+		statefulSet, err := s.clientset.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("無法取得 StatefulSet %s: %w", name, err)
+		}
+		selector = statefulSet.Spec.Selector
+	case "DaemonSet":
+		daemonSet, err := s.clientset.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("無法取得 DaemonSet %s: %w", name, err)
+		}
+		selector = daemonSet.Spec.Selector
+	default:
+		return nil, fmt.Errorf("不支援的工作負載類型 %q", kind)
+	}
+
+	labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, fmt.Errorf("無效的標籤選擇器: %w", err)
+	}
+
+	pods, err := s.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector.String()})
+	if err != nil {
+		return nil, fmt.Errorf("無法取得 Pod 列表: %w", err)
+	}
+	return pods.Items, nil
+}
+
+// workloadFromOwner 依控制器擁有者推斷工作負載；由 Deployment 建立的 ReplicaSet 以 pod-template-hash 還原 Deployment 名稱
+func workloadFromOwner(kind, name, templateHash string) (string, string) {
+	if kind == "ReplicaSet" && templateHash != "" && strings.HasSuffix(name, "-"+templateHash) {
+		return "Deployment", strings.TrimSuffix(name, "-"+templateHash)
+	}
+	return kind, name
+}
+
+// podWorkload 取得 Pod 所屬的工作負載類型與名稱，沒有控制器時回傳 Pod 本身
+func podWorkload(pod *corev1.Pod) (string, string) {
+	for _, owner := range pod.OwnerReferences {
+		if owner.Controller != nil && *owner.Controller {
+			return workloadFromOwner(owner.Kind, owner.Name, pod.Labels[appsv1.DefaultDeploymentUniqueLabelKey])
+		}
+	}
+	return "Pod", pod.Name
+}
+
+// PodWorkload 取得 Pod 所屬的工作負載類型與名稱，沒有控制器時回傳 Pod 本身
+func PodWorkload(pod Pod) (string, string) {
+	for _, owner := range pod.OwnerReferences {
+		if owner.Controller {
+			return workloadFromOwner(owner.Kind, owner.Name, pod.Labels[appsv1.DefaultDeploymentUniqueLabelKey])
+		}
+	}
+	return "Pod", pod.Name
+}
@@ -50,6 +50,28 @@ func (l *Logger) LogServerError(err error) {
 	l.Printf("伺服器錯誤: %v\n", err)
 }
 
+// clientKey 通過驗證的用戶端名稱在 context 中的鍵
+type clientKey struct{}
+
+// WithClient 在 context 中記錄通過驗證的用戶端名稱，請求的日誌會標示用戶端
+func WithClient(ctx context.Context, client string) context.Context {
+	return context.WithValue(ctx, clientKey{}, client)
+}
+
+// ClientFromContext 取得通過驗證的用戶端名稱，stdio 模式或未啟用驗證時為空字串
+func ClientFromContext(ctx context.Context) string {
+	client, _ := ctx.Value(clientKey{}).(string)
+	return client
+}
+
+// clientLabel 日誌中標示用戶端的文字，沒有用戶端名稱時為空字串
+func clientLabel(ctx context.Context) string {
+	if client := ClientFromContext(ctx); client != "" {
+		return " 用戶端:" + client
+	}
+	return ""
+}
+
 // 設定 req/res 的 logging Hooks
 func (l *Logger) ConfigureLoggingHooks() *server.Hooks {
 	hooks := &server.Hooks{}
@@ -57,18 +79,18 @@ func (l *Logger) ConfigureLoggingHooks() *server.Hooks {
 	// 請求
 	hooks.AddBeforeAny(func(ctx context.Context, id any, method mcp.MCPMethod, message any) {
 		reqJSON, _ := json.MarshalIndent(message, "", "  ")
-		l.Printf("收到請求 [%s] ID:%v\n請求內容: %s\n", method, id, string(reqJSON))
+		l.Printf("收到請求 [%s] ID:%v%s\n請求內容: %s\n", method, id, clientLabel(ctx), string(reqJSON))
 	})
 
 	// 成功的回應
 	hooks.AddOnSuccess(func(ctx context.Context, id any, method mcp.MCPMethod, message any, result any) {
 		resJSON, _ := json.MarshalIndent(result, "", "  ")
-		l.Printf("回應請求 [%s] ID:%v\n回應內容: %s\n", method, id, string(resJSON))
+		l.Printf("回應請求 [%s] ID:%v%s\n回應內容: %s\n", method, id, clientLabel(ctx), string(resJSON))
 	})
 
 	// 錯誤的回應
 	hooks.AddOnError(func(ctx context.Context, id any, method mcp.MCPMethod, message any, err error) {
-		l.Printf("請求錯誤 [%s] ID:%v\n錯誤訊息: %v\n", method, id, err)
+		l.Printf("請求錯誤 [%s] ID:%v%s\n錯誤訊息: %v\n", method, id, clientLabel(ctx), err)
 	})
 
 	return hooks
@@ -1,8 +1,13 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"slices"
+	"sort"
+	"strings"
+	"time"
 
 	"mcp-gke-monitor/config"
 	"mcp-gke-monitor/gke"
@@ -62,13 +67,20 @@ func main() {
 	if appConfig.Credentials != nil {
 		// 使用 Google Cloud 凭证創建 GKE 服務
 		gkeConfig := gke.ServiceConfig{
-			UseCredentials:   true,
-			CredentialsFile:  appConfig.GKE.CredentialsFile,
-			ProjectID:        appConfig.Credentials.ProjectID,
-			ClusterName:      appConfig.Credentials.GkeClusterName,
-			Location:         appConfig.Credentials.GkeLocation,
-			DefaultNamespace: appConfig.GKE.Namespace,
-			Logger:           appLogger,
+			UseCredentials:          true,
+			CredentialsFile:         appConfig.GKE.CredentialsFile,
+			ProjectID:               appConfig.Credentials.ProjectID,
+			ClusterName:             appConfig.Credentials.GkeClusterName,
+			Location:                appConfig.Credentials.GkeLocation,
+			DefaultNamespace:        appConfig.GKE.Namespace,
+			WriteEnabled:            appConfig.Write.Enabled,
+			MinReplicas:             appConfig.Write.MinReplicas,
+			MaxReplicas:             appConfig.Write.MaxReplicas,
+			ExecAllowlist:           appConfig.Exec.AllowedCommands,
+			PrometheusURL:           appConfig.Prometheus.URL,
+			PrometheusBearerToken:   appConfig.Prometheus.BearerToken,
+			PrometheusUseGoogleAuth: appConfig.Prometheus.UseGoogleCredentials,
+			Logger:                  appLogger,
 		}
 
 		gkeService, err = gke.NewServiceWithConfig(gkeConfig)
@@ -83,7 +95,13 @@ func main() {
 	} else {
 		// 使用傳統的 kubeconfig 方式
 		defaultConfig := gke.ServiceConfig{
-			Logger: appLogger,
+			WriteEnabled:          appConfig.Write.Enabled,
+			MinReplicas:           appConfig.Write.MinReplicas,
+			MaxReplicas:           appConfig.Write.MaxReplicas,
+			ExecAllowlist:         appConfig.Exec.AllowedCommands,
+			PrometheusURL:         appConfig.Prometheus.URL,
+			PrometheusBearerToken: appConfig.Prometheus.BearerToken,
+			Logger:                appLogger,
 		}
 		gkeService, err = gke.NewServiceWithConfig(defaultConfig)
 		if err != nil {
@@ -95,16 +113,116 @@ func main() {
 		appLogger.Println("使用傳統 kubeconfig 連接到 GKE")
 	}
 
+	if appConfig.Write.Enabled {
+		if !isStdioMode {
+			fmt.Println("警告: 已啟用寫入模式，工具可以變更叢集狀態")
+		}
+		appLogger.Println("警告: 已啟用寫入模式，工具可以變更叢集狀態")
+	}
+
+	if appConfig.Metrics.Enabled {
+		collectorConfig := gke.MetricsCollectorConfig{
+			Interval:  time.Duration(appConfig.Metrics.IntervalSeconds) * time.Second,
+			Retention: time.Duration(appConfig.Metrics.RetentionHours) * time.Hour,
+			StorePath: appConfig.Metrics.StorePath,
+		}
+		if err := gkeService.StartMetricsCollector(context.Background(), collectorConfig); err != nil {
+			if !isStdioMode {
+				fmt.Printf("警告: 啟動指標收集失敗: %v\n", err)
+			}
+			appLogger.Printf("警告: 啟動指標收集失敗: %v", err)
+		} else {
+			appLogger.Printf("已啟動指標收集，取樣間隔: %s，保存時間: %s", collectorConfig.Interval, collectorConfig.Retention)
+		}
+	}
+
 	gkeHandler := gke.NewHandler(gkeService)
 
 	//-----------------------------------------------------------------
 	// 優化服務
 	//-----------------------------------------------------------------
-	optimizationService, err := optimization.NewServiceWithLogger(gkeService, appLogger)
+	metricsProvider, err := gkeService.NewMetricsProvider(appConfig.Metrics.Provider)
+	if err != nil {
+		log.Fatalf("初始化使用量資料來源失敗: %v", err)
+	}
+	appLogger.Printf("優化分析使用量資料來源: %s", metricsProvider.Name())
+
+	optimizationService, err := optimization.NewServiceWithProvider(gkeService, metricsProvider, appLogger)
 	if err != nil {
 		log.Fatalf("初始化優化服務失敗: %v", err)
 	}
 
+	if err := optimizationService.SetExcludeSelectors(appConfig.Optimization.ExcludeSelectors); err != nil {
+		log.Fatalf("初始化優化服務失敗: %v", err)
+	}
+	if err := optimizationService.SetCriteriaPath(appConfig.Optimization.CriteriaPath); err != nil {
+		log.Fatalf("初始化優化服務失敗: %v", err)
+	}
+	if err := optimizationService.SetSnoozePath(appConfig.Optimization.SnoozePath); err != nil {
+		log.Fatalf("初始化優化服務失敗: %v", err)
+	}
+	optimizationService.SetAnalysisWorkers(appConfig.Optimization.AnalysisWorkers)
+	optimizationService.SetProductionNamespaces(appConfig.Optimization.ProductionNamespaces)
+	optimizationService.SetAllowedRegistries(appConfig.Optimization.AllowedRegistries)
+	optimizationService.SetRequiredLabels(appConfig.Optimization.RequiredLabels)
+	optimizationService.SetIdleNamespaceDays(appConfig.Optimization.IdleNamespaceDays)
+	optimizationService.SetReportHistoryDir(appConfig.Optimization.ReportHistoryDir)
+	optimizationService.SetExportDir(appConfig.Optimization.ExportDir)
+	if err := optimizationService.SetSLOTiers(sloTiers(appConfig.Optimization.SLOTiers)); err != nil {
+		log.Fatalf("初始化優化服務失敗: %v", err)
+	}
+	if err := optimizationService.SetSeverityOverrides(severityOverrides(appConfig.Optimization.SeverityOverrides)); err != nil {
+		log.Fatalf("初始化優化服務失敗: %v", err)
+	}
+	if err := optimizationService.SetReportBucket(appConfig.Optimization.ReportBucket); err != nil {
+		log.Fatalf("初始化優化服務失敗: %v", err)
+	}
+	if appConfig.Optimization.ReportBucket != "" && !gkeService.ObjectStorageAvailable() {
+		appLogger.Printf("警告: 已設定 optimization.reportBucket，但 Cloud Storage 需要使用 Google Cloud 服務帳戶凭证，報告不會上傳")
+	}
+	optimizationService.SetCostModel(optimization.CostModel{
+		Currency:        appConfig.Optimization.Pricing.Currency,
+		CPUCoreHour:     appConfig.Optimization.Pricing.CPUCoreHour,
+		MemoryGiBHour:   appConfig.Optimization.Pricing.MemoryGiBHour,
+		StorageGiBMonth: appConfig.Optimization.Pricing.StorageGiBMonth,
+
+		AutopilotCPUCoreHour:    appConfig.Optimization.Pricing.AutopilotCPUCoreHour,
+		AutopilotMemoryGiBHour:  appConfig.Optimization.Pricing.AutopilotMemoryGiBHour,
+		AutopilotStorageGiBHour: appConfig.Optimization.Pricing.AutopilotStorageGiBHour,
+	})
+
+	if appConfig.Credentials != nil && appConfig.Credentials.GkeClusterName != "" {
+		optimizationService.SetClusterName(appConfig.Credentials.GkeClusterName)
+	} else {
+		optimizationService.SetClusterName(appConfig.GKE.ClusterName)
+	}
+	for _, profile := range appConfig.Clusters {
+		clusterService, err := newClusterOptimizationService(profile, appLogger)
+		if err == nil {
+			err = optimizationService.AddCluster(profile.Name, clusterService)
+		}
+		if err != nil {
+			if !isStdioMode {
+				fmt.Printf("警告: 無法連接叢集 %s，跨叢集比較將略過此叢集: %v\n", profile.Name, err)
+			}
+			appLogger.Printf("警告: 無法連接叢集 %s，跨叢集比較將略過此叢集: %v", profile.Name, err)
+			continue
+		}
+		appLogger.Printf("已加入跨叢集比較的叢集: %s", profile.Name)
+	}
+
+	if err := optimizationService.SetNotifier(optimization.NotifierConfig{
+		WebhookURL: appConfig.Notification.WebhookURL,
+		Format:     appConfig.Notification.Format,
+		Interval:   time.Duration(appConfig.Notification.IntervalHours) * time.Hour,
+		Namespaces: appConfig.Notification.Namespaces,
+	}); err != nil {
+		log.Fatalf("初始化優化服務失敗: %v", err)
+	}
+	if optimizationService.StartNotificationSchedule(context.Background()) {
+		appLogger.Printf("已啟動定期報告通知，間隔: %d 小時", appConfig.Notification.IntervalHours)
+	}
+
 	optimizationHandler := optimization.NewHandler(optimizationService)
 
 	//-----------------------------------------------------------------
@@ -113,9 +231,10 @@ func main() {
 
 	// 初始化 MCP 伺服器
 	mcpServer := server.NewMCPServer(server.MCPConfig{
-		Name:    "mcp-gke-monitor",
-		Version: "0.0.1",
-		Logger:  appLogger,
+		Name:     "mcp-gke-monitor",
+		Version:  "0.0.1",
+		Logger:   appLogger,
+		Timeouts: appConfig.Timeouts,
 	})
 
 	// 註冊工具
@@ -124,6 +243,15 @@ func main() {
 	// 註冊資源
 	server.RegisterResources(mcpServer)
 
+	// 註冊提示
+	registeredPrompts := server.RegisterPrompts(mcpServer)
+
+	for tool := range appConfig.Timeouts.Tools {
+		if !slices.Contains(registeredTools, tool) {
+			appLogger.Printf("警告: timeouts.tools 中的工具 %s 不存在", tool)
+		}
+	}
+
 	if !isStdioMode {
 		fmt.Println("MCP 伺服器初始化完成")
 		// 顯示已註冊的工具列表
@@ -131,6 +259,7 @@ func main() {
 		for i, toolName := range registeredTools {
 			fmt.Printf("  %d. %s\n", i+1, toolName)
 		}
+		fmt.Printf("已註冊 %d 個提示: %s\n", len(registeredPrompts), strings.Join(registeredPrompts, ", "))
 	}
 
 	// 記錄到日誌文件
@@ -139,9 +268,75 @@ func main() {
 	for i, toolName := range registeredTools {
 		appLogger.Printf("  %d. %s", i+1, toolName)
 	}
+	appLogger.Printf("已註冊 %d 個提示: %s", len(registeredPrompts), strings.Join(registeredPrompts, ", "))
 
 	// 啟動伺服器 (根據組態決定啟動模式)
 	if err := server.StartServer(mcpServer, appConfig, appLogger); err != nil {
 		log.Fatalf("伺服器錯誤: %v", err)
 	}
 }
+
+// newClusterOptimizationService 依叢集連線設定建立跨叢集比較用的優化服務，其他叢集只做唯讀的分析
+func newClusterOptimizationService(profile config.ClusterProfile, appLogger *logger.Logger) (*optimization.Service, error) {
+	serviceConfig := gke.ServiceConfig{
+		KubeConfigPath: profile.KubeConfigPath,
+		KubeContext:    profile.Context,
+		Logger:         appLogger,
+	}
+	if profile.CredentialsFile != "" {
+		credentials, err := config.LoadGkeCredentials(profile.CredentialsFile)
+		if err != nil {
+			return nil, err
+		}
+		serviceConfig.UseCredentials = true
+		serviceConfig.CredentialsFile = profile.CredentialsFile
+		serviceConfig.ProjectID = valueOrDefault(profile.ProjectID, credentials.ProjectID)
+		serviceConfig.ClusterName = valueOrDefault(profile.GkeClusterName, credentials.GkeClusterName)
+		serviceConfig.Location = valueOrDefault(profile.Location, credentials.GkeLocation)
+	}
+
+	gkeService, err := gke.NewServiceWithConfig(serviceConfig)
+	if err != nil {
+		return nil, fmt.Errorf("初始化 GKE 服務失敗: %w", err)
+	}
+	return optimization.NewServiceWithLogger(gkeService, appLogger)
+}
+
+// sloTiers 將設定檔的可用性等級轉換為優化服務的設定，依名稱排序
+func sloTiers(tiers map[string]config.SLOTierConfig) []optimization.SLOTier {
+	result := make([]optimization.SLOTier, 0, len(tiers))
+	for name, tier := range tiers {
+		result = append(result, optimization.SLOTier{
+			Name:           name,
+			Namespaces:     tier.Namespaces,
+			HeadroomFactor: tier.HeadroomFactor,
+			RequestBasis:   tier.RequestBasis,
+			MinReplicas:    tier.MinReplicas,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// severityOverrides 將設定檔的嚴重程度覆寫轉換為優化服務的設定
+func severityOverrides(overrides []config.SeverityOverrideConfig) []optimization.SeverityOverride {
+	result := make([]optimization.SeverityOverride, 0, len(overrides))
+	for _, override := range overrides {
+		result = append(result, optimization.SeverityOverride{
+			Issue:      override.Issue,
+			Severity:   optimization.Priority(override.Severity),
+			Namespaces: override.Namespaces,
+		})
+	}
+	return result
+}
+
+// valueOrDefault value 為空字串時回傳 fallback
+func valueOrDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
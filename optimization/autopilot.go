@@ -0,0 +1,281 @@
+package optimization
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"mcp-gke-monitor/gke"
+)
+
+// Autopilot 一般用途 (general-purpose) compute class 的資源規則；較新的 GKE 版本上限可能較高，以 GKE 文件為準
+const (
+	autopilotMinCPU    = 50       // 每個 Pod 最少的 CPU (millicores)
+	autopilotMinMemory = 52 << 20 // 每個 Pod 最少的記憶體 (bytes)
+	autopilotMaxCPU    = 28000    // 每個 Pod 最多的 CPU (millicores)
+	autopilotMaxMemory = 80 << 30 // 每個 Pod 最多的記憶體 (bytes)
+
+	autopilotMaxEphemeralStorage = 10 << 30 // 每個 Pod 最多的 ephemeral storage (bytes)
+
+	// 容器未設定 requests 時 Autopilot 套用的預設值
+	autopilotDefaultCPU              = 500
+	autopilotDefaultMemory           = 2 << 30
+	autopilotDefaultEphemeralStorage = 1 << 30
+
+	// 每 vCPU 的記憶體 (GiB) 必須介於此範圍，Autopilot 會調高較少的一方
+	autopilotMinMemoryPerCPU = 1.0
+	autopilotMaxMemoryPerCPU = 6.5
+
+	// Autopilot 允許的 hostPath：只能以唯讀掛載 /var/log 下的路徑
+	autopilotHostPathPrefix = "/var/log"
+)
+
+// autopilotCapabilities Autopilot 允許容器加入的 Linux capabilities
+var autopilotCapabilities = map[string]bool{
+	"AUDIT_WRITE": true, "CHOWN": true, "DAC_OVERRIDE": true, "FOWNER": true, "FSETID": true,
+	"KILL": true, "MKNOD": true, "NET_BIND_SERVICE": true, "NET_RAW": true, "SETFCAP": true,
+	"SETGID": true, "SETPCAP": true, "SETUID": true, "SYS_CHROOT": true, "SYS_PTRACE": true,
+}
+
+// autopilotNodeLabels Autopilot 可以用於 nodeSelector 與節點親和性的節點標籤
+var autopilotNodeLabels = map[string]bool{
+	"cloud.google.com/compute-class":       true,
+	"cloud.google.com/gke-spot":            true,
+	"cloud.google.com/gke-accelerator":     true,
+	"cloud.google.com/machine-family":      true,
+	"cloud.google.com/gke-placement-group": true,
+	"topology.kubernetes.io/zone":          true,
+	"topology.kubernetes.io/region":        true,
+	"kubernetes.io/os":                     true,
+	"kubernetes.io/arch":                   true,
+}
+
+// AssessAutopilot 評估命名空間的工作負載能否在 GKE Autopilot 執行，並比較目前 (Standard) 與 Autopilot 的每月成本
+// Standard 的成本以 requests 乘上節點單價，再依叢集 requests 佔節點可分配量的比例分攤整個節點的成本；
+// Autopilot 依調整後的 Pod requests 計費，未設定的 requests 套用 Autopilot 的預設值
+func (s *Service) AssessAutopilot(ctx context.Context, namespace string) (*AutopilotAssessment, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if namespace == "" {
+		namespace = "default"
+	}
+
+	profiles, err := s.gkeService.GetPodPlatformProfiles(ctx, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("無法取得 Pod 規格: %w", err)
+	}
+
+	assessment := &AutopilotAssessment{
+		GeneratedAt:         time.Now(),
+		Namespace:           namespace,
+		Currency:            s.costModel.Currency,
+		ClusterRequestRatio: 1,
+		Workloads:           []AutopilotWorkload{},
+	}
+
+	nodes, err := s.gkeService.GetNodeUtilization(ctx)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Printf("警告: 無法取得節點使用狀況，Standard 成本只計算 requests: %v", err)
+		}
+		assessment.Notes = append(assessment.Notes, "無法取得節點資訊，Standard 成本只計算 requests，未分攤節點上未被請求的容量")
+	} else if ratio := clusterRequestRatio(nodes); ratio > 0 {
+		assessment.ClusterRequestRatio = ratio
+	}
+
+	workloads := make(map[string]*AutopilotWorkload)
+	var order []string
+	for _, profile := range profiles {
+		if systemNamespace(profile.Namespace) {
+			continue
+		}
+		key := profile.Namespace + "/" + profile.WorkloadKind + "/" + profile.WorkloadName
+		workload, ok := workloads[key]
+		if !ok {
+			workload = &AutopilotWorkload{
+				Kind:      profile.WorkloadKind,
+				Name:      profile.WorkloadName,
+				Namespace: profile.Namespace,
+			}
+			workloads[key] = workload
+			order = append(order, key)
+		}
+		workload.Pods++
+
+		blockers, adjustments, cpu, memory, storage := autopilotPodRequirements(profile)
+		for _, blocker := range blockers {
+			if !slices.Contains(workload.Blockers, blocker) {
+				workload.Blockers = append(workload.Blockers, blocker)
+			}
+		}
+		for _, adjustment := range adjustments {
+			if !slices.Contains(workload.Adjustments, adjustment) {
+				workload.Adjustments = append(workload.Adjustments, adjustment)
+			}
+		}
+
+		var currentCPU, currentMemory int64
+		for _, container := range profile.Containers {
+			currentCPU += container.CPU
+			currentMemory += container.Memory
+		}
+		workload.StandardMonthlyCost += s.costModel.monthlyCost(float64(currentCPU), float64(currentMemory)/(1<<20)) / assessment.ClusterRequestRatio
+		workload.AutopilotMonthlyCost += s.costModel.autopilotMonthlyCost(cpu, memory, storage)
+	}
+
+	for _, key := range order {
+		workload := workloads[key]
+		workload.Compatible = len(workload.Blockers) == 0
+		workload.StandardMonthlyCost = roundCost(workload.StandardMonthlyCost)
+		workload.AutopilotMonthlyCost = roundCost(workload.AutopilotMonthlyCost)
+		if workload.Compatible {
+			assessment.CompatibleWorkloads++
+		} else {
+			assessment.IncompatibleWorkloads++
+		}
+		assessment.StandardMonthlyCost += workload.StandardMonthlyCost
+		assessment.AutopilotMonthlyCost += workload.AutopilotMonthlyCost
+		assessment.Workloads = append(assessment.Workloads, *workload)
+	}
+	sort.SliceStable(assessment.Workloads, func(i, j int) bool {
+		a, b := assessment.Workloads[i], assessment.Workloads[j]
+		if a.Compatible != b.Compatible {
+			return !a.Compatible
+		}
+		return a.AutopilotMonthlyCost-a.StandardMonthlyCost > b.AutopilotMonthlyCost-b.StandardMonthlyCost
+	})
+
+	assessment.StandardMonthlyCost = roundCost(assessment.StandardMonthlyCost)
+	assessment.AutopilotMonthlyCost = roundCost(assessment.AutopilotMonthlyCost)
+	assessment.MonthlyDifference = roundCost(assessment.AutopilotMonthlyCost - assessment.StandardMonthlyCost)
+	assessment.Summary = autopilotSummary(assessment)
+
+	return assessment, nil
+}
+
+// autopilotPodRequirements 檢查 Pod 在 Autopilot 上的限制，回傳無法執行的原因、Autopilot 會調整的 requests，
+// 以及調整後計費的 CPU (millicores)、記憶體與 ephemeral storage (bytes)
+func autopilotPodRequirements(profile gke.PodPlatformProfile) (blockers, adjustments []string, cpu, memory, storage int64) {
+	if len(profile.PrivilegedContainers) > 0 {
+		blockers = append(blockers, fmt.Sprintf("特權容器: %s", strings.Join(profile.PrivilegedContainers, "、")))
+	}
+	if profile.HostNetwork {
+		blockers = append(blockers, "使用 hostNetwork")
+	}
+	if profile.HostPID || profile.HostIPC {
+		blockers = append(blockers, "使用 hostPID 或 hostIPC")
+	}
+	for _, volume := range profile.HostPathVolumes {
+		if !volume.ReadOnly || !strings.HasPrefix(volume.Path, autopilotHostPathPrefix) {
+			blockers = append(blockers, fmt.Sprintf("hostPath 卷 %s (%s)，只允許唯讀掛載 %s", volume.Name, volume.Path, autopilotHostPathPrefix))
+		}
+	}
+	for _, added := range profile.AddedCapabilities {
+		capability := strings.TrimPrefix(added[strings.LastIndex(added, " ")+1:], "CAP_")
+		if !autopilotCapabilities[capability] {
+			blockers = append(blockers, fmt.Sprintf("不允許的 capability %s", added))
+		}
+	}
+	for _, selector := range profile.NodeSelectors {
+		key := selector
+		if i := strings.IndexAny(selector, "= "); i >= 0 {
+			key = selector[:i]
+		}
+		if !autopilotNodeLabels[key] {
+			blockers = append(blockers, fmt.Sprintf("節點選擇條件 %s 使用 Autopilot 不支援的節點標籤", selector))
+		}
+	}
+	if len(profile.HostPorts) > 0 {
+		adjustments = append(adjustments, fmt.Sprintf("使用 hostPort (%s)，需要確認叢集版本是否允許", strings.Join(profile.HostPorts, "、")))
+	}
+
+	// 容器未設定的 requests 套用預設值；初始化容器與一般容器不會同時執行，以較大者計費
+	withDefaults := func(containers []gke.ContainerRequests) (cpu, memory, storage int64, defaulted []string) {
+		for _, container := range containers {
+			if container.CPU == 0 || container.Memory == 0 {
+				defaulted = append(defaulted, container.Name)
+			}
+			cpu += valueOr(container.CPU, autopilotDefaultCPU)
+			memory += valueOr(container.Memory, autopilotDefaultMemory)
+			storage += valueOr(container.EphemeralStorage, autopilotDefaultEphemeralStorage)
+		}
+		return cpu, memory, storage, defaulted
+	}
+	cpu, memory, storage, defaulted := withDefaults(profile.Containers)
+	if len(defaulted) > 0 {
+		adjustments = append(adjustments, fmt.Sprintf("容器 %s 未設定 CPU 或記憶體 requests，會套用預設值 500m / 2Gi", strings.Join(defaulted, "、")))
+	}
+	for _, container := range profile.InitContainers {
+		initCPU, initMemory, initStorage, _ := withDefaults([]gke.ContainerRequests{container})
+		cpu, memory, storage = max(cpu, initCPU), max(memory, initMemory), max(storage, initStorage)
+	}
+
+	if cpu < autopilotMinCPU || memory < autopilotMinMemory {
+		adjustments = append(adjustments, "requests 低於 Autopilot 的最小值 (50m / 52Mi)，會調高到最小值")
+		cpu, memory = max(cpu, autopilotMinCPU), max(memory, autopilotMinMemory)
+	}
+	memoryPerCPU := float64(memory) / (1 << 30) / (float64(cpu) / 1000)
+	switch {
+	case memoryPerCPU < autopilotMinMemoryPerCPU:
+		memory = int64(float64(cpu) / 1000 * autopilotMinMemoryPerCPU * (1 << 30))
+		adjustments = append(adjustments, "每 vCPU 的記憶體少於 1 GiB，Autopilot 會調高記憶體 requests")
+	case memoryPerCPU > autopilotMaxMemoryPerCPU:
+		cpu = int64(float64(memory) / (1 << 30) / autopilotMaxMemoryPerCPU * 1000)
+		adjustments = append(adjustments, "每 vCPU 的記憶體超過 6.5 GiB，Autopilot 會調高 CPU requests")
+	}
+	if cpu > autopilotMaxCPU || memory > autopilotMaxMemory {
+		blockers = append(blockers, "requests 超過一般用途 compute class 的上限 (28 vCPU / 80 GiB)，需要改用 Balanced 或 Scale-Out compute class")
+	}
+	if storage > autopilotMaxEphemeralStorage {
+		blockers = append(blockers, "ephemeral storage 超過 10 GiB 的上限，需要改用 PVC")
+	}
+
+	return blockers, adjustments, cpu, memory, storage
+}
+
+// clusterRequestRatio 叢集 CPU 與記憶體 requests 佔節點可分配量比例的較大者，用於將節點成本分攤到 requests
+func clusterRequestRatio(nodes []gke.NodeUtilization) float64 {
+	var cpuAllocatable, cpuRequested, memoryAllocatable, memoryRequested int64
+	for _, node := range nodes {
+		cpuAllocatable += node.CPUAllocatable
+		cpuRequested += node.CPURequested
+		memoryAllocatable += node.MemoryAllocatable
+		memoryRequested += node.MemoryRequested
+	}
+	if cpuAllocatable == 0 || memoryAllocatable == 0 {
+		return 0
+	}
+	return min(max(float64(cpuRequested)/float64(cpuAllocatable), float64(memoryRequested)/float64(memoryAllocatable)), 1)
+}
+
+// autopilotSummary 評估結果的說明
+func autopilotSummary(assessment *AutopilotAssessment) string {
+	total := assessment.CompatibleWorkloads + assessment.IncompatibleWorkloads
+	if total == 0 {
+		return "命名空間中沒有執行中的工作負載"
+	}
+
+	summary := fmt.Sprintf("%d 個工作負載中有 %d 個可以直接在 Autopilot 執行", total, assessment.CompatibleWorkloads)
+	switch {
+	case assessment.MonthlyDifference < 0:
+		summary += fmt.Sprintf("，Autopilot 每月估計可節省 %.2f %s", -assessment.MonthlyDifference, assessment.Currency)
+	case assessment.MonthlyDifference > 0:
+		summary += fmt.Sprintf("，Autopilot 每月估計多 %.2f %s", assessment.MonthlyDifference, assessment.Currency)
+	}
+	if assessment.ClusterRequestRatio < 1 {
+		summary += fmt.Sprintf("；目前節點只有 %.0f%% 的容量被請求，Autopilot 不需要為未被請求的容量付費", assessment.ClusterRequestRatio*100)
+	}
+	return summary
+}
+
+// valueOr 值為 0 時回傳預設值
+func valueOr(value, fallback int64) int64 {
+	if value == 0 {
+		return fallback
+	}
+	return value
+}
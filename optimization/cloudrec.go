@@ -0,0 +1,175 @@
+package optimization
+
+import (
+	"fmt"
+	"strings"
+
+	"mcp-gke-monitor/gke"
+)
+
+const (
+	// cloudConfidence Recommender API 的建議無法得知觀察的期間與使用量的波動，套用前需要人工確認
+	cloudConfidence       = ConfidenceMedium
+	cloudConfidenceReason = "Google Cloud Recommender 依 Google 觀察的使用量產生，無法確認資料涵蓋的時間與波動"
+)
+
+// cloudPriorities Recommender API 的優先級對應的建議優先級
+var cloudPriorities = map[string]Priority{
+	"P1": PriorityHigh,
+	"P2": PriorityMedium,
+	"P3": PriorityLow,
+	"P4": PriorityLow,
+}
+
+// mergeCloudRecommendations 將 Recommender API 的節點、節點池與磁碟建議併入本地建議，同一個目標只保留一筆建議
+// 磁碟只處理命名空間中 PVC 使用的磁碟，PVC 已有本地建議時把 Google 的建議附在本地建議上；
+// 節點池已有機器類型建議時略過池中各節點的機器類型建議，節點同時有閒置與機器類型建議時只保留閒置建議
+func (s *Service) mergeCloudRecommendations(recommendations []Recommendation, cloud []gke.CloudRecommendation, claims []gke.PersistentVolumeClaimUsage) []Recommendation {
+	claimsByVolume := make(map[string]gke.PersistentVolumeClaimUsage, len(claims))
+	for _, claim := range claims {
+		if claim.VolumeName != "" {
+			claimsByVolume[claim.VolumeName] = claim
+		}
+	}
+	claimRecommendations := make(map[string]int)
+	for i, rec := range recommendations {
+		if rec.WorkloadKind == "PersistentVolumeClaim" {
+			if _, exists := claimRecommendations[rec.Namespace+"/"+rec.WorkloadName]; !exists {
+				claimRecommendations[rec.Namespace+"/"+rec.WorkloadName] = i
+			}
+		}
+	}
+	resizedPools := make(map[string]bool)
+	idleNodes := make(map[string]bool)
+	for _, rec := range cloud {
+		switch rec.Recommender {
+		case gke.RecommenderInstanceGroupMachineType:
+			resizedPools[rec.NodePool] = true
+		case gke.RecommenderInstanceIdle:
+			idleNodes[rec.ResourceName] = true
+		}
+	}
+
+	for _, rec := range cloud {
+		switch rec.Recommender {
+		case gke.RecommenderDiskIdle:
+			claim, ok := claimsByVolume[rec.ResourceName]
+			if !ok {
+				continue
+			}
+			if i, ok := claimRecommendations[claim.Namespace+"/"+claim.Name]; ok {
+				recommendations[i].Cloud = &rec
+				continue
+			}
+			recommendations = append(recommendations, s.cloudDiskRecommendation(rec, claim))
+		case gke.RecommenderInstanceMachineType:
+			if resizedPools[rec.NodePool] || idleNodes[rec.ResourceName] {
+				continue
+			}
+			recommendations = append(recommendations, s.cloudNodeRecommendation(rec))
+		default:
+			recommendations = append(recommendations, s.cloudNodeRecommendation(rec))
+		}
+	}
+	return recommendations
+}
+
+// cloudNodeRecommendation 將節點或節點池的建議轉換為建議；GKE 節點由節點池管理，變更需要透過節點池而不是直接修改 VM
+func (s *Service) cloudNodeRecommendation(rec gke.CloudRecommendation) Recommendation {
+	recommendation := Recommendation{
+		ID:          fmt.Sprintf("REC-%s-cloud-%s", rec.ResourceName, strings.ToLower(strings.ReplaceAll(rec.Subtype, "_", "-"))),
+		Type:        RecommendationNode,
+		Priority:    cloudPriority(rec.Priority),
+		Description: rec.Description,
+		Cloud:       &rec,
+
+		Confidence:       cloudConfidence,
+		ConfidenceReason: cloudConfidenceReason,
+	}
+	machineType := rec.SuggestedMachineType
+	if machineType == "" {
+		machineType = "建議機器類型"
+	}
+
+	switch rec.Recommender {
+	case gke.RecommenderInstanceGroupMachineType:
+		recommendation.Issue = "NODE_POOL_MACHINE_TYPE"
+		recommendation.WorkloadKind = "NodePool"
+		recommendation.WorkloadName = rec.NodePool
+		recommendation.Title = fmt.Sprintf("節點池 %s 在 %s 的機器類型可以調整", rec.NodePool, rec.Zone)
+		recommendation.Impact = "依節點實際的使用量調整機器類型，減少節點的費用"
+		recommendation.Action = fmt.Sprintf("GKE 節點池無法直接變更機器類型：建立使用 %s 的新節點池，cordon 並排空舊節點池的節點後刪除舊節點池", machineType)
+	case gke.RecommenderInstanceIdle:
+		recommendation.Issue = "NODE_IDLE"
+		recommendation.WorkloadKind = "Node"
+		recommendation.WorkloadName = rec.ResourceName
+		recommendation.Title = fmt.Sprintf("節點 %s 閒置", rec.ResourceName)
+		recommendation.Impact = "減少閒置的節點，停止持續計費"
+		recommendation.Action = fmt.Sprintf("不要直接停止 VM (節點池會重新建立)：為節點池 %s 啟用 cluster autoscaler 或縮減節點數，或以 simulate_node_consolidation 確認節點上的 Pod 可以移到其他節點", rec.NodePool)
+	default:
+		recommendation.Issue = "NODE_MACHINE_TYPE"
+		recommendation.WorkloadKind = "Node"
+		recommendation.WorkloadName = rec.ResourceName
+		recommendation.Title = fmt.Sprintf("節點 %s 的機器類型可以調整", rec.ResourceName)
+		recommendation.Impact = "依節點實際的使用量調整機器類型，減少節點的費用"
+		recommendation.Action = fmt.Sprintf("不要直接修改節點的 VM：建立使用 %s 的節點池並將工作負載移過去，或確認節點池 %s 的其他節點是否有相同的建議", machineType, rec.NodePool)
+	}
+	if rec.Currency != "" && rec.Currency != s.costModel.Currency {
+		recommendation.Description += fmt.Sprintf(" (Google Cloud 估算每月節省 %.2f %s，與成本模型的幣別不同，不計入節省成本)", rec.MonthlySavings, rec.Currency)
+	}
+	return recommendation
+}
+
+// cloudDiskRecommendation 將 PVC 使用的閒置磁碟建議轉換為 PVC 的建議
+func (s *Service) cloudDiskRecommendation(rec gke.CloudRecommendation, claim gke.PersistentVolumeClaimUsage) Recommendation {
+	waste := ClaimWaste{
+		Name:         claim.Name,
+		Namespace:    claim.Namespace,
+		Phase:        claim.Phase,
+		StorageClass: claim.StorageClass,
+		DiskType:     claim.DiskType,
+		Capacity:     claim.Capacity,
+		Used:         claim.Used,
+		MonthlyCost:  roundCost(s.costModel.storageMonthlyCost(claim.DiskType, claim.CapacityBytes)),
+		Reason:       rec.Description,
+	}
+	waste.EstimatedMonthlySavings = waste.MonthlyCost
+	if rec.Currency == "" || rec.Currency == s.costModel.Currency {
+		waste.EstimatedMonthlySavings = roundCost(rec.MonthlySavings)
+	}
+
+	return Recommendation{
+		ID:           fmt.Sprintf("REC-%s-cloud-idle-disk", claim.Name),
+		Type:         RecommendationStorage,
+		Issue:        "PVC_IDLE_DISK",
+		Priority:     cloudPriority(rec.Priority),
+		Title:        fmt.Sprintf("PVC %s 的磁碟 %s 閒置", claim.Name, rec.ResourceName),
+		Description:  rec.Description,
+		Impact:       "刪除長期沒有讀寫的永久磁碟，停止持續計費",
+		Action:       fmt.Sprintf("確認資料不再需要 (或先建立 VolumeSnapshot 備份) 後刪除 PVC：kubectl delete pvc %s -n %s；reclaimPolicy 為 Retain 時需另外刪除 PV 與磁碟", claim.Name, claim.Namespace),
+		Namespace:    claim.Namespace,
+		WorkloadKind: "PersistentVolumeClaim",
+		WorkloadName: claim.Name,
+		Storage:      &waste,
+		Cloud:        &rec,
+
+		Confidence:       cloudConfidence,
+		ConfidenceReason: cloudConfidenceReason,
+	}
+}
+
+// cloudPriority Recommender API 的優先級對應的建議優先級，未知的優先級視為 LOW
+func cloudPriority(priority string) Priority {
+	if mapped, ok := cloudPriorities[priority]; ok {
+		return mapped
+	}
+	return PriorityLow
+}
+
+// cloudSavings Recommender API 估算的每月節省成本，幣別與成本模型不同時無法比較，回傳 0
+func (s *Service) cloudSavings(rec *gke.CloudRecommendation) float64 {
+	if rec.Currency != "" && rec.Currency != s.costModel.Currency {
+		return 0
+	}
+	return rec.MonthlySavings
+}
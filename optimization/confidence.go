@@ -0,0 +1,164 @@
+package optimization
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"mcp-gke-monitor/gke"
+)
+
+const (
+	// confidenceHighHours 使用量資料涵蓋的時間達到此值 (小時) 才可能是 HIGH，5 天涵蓋一般工作日的尖峰
+	confidenceHighHours = 120.0
+
+	// confidenceMediumHours 使用量資料涵蓋的時間少於此值 (小時) 時為 LOW，無法代表每日的尖峰
+	confidenceMediumHours = 24.0
+
+	// confidenceMinSamples 資料點少於此數量時為 LOW
+	confidenceMinSamples = 12
+
+	// confidenceMaxVariation 使用量的變異係數 (標準差 / 平均) 超過此值時信心程度降一級，尖峰難以預測
+	confidenceMaxVariation = 1.0
+)
+
+// confidenceRank 信心程度排序，數字越小越可靠
+var confidenceRank = map[Confidence]int{
+	ConfidenceHigh:   0,
+	ConfidenceMedium: 1,
+	ConfidenceLow:    2,
+}
+
+// usageBasedIssues 依使用量判斷的問題，信心程度取決於 Pod 使用量資料的品質
+var usageBasedIssues = map[string]bool{
+	"CPU_OVER_PROVISIONED":     true,
+	"CPU_UNDER_PROVISIONED":    true,
+	"CPU_THROTTLED":            true,
+	"MEMORY_OVER_PROVISIONED":  true,
+	"MEMORY_UNDER_PROVISIONED": true,
+	"GPU_IDLE":                 true,
+}
+
+// trendIssues 依歷史使用量的成長趨勢推估的問題，趨勢可能隨負載改變
+var trendIssues = map[string]bool{
+	"MEMORY_LEAK":  true,
+	"OOM_IMMINENT": true,
+}
+
+// usageConfidence 依使用量資料的來源、涵蓋的時間、資料點數量與波動程度評估使用量資料的信心程度
+func usageConfidence(source string, history *gke.MetricsSummary) (Confidence, string) {
+	confidence, reasons := coverageConfidence(source, history)
+	if confidence == ConfidenceLow {
+		return confidence, strings.Join(reasons, "；")
+	}
+	if variation := usageVariation(history); variation > confidenceMaxVariation {
+		confidence = lowerConfidence(confidence)
+		reasons = append(reasons, fmt.Sprintf("使用量波動大 (變異係數 %.2f)", variation))
+	}
+
+	if len(reasons) == 0 {
+		return confidence, fmt.Sprintf("%s 的使用量資料涵蓋 %.1f 小時且波動穩定", source, history.Coverage().Hours())
+	}
+	return confidence, strings.Join(reasons, "；")
+}
+
+// coverageConfidence 依使用量資料的來源、涵蓋的時間與資料點數量評估信心程度，不考慮波動程度
+// 只有 Metrics API 的單次取樣時為 LOW；背景收集器只在伺服器執行期間取樣，最高為 MEDIUM
+func coverageConfidence(source string, history *gke.MetricsSummary) (Confidence, []string) {
+	if history == nil {
+		return ConfidenceLow, []string{"只有 Metrics API 的單次取樣，無法代表尖峰使用量"}
+	}
+
+	hours := history.Coverage().Hours()
+	if hours < confidenceMediumHours || history.Samples < confidenceMinSamples {
+		return ConfidenceLow, []string{fmt.Sprintf("%s 的使用量資料只涵蓋 %.1f 小時 (%d 個資料點)", source, hours, history.Samples)}
+	}
+
+	confidence := ConfidenceHigh
+	var reasons []string
+	if hours < confidenceHighHours {
+		confidence = ConfidenceMedium
+		reasons = append(reasons, fmt.Sprintf("使用量資料只涵蓋 %.1f 小時 (需要 %.0f 小時)", hours, confidenceHighHours))
+	}
+	if source == gke.UsageSourceCollector {
+		confidence = ConfidenceMedium
+		reasons = append(reasons, "背景收集器只在伺服器執行期間取樣，資料可能有缺口")
+	}
+	return confidence, reasons
+}
+
+// usageVariation CPU 與記憶體使用量變異係數的較大者
+func usageVariation(history *gke.MetricsSummary) float64 {
+	var variation float64
+	for _, stats := range []gke.SeriesStats{history.CPUMillicores, history.MemoryBytes} {
+		if stats.Avg > 0 {
+			variation = math.Max(variation, stats.StdDev/stats.Avg)
+		}
+	}
+	return variation
+}
+
+// lowerConfidence 將信心程度降一級
+func lowerConfidence(confidence Confidence) Confidence {
+	if confidence == ConfidenceHigh {
+		return ConfidenceMedium
+	}
+	return ConfidenceLow
+}
+
+// issueConfidence Pod 問題的信心程度：依使用量判斷的問題採用 Pod 使用量資料的信心程度，依 Pod 規格或目前狀態判斷的問題為 HIGH
+func issueConfidence(podOpt PodOptimization, issueType string) (Confidence, string) {
+	switch {
+	case usageBasedIssues[issueType]:
+		if podOpt.InsufficientData {
+			return ConfidenceLow, fmt.Sprintf("使用量資料只涵蓋 %.1f 小時，未達 minObservationHours", podOpt.ObservedHours)
+		}
+		return podOpt.UsageConfidence, podOpt.ConfidenceReason
+	case trendIssues[issueType]:
+		return ConfidenceMedium, "依歷史使用量的成長趨勢推估，負載改變時趨勢可能不同"
+	default:
+		return ConfidenceHigh, "依 Pod 規格與目前狀態判斷"
+	}
+}
+
+// workloadConfidence 工作負載層級建議的信心程度，取各 Pod 使用量資料中最低的信心程度
+func workloadConfidence(pods []PodOptimization) (Confidence, string) {
+	confidence, reason := ConfidenceHigh, ""
+	for _, podOpt := range pods {
+		if reason == "" || confidenceRank[podOpt.UsageConfidence] > confidenceRank[confidence] {
+			confidence, reason = podOpt.UsageConfidence, podOpt.ConfidenceReason
+		}
+	}
+	return confidence, reason
+}
+
+// autoscalingConfidence HPA 建議的信心程度，取各 Pod 歷史使用量中最低的信心程度
+// 使用量波動大正是建議 HPA 的原因，不因波動降低信心程度
+func (s *Service) autoscalingConfidence(pods []PodOptimization, usageHistory map[string]*gke.MetricsSummary) (Confidence, string) {
+	confidence, reason := ConfidenceHigh, ""
+	for _, podOpt := range pods {
+		podConfidence, reasons := coverageConfidence(s.metrics.Name(), usageHistory[podOpt.Namespace+"/"+podOpt.PodName])
+		if reason == "" || confidenceRank[podConfidence] > confidenceRank[confidence] {
+			confidence, reason = podConfidence, strings.Join(reasons, "；")
+			if reason == "" {
+				reason = fmt.Sprintf("%s 的使用量資料涵蓋 %.1f 小時", s.metrics.Name(), podOpt.ObservedHours)
+			}
+		}
+	}
+	return confidence, reason
+}
+
+// assignDefaultConfidence 沒有依使用量判斷的建議 (例如 PDB、清理與配額建議) 依叢集設定判斷，信心程度為 HIGH
+func assignDefaultConfidence(recommendations []Recommendation) {
+	for i := range recommendations {
+		if recommendations[i].Confidence == "" {
+			recommendations[i].Confidence = ConfidenceHigh
+			recommendations[i].ConfidenceReason = "依叢集目前的設定判斷"
+		}
+	}
+}
+
+// meetsConfidence 建議的信心程度是否達到 minimum
+func meetsConfidence(rec Recommendation, minimum Confidence) bool {
+	return confidenceRank[rec.Confidence] <= confidenceRank[minimum]
+}
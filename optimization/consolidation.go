@@ -0,0 +1,212 @@
+package optimization
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"mcp-gke-monitor/gke"
+)
+
+// consolidationBin 模擬時的節點，容量為可分配量扣除 DaemonSet 與靜態 Pod 後的剩餘量
+type consolidationBin struct {
+	name      string
+	cpuFree   int64
+	memFree   int64
+	podsFree  int64
+	pods      []gke.NodePod
+	open      bool
+	allocated int64 // 可分配的 CPU，用來決定開啟節點的順序
+}
+
+// fits 判斷 Pod 是否放得進節點，且與節點上的 Pod 沒有反親和性衝突
+func (b *consolidationBin) fits(pod gke.NodePod) bool {
+	if pod.CPURequest > b.cpuFree || pod.MemoryRequest > b.memFree || b.podsFree < 1 {
+		return false
+	}
+	for _, placed := range b.pods {
+		if pod.ConflictsWith(placed) {
+			return false
+		}
+	}
+	return true
+}
+
+// place 將 Pod 放到節點上並扣除容量
+func (b *consolidationBin) place(pod gke.NodePod) {
+	b.cpuFree -= pod.CPURequest
+	b.memFree -= pod.MemoryRequest
+	b.podsFree--
+	b.pods = append(b.pods, pod)
+	b.open = true
+}
+
+// SimulateNodeConsolidation 模擬將目前 Pod 的請求量重新裝箱到最少的節點上，估算可以釋放的節點
+// nodePool 不為空時只模擬該節點池
+func (s *Service) SimulateNodeConsolidation(ctx context.Context, nodePool string) (*ConsolidationSimulation, error) {
+	nodes, err := s.gkeService.GetNodeUtilization(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if nodePool != "" {
+		filtered := nodes[:0]
+		for _, node := range nodes {
+			if node.NodePool == nodePool {
+				filtered = append(filtered, node)
+			}
+		}
+		if len(filtered) == 0 {
+			return nil, fmt.Errorf("找不到節點池 %s 的節點", nodePool)
+		}
+		nodes = filtered
+	}
+
+	simulation := simulateConsolidation(nodes)
+	simulation.GeneratedAt = time.Now()
+	return simulation, nil
+}
+
+// simulateConsolidation 依節點池分別進行裝箱模擬；Pod 只能移到同一個節點池的其他節點
+func simulateConsolidation(nodes []gke.NodeUtilization) *ConsolidationSimulation {
+	pools := make(map[string][]gke.NodeUtilization)
+	for _, node := range nodes {
+		// 已停止排程的節點不能接收 Pod，也已經在移除中，不列入模擬
+		if node.Unschedulable || node.CPUAllocatable == 0 || node.MemoryAllocatable == 0 {
+			continue
+		}
+		pools[node.NodePool] = append(pools[node.NodePool], node)
+	}
+
+	simulation := &ConsolidationSimulation{Pools: []PoolConsolidation{}}
+	for name, poolNodes := range pools {
+		result := consolidatePool(name, poolNodes)
+		simulation.Pools = append(simulation.Pools, result)
+		simulation.CurrentNodes += result.CurrentNodes
+		simulation.RequiredNodes += result.RequiredNodes
+		simulation.FreedNodes += result.FreedNodes
+	}
+
+	sort.Slice(simulation.Pools, func(i, j int) bool {
+		a, b := simulation.Pools[i], simulation.Pools[j]
+		if a.FreedNodes != b.FreedNodes {
+			return a.FreedNodes > b.FreedNodes
+		}
+		return a.NodePool < b.NodePool
+	})
+
+	return simulation
+}
+
+// consolidatePool 以 first-fit decreasing 將節點池的 Pod 裝到最少的節點上
+// DaemonSet 與靜態 Pod 在每個節點上都有，直接從容量扣除；沒有擁有者的 Pod 不會被重新建立，固定在目前的節點上；
+// 放不進任何節點的 Pod 留在原本的節點，該節點也必須保留
+func consolidatePool(name string, nodes []gke.NodeUtilization) PoolConsolidation {
+	bins := make([]*consolidationBin, 0, len(nodes))
+	binByName := make(map[string]*consolidationBin, len(nodes))
+	var movable []gke.NodePod
+	origin := make(map[string]string)
+	var cpuTotal, memoryTotal int64
+
+	for _, node := range nodes {
+		bin := &consolidationBin{
+			name:      node.NodeName,
+			cpuFree:   node.CPUAllocatable,
+			memFree:   node.MemoryAllocatable,
+			podsFree:  node.PodCapacity,
+			allocated: node.CPUAllocatable,
+		}
+		if bin.podsFree == 0 {
+			// 未回報 Pod 數上限時不以 Pod 數限制
+			bin.podsFree = int64(len(node.Pods)) + 1<<20
+		}
+		cpuTotal += node.CPUAllocatable
+		memoryTotal += node.MemoryAllocatable
+
+		for _, pod := range node.Pods {
+			switch {
+			case pod.DaemonSet || pod.Mirror:
+				bin.cpuFree -= pod.CPURequest
+				bin.memFree -= pod.MemoryRequest
+				bin.podsFree--
+			case pod.OwnerKind == "":
+				bin.place(pod)
+			default:
+				movable = append(movable, pod)
+				origin[pod.Namespace+"/"+pod.Name] = node.NodeName
+			}
+		}
+		bins = append(bins, bin)
+		binByName[bin.name] = bin
+	}
+
+	// 依 CPU 與記憶體佔節點池總量的較大比例由大到小排序
+	dominant := func(pod gke.NodePod) float64 {
+		return max(float64(pod.CPURequest)/float64(cpuTotal), float64(pod.MemoryRequest)/float64(memoryTotal))
+	}
+	sort.SliceStable(movable, func(i, j int) bool {
+		return dominant(movable[i]) > dominant(movable[j])
+	})
+
+	// 優先開啟較大的節點
+	sort.SliceStable(bins, func(i, j int) bool {
+		return bins[i].allocated > bins[j].allocated
+	})
+
+	result := PoolConsolidation{
+		NodePool:      name,
+		CurrentNodes:  len(nodes),
+		FreeableNodes: []string{},
+		UnplacedPods:  []string{},
+	}
+
+	for _, pod := range movable {
+		key := pod.Namespace + "/" + pod.Name
+		var target *consolidationBin
+		for _, bin := range bins {
+			if bin.open && bin.fits(pod) {
+				target = bin
+				break
+			}
+		}
+		if target == nil {
+			for _, bin := range bins {
+				if !bin.open && bin.fits(pod) {
+					target = bin
+					break
+				}
+			}
+		}
+		if target == nil {
+			// 放不進任何節點，留在原本的節點
+			target = binByName[origin[key]]
+			result.UnplacedPods = append(result.UnplacedPods, key)
+		}
+		if target.name != origin[key] {
+			result.MovedPods++
+		}
+		target.place(pod)
+	}
+
+	if len(movable) == 0 && len(bins) > 0 && !bins[0].open {
+		// 節點池沒有工作負載 Pod 時仍保留一個節點
+		bins[0].open = true
+	}
+
+	for _, bin := range bins {
+		if bin.open {
+			result.RequiredNodes++
+			continue
+		}
+		result.FreeableNodes = append(result.FreeableNodes, bin.name)
+	}
+	sort.Strings(result.FreeableNodes)
+	result.FreedNodes = len(result.FreeableNodes)
+	if result.FreedNodes == 0 {
+		// 沒有節點可以釋放時不需要搬移 Pod
+		result.MovedPods = 0
+	}
+
+	return result
+}
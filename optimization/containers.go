@@ -0,0 +1,147 @@
+package optimization
+
+import (
+	"fmt"
+
+	"mcp-gke-monitor/gke"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// analyzeContainers 分別分析各容器的 CPU 與記憶體使用率
+// Pod 層級的合計會把 sidecar 與主容器的使用率混在一起，需要各自判斷；有歷史使用量時 Pod 的尖峰依各容器目前使用量的比例分配
+func (s *Service) analyzeContainers(usage gke.ResourceUsage, history *gke.MetricsSummary) []ContainerAnalysis {
+	if len(usage.Containers) == 0 {
+		return nil
+	}
+
+	var cpuTotal, memoryTotal float64
+	for _, container := range usage.Containers {
+		cpuTotal += s.parseResourceValue(container.CPU.Current)
+		memoryTotal += s.parseResourceValue(container.Memory.Current)
+	}
+
+	result := make([]ContainerAnalysis, 0, len(usage.Containers))
+	for _, container := range usage.Containers {
+		cpuCurrent, memoryCurrent := container.CPU.Current, container.Memory.Current
+		if history != nil {
+			cpuShare := share(s.parseResourceValue(container.CPU.Current), cpuTotal, len(usage.Containers))
+			memoryShare := share(s.parseResourceValue(container.Memory.Current), memoryTotal, len(usage.Containers))
+			cpuCurrent = fmt.Sprintf("%dm", int64(float64(history.CPUMillicores.Max)*cpuShare))
+			memoryCurrent = fmt.Sprintf("%dMi", int64(float64(history.MemoryBytes.Max)*memoryShare)/(1024*1024))
+		}
+
+		cpu := s.analyzeResourceMetric(cpuCurrent, configuredQuantity(container.CPU.Request, true), configuredQuantity(container.CPU.Limit, true), "CPU")
+		if throttling := container.CPU.Throttling; throttling != nil {
+			containerThrottling := *throttling
+			containerThrottling.Container = container.Name
+			s.applyCPUThrottling(&cpu, &containerThrottling)
+		}
+		memory := s.analyzeResourceMetric(memoryCurrent, configuredQuantity(container.Memory.Request, false), configuredQuantity(container.Memory.Limit, false), "MEMORY")
+
+		result = append(result, ContainerAnalysis{
+			Name:   container.Name,
+			CPU:    cpu,
+			Memory: memory,
+		})
+	}
+	return result
+}
+
+// configuredQuantity 將容器的 requests 或 limits 轉換為與 Pod 合計相同的單位 (CPU 為 m、記憶體為 Mi)
+// 未設定的值在容器使用量中為 "0"，轉換為空字串
+func configuredQuantity(value string, cpu bool) string {
+	if resourceUnset(value) {
+		return ""
+	}
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil {
+		return value
+	}
+	if cpu {
+		return fmt.Sprintf("%dm", quantity.MilliValue())
+	}
+	return fmt.Sprintf("%dMi", quantity.Value()/(1024*1024))
+}
+
+// resourceIssues CPU 與記憶體配置問題；多容器 Pod 以各容器的分析判斷並標示容器，單一容器或缺少容器使用量時以 Pod 層級的分析判斷
+func resourceIssues(resourceAnalysis ResourceAnalysis, containers []ContainerAnalysis) []OptimizationIssue {
+	if len(containers) < 2 {
+		return metricIssues(resourceAnalysis.CPU, resourceAnalysis.Memory, "")
+	}
+
+	var issues []OptimizationIssue
+	for _, container := range containers {
+		issues = append(issues, metricIssues(container.CPU, container.Memory, container.Name)...)
+	}
+	return issues
+}
+
+// metricIssues 依 CPU 與記憶體的分析狀態產生問題，container 不為空時描述中標示容器
+func metricIssues(cpu, memory ResourceMetric, container string) []OptimizationIssue {
+	prefix := ""
+	if container != "" {
+		prefix = fmt.Sprintf("容器 %s ", container)
+	}
+
+	var issues []OptimizationIssue
+	switch cpu.Status {
+	case "OVER_PROVISIONED":
+		issues = append(issues, OptimizationIssue{
+			Type:        "CPU_OVER_PROVISIONED",
+			Severity:    PriorityMedium,
+			Container:   container,
+			Description: prefix + "CPU 資源過度配置",
+			Suggestion:  cpu.Suggestion,
+		})
+	case "UNDER_PROVISIONED":
+		issues = append(issues, OptimizationIssue{
+			Type:        "CPU_UNDER_PROVISIONED",
+			Severity:    PriorityHigh,
+			Container:   container,
+			Description: prefix + "CPU 資源不足",
+			Suggestion:  cpu.Suggestion,
+		})
+	case "THROTTLED":
+		issues = append(issues, OptimizationIssue{
+			Type:        "CPU_THROTTLED",
+			Severity:    PriorityHigh,
+			Container:   container,
+			Description: fmt.Sprintf("%sCPU 資源不足：持續節流 (%.1f%% 週期被節流)", prefix, cpu.ThrottledPercentage),
+			Suggestion:  cpu.Suggestion,
+		})
+	}
+
+	switch memory.Status {
+	case "OVER_PROVISIONED":
+		issues = append(issues, OptimizationIssue{
+			Type:        "MEMORY_OVER_PROVISIONED",
+			Severity:    PriorityMedium,
+			Container:   container,
+			Description: prefix + "記憶體資源過度配置",
+			Suggestion:  memory.Suggestion,
+		})
+	case "UNDER_PROVISIONED":
+		issues = append(issues, OptimizationIssue{
+			Type:        "MEMORY_UNDER_PROVISIONED",
+			Severity:    PriorityHigh,
+			Container:   container,
+			Description: prefix + "記憶體資源不足",
+			Suggestion:  memory.Suggestion,
+		})
+	}
+	return issues
+}
+
+// containerSuggestions 只保留問題所屬容器的建議值，Pod 層級的問題保留所有容器
+func containerSuggestions(suggestions []ResourceSuggestion, container string) []ResourceSuggestion {
+	if container == "" {
+		return suggestions
+	}
+	for _, suggestion := range suggestions {
+		if suggestion.Container == container {
+			return []ResourceSuggestion{suggestion}
+		}
+	}
+	return nil
+}
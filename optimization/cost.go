@@ -0,0 +1,211 @@
+package optimization
+
+import (
+	"maps"
+	"math"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	// hoursPerMonth 估算每月成本的時數
+	hoursPerMonth = 730
+
+	// defaultCPUCoreHour 與 defaultMemoryGiBHour 預設單價，取 GKE Standard E2 隨選節點 (us-central1) 的 USD 價格
+	defaultCPUCoreHour   = 0.021811
+	defaultMemoryGiBHour = 0.002923
+
+	// Autopilot 一般用途 Pod 的預設單價 (us-central1 USD)，依 Pod 的 requests 計費
+	defaultAutopilotCPUCoreHour    = 0.0445
+	defaultAutopilotMemoryGiBHour  = 0.0049225
+	defaultAutopilotStorageGiBHour = 0.0000548
+)
+
+// defaultStorageGiBMonth 預設各永久磁碟類型每 GiB 每月的單價，取 us-central1 的 USD 價格 (不含 pd-extreme 的 IOPS 費用)
+var defaultStorageGiBMonth = map[string]float64{
+	"pd-standard": 0.04,
+	"pd-balanced": 0.10,
+	"pd-ssd":      0.17,
+	"pd-extreme":  0.125,
+}
+
+// availabilityValue 影響可用性的建議在排序時換算的每月價值，讓高風險的可用性問題排在只能省下少量成本的建議之前
+var availabilityValue = map[Priority]float64{
+	PriorityHigh:   200,
+	PriorityMedium: 50,
+	PriorityLow:    10,
+}
+
+// CostModel 依 requests 估算成本的單價
+type CostModel struct {
+	Currency      string  `json:"currency"`
+	CPUCoreHour   float64 `json:"cpuCoreHour"`   // 每 vCPU 每小時
+	MemoryGiBHour float64 `json:"memoryGiBHour"` // 每 GiB 記憶體每小時
+
+	// StorageGiBMonth 各永久磁碟類型 (pd-standard、pd-balanced、pd-ssd 等) 每 GiB 每月的單價
+	StorageGiBMonth map[string]float64 `json:"storageGiBMonth"`
+
+	// Autopilot 一般用途 Pod 每小時的單價，用於評估改用 Autopilot 的成本
+	AutopilotCPUCoreHour    float64 `json:"autopilotCpuCoreHour"`
+	AutopilotMemoryGiBHour  float64 `json:"autopilotMemoryGiBHour"`
+	AutopilotStorageGiBHour float64 `json:"autopilotStorageGiBHour"` // ephemeral storage
+}
+
+// defaultCostModel 預設的成本模型
+func defaultCostModel() CostModel {
+	return CostModel{
+		Currency:        "USD",
+		CPUCoreHour:     defaultCPUCoreHour,
+		MemoryGiBHour:   defaultMemoryGiBHour,
+		StorageGiBMonth: maps.Clone(defaultStorageGiBMonth),
+
+		AutopilotCPUCoreHour:    defaultAutopilotCPUCoreHour,
+		AutopilotMemoryGiBHour:  defaultAutopilotMemoryGiBHour,
+		AutopilotStorageGiBHour: defaultAutopilotStorageGiBHour,
+	}
+}
+
+// SetCostModel 設定估算節省成本的單價，未設定的欄位使用預設值
+func (s *Service) SetCostModel(model CostModel) {
+	defaults := defaultCostModel()
+	if model.Currency == "" {
+		model.Currency = defaults.Currency
+	}
+	if model.CPUCoreHour <= 0 {
+		model.CPUCoreHour = defaults.CPUCoreHour
+	}
+	if model.MemoryGiBHour <= 0 {
+		model.MemoryGiBHour = defaults.MemoryGiBHour
+	}
+	if model.AutopilotCPUCoreHour <= 0 {
+		model.AutopilotCPUCoreHour = defaults.AutopilotCPUCoreHour
+	}
+	if model.AutopilotMemoryGiBHour <= 0 {
+		model.AutopilotMemoryGiBHour = defaults.AutopilotMemoryGiBHour
+	}
+	if model.AutopilotStorageGiBHour <= 0 {
+		model.AutopilotStorageGiBHour = defaults.AutopilotStorageGiBHour
+	}
+	prices := defaults.StorageGiBMonth
+	for diskType, price := range model.StorageGiBMonth {
+		if price > 0 {
+			prices[diskType] = price
+		}
+	}
+	model.StorageGiBMonth = prices
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.costModel = model
+}
+
+// monthlyCost 計算 CPU (millicores) 與記憶體 (MiB) requests 的每月成本
+func (m CostModel) monthlyCost(cpuMillicores, memoryMi float64) float64 {
+	return (cpuMillicores/1000*m.CPUCoreHour + memoryMi/1024*m.MemoryGiBHour) * hoursPerMonth
+}
+
+// autopilotMonthlyCost 計算 Autopilot Pod 的每月成本，CPU 以 millicores、記憶體與 ephemeral storage 以 bytes 表示
+func (m CostModel) autopilotMonthlyCost(cpuMillicores, memoryBytes, storageBytes int64) float64 {
+	return (float64(cpuMillicores)/1000*m.AutopilotCPUCoreHour +
+		float64(memoryBytes)/(1<<30)*m.AutopilotMemoryGiBHour +
+		float64(storageBytes)/(1<<30)*m.AutopilotStorageGiBHour) * hoursPerMonth
+}
+
+// storageMonthlyCost 計算永久磁碟的每月成本，沒有單價的磁碟類型回傳 0
+func (m CostModel) storageMonthlyCost(diskType string, bytes int64) float64 {
+	return float64(bytes) / (1 << 30) * m.StorageGiBMonth[diskType]
+}
+
+// rankRecommendations 估算各建議每月可節省的成本與對可用性的影響，依兩者換算的價值由高到低排序
+// 節省成本為負值表示建議會增加 requests；價值相同時依優先級排序
+func (s *Service) rankRecommendations(recommendations []Recommendation, workloads []*workloadGroup) {
+	index := make(map[string]*workloadGroup, len(workloads))
+	for _, workload := range workloads {
+		index[workload.key()] = workload
+	}
+
+	for i := range recommendations {
+		rec := &recommendations[i]
+		workload := index[rec.Namespace+"/"+rec.WorkloadKind+"/"+rec.WorkloadName]
+		rec.EstimatedMonthlySavings = math.Round(s.estimateSavings(*rec, workload)*100) / 100
+		rec.AvailabilityImpact = availabilityImpact(*rec)
+	}
+
+	sort.SliceStable(recommendations, func(i, j int) bool {
+		a, b := recommendations[i], recommendations[j]
+		scoreA := a.EstimatedMonthlySavings + availabilityValue[a.AvailabilityImpact]
+		scoreB := b.EstimatedMonthlySavings + availabilityValue[b.AvailabilityImpact]
+		if scoreA != scoreB {
+			return scoreA > scoreB
+		}
+		return priorityRank[a.Priority] < priorityRank[b.Priority]
+	})
+}
+
+// estimateSavings 估算建議每月可節省的成本
+// 資源建議以目前與建議的 requests 差額計算，合併為工作負載的建議乘上副本數；副本數建議以減少的副本乘上單一 Pod 的 requests
+// PVC 建議使用分析時依磁碟單價估算的值，節點建議使用 Recommender API 估算的值；無法以 requests 估算的建議 (例如 HPA、清理與健康問題) 回傳 0
+func (s *Service) estimateSavings(rec Recommendation, workload *workloadGroup) float64 {
+	if rec.Storage != nil {
+		return rec.Storage.EstimatedMonthlySavings
+	}
+	if rec.Type == RecommendationNode && rec.Cloud != nil {
+		return s.cloudSavings(rec.Cloud)
+	}
+	if rec.Replicas != nil && workload != nil && len(workload.pods) > 0 {
+		cpu, memory := podRequests(workload.pods[0].SuggestedResources)
+		delta := float64(rec.Replicas.CurrentReplicas - rec.Replicas.SuggestedReplicas)
+		return s.costModel.monthlyCost(float64(cpu)*delta, float64(memory)*delta)
+	}
+	if len(rec.Suggested) == 0 {
+		return 0
+	}
+
+	replicas := 1.0
+	if len(rec.Evidence) > 0 && workload != nil {
+		replicas = float64(len(workload.pods))
+	}
+
+	resourceName := recommendationResource(rec)
+	var cpuDelta, memoryDelta float64
+	for _, suggestion := range rec.Suggested {
+		if resourceName != "memory" {
+			cpuDelta += quantityDelta(suggestion.Current.CPURequest, suggestion.Suggested.CPURequest, true)
+		}
+		if resourceName != "cpu" {
+			memoryDelta += quantityDelta(suggestion.Current.MemoryRequest, suggestion.Suggested.MemoryRequest, false)
+		}
+	}
+	return s.costModel.monthlyCost(cpuDelta*replicas, memoryDelta*replicas)
+}
+
+// quantityDelta 計算目前與建議資源量的差額，CPU 以 millicores、記憶體以 MiB 表示；未設定或無法解析的值視為 0
+func quantityDelta(current, suggested string, cpu bool) float64 {
+	value := func(s string) float64 {
+		quantity, err := resource.ParseQuantity(s)
+		if err != nil {
+			return 0
+		}
+		if cpu {
+			return float64(quantity.MilliValue())
+		}
+		return float64(quantity.Value()) / (1024 * 1024)
+	}
+	return value(current) - value(suggested)
+}
+
+// availabilityImpact 建議對可用性的影響，不影響可用性 (例如縮減資源、清理與安全性) 時回傳空字串
+func availabilityImpact(rec Recommendation) Priority {
+	switch {
+	case rec.Type == RecommendationHealth || (rec.Type == RecommendationStorage && rec.Storage == nil):
+		return rec.Priority
+	case rec.Issue == "CPU_UNDER_PROVISIONED" || rec.Issue == "MEMORY_UNDER_PROVISIONED" || rec.Issue == "CPU_THROTTLED" || rec.Issue == "OOM_IMMINENT":
+		return rec.Priority
+	case rec.Replicas != nil && rec.Replicas.SuggestedReplicas > rec.Replicas.CurrentReplicas:
+		return rec.Priority
+	case rec.DisruptionBudget != nil || rec.Spread != nil || rec.Quota != nil:
+		return rec.Priority
+	}
+	return ""
+}
@@ -0,0 +1,132 @@
+package optimization
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"mcp-gke-monitor/gke"
+)
+
+// crashLogTailLines 重啟次數過多時取得容器上一次執行的日誌行數
+const crashLogTailLines = 100
+
+// diagnoseRestarts 重啟次數過多時取得上一次執行的日誌與最近的 Warning 事件，將結束原因與日誌摘要補充到問題說明中
+// 無法取得時回傳 nil，問題維持原本的說明
+func (s *Service) diagnoseRestarts(ctx context.Context, pod gke.Pod, issues []OptimizationIssue) *gke.CrashDiagnosis {
+	index := -1
+	for i, issue := range issues {
+		if issue.Type == "HIGH_RESTART_COUNT" {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return nil
+	}
+
+	diagnosis, err := s.gkeService.GetCrashDiagnosis(ctx, pod.Name, pod.Namespace, crashLogTailLines)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Printf("警告: 無法取得 Pod %s 的重啟日誌: %v", pod.Name, err)
+		}
+		return nil
+	}
+
+	if summary := crashSummary(diagnosis); summary != "" {
+		issues[index].Suggestion = summary + "；" + issues[index].Suggestion
+	}
+
+	// 重啟全部來自節點搶占或重新啟動時不是應用程式的問題，降低優先級
+	if cause := dominantRestartCause(diagnosis); cause != "" {
+		issues[index].Description += fmt.Sprintf("，主要原因: %s", restartCauseLabels[cause])
+		if diagnosis.RestartCauses[gke.RestartCauseNodePreemption] == totalRestarts(diagnosis) {
+			issues[index].Severity = PriorityLow
+		}
+	}
+	return diagnosis
+}
+
+// restartCauseLabels 重啟原因的說明
+var restartCauseLabels = map[string]string{
+	gke.RestartCauseOOMKilled:      "記憶體不足 (OOMKilled)",
+	gke.RestartCauseLivenessProbe:  "liveness probe 失敗",
+	gke.RestartCauseNodePreemption: "節點被搶占或重新啟動",
+	gke.RestartCauseErrorExit:      "應用程式錯誤結束",
+	gke.RestartCauseCompleted:      "主程式正常結束後被重新啟動",
+	gke.RestartCauseUnknown:        "原因不明",
+}
+
+// dominantRestartCause 重啟次數最多的原因，次數相同時應用程式需要處理的原因優先
+func dominantRestartCause(diagnosis *gke.CrashDiagnosis) string {
+	best := ""
+	for _, cause := range []string{
+		gke.RestartCauseOOMKilled,
+		gke.RestartCauseLivenessProbe,
+		gke.RestartCauseErrorExit,
+		gke.RestartCauseCompleted,
+		gke.RestartCauseNodePreemption,
+		gke.RestartCauseUnknown,
+	} {
+		if count := diagnosis.RestartCauses[cause]; count > 0 && (best == "" || count > diagnosis.RestartCauses[best]) {
+			best = cause
+		}
+	}
+	return best
+}
+
+// totalRestarts 各容器重啟次數的總和
+func totalRestarts(diagnosis *gke.CrashDiagnosis) int32 {
+	var total int32
+	for _, count := range diagnosis.RestartCauses {
+		total += count
+	}
+	return total
+}
+
+// crashSummary 以一句話說明重啟最多的容器上一次的結束原因、最後一行錯誤日誌與最近的 Warning 事件
+func crashSummary(diagnosis *gke.CrashDiagnosis) string {
+	var parts []string
+	if len(diagnosis.Containers) > 0 {
+		crash := diagnosis.Containers[0]
+		if crash.Reason != "" {
+			parts = append(parts, fmt.Sprintf("容器 %s 上一次以 %s (exit code %d) 結束", crash.Container, crash.Reason, crash.ExitCode))
+		}
+		if len(crash.Excerpt) > 0 {
+			parts = append(parts, fmt.Sprintf("日誌: %s", crash.Excerpt[len(crash.Excerpt)-1]))
+		}
+	}
+	if len(diagnosis.Events) > 0 {
+		event := diagnosis.Events[0]
+		parts = append(parts, fmt.Sprintf("最近事件 %s: %s", event.Reason, event.Message))
+	}
+	return strings.Join(parts, "，")
+}
+
+// crashAction 依結束原因提供具體的處理方式
+func crashAction(diagnosis *gke.CrashDiagnosis) string {
+	if len(diagnosis.Containers) == 0 {
+		return "檢查 crash 摘要中的 Warning 事件並修復問題"
+	}
+
+	crash := diagnosis.Containers[0]
+	switch crash.Cause {
+	case gke.RestartCauseOOMKilled:
+		return fmt.Sprintf("容器 %s 因記憶體不足被終止，提高記憶體 limit 或找出記憶體使用量成長的原因", crash.Container)
+	case gke.RestartCauseLivenessProbe:
+		return fmt.Sprintf("容器 %s 因 liveness probe 失敗被重啟，放寬 liveness probe 的 timeoutSeconds 與 failureThreshold，並檢查應用程式在負載下的回應時間", crash.Container)
+	case gke.RestartCauseNodePreemption:
+		return "重啟來自節點被搶占或重新啟動，不是應用程式的問題；以多個副本、PodDisruptionBudget 與跨節點分散容忍中斷，關鍵服務改用非 Spot 的節點池"
+	case gke.RestartCauseCompleted:
+		return fmt.Sprintf("容器 %s 的主程式正常結束 (exit code 0) 後被重新啟動，一次性的工作改用 Job，常駐服務確認主程式不會提前結束", crash.Container)
+	}
+	switch {
+	case crash.ExitCode == 137 || crash.ExitCode == 143:
+		return fmt.Sprintf("容器 %s 被外部終止 (exit code %d)，檢查節點的記憶體壓力、Warning 事件與應用程式的結束訊號處理", crash.Container, crash.ExitCode)
+	case len(crash.Excerpt) > 0:
+		return fmt.Sprintf("依 crash 摘要中容器 %s 的錯誤日誌修復應用程式，完整日誌可用 kubectl logs %s -c %s --previous 查看",
+			crash.Container, diagnosis.PodName, crash.Container)
+	default:
+		return fmt.Sprintf("以 kubectl logs %s -c %s --previous 查看容器上一次執行的日誌並修復問題", diagnosis.PodName, crash.Container)
+	}
+}
@@ -0,0 +1,87 @@
+package optimization
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// SetCriteriaPath 設定優化標準的狀態檔，檔案存在時載入先前以 update_optimization_criteria 更新的標準
+// 之後每次更新都會寫回檔案，服務重新啟動後不會回到預設值；path 為空時只保存在記憶體中
+func (s *Service) SetCriteriaPath(path string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.criteriaPath = path
+	if path == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("讀取優化標準檔案失敗: %w", err)
+	}
+
+	// 以目前標準為基礎解析，檔案中沒有的欄位 (例如新版本新增的標準) 沿用預設值
+	criteria := s.criteria
+	if err := json.Unmarshal(data, &criteria); err != nil {
+		return fmt.Errorf("解析優化標準檔案失敗: %w", err)
+	}
+	if criteria.HeadroomFactor < 1 {
+		criteria.HeadroomFactor = defaultHeadroomFactor
+	}
+	if criteria.MinReplicas < 1 {
+		criteria.MinReplicas = defaultMinReplicas
+	}
+	if criteria.DiskThreshold <= 0 || criteria.DiskThreshold > 100 {
+		criteria.DiskThreshold = defaultDiskThreshold
+	}
+	if criteria.MinObservationHours < 0 {
+		criteria.MinObservationHours = defaultMinObservationHours
+	}
+	if criteria.CPULimitPolicy == "" {
+		criteria.CPULimitPolicy = CPULimitPolicyRaise
+	}
+	if err := validCPULimitPolicy(criteria.CPULimitPolicy); err != nil {
+		return fmt.Errorf("優化標準檔案的 CPU limit 政策無效: %w", err)
+	}
+	if err := criteria.Scoring.validate(); err != nil {
+		return fmt.Errorf("優化標準檔案的評分權重無效: %w", err)
+	}
+	s.criteria = criteria
+
+	if s.logger != nil {
+		s.logger.Printf("已從 %s 載入優化標準", path)
+	}
+	return nil
+}
+
+// CriteriaPath 取得優化標準的狀態檔路徑，未設定時為空字串
+func (s *Service) CriteriaPath() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.criteriaPath
+}
+
+// saveCriteria 將優化標準寫入狀態檔，先寫入暫存檔再取代，避免中途失敗造成檔案損毀
+// 呼叫端需持有 s.mu
+func (s *Service) saveCriteria(criteria OptimizationCriteria) error {
+	data, err := json.MarshalIndent(criteria, "", "  ")
+	if err != nil {
+		return fmt.Errorf("序列化優化標準失敗: %w", err)
+	}
+
+	tmpPath := s.criteriaPath + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o644); err != nil {
+		return fmt.Errorf("寫入優化標準暫存檔失敗: %w", err)
+	}
+	if err := os.Rename(tmpPath, s.criteriaPath); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("取代優化標準檔案失敗: %w", err)
+	}
+	return nil
+}
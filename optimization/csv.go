@@ -0,0 +1,146 @@
+package optimization
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ExportFormatCSV 匯出為三個 CSV 檔案 (Pod 分析、資源浪費與建議)，欄位固定，新增欄位只會加在最後
+const ExportFormatCSV = "csv"
+
+// 各 CSV 檔案的欄位，順序即為檔案中的欄位順序
+var (
+	podAnalysisCSVColumns = []string{
+		"namespace", "pod", "status", "workload_kind", "workload_name", "node", "optimization_score", "usage_source",
+		"cpu_request", "cpu_limit", "cpu_current", "cpu_utilization", "cpu_status",
+		"memory_request", "memory_limit", "memory_current", "memory_utilization", "memory_status",
+		"ready", "restart_count", "health_score", "issues",
+	}
+	resourceWasteCSVColumns = []string{
+		"category", "namespace", "pod", "resource", "allocated", "used", "waste_percentage", "waste_amount",
+	}
+	recommendationCSVColumns = []string{
+		"id", "priority", "type", "issue", "availability_impact", "title", "namespace", "workload_kind", "workload_name", "pod",
+		"estimated_monthly_savings", "currency", "action", "container", "confidence",
+	}
+)
+
+// reportCSVFiles 將報告轉換為 CSV 檔案，key 為檔名後綴
+func reportCSVFiles(report *OptimizationReport) (map[string][]byte, error) {
+	var podRows [][]string
+	for _, pod := range report.PodAnalysis {
+		issues := make([]string, 0, len(pod.Issues))
+		for _, issue := range pod.Issues {
+			if issue.Container != "" {
+				issues = append(issues, issue.Type+":"+issue.Container)
+				continue
+			}
+			issues = append(issues, issue.Type)
+		}
+		cpu, memory := pod.ResourceAnalysis.CPU, pod.ResourceAnalysis.Memory
+		podRows = append(podRows, []string{
+			pod.Namespace, pod.PodName, pod.Status, pod.WorkloadKind, pod.WorkloadName, pod.NodeName,
+			formatCSVFloat(pod.OptimizationScore), pod.UsageSource,
+			cpu.Request, cpu.Limit, cpu.Current, formatCSVFloat(cpu.Utilization), cpu.Status,
+			memory.Request, memory.Limit, memory.Current, formatCSVFloat(memory.Utilization), memory.Status,
+			strconv.FormatBool(pod.HealthStatus.Ready), strconv.Itoa(int(pod.HealthStatus.RestartCount)),
+			formatCSVFloat(pod.HealthStatus.HealthScore), strings.Join(issues, ";"),
+		})
+	}
+
+	waste := report.ResourceWaste
+	var wasteRows [][]string
+	for _, category := range []struct {
+		name string
+		pods []ResourceWaste
+	}{
+		{"over_provisioned", waste.OverProvisionedPods},
+		{"under_utilized", waste.UnderUtilizedPods},
+	} {
+		for _, pod := range category.pods {
+			wasteRows = append(wasteRows, []string{
+				category.name, pod.Namespace, pod.PodName, pod.ResourceType, pod.Allocated, pod.Used,
+				formatCSVFloat(pod.WastePercentage), pod.WasteAmount,
+			})
+		}
+	}
+	for _, pod := range waste.IdlePods {
+		wasteRows = append(wasteRows, []string{"idle", report.Namespace, pod, "", "", "", "", ""})
+	}
+	if storage := waste.Storage; storage != nil {
+		for _, category := range []struct {
+			name   string
+			claims []ClaimWaste
+		}{
+			{"pvc_oversized", storage.OversizedClaims},
+			{"pvc_unused", storage.UnusedClaims},
+			{"pvc_unbound", storage.UnboundClaims},
+			{"pvc_premium_disk", storage.PremiumClaims},
+		} {
+			// PVC 的浪費量以每月可節省的成本表示
+			for _, claim := range category.claims {
+				wasteRows = append(wasteRows, []string{
+					category.name, claim.Namespace, claim.Name, "STORAGE", claim.Capacity, claim.Used, "",
+					fmt.Sprintf("%.2f %s/月", claim.EstimatedMonthlySavings, storage.Currency),
+				})
+			}
+		}
+	}
+
+	var recommendationRows [][]string
+	for _, rec := range report.Recommendations {
+		recommendationRows = append(recommendationRows, []string{
+			rec.ID, string(rec.Priority), string(rec.Type), rec.Issue, string(rec.AvailabilityImpact), rec.Title,
+			rec.Namespace, rec.WorkloadKind, rec.WorkloadName, rec.PodName,
+			strconv.FormatFloat(rec.EstimatedMonthlySavings, 'f', 2, 64), report.Summary.Currency, rec.Action,
+			rec.Container, string(rec.Confidence),
+		})
+	}
+
+	files := make(map[string][]byte, 3)
+	for name, table := range map[string]struct {
+		columns []string
+		rows    [][]string
+	}{
+		"pods":            {podAnalysisCSVColumns, podRows},
+		"waste":           {resourceWasteCSVColumns, wasteRows},
+		"recommendations": {recommendationCSVColumns, recommendationRows},
+	} {
+		content, err := encodeCSV(table.columns, table.rows)
+		if err != nil {
+			return nil, fmt.Errorf("產生 %s CSV 失敗: %w", name, err)
+		}
+		files[name] = content
+	}
+	return files, nil
+}
+
+// csvColumns 各 CSV 檔案的欄位，key 為檔名後綴
+func csvColumns() map[string][]string {
+	return map[string][]string{
+		"pods":            podAnalysisCSVColumns,
+		"waste":           resourceWasteCSVColumns,
+		"recommendations": recommendationCSVColumns,
+	}
+}
+
+// encodeCSV 輸出含標題列的 CSV
+func encodeCSV(columns []string, rows [][]string) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write(columns); err != nil {
+		return nil, err
+	}
+	if err := writer.WriteAll(rows); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// formatCSVFloat 數值保留兩位小數
+func formatCSVFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', 2, 64)
+}
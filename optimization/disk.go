@@ -0,0 +1,100 @@
+package optimization
+
+import (
+	"fmt"
+	"sort"
+
+	"mcp-gke-monitor/gke"
+)
+
+const (
+	// defaultDiskThreshold 磁碟使用率超過此值 (%) 視為即將用盡
+	defaultDiskThreshold = 85.0
+
+	// diskCriticalThreshold 磁碟使用率超過此值 (%) 時列為高優先級
+	diskCriticalThreshold = 95.0
+)
+
+// analyzeDiskMetric 分析 Pod ephemeral storage 的使用率，總量為 ephemeral-storage limit 或節點磁碟剩餘空間
+func (s *Service) analyzeDiskMetric(disk gke.DiskUsage) ResourceMetric {
+	metric := ResourceMetric{
+		Current: disk.Used,
+		Request: "-",
+		Limit:   disk.Total,
+	}
+	if disk.Used == "" || disk.Total == "" {
+		metric.Status = "UNKNOWN"
+		metric.Suggestion = "無法取得磁碟使用量 (需要 nodes/proxy 的 get 權限讀取 kubelet stats)"
+		return metric
+	}
+
+	metric.Utilization = s.calculateDiskUtilization(disk.Used, disk.Total)
+	metric.Status, metric.Suggestion = s.diskStatus(metric.Utilization, "ephemeral storage", disk.Used, disk.Total)
+	return metric
+}
+
+// analyzeVolumes 分析有獨立容量的卷 (PVC 與設定 sizeLimit 的 emptyDir) 的使用率，依名稱排序
+func (s *Service) analyzeVolumes(volumes map[string]gke.Volume) []VolumeAnalysis {
+	var result []VolumeAnalysis
+	for _, volume := range volumes {
+		if volume.Total == "" {
+			continue
+		}
+		analysis := VolumeAnalysis{
+			Name:        volume.Name,
+			Type:        volume.Type,
+			MountPath:   volume.MountPath,
+			Used:        volume.Used,
+			Total:       volume.Total,
+			Utilization: s.calculateDiskUtilization(volume.Used, volume.Total),
+		}
+		analysis.Status, analysis.Suggestion = s.diskStatus(analysis.Utilization, fmt.Sprintf("卷 %s", volume.Name), volume.Used, volume.Total)
+		result = append(result, analysis)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// diskStatus 依使用率判斷磁碟狀態
+func (s *Service) diskStatus(utilization float64, target, used, total string) (string, string) {
+	threshold := s.criteria.DiskThreshold
+	if threshold <= 0 {
+		threshold = defaultDiskThreshold
+	}
+	if utilization < threshold {
+		return "OPTIMAL", "磁碟使用正常"
+	}
+	return "NEAR_FULL", fmt.Sprintf("%s 已使用 %s / %s (%.1f%%)，即將用盡", target, used, total, utilization)
+}
+
+// diskIssues ephemeral storage 與卷即將用盡的問題，超過 diskCriticalThreshold 時為高優先級
+func diskIssues(resourceAnalysis ResourceAnalysis) []OptimizationIssue {
+	severity := func(utilization float64) Priority {
+		if utilization >= diskCriticalThreshold {
+			return PriorityHigh
+		}
+		return PriorityMedium
+	}
+
+	var issues []OptimizationIssue
+	if disk := resourceAnalysis.Disk; disk.Status == "NEAR_FULL" {
+		issues = append(issues, OptimizationIssue{
+			Type:        "DISK_NEAR_FULL",
+			Severity:    severity(disk.Utilization),
+			Description: fmt.Sprintf("ephemeral storage 即將用盡 (%.1f%%)", disk.Utilization),
+			Suggestion:  disk.Suggestion,
+		})
+	}
+	for _, volume := range resourceAnalysis.Volumes {
+		if volume.Status != "NEAR_FULL" {
+			continue
+		}
+		issues = append(issues, OptimizationIssue{
+			Type:        "VOLUME_NEAR_FULL",
+			Severity:    severity(volume.Utilization),
+			Description: fmt.Sprintf("%s 卷 %s 即將用盡 (%.1f%%)", volume.Type, volume.Name, volume.Utilization),
+			Suggestion:  volume.Suggestion,
+		})
+	}
+	return issues
+}
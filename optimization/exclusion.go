@@ -0,0 +1,43 @@
+package optimization
+
+import (
+	"fmt"
+	"strconv"
+
+	"mcp-gke-monitor/gke"
+
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// IgnoreAnnotation Pod 加上此註解且值為 "true" 時不納入優化分析 (可設定在工作負載的 Pod template)
+const IgnoreAnnotation = "mcp-optimizer/ignore"
+
+// SetExcludeSelectors 設定排除優化分析的標籤選擇器，符合任一選擇器的 Pod 不會被分析
+func (s *Service) SetExcludeSelectors(selectors []string) error {
+	parsed := make([]labels.Selector, 0, len(selectors))
+	for _, selector := range selectors {
+		sel, err := labels.Parse(selector)
+		if err != nil {
+			return fmt.Errorf("無效的標籤選擇器 %q: %w", selector, err)
+		}
+		parsed = append(parsed, sel)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.excludeSelectors = parsed
+	return nil
+}
+
+// exclusionReason 判斷 Pod 是否排除在優化分析之外，回傳排除原因，不排除時回傳空字串
+func (s *Service) exclusionReason(pod gke.Pod) string {
+	if ignore, err := strconv.ParseBool(pod.Annotations[IgnoreAnnotation]); err == nil && ignore {
+		return fmt.Sprintf("註解 %s=true", IgnoreAnnotation)
+	}
+	for _, selector := range s.excludeSelectors {
+		if selector.Matches(labels.Set(pod.Labels)) {
+			return fmt.Sprintf("符合排除選擇器 %s", selector.String())
+		}
+	}
+	return ""
+}
@@ -0,0 +1,104 @@
+package optimization
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ExportFormatHTML 匯出為可直接以瀏覽器開啟或列印為 PDF 的 HTML 頁面
+const ExportFormatHTML = "html"
+
+// SetExportDir 設定匯出報告的目錄，每個命名空間一個子目錄；dir 為空時不允許匯出
+func (s *Service) SetExportDir(dir string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.exportDir = dir
+}
+
+// ExportOptimizationReport 將優化報告匯出為檔案
+// reportID 為空時以 window 為分析期間產生新的報告並保存到報告歷史，否則匯出已保存的報告
+func (s *Service) ExportOptimizationReport(ctx context.Context, namespace, reportID, format string, window time.Duration) (*ReportExport, error) {
+	s.mu.RLock()
+	dir := s.exportDir
+	historyDir := s.reportHistoryDir
+	s.mu.RUnlock()
+	if dir == "" {
+		return nil, fmt.Errorf("未設定報告匯出目錄 (optimization.exportDir)")
+	}
+	if format == "" {
+		format = ExportFormatHTML
+	}
+	if format != ExportFormatHTML && format != ExportFormatCSV {
+		return nil, fmt.Errorf("不支援的匯出格式 %q (html 或 csv)", format)
+	}
+
+	var report *OptimizationReport
+	if reportID != "" {
+		if historyDir == "" {
+			return nil, fmt.Errorf("未設定報告保存目錄 (optimization.reportHistoryDir)")
+		}
+		loaded, err := loadReport(historyDir, reportID)
+		if err != nil {
+			return nil, err
+		}
+		report = loaded
+	} else {
+		generated, err := s.GenerateOptimizationReport(ctx, namespace, window)
+		if err != nil {
+			return nil, err
+		}
+		s.archiveReport(ctx, generated)
+		report = generated
+	}
+
+	export := &ReportExport{
+		ReportID:    report.ID,
+		Namespace:   report.Namespace,
+		GeneratedAt: report.GeneratedAt,
+		Format:      format,
+		Files:       []string{},
+	}
+	base := filepath.Join(dir, report.Namespace, report.GeneratedAt.UTC().Format(reportIDTimeFormat))
+
+	if format == ExportFormatHTML {
+		content, err := RenderReportHTML(report)
+		if err != nil {
+			return nil, err
+		}
+		if err := writeExportFile(base+".html", content); err != nil {
+			return nil, err
+		}
+		export.Files = append(export.Files, base+".html")
+		return export, nil
+	}
+
+	files, err := reportCSVFiles(report)
+	if err != nil {
+		return nil, err
+	}
+	export.Columns = make(map[string][]string, len(files))
+	columns := csvColumns()
+	for _, name := range []string{"pods", "waste", "recommendations"} {
+		path := base + "-" + name + ".csv"
+		if err := writeExportFile(path, files[name]); err != nil {
+			return nil, err
+		}
+		export.Files = append(export.Files, path)
+		export.Columns[filepath.Base(path)] = columns[name]
+	}
+	return export, nil
+}
+
+// writeExportFile 寫入匯出檔案，必要時建立目錄
+func writeExportFile(path string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("建立匯出目錄失敗: %w", err)
+	}
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		return fmt.Errorf("寫入匯出檔案失敗: %w", err)
+	}
+	return nil
+}
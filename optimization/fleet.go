@@ -0,0 +1,218 @@
+package optimization
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"mcp-gke-monitor/gke"
+)
+
+// defaultClusterName 未設定叢集名稱時報告中顯示的名稱
+const defaultClusterName = "GKE-Cluster"
+
+// topRecommendationsPerCluster 跨叢集比較中每個叢集列出的建議數量
+const topRecommendationsPerCluster = 3
+
+// fleetCluster 用於跨叢集比較的叢集
+type fleetCluster struct {
+	name    string
+	service *Service
+}
+
+// SetClusterName 設定報告中顯示的叢集名稱，空字串時使用預設名稱
+func (s *Service) SetClusterName(name string) {
+	if name == "" {
+		name = defaultClusterName
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.clusterName = name
+}
+
+// AddCluster 加入用於跨叢集比較的其他叢集；比較時沿用此服務的優化標準、排除條件與成本模型，結果才能互相比較
+func (s *Service) AddCluster(name string, cluster *Service) error {
+	if cluster == nil {
+		return fmt.Errorf("叢集 %s 的優化服務不能為空", name)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if name == s.clusterName {
+		return fmt.Errorf("叢集名稱 %s 與目前的叢集重複", name)
+	}
+	for _, existing := range s.clusters {
+		if existing.name == name {
+			return fmt.Errorf("叢集名稱 %s 重複", name)
+		}
+	}
+	cluster.SetClusterName(name)
+	s.clusters = append(s.clusters, fleetCluster{name: name, service: cluster})
+	return nil
+}
+
+// CompareClusters 在目前的叢集與以 AddCluster 加入的叢集上並行產生命名空間的優化報告，比較分數、浪費與成本
+// 無法分析的叢集列出錯誤，不影響其他叢集的結果；window 為分析期間，0 時使用預設期間
+func (s *Service) CompareClusters(ctx context.Context, namespace string, window time.Duration) (*ClusterComparison, error) {
+	if namespace == "" {
+		namespace = "default"
+	}
+
+	s.mu.RLock()
+	if len(s.clusters) == 0 {
+		s.mu.RUnlock()
+		return nil, fmt.Errorf("未設定其他叢集，請在配置的 clusters 中加入要比較的叢集")
+	}
+	clusters := append([]fleetCluster{{name: s.clusterName, service: s}}, s.clusters...)
+	settings := s.analysisSettings()
+	currency := s.costModel.Currency
+	s.mu.RUnlock()
+
+	for _, cluster := range clusters[1:] {
+		cluster.service.applyAnalysisSettings(settings)
+	}
+
+	comparison := &ClusterComparison{
+		GeneratedAt: time.Now(),
+		Namespace:   namespace,
+		Currency:    currency,
+		Clusters:    make([]ClusterOptimizationSummary, len(clusters)),
+	}
+
+	// 各叢集的報告同時產生，只回報完成的叢集數，不回報各報告的進度
+	reportCtx := gke.WithoutProgress(ctx)
+	var mu sync.Mutex
+	completed := 0
+	var wg sync.WaitGroup
+	for i, cluster := range clusters {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			comparison.Clusters[i] = cluster.service.clusterSummary(reportCtx, cluster.name, namespace, window)
+
+			mu.Lock()
+			defer mu.Unlock()
+			completed++
+			gke.ReportProgress(ctx, completed, len(clusters), fmt.Sprintf("已完成叢集 %s 的分析 (%d/%d)", cluster.name, completed, len(clusters)))
+		}()
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("跨叢集比較已取消: %w", err)
+	}
+
+	var scoreTotal float64
+	for _, cluster := range comparison.Clusters {
+		if cluster.Error != "" {
+			comparison.FailedClusters++
+			continue
+		}
+		scoreTotal += cluster.OverallScore
+		comparison.TotalEstimatedMonthlySavings += cluster.EstimatedMonthlySavings
+		comparison.TotalNodeMonthlyCost += cluster.NodeMonthlyCost
+	}
+	if analyzed := len(comparison.Clusters) - comparison.FailedClusters; analyzed > 0 {
+		comparison.AverageScore = math.Round(scoreTotal/float64(analyzed)*10) / 10
+	}
+	comparison.TotalEstimatedMonthlySavings = roundCost(comparison.TotalEstimatedMonthlySavings)
+	comparison.TotalNodeMonthlyCost = roundCost(comparison.TotalNodeMonthlyCost)
+
+	// 分數最低 (最需要優化) 的叢集在前，分析失敗的叢集在最後
+	sort.SliceStable(comparison.Clusters, func(i, j int) bool {
+		a, b := comparison.Clusters[i], comparison.Clusters[j]
+		if (a.Error == "") != (b.Error == "") {
+			return a.Error == ""
+		}
+		return a.OverallScore < b.OverallScore
+	})
+
+	return comparison, nil
+}
+
+// clusterSummary 產生單一叢集的優化報告並整理為比較用的摘要
+func (s *Service) clusterSummary(ctx context.Context, name, namespace string, window time.Duration) ClusterOptimizationSummary {
+	summary := ClusterOptimizationSummary{Cluster: name}
+
+	report, err := s.GenerateOptimizationReport(ctx, namespace, window)
+	if err != nil {
+		summary.Error = err.Error()
+		return summary
+	}
+
+	summary.OverallScore = report.Summary.OverallScore
+	summary.TotalPods = report.Summary.TotalPods
+	summary.PodsNeedingOptimization = report.Summary.PodsNeedingOptimization
+	summary.Recommendations = len(report.Recommendations)
+	summary.EstimatedMonthlySavings = report.Summary.EstimatedMonthlySavings
+	summary.CPUWaste = report.ResourceWaste.TotalWastage.TotalCPUWaste
+	summary.MemoryWaste = report.ResourceWaste.TotalWastage.TotalMemoryWaste
+	summary.WastePercentage = report.ResourceWaste.TotalWastage.WastePercentage
+	if nodes := report.ResourceWaste.Nodes; nodes != nil {
+		summary.RemovableNodes = nodes.RemovableNodes
+	}
+	if storage := report.ResourceWaste.Storage; storage != nil {
+		summary.StorageMonthlyCost = storage.MonthlyCost
+	}
+	for _, rec := range report.Recommendations {
+		if rec.Priority == PriorityHigh {
+			summary.HighPriority++
+		}
+		if len(summary.TopRecommendations) < topRecommendationsPerCluster {
+			summary.TopRecommendations = append(summary.TopRecommendations, rec.Title)
+		}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	nodes, err := s.gkeService.GetNodeUtilization(ctx)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Printf("警告: 無法取得叢集 %s 的節點，略過節點成本: %v", name, err)
+		}
+		return summary
+	}
+	var cpu, memory int64
+	for _, node := range nodes {
+		cpu += node.CPUAllocatable
+		memory += node.MemoryAllocatable
+	}
+	summary.Nodes = len(nodes)
+	summary.NodeMonthlyCost = roundCost(s.costModel.monthlyCost(float64(cpu), float64(memory)/(1<<20)))
+	return summary
+}
+
+// analysisSettings 影響分析結果的設定，跨叢集比較時套用到其他叢集
+// 呼叫端需持有 s.mu 讀鎖
+func (s *Service) analysisSettings() *Service {
+	return &Service{
+		criteria:             s.criteria,
+		excludeSelectors:     s.excludeSelectors,
+		analysisWorkers:      s.analysisWorkers,
+		productionNamespaces: s.productionNamespaces,
+		allowedRegistries:    s.allowedRegistries,
+		requiredLabels:       s.requiredLabels,
+		costModel:            s.costModel,
+		sloTiers:             s.sloTiers,
+		severityOverrides:    s.severityOverrides,
+	}
+}
+
+// applyAnalysisSettings 套用其他服務的分析設定
+func (s *Service) applyAnalysisSettings(settings *Service) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.criteria = settings.criteria
+	s.excludeSelectors = settings.excludeSelectors
+	s.analysisWorkers = settings.analysisWorkers
+	s.productionNamespaces = settings.productionNamespaces
+	s.allowedRegistries = settings.allowedRegistries
+	s.requiredLabels = settings.requiredLabels
+	s.costModel = settings.costModel
+	s.sloTiers = settings.sloTiers
+	s.severityOverrides = settings.severityOverrides
+}
@@ -5,6 +5,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
+
+	"mcp-gke-monitor/gke"
 
 	"github.com/mark3labs/mcp-go/mcp"
 )
@@ -27,11 +30,28 @@ func (h *Handler) GenerateOptimizationReport(ctx context.Context, request mcp.Ca
 		namespace = ns
 	}
 
-	report, err := h.service.GenerateOptimizationReport(namespace)
+	window, err := h.windowArgument(request)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := h.service.GenerateOptimizationReport(ctx, namespace, window)
 	if err != nil {
 		return nil, fmt.Errorf("生成優化報告失敗: %w", err)
 	}
 
+	// 保存報告，之後可以用 compare_optimization_reports 比較進度；設定 bucket 時同時上傳到 Cloud Storage
+	h.service.archiveReport(ctx, report)
+
+	format, _ := request.Params.Arguments["format"].(string)
+	switch format {
+	case "", ReportFormatJSON:
+	case ReportFormatMarkdown:
+		return mcp.NewToolResultText(RenderReportMarkdown(report)), nil
+	default:
+		return nil, fmt.Errorf("不支援的報告格式 %q (json 或 markdown)", format)
+	}
+
 	reportJSON, err := json.Marshal(report)
 	if err != nil {
 		return nil, fmt.Errorf("序列化優化報告失敗: %w", err)
@@ -48,8 +68,13 @@ func (h *Handler) GetOptimizationSummary(ctx context.Context, request mcp.CallTo
 		namespace = ns
 	}
 
+	window, err := h.windowArgument(request)
+	if err != nil {
+		return nil, err
+	}
+
 	// 生成完整報告然後提取摘要
-	report, err := h.service.GenerateOptimizationReport(namespace)
+	report, err := h.service.GenerateOptimizationReport(ctx, namespace, window)
 	if err != nil {
 		return nil, fmt.Errorf("生成優化摘要失敗: %w", err)
 	}
@@ -59,12 +84,14 @@ func (h *Handler) GetOptimizationSummary(ctx context.Context, request mcp.CallTo
 		ClusterName string              `json:"clusterName"`
 		Namespace   string              `json:"namespace"`
 		GeneratedAt string              `json:"generatedAt"`
+		Window      string              `json:"window,omitempty"`
 		Summary     OptimizationSummary `json:"summary"`
 		TopIssues   []string            `json:"topIssues"`
 	}{
 		ClusterName: report.ClusterName,
 		Namespace:   report.Namespace,
 		GeneratedAt: report.GeneratedAt.Format("2006-01-02 15:04:05"),
+		Window:      report.Window,
 		Summary:     report.Summary,
 		TopIssues:   h.extractTopIssues(report.Recommendations),
 	}
@@ -95,14 +122,24 @@ func (h *Handler) GetOptimizationRecommendations(ctx context.Context, request mc
 		recommendationType = rt
 	}
 
+	minConfidence, _ := request.Params.Arguments["minConfidence"].(string)
+	if _, ok := confidenceRank[Confidence(minConfidence)]; minConfidence != "" && !ok {
+		return nil, fmt.Errorf("minConfidence 必須是 HIGH、MEDIUM 或 LOW: %s", minConfidence)
+	}
+
+	window, err := h.windowArgument(request)
+	if err != nil {
+		return nil, err
+	}
+
 	// 生成完整報告
-	report, err := h.service.GenerateOptimizationReport(namespace)
+	report, err := h.service.GenerateOptimizationReport(ctx, namespace, window)
 	if err != nil {
 		return nil, fmt.Errorf("取得優化建議失敗: %w", err)
 	}
 
 	// 過濾建議
-	filteredRecommendations := h.filterRecommendations(report.Recommendations, priority, recommendationType)
+	filteredRecommendations := h.filterRecommendations(report.Recommendations, priority, recommendationType, Confidence(minConfidence))
 
 	// 創建回應
 	response := struct {
@@ -137,8 +174,13 @@ func (h *Handler) GetResourceWasteAnalysis(ctx context.Context, request mcp.Call
 		namespace = ns
 	}
 
+	window, err := h.windowArgument(request)
+	if err != nil {
+		return nil, err
+	}
+
 	// 生成完整報告
-	report, err := h.service.GenerateOptimizationReport(namespace)
+	report, err := h.service.GenerateOptimizationReport(ctx, namespace, window)
 	if err != nil {
 		return nil, fmt.Errorf("取得資源浪費分析失敗: %w", err)
 	}
@@ -180,8 +222,13 @@ func (h *Handler) GetPodOptimizationAnalysis(ctx context.Context, request mcp.Ca
 		namespace = ns
 	}
 
+	window, err := h.windowArgument(request)
+	if err != nil {
+		return nil, err
+	}
+
 	// 生成完整報告
-	report, err := h.service.GenerateOptimizationReport(namespace)
+	report, err := h.service.GenerateOptimizationReport(ctx, namespace, window)
 	if err != nil {
 		return nil, fmt.Errorf("取得 Pod 優化分析失敗: %w", err)
 	}
@@ -199,10 +246,10 @@ func (h *Handler) GetPodOptimizationAnalysis(ctx context.Context, request mcp.Ca
 		return nil, fmt.Errorf("找不到 Pod %s 的分析資料", podName)
 	}
 
-	// 找到相關的建議
+	// 找到相關的建議，包含以此 Pod 為佐證的工作負載建議
 	var relatedRecommendations []Recommendation
 	for _, rec := range report.Recommendations {
-		if rec.PodName == podName {
+		if h.recommendationCoversPod(rec, *podAnalysis) {
 			relatedRecommendations = append(relatedRecommendations, rec)
 		}
 	}
@@ -237,15 +284,28 @@ func (h *Handler) GetOptimizationCriteria(ctx context.Context, request mcp.CallT
 	criteria := h.service.GetOptimizationCriteria()
 
 	response := struct {
-		Criteria    OptimizationCriteria `json:"criteria"`
-		Description map[string]string    `json:"description"`
+		Criteria          OptimizationCriteria `json:"criteria"`
+		SLOTiers          []SLOTier            `json:"sloTiers,omitempty"`
+		SeverityOverrides []SeverityOverride   `json:"severityOverrides,omitempty"`
+		Description       map[string]string    `json:"description"`
 	}{
-		Criteria: criteria,
+		Criteria:          criteria,
+		SLOTiers:          h.service.SLOTiers(),
+		SeverityOverrides: h.service.SeverityOverrides(),
 		Description: map[string]string{
 			"cpuThreshold":    "CPU 使用率低於此值視為過度配置",
 			"memoryThreshold": "記憶體使用率低於此值視為過度配置",
 			"healthThreshold": "重啟次數超過此值視為不健康",
 			"idleThreshold":   "使用率低於此值視為閒置",
+			"headroomFactor":  "建議的 requests 與 limits 為 P95 與尖峰使用量乘上此係數",
+			"minReplicas":     "副本數建議不會低於此值，維持高可用",
+			"diskThreshold":   "ephemeral storage 與 PVC 的使用率超過此值視為即將用盡，超過 95% 為高優先級",
+
+			"minObservationHours": "使用量資料涵蓋的時間少於此值 (小時) 的工作負載只標記為資料不足，不提供資源、副本數與 HPA 建議值",
+			"cpuLimitPolicy":      "持續 CPU 節流時的建議：raise 提高 CPU limit，remove 移除 CPU limit 只保留 requests",
+			"sloTiers":            "可用性等級 (設定檔 optimization.sloTiers)：所屬命名空間的建議值改用等級的 headroomFactor、requestBasis (p95 或 peak) 與 minReplicas",
+			"severityOverrides":   "嚴重程度覆寫 (設定檔 optimization.severityOverrides)：問題類型在所列命名空間 (未列出時為所有命名空間) 改用設定的嚴重程度，命名空間的覆寫優先",
+			"scoring":             "優化分數 = (100 - 各問題依嚴重程度的扣分) × (1 - healthWeight) + 健康分數 × healthWeight；健康分數依重啟、未就緒、非 Running 與記憶體洩漏扣分",
 		},
 	}
 
@@ -286,17 +346,75 @@ func (h *Handler) UpdateOptimizationCriteria(ctx context.Context, request mcp.Ca
 		newCriteria.IdleThreshold = h.service.GetOptimizationCriteria().IdleThreshold
 	}
 
+	if headroomFactor, ok := request.Params.Arguments["headroomFactor"].(float64); ok {
+		if headroomFactor < 1 {
+			return nil, errors.New("headroomFactor 必須大於或等於 1")
+		}
+		newCriteria.HeadroomFactor = headroomFactor
+	} else {
+		newCriteria.HeadroomFactor = h.service.GetOptimizationCriteria().HeadroomFactor
+	}
+
+	if minReplicas, ok := request.Params.Arguments["minReplicas"].(float64); ok {
+		if minReplicas < 1 {
+			return nil, errors.New("minReplicas 必須大於或等於 1")
+		}
+		newCriteria.MinReplicas = int32(minReplicas)
+	} else {
+		newCriteria.MinReplicas = h.service.GetOptimizationCriteria().MinReplicas
+	}
+
+	if diskThreshold, ok := request.Params.Arguments["diskThreshold"].(float64); ok {
+		if diskThreshold <= 0 || diskThreshold > 100 {
+			return nil, errors.New("diskThreshold 必須介於 0 與 100 之間")
+		}
+		newCriteria.DiskThreshold = diskThreshold
+	} else {
+		newCriteria.DiskThreshold = h.service.GetOptimizationCriteria().DiskThreshold
+	}
+
+	if minObservationHours, ok := request.Params.Arguments["minObservationHours"].(float64); ok {
+		if minObservationHours < 0 {
+			return nil, errors.New("minObservationHours 不可為負值")
+		}
+		newCriteria.MinObservationHours = minObservationHours
+	} else {
+		newCriteria.MinObservationHours = h.service.GetOptimizationCriteria().MinObservationHours
+	}
+
+	if cpuLimitPolicy, ok := request.Params.Arguments["cpuLimitPolicy"].(string); ok && cpuLimitPolicy != "" {
+		if err := validCPULimitPolicy(cpuLimitPolicy); err != nil {
+			return nil, err
+		}
+		newCriteria.CPULimitPolicy = cpuLimitPolicy
+	} else {
+		newCriteria.CPULimitPolicy = h.service.GetOptimizationCriteria().CPULimitPolicy
+	}
+
+	newCriteria.Scoring = h.service.GetOptimizationCriteria().Scoring
+	if scoring, ok := request.Params.Arguments["scoring"].(map[string]interface{}); ok {
+		weights, err := mergeScoringWeights(newCriteria.Scoring, scoring)
+		if err != nil {
+			return nil, err
+		}
+		newCriteria.Scoring = weights
+	}
+
 	// 更新標準
-	h.service.UpdateOptimizationCriteria(newCriteria)
+	if err := h.service.UpdateOptimizationCriteria(newCriteria); err != nil {
+		return nil, fmt.Errorf("更新優化標準失敗: %w", err)
+	}
 
 	response := struct {
 		Message     string               `json:"message"`
 		UpdatedAt   string               `json:"updatedAt"`
 		NewCriteria OptimizationCriteria `json:"newCriteria"`
+		SavedTo     string               `json:"savedTo,omitempty"` // 優化標準的狀態檔，服務重新啟動後會載入
 	}{
 		Message:     "優化標準已成功更新",
 		UpdatedAt:   fmt.Sprintf("%v", request.Params.Arguments),
 		NewCriteria: newCriteria,
+		SavedTo:     h.service.CriteriaPath(),
 	}
 
 	responseJSON, err := json.Marshal(response)
@@ -307,8 +425,353 @@ func (h *Handler) UpdateOptimizationCriteria(ctx context.Context, request mcp.Ca
 	return mcp.NewToolResultText(string(responseJSON)), nil
 }
 
+// GetRecommendationPatch 將 CPU 與記憶體建議轉換為可直接套用的 patch YAML
+func (h *Handler) GetRecommendationPatch(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	namespace := ""
+	if ns, ok := request.Params.Arguments["namespace"].(string); ok {
+		namespace = ns
+	}
+
+	recommendationID := ""
+	if id, ok := request.Params.Arguments["recommendationId"].(string); ok {
+		recommendationID = id
+	}
+
+	format := ""
+	if f, ok := request.Params.Arguments["format"].(string); ok {
+		format = f
+	}
+
+	window, err := h.windowArgument(request)
+	if err != nil {
+		return nil, err
+	}
+
+	patches, err := h.service.GetRecommendationPatches(ctx, namespace, recommendationID, format, window)
+	if err != nil {
+		return nil, fmt.Errorf("產生建議 patch 失敗: %w", err)
+	}
+
+	responseJSON, err := json.Marshal(patches)
+	if err != nil {
+		return nil, fmt.Errorf("序列化建議 patch 失敗: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(responseJSON)), nil
+}
+
+// ApplyRecommendation 套用資源建議，預設僅進行 dry-run
+func (h *Handler) ApplyRecommendation(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// 建議 ID 是必要參數
+	recommendationID, ok := request.Params.Arguments["recommendationId"].(string)
+	if !ok || recommendationID == "" {
+		return nil, errors.New("必須提供有效的建議 ID")
+	}
+
+	namespace := ""
+	if ns, ok := request.Params.Arguments["namespace"].(string); ok {
+		namespace = ns
+	}
+
+	confirm, _ := request.Params.Arguments["confirm"].(string)
+	commit, _ := request.Params.Arguments["commit"].(bool)
+	if commit && confirm == "" {
+		return nil, errors.New("實際套用時必須提供確認參數 confirm (值為建議 ID)")
+	}
+
+	window, err := h.windowArgument(request)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := h.service.ApplyRecommendation(ctx, namespace, recommendationID, confirm, commit, window)
+	if err != nil {
+		return nil, fmt.Errorf("套用建議失敗: %w", err)
+	}
+
+	responseJSON, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("序列化套用結果失敗: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(responseJSON)), nil
+}
+
+// SimulateNodeConsolidation 模擬將 Pod 重新裝箱到最少的節點，回報可以釋放的節點
+func (h *Handler) SimulateNodeConsolidation(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	nodePool, _ := request.Params.Arguments["nodePool"].(string)
+
+	simulation, err := h.service.SimulateNodeConsolidation(ctx, nodePool)
+	if err != nil {
+		return nil, fmt.Errorf("模擬節點整併失敗: %w", err)
+	}
+
+	responseJSON, err := json.Marshal(simulation)
+	if err != nil {
+		return nil, fmt.Errorf("序列化模擬結果失敗: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(responseJSON)), nil
+}
+
+// DetectIdleNamespaces 找出所有工作負載都已閒置一段期間的命名空間
+func (h *Handler) DetectIdleNamespaces(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	days := 0
+	if value, ok := request.Params.Arguments["days"].(float64); ok {
+		days = int(value)
+	}
+
+	report, err := h.service.DetectIdleNamespaces(ctx, days)
+	if err != nil {
+		return nil, fmt.Errorf("偵測閒置命名空間失敗: %w", err)
+	}
+
+	responseJSON, err := json.Marshal(report)
+	if err != nil {
+		return nil, fmt.Errorf("序列化閒置命名空間失敗: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(responseJSON)), nil
+}
+
+// AssessAutopilotSuitability 評估命名空間的工作負載能否改用 GKE Autopilot 並比較成本
+func (h *Handler) AssessAutopilotSuitability(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	namespace, _ := request.Params.Arguments["namespace"].(string)
+
+	assessment, err := h.service.AssessAutopilot(ctx, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("評估 Autopilot 可行性失敗: %w", err)
+	}
+
+	responseJSON, err := json.Marshal(assessment)
+	if err != nil {
+		return nil, fmt.Errorf("序列化 Autopilot 評估結果失敗: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(responseJSON)), nil
+}
+
+// CompareClusters 在配置的叢集上執行優化分析並比較分數、浪費與成本
+func (h *Handler) CompareClusters(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	namespace, _ := request.Params.Arguments["namespace"].(string)
+	window, err := h.windowArgument(request)
+	if err != nil {
+		return nil, err
+	}
+
+	comparison, err := h.service.CompareClusters(ctx, namespace, window)
+	if err != nil {
+		return nil, fmt.Errorf("跨叢集比較失敗: %w", err)
+	}
+
+	responseJSON, err := json.Marshal(comparison)
+	if err != nil {
+		return nil, fmt.Errorf("序列化跨叢集比較結果失敗: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(responseJSON)), nil
+}
+
+// AnalyzeStartupLatency 分析各工作負載從 Pod 建立到就緒的時間
+func (h *Handler) AnalyzeStartupLatency(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	namespace, _ := request.Params.Arguments["namespace"].(string)
+
+	report, err := h.service.AnalyzeStartupLatency(ctx, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("分析啟動時間失敗: %w", err)
+	}
+
+	responseJSON, err := json.Marshal(report)
+	if err != nil {
+		return nil, fmt.Errorf("序列化啟動時間分析失敗: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(responseJSON)), nil
+}
+
+// AnalyzePodSecurity 依 Pod Security Standards 評估 Pod 並依命名空間列出違規
+func (h *Handler) AnalyzePodSecurity(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	namespace, _ := request.Params.Arguments["namespace"].(string)
+	level, _ := request.Params.Arguments["level"].(string)
+
+	report, err := h.service.AnalyzePodSecurity(ctx, namespace, level)
+	if err != nil {
+		return nil, fmt.Errorf("分析 Pod Security Standards 失敗: %w", err)
+	}
+
+	responseJSON, err := json.Marshal(report)
+	if err != nil {
+		return nil, fmt.Errorf("序列化 Pod Security Standards 分析失敗: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(responseJSON)), nil
+}
+
+// ListOptimizationReports 列出命名空間已保存的優化報告
+func (h *Handler) ListOptimizationReports(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	namespace, _ := request.Params.Arguments["namespace"].(string)
+
+	reports, err := h.service.ListOptimizationReports(namespace)
+	if err != nil {
+		return nil, fmt.Errorf("列出優化報告失敗: %w", err)
+	}
+
+	responseJSON, err := json.Marshal(reports)
+	if err != nil {
+		return nil, fmt.Errorf("序列化報告列表失敗: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(responseJSON)), nil
+}
+
+// CompareOptimizationReports 比較兩份已保存的優化報告
+func (h *Handler) CompareOptimizationReports(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	namespace, _ := request.Params.Arguments["namespace"].(string)
+	baselineID, _ := request.Params.Arguments["baselineId"].(string)
+	currentID, _ := request.Params.Arguments["currentId"].(string)
+
+	comparison, err := h.service.CompareOptimizationReports(namespace, baselineID, currentID)
+	if err != nil {
+		return nil, fmt.Errorf("比較優化報告失敗: %w", err)
+	}
+
+	responseJSON, err := json.Marshal(comparison)
+	if err != nil {
+		return nil, fmt.Errorf("序列化比較結果失敗: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(responseJSON)), nil
+}
+
+// ExportOptimizationReport 將優化報告匯出為檔案
+func (h *Handler) ExportOptimizationReport(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	namespace, _ := request.Params.Arguments["namespace"].(string)
+	reportID, _ := request.Params.Arguments["reportId"].(string)
+	format, _ := request.Params.Arguments["format"].(string)
+	window, err := h.windowArgument(request)
+	if err != nil {
+		return nil, err
+	}
+
+	export, err := h.service.ExportOptimizationReport(ctx, namespace, reportID, format, window)
+	if err != nil {
+		return nil, fmt.Errorf("匯出優化報告失敗: %w", err)
+	}
+
+	responseJSON, err := json.Marshal(export)
+	if err != nil {
+		return nil, fmt.Errorf("序列化匯出結果失敗: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(responseJSON)), nil
+}
+
+// SendReport 產生優化報告並傳送到設定的 webhook
+func (h *Handler) SendReport(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	namespace, _ := request.Params.Arguments["namespace"].(string)
+
+	result, err := h.service.SendReport(ctx, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("傳送優化報告失敗: %w", err)
+	}
+
+	responseJSON, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("序列化傳送結果失敗: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(responseJSON)), nil
+}
+
+// SnoozeRecommendation 暫停建議直到指定時間
+func (h *Handler) SnoozeRecommendation(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	untilValue, _ := request.Params.Arguments["until"].(string)
+	if untilValue == "" {
+		return nil, errors.New("必須指定 until")
+	}
+	until, err := ParseSnoozeUntil(untilValue)
+	if err != nil {
+		return nil, err
+	}
+
+	snooze := Snooze{Until: until}
+	snooze.Namespace, _ = request.Params.Arguments["namespace"].(string)
+	snooze.RecommendationID, _ = request.Params.Arguments["recommendationId"].(string)
+	snooze.WorkloadKind, _ = request.Params.Arguments["workloadKind"].(string)
+	snooze.WorkloadName, _ = request.Params.Arguments["workloadName"].(string)
+	snooze.Issue, _ = request.Params.Arguments["issue"].(string)
+	snooze.Reason, _ = request.Params.Arguments["reason"].(string)
+
+	result, err := h.service.SnoozeRecommendation(snooze)
+	if err != nil {
+		return nil, fmt.Errorf("暫停建議失敗: %w", err)
+	}
+
+	responseJSON, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("序列化暫停設定失敗: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(responseJSON)), nil
+}
+
+// UnsnoozeRecommendation 取消暫停建議
+func (h *Handler) UnsnoozeRecommendation(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	id, _ := request.Params.Arguments["snoozeId"].(string)
+	if id == "" {
+		return nil, errors.New("必須指定 snoozeId")
+	}
+
+	if err := h.service.UnsnoozeRecommendation(id); err != nil {
+		return nil, fmt.Errorf("取消暫停建議失敗: %w", err)
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("已取消暫停 %s", id)), nil
+}
+
+// ListSnoozedRecommendations 列出暫停中的建議設定
+func (h *Handler) ListSnoozedRecommendations(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	namespace, _ := request.Params.Arguments["namespace"].(string)
+
+	responseJSON, err := json.Marshal(h.service.ListSnoozes(namespace))
+	if err != nil {
+		return nil, fmt.Errorf("序列化暫停設定失敗: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(responseJSON)), nil
+}
+
 // 輔助函數
 
+// windowArgument 解析分析期間參數 window (例如 1h、24h、7d)，未提供時回傳 0 表示使用預設期間
+func (h *Handler) windowArgument(request mcp.CallToolRequest) (time.Duration, error) {
+	value, _ := request.Params.Arguments["window"].(string)
+	if value == "" {
+		return 0, nil
+	}
+	window, err := gke.ParseDuration(value)
+	if err != nil || window <= 0 {
+		return 0, fmt.Errorf("分析期間 window 必須是正的時間長度 (例如 1h, 24h, 7d): %q", value)
+	}
+	return window, nil
+}
+
+// recommendationCoversPod 判斷建議是否與 Pod 相關：Pod 本身的建議、以 Pod 為佐證的建議，或 Pod 所屬工作負載的副本數與 HPA 建議
+func (h *Handler) recommendationCoversPod(rec Recommendation, podAnalysis PodOptimization) bool {
+	if rec.PodName == podAnalysis.PodName {
+		return true
+	}
+	for _, evidence := range rec.Evidence {
+		if evidence.PodName == podAnalysis.PodName {
+			return true
+		}
+	}
+	return rec.PodName == "" && len(rec.Evidence) == 0 &&
+		rec.Namespace == podAnalysis.Namespace &&
+		rec.WorkloadKind == podAnalysis.WorkloadKind &&
+		rec.WorkloadName == podAnalysis.WorkloadName
+}
+
 // extractTopIssues 提取主要問題
 func (h *Handler) extractTopIssues(recommendations []Recommendation) []string {
 	var topIssues []string
@@ -336,7 +799,7 @@ func (h *Handler) extractTopIssues(recommendations []Recommendation) []string {
 }
 
 // filterRecommendations 過濾建議
-func (h *Handler) filterRecommendations(recommendations []Recommendation, priority, recommendationType string) []Recommendation {
+func (h *Handler) filterRecommendations(recommendations []Recommendation, priority, recommendationType string, minConfidence Confidence) []Recommendation {
 	var filtered []Recommendation
 
 	for _, rec := range recommendations {
@@ -350,6 +813,11 @@ func (h *Handler) filterRecommendations(recommendations []Recommendation, priori
 			continue
 		}
 
+		// 信心程度過濾，保留達到最低信心程度的建議
+		if minConfidence != "" && !meetsConfidence(rec, minConfidence) {
+			continue
+		}
+
 		filtered = append(filtered, rec)
 	}
 
@@ -368,6 +836,10 @@ func (h *Handler) generateWasteInsights(waste ResourceWasteAnalysis) []string {
 		insights = append(insights, fmt.Sprintf("發現 %d 個閒置 Pod，建議考慮縮減或刪除", len(waste.IdlePods)))
 	}
 
+	if storage := waste.Storage; storage != nil && storage.EstimatedMonthlySavings > 0 {
+		insights = append(insights, fmt.Sprintf("PVC 容量過大、沒有使用或使用高價磁碟，每月可節省約 %.2f %s", storage.EstimatedMonthlySavings, storage.Currency))
+	}
+
 	if waste.TotalWastage.WastePercentage > 20 {
 		insights = append(insights, fmt.Sprintf("整體資源浪費率達 %.1f%%，建議立即優化", waste.TotalWastage.WastePercentage))
 	} else if waste.TotalWastage.WastePercentage > 10 {
@@ -0,0 +1,319 @@
+package optimization
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	// defaultReportHistoryLimit 每個命名空間保留的報告數量，超過時刪除最舊的報告
+	defaultReportHistoryLimit = 100
+
+	// reportIDTimeFormat 報告 ID 中的時間格式 (UTC)
+	reportIDTimeFormat = "20060102T150405.000Z"
+)
+
+// SetReportHistoryDir 設定保存優化報告的目錄，每個命名空間一個子目錄；dir 為空時不保存報告
+func (s *Service) SetReportHistoryDir(dir string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reportHistoryDir = dir
+}
+
+// storeReport 將報告寫入歷史目錄並設定報告 ID (namespace/時間)，超過保留數量時刪除最舊的報告
+// 保存失敗不影響報告本身，只記錄警告
+func (s *Service) storeReport(report *OptimizationReport) {
+	s.mu.RLock()
+	dir := s.reportHistoryDir
+	s.mu.RUnlock()
+	if dir == "" {
+		return
+	}
+
+	report.ID = report.Namespace + "/" + report.GeneratedAt.UTC().Format(reportIDTimeFormat)
+	if err := writeReport(dir, report); err != nil {
+		report.ID = ""
+		if s.logger != nil {
+			s.logger.Printf("警告: 無法保存優化報告: %v", err)
+		}
+		return
+	}
+
+	ids, err := storedReportIDs(dir, report.Namespace)
+	if err != nil || len(ids) <= defaultReportHistoryLimit {
+		return
+	}
+	for _, id := range ids[:len(ids)-defaultReportHistoryLimit] {
+		os.Remove(reportPath(dir, id))
+	}
+}
+
+// writeReport 先寫入暫存檔再取代，避免中途失敗留下不完整的報告
+func writeReport(dir string, report *OptimizationReport) error {
+	path := reportPath(dir, report.ID)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("建立報告目錄失敗: %w", err)
+	}
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("序列化優化報告失敗: %w", err)
+	}
+
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o644); err != nil {
+		return fmt.Errorf("寫入報告暫存檔失敗: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("取代報告檔案失敗: %w", err)
+	}
+	return nil
+}
+
+// reportPath 報告 ID 對應的檔案路徑
+func reportPath(dir, id string) string {
+	return filepath.Join(dir, filepath.FromSlash(id)+".json")
+}
+
+// storedReportIDs 取得命名空間已保存的報告 ID，由舊到新排序
+func storedReportIDs(dir, namespace string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(dir, namespace))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("讀取報告目錄失敗: %w", err)
+	}
+
+	var ids []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		ids = append(ids, namespace+"/"+strings.TrimSuffix(name, ".json"))
+	}
+	// 時間格式固定長度，字串排序即為時間排序
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// loadReport 讀取已保存的報告
+func loadReport(dir, id string) (*OptimizationReport, error) {
+	if strings.Contains(id, "..") || strings.Count(id, "/") != 1 {
+		return nil, fmt.Errorf("無效的報告 ID %q", id)
+	}
+
+	data, err := os.ReadFile(reportPath(dir, id))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("找不到報告 %s", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("讀取報告 %s 失敗: %w", id, err)
+	}
+
+	var report OptimizationReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("解析報告 %s 失敗: %w", id, err)
+	}
+	report.ID = id
+	return &report, nil
+}
+
+// ListOptimizationReports 列出命名空間已保存的報告，由新到舊排序
+func (s *Service) ListOptimizationReports(namespace string) ([]StoredReport, error) {
+	s.mu.RLock()
+	dir := s.reportHistoryDir
+	s.mu.RUnlock()
+	if dir == "" {
+		return nil, fmt.Errorf("未設定報告保存目錄 (optimization.reportHistoryDir)")
+	}
+	if namespace == "" {
+		namespace = "default"
+	}
+
+	ids, err := storedReportIDs(dir, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	reports := make([]StoredReport, 0, len(ids))
+	for i := len(ids) - 1; i >= 0; i-- {
+		report, err := loadReport(dir, ids[i])
+		if err != nil {
+			if s.logger != nil {
+				s.logger.Printf("警告: %v", err)
+			}
+			continue
+		}
+		reports = append(reports, StoredReport{
+			ID:                      report.ID,
+			Namespace:               report.Namespace,
+			GeneratedAt:             report.GeneratedAt,
+			Window:                  report.Window,
+			OverallScore:            report.Summary.OverallScore,
+			Recommendations:         len(report.Recommendations),
+			EstimatedMonthlySavings: report.Summary.EstimatedMonthlySavings,
+		})
+	}
+	return reports, nil
+}
+
+// CompareOptimizationReports 比較命名空間的兩份已保存報告：已解決與新出現的問題，以及各工作負載的分數變化
+// currentID 為空時使用最新的報告，baselineID 為空時使用 current 的前一份報告
+func (s *Service) CompareOptimizationReports(namespace, baselineID, currentID string) (*ReportComparison, error) {
+	s.mu.RLock()
+	dir := s.reportHistoryDir
+	s.mu.RUnlock()
+	if dir == "" {
+		return nil, fmt.Errorf("未設定報告保存目錄 (optimization.reportHistoryDir)")
+	}
+	if namespace == "" {
+		namespace = "default"
+	}
+
+	if baselineID == "" || currentID == "" {
+		ids, err := storedReportIDs(dir, namespace)
+		if err != nil {
+			return nil, err
+		}
+		if currentID == "" {
+			if len(ids) == 0 {
+				return nil, fmt.Errorf("命名空間 %s 沒有已保存的報告，請先以 generate_optimization_report 產生報告", namespace)
+			}
+			currentID = ids[len(ids)-1]
+		}
+		if baselineID == "" {
+			position := sort.SearchStrings(ids, currentID)
+			if position == 0 {
+				return nil, fmt.Errorf("報告 %s 之前沒有可比較的報告", currentID)
+			}
+			baselineID = ids[position-1]
+		}
+	}
+
+	baseline, err := loadReport(dir, baselineID)
+	if err != nil {
+		return nil, err
+	}
+	current, err := loadReport(dir, currentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return compareReports(baseline, current), nil
+}
+
+// compareReports 比較兩份報告，問題以工作負載與問題類型識別，工作負載層級的建議以建議 ID 識別
+func compareReports(baseline, current *OptimizationReport) *ReportComparison {
+	comparison := &ReportComparison{
+		BaselineID:          baseline.ID,
+		CurrentID:           current.ID,
+		BaselineGeneratedAt: baseline.GeneratedAt,
+		CurrentGeneratedAt:  current.GeneratedAt,
+		ScoreChange:         round1(current.Summary.OverallScore - baseline.Summary.OverallScore),
+		SavingsChange:       math.Round((current.Summary.EstimatedMonthlySavings-baseline.Summary.EstimatedMonthlySavings)*100) / 100,
+		ResolvedIssues:      []IssueChange{},
+		NewIssues:           []IssueChange{},
+		Workloads:           []WorkloadScoreChange{},
+	}
+
+	baselineIssues := recommendationsByIssue(baseline.Recommendations)
+	currentIssues := recommendationsByIssue(current.Recommendations)
+	for key, rec := range baselineIssues {
+		if _, ok := currentIssues[key]; !ok {
+			comparison.ResolvedIssues = append(comparison.ResolvedIssues, issueChange(rec))
+		}
+	}
+	for key, rec := range currentIssues {
+		if _, ok := baselineIssues[key]; !ok {
+			comparison.NewIssues = append(comparison.NewIssues, issueChange(rec))
+		}
+	}
+	sortIssueChanges(comparison.ResolvedIssues)
+	sortIssueChanges(comparison.NewIssues)
+
+	baselineScores := make(map[string]WorkloadOptimization, len(baseline.Workloads))
+	for _, workload := range baseline.Workloads {
+		baselineScores[workload.Namespace+"/"+workload.Kind+"/"+workload.Name] = workload
+	}
+	for _, workload := range current.Workloads {
+		previous, ok := baselineScores[workload.Namespace+"/"+workload.Kind+"/"+workload.Name]
+		if !ok {
+			continue
+		}
+		comparison.Workloads = append(comparison.Workloads, WorkloadScoreChange{
+			Kind:          workload.Kind,
+			Name:          workload.Name,
+			Namespace:     workload.Namespace,
+			BaselineScore: round1(previous.OptimizationScore),
+			CurrentScore:  round1(workload.OptimizationScore),
+			Change:        round1(workload.OptimizationScore - previous.OptimizationScore),
+		})
+	}
+	sort.SliceStable(comparison.Workloads, func(i, j int) bool {
+		return math.Abs(comparison.Workloads[i].Change) > math.Abs(comparison.Workloads[j].Change)
+	})
+
+	return comparison
+}
+
+// recommendationsByIssue 以 namespace/Kind/name/問題類型 (多容器 Pod 再加上容器) 識別問題建議；Pod 層級的建議 ID 含有 Pod 名稱，Pod 重建後會改變
+func recommendationsByIssue(recommendations []Recommendation) map[string]Recommendation {
+	result := make(map[string]Recommendation, len(recommendations))
+	for _, rec := range recommendations {
+		key := rec.ID
+		if rec.Issue != "" {
+			key = rec.Namespace + "/" + rec.WorkloadKind + "/" + rec.WorkloadName + "/" + rec.Issue
+			if rec.Container != "" {
+				key += "/" + rec.Container
+			}
+		}
+		result[key] = rec
+	}
+	return result
+}
+
+// issueChange 將建議轉換為問題變化
+func issueChange(rec Recommendation) IssueChange {
+	issue := rec.Issue
+	if issue == "" {
+		issue = rec.ID
+	}
+	return IssueChange{
+		Issue:        issue,
+		Type:         rec.Type,
+		Priority:     rec.Priority,
+		Title:        rec.Title,
+		Namespace:    rec.Namespace,
+		WorkloadKind: rec.WorkloadKind,
+		WorkloadName: rec.WorkloadName,
+		Container:    rec.Container,
+	}
+}
+
+// sortIssueChanges 依優先級、工作負載與問題排序
+func sortIssueChanges(changes []IssueChange) {
+	sort.Slice(changes, func(i, j int) bool {
+		a, b := changes[i], changes[j]
+		if priorityRank[a.Priority] != priorityRank[b.Priority] {
+			return priorityRank[a.Priority] < priorityRank[b.Priority]
+		}
+		if a.WorkloadName != b.WorkloadName {
+			return a.WorkloadName < b.WorkloadName
+		}
+		return a.Issue < b.Issue
+	})
+}
+
+// round1 四捨五入到小數點後一位
+func round1(value float64) float64 {
+	return math.Round(value*10) / 10
+}
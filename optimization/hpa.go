@@ -0,0 +1,196 @@
+package optimization
+
+import (
+	"fmt"
+	"math"
+
+	"mcp-gke-monitor/gke"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// hpaVariationThreshold CPU 使用量的變異係數 (標準差 / 平均) 超過此值視為突發型負載
+	hpaVariationThreshold = 0.5
+
+	// hpaPeakRatioThreshold 尖峰與平均使用量的比例超過此值視為突發型負載
+	hpaPeakRatioThreshold = 2.0
+
+	// hpaMinTargetUtilization 與 hpaMaxTargetUtilization 建議目標使用率的範圍 (%)
+	hpaMinTargetUtilization = 50
+	hpaMaxTargetUtilization = 80
+)
+
+// recommendAutoscaling 找出使用量波動大且沒有 HPA 的 Deployment 與 StatefulSet，依歷史使用量建議 HPA 參數
+// 突發型負載的尖峰與平均差距大，靜態調整 requests 不是浪費資源就是在尖峰時不足
+func (s *Service) recommendAutoscaling(workloads []*workloadGroup, usageHistory map[string]*gke.MetricsSummary, autoscaled map[string]string) []Recommendation {
+	var recommendations []Recommendation
+	for _, workload := range workloads {
+		if workload.kind != "Deployment" && workload.kind != "StatefulSet" {
+			continue
+		}
+		if _, ok := autoscaled[workload.key()]; ok {
+			continue
+		}
+		if len(workload.pods) > 0 && workload.pods[0].InsufficientData {
+			continue
+		}
+
+		suggestion := s.suggestAutoscaling(workload.pods, usageHistory)
+		if suggestion == nil {
+			continue
+		}
+
+		manifest, err := hpaManifest(workload.kind, workload.name, workload.namespace, suggestion)
+		if err != nil {
+			if s.logger != nil {
+				s.logger.Printf("警告: 無法產生 %s %s 的 HPA 設定: %v", workload.kind, workload.name, err)
+			}
+		}
+		suggestion.Manifest = manifest
+		confidence, confidenceReason := s.autoscalingConfidence(workload.pods, usageHistory)
+
+		recommendations = append(recommendations, Recommendation{
+			ID:       fmt.Sprintf("REC-%s-hpa", workload.name),
+			Type:     RecommendationReplica,
+			Priority: PriorityMedium,
+			Title:    fmt.Sprintf("%s %s 的 CPU 使用量波動大但沒有 HPA", workload.kind, workload.name),
+			Description: fmt.Sprintf("CPU 使用量的變異係數為 %.2f，尖峰為平均的 %.1f 倍，固定副本數無法同時兼顧離峰成本與尖峰容量",
+				suggestion.CoefficientOfVariation, suggestion.PeakToAverage),
+			Impact: "離峰時自動縮減副本降低成本，尖峰時自動擴充避免資源不足",
+			Action: fmt.Sprintf("建立 HPA：以 CPU 使用率 %d%% 為目標，minReplicas %d、maxReplicas %d",
+				suggestion.TargetUtilization, suggestion.MinReplicas, suggestion.MaxReplicas),
+			Namespace:    workload.namespace,
+			WorkloadKind: workload.kind,
+			WorkloadName: workload.name,
+			Autoscaling:  suggestion,
+
+			Confidence:       confidence,
+			ConfidenceReason: confidenceReason,
+		})
+	}
+
+	return recommendations
+}
+
+// suggestAutoscaling 依工作負載各 Pod 的 CPU 使用量統計計算 HPA 參數，使用量穩定或資料不足時回傳 nil
+// 目標使用率取 P95 與尖峰的比例，讓 HPA 來不及擴充時的尖峰仍在 requests 之內；
+// 最少副本數足以承擔離峰使用量，最多副本數足以承擔尖峰使用量並保留餘裕
+func (s *Service) suggestAutoscaling(pods []PodOptimization, usageHistory map[string]*gke.MetricsSummary) *AutoscalingSuggestion {
+	var sumMin, sumAvg, sumP95, sumMax, sumVariation float64
+	var podRequest int64
+	samples := 0
+	for _, podOpt := range pods {
+		history := usageHistory[podOpt.Namespace+"/"+podOpt.PodName]
+		if history == nil || history.CPUMillicores.Avg <= 0 {
+			continue
+		}
+		stats := history.CPUMillicores
+		sumMin += float64(stats.Min)
+		sumAvg += stats.Avg
+		sumP95 += float64(stats.P95)
+		sumMax += float64(stats.Max)
+		sumVariation += stats.StdDev / stats.Avg
+		samples++
+
+		if request := podCPURequest(podOpt.SuggestedResources); request > podRequest {
+			podRequest = request
+		}
+	}
+	if samples == 0 || podRequest == 0 || sumMax <= 0 {
+		return nil
+	}
+
+	variation := sumVariation / float64(samples)
+	peakRatio := sumMax / sumAvg
+	if variation < hpaVariationThreshold && peakRatio < hpaPeakRatioThreshold {
+		return nil
+	}
+
+	target := int32(math.Round(100 * sumP95 / sumMax))
+	target = max(min(target, hpaMaxTargetUtilization), hpaMinTargetUtilization)
+
+	// 以實際有使用量資料的 Pod 推算整個工作負載的總量
+	scale := float64(len(pods)) / float64(samples)
+	capacity := float64(podRequest) * float64(target) / 100
+
+	policy := s.sizingPolicyFor(pods[0].Namespace)
+	headroom := policy.headroom
+
+	minReplicas := max(int32(math.Ceil(sumMin*scale/capacity)), 1)
+	if len(pods) >= 2 {
+		// 已有多個副本的工作負載維持至少兩個副本，避免單點故障
+		minReplicas = max(minReplicas, 2)
+	}
+	if policy.tier != "" {
+		// 可用性等級要求的副本數下限
+		minReplicas = max(minReplicas, policy.minReplicas)
+	}
+	maxReplicas := max(int32(math.Ceil(sumMax*scale*headroom/capacity)), minReplicas+1, int32(len(pods)))
+
+	return &AutoscalingSuggestion{
+		Metric:                 "cpu",
+		TargetUtilization:      target,
+		MinReplicas:            minReplicas,
+		MaxReplicas:            maxReplicas,
+		CurrentReplicas:        len(pods),
+		PodCPURequest:          fmt.Sprintf("%dm", podRequest),
+		CoefficientOfVariation: math.Round(variation*100) / 100,
+		PeakToAverage:          math.Round(peakRatio*10) / 10,
+	}
+}
+
+// podCPURequest 計算 Pod 的 CPU request 總和 (millicores)，容器未設定 request 時以建議值計算
+func podCPURequest(suggestions []ResourceSuggestion) int64 {
+	var total int64
+	for _, suggestion := range suggestions {
+		request := suggestion.Current.CPURequest
+		if quantity, err := resource.ParseQuantity(request); err != nil || quantity.IsZero() {
+			request = suggestion.Suggested.CPURequest
+		}
+		if quantity, err := resource.ParseQuantity(request); err == nil {
+			total += quantity.MilliValue()
+		}
+	}
+	return total
+}
+
+// hpaManifest 產生 autoscaling/v2 HPA 的 YAML
+func hpaManifest(kind, name, namespace string, suggestion *AutoscalingSuggestion) (string, error) {
+	manifest := map[string]interface{}{
+		"apiVersion": "autoscaling/v2",
+		"kind":       "HorizontalPodAutoscaler",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": namespace,
+		},
+		"spec": map[string]interface{}{
+			"scaleTargetRef": map[string]interface{}{
+				"apiVersion": "apps/v1",
+				"kind":       kind,
+				"name":       name,
+			},
+			"minReplicas": suggestion.MinReplicas,
+			"maxReplicas": suggestion.MaxReplicas,
+			"metrics": []interface{}{
+				map[string]interface{}{
+					"type": "Resource",
+					"resource": map[string]interface{}{
+						"name": suggestion.Metric,
+						"target": map[string]interface{}{
+							"type":               "Utilization",
+							"averageUtilization": suggestion.TargetUtilization,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	content, err := yaml.Marshal(manifest)
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
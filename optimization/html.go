@@ -0,0 +1,130 @@
+package optimization
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"sort"
+	"strings"
+)
+
+// reportHTMLTemplate 優化報告的 HTML 頁面，樣式內嵌在頁面中，列印時會隱藏背景色並避免表格列被截斷，可從瀏覽器另存為 PDF
+var reportHTMLTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"target":      recommendationTarget,
+	"issueCounts": formatIssueCounts,
+	"money":       func(value float64) string { return fmt.Sprintf("%.2f", value) },
+	"score":       func(value float64) string { return fmt.Sprintf("%.1f", value) },
+	"lower":       func(p Priority) string { return strings.ToLower(string(p)) },
+}).Parse(`<!DOCTYPE html>
+<html lang="zh-Hant">
+<head>
+<meta charset="utf-8">
+<title>優化報告 - {{.Report.Namespace}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", "Noto Sans TC", "Microsoft JhengHei", sans-serif; margin: 2rem auto; max-width: 1200px; color: #202124; padding: 0 1rem; }
+h1 { border-bottom: 3px solid #1a73e8; padding-bottom: .5rem; }
+h2 { margin-top: 2.5rem; color: #1a73e8; }
+.meta { color: #5f6368; }
+.cards { display: flex; flex-wrap: wrap; gap: 1rem; margin: 1.5rem 0; }
+.card { flex: 1 1 180px; border: 1px solid #dadce0; border-radius: 8px; padding: 1rem; }
+.card .value { font-size: 1.8rem; font-weight: 600; }
+.card .label { color: #5f6368; font-size: .9rem; }
+table { border-collapse: collapse; width: 100%; margin: 1rem 0; font-size: .9rem; }
+th, td { border: 1px solid #dadce0; padding: .4rem .6rem; text-align: left; vertical-align: top; }
+th { background: #f1f3f4; }
+td.number { text-align: right; white-space: nowrap; }
+.priority { display: inline-block; border-radius: 4px; padding: 0 .4rem; font-weight: 600; color: #fff; }
+.priority.high { background: #d93025; }
+.priority.medium { background: #f29900; }
+.priority.low { background: #1e8e3e; }
+@media print {
+	body { margin: 0; max-width: none; }
+	h2 { page-break-after: avoid; }
+	tr { page-break-inside: avoid; }
+	.priority { color: #202124; background: none !important; border: 1px solid #202124; }
+}
+</style>
+</head>
+<body>
+<h1>優化報告: {{.Report.Namespace}}</h1>
+<p class="meta">叢集 {{.Report.ClusterName}} · 產生時間 {{.Report.GeneratedAt.Format "2006-01-02 15:04:05"}}{{if .Report.Window}} · 分析期間 {{.Report.Window}}{{end}}{{if .Report.ID}} · 報告 ID {{.Report.ID}}{{end}}</p>
+
+<div class="cards">
+	<div class="card"><div class="value">{{score .Report.Summary.OverallScore}}</div><div class="label">整體分數 (滿分 100)</div></div>
+	<div class="card"><div class="value">{{money .Report.Summary.EstimatedMonthlySavings}} {{.Report.Summary.Currency}}</div><div class="label">估算每月可節省</div></div>
+	<div class="card"><div class="value">{{.Report.Summary.PodsNeedingOptimization}} / {{.Report.Summary.TotalPods}}</div><div class="label">需要優化的 Pod</div></div>
+	<div class="card"><div class="value">{{len .Report.Recommendations}}</div><div class="label">建議數量</div></div>
+</div>
+
+<h2>建議</h2>
+{{if not .Report.Recommendations}}<p>目前沒有優化建議。</p>{{else}}
+<table>
+<tr><th>優先級</th><th>建議</th><th>類型</th><th>信心</th><th>對象</th><th>每月節省 ({{.Report.Summary.Currency}})</th><th>行動</th></tr>
+{{range .Report.Recommendations}}<tr>
+	<td><span class="priority {{lower .Priority}}">{{.Priority}}</span></td>
+	<td>{{.Title}}</td>
+	<td>{{.Type}}</td>
+	<td title="{{.ConfidenceReason}}">{{.Confidence}}</td>
+	<td>{{target .}}</td>
+	<td class="number">{{money .EstimatedMonthlySavings}}</td>
+	<td>{{.Action}}</td>
+</tr>
+{{end}}</table>{{end}}
+
+{{if .Report.Workloads}}<h2>工作負載</h2>
+<table>
+<tr><th>工作負載</th><th>Pod 數</th><th>分數</th><th>問題</th></tr>
+{{range .Report.Workloads}}<tr>
+	<td>{{.Kind}}/{{.Name}}</td>
+	<td class="number">{{.PodCount}}</td>
+	<td class="number">{{score .OptimizationScore}}</td>
+	<td>{{issueCounts .IssueCounts}}</td>
+</tr>
+{{end}}</table>{{end}}
+
+<h2>資源浪費</h2>
+{{with .Report.ResourceWaste}}<ul>
+	<li>估算成本: {{.TotalWastage.EstimatedCost}}</li>
+	{{if .IdlePods}}<li>閒置 Pod: {{range $i, $pod := .IdlePods}}{{if $i}}, {{end}}{{$pod}}{{end}}</li>{{end}}
+	{{with .Nodes}}<li>使用不足的節點: {{len .UnderutilizedNodes}}，只有單一小型 Pod 的節點: {{len .SinglePodNodes}}，節點池可減少的節點: {{.RemovableNodes}}</li>
+	{{with .Consolidation}}<li>裝箱模擬: {{.CurrentNodes}} 個節點可整併為 {{.RequiredNodes}} 個，可釋放 {{.FreedNodes}} 個</li>{{end}}{{end}}
+	{{with .Storage}}<li>PVC 每月成本: {{printf "%.2f" .MonthlyCost}} {{.Currency}}，容量過大: {{len .OversizedClaims}}，沒有使用: {{len .UnusedClaims}}，無法繫結: {{len .UnboundClaims}}，高價磁碟: {{len .PremiumClaims}}，可節省: {{printf "%.2f" .EstimatedMonthlySavings}} {{.Currency}}/月</li>{{end}}
+</ul>
+{{if .OverProvisionedPods}}<table>
+<tr><th>Pod</th><th>資源</th><th>配置</th><th>使用</th><th>浪費</th></tr>
+{{range .OverProvisionedPods}}<tr><td>{{.PodName}}</td><td>{{.ResourceType}}</td><td>{{.Allocated}}</td><td>{{.Used}}</td><td>{{.WasteAmount}}</td></tr>
+{{end}}</table>{{end}}{{end}}
+
+{{if .Report.Snoozed}}<h2>暫停的建議</h2>
+<table>
+<tr><th>建議</th><th>優先級</th><th>暫停至</th><th>原因</th></tr>
+{{range .Report.Snoozed}}<tr><td>{{.Title}}</td><td>{{.Priority}}</td><td>{{.Until.Format "2006-01-02"}}</td><td>{{.Reason}}</td></tr>
+{{end}}</table>{{end}}
+
+{{if .Report.ExcludedPods}}<h2>排除的 Pod</h2>
+<table>
+<tr><th>Pod</th><th>原因</th></tr>
+{{range .Report.ExcludedPods}}<tr><td>{{.PodName}}</td><td>{{.Reason}}</td></tr>
+{{end}}</table>{{end}}
+</body>
+</html>
+`))
+
+// RenderReportHTML 將優化報告轉換為含樣式的 HTML 頁面，內容與 Markdown 報告相同
+func RenderReportHTML(report *OptimizationReport) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := reportHTMLTemplate.Execute(&buf, struct{ Report *OptimizationReport }{report}); err != nil {
+		return nil, fmt.Errorf("產生 HTML 報告失敗: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// formatIssueCounts 將各問題類型出現的 Pod 數轉換為排序後的文字
+func formatIssueCounts(counts map[string]int) string {
+	issues := make([]string, 0, len(counts))
+	for issue, count := range counts {
+		issues = append(issues, fmt.Sprintf("%s ×%d", issue, count))
+	}
+	sort.Strings(issues)
+	return strings.Join(issues, ", ")
+}
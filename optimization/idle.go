@@ -0,0 +1,165 @@
+package optimization
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"mcp-gke-monitor/gke"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	// defaultIdleNamespaceDays 命名空間所有工作負載持續閒置多少天才視為閒置命名空間
+	defaultIdleNamespaceDays = 7
+
+	// idleCPUMillicoresWithoutRequest 未設定 CPU request 的 Pod，尖峰 CPU 使用量低於此值 (millicores) 視為閒置
+	idleCPUMillicoresWithoutRequest = 5
+)
+
+// systemNamespacePrefixes 叢集元件的命名空間，不列入閒置偵測
+var systemNamespacePrefixes = []string{"kube-", "gke-", "gmp-", "config-management-"}
+
+// SetIdleNamespaceDays 設定命名空間持續閒置多少天才視為閒置命名空間，小於 1 時使用預設值
+func (s *Service) SetIdleNamespaceDays(days int) {
+	if days < 1 {
+		days = defaultIdleNamespaceDays
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.idleNamespaceDays = days
+}
+
+// DetectIdleNamespaces 找出所有工作負載在期間內的尖峰 CPU 使用量都低於閒置閾值的命名空間，建議封存或刪除
+// days 小於 1 時使用設定的天數；需要有歷史使用量的資料來源，只有目前取樣時無法判斷期間內是否閒置
+func (s *Service) DetectIdleNamespaces(ctx context.Context, days int) (*IdleNamespaceReport, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if days < 1 {
+		days = s.idleNamespaceDays
+	}
+	if s.metrics.Name() == gke.UsageSourceMetricsAPI {
+		return nil, fmt.Errorf("使用量資料來源 %s 只有目前取樣，無法判斷 %d 天內是否閒置，請啟用 Cloud Monitoring、Prometheus 或指標收集器", s.metrics.Name(), days)
+	}
+
+	window := time.Duration(days) * 24 * time.Hour
+	pods, err := s.gkeService.GetAllPods(ctx, gke.AllNamespaces)
+	if err != nil {
+		return nil, fmt.Errorf("無法取得 Pod 列表: %w", err)
+	}
+	usage, err := s.metrics.NamespaceUsage(ctx, gke.AllNamespaces, window)
+	if err != nil {
+		return nil, fmt.Errorf("無法從 %s 取得使用量: %w", s.metrics.Name(), err)
+	}
+
+	byNamespace := make(map[string][]gke.Pod)
+	for _, pod := range pods {
+		// 已結束的 Pod 不佔用資源；被排除的 Pod (例如批次工作) 不代表命名空間是否還在使用
+		if pod.Status == "Succeeded" || pod.Status == "Failed" || s.exclusionReason(pod) != "" {
+			continue
+		}
+		if systemNamespace(pod.Namespace) {
+			continue
+		}
+		byNamespace[pod.Namespace] = append(byNamespace[pod.Namespace], pod)
+	}
+
+	report := &IdleNamespaceReport{
+		GeneratedAt:   time.Now(),
+		Days:          days,
+		IdleThreshold: s.criteria.IdleThreshold,
+		UsageSource:   s.metrics.Name(),
+		Namespaces:    []IdleNamespace{},
+	}
+	since := report.GeneratedAt.Add(-window)
+	for namespace, namespacePods := range byNamespace {
+		if idle := s.idleNamespace(namespace, namespacePods, usage, since); idle != nil {
+			report.Namespaces = append(report.Namespaces, *idle)
+		}
+	}
+
+	// 請求量越大的閒置命名空間越值得優先處理
+	sort.Slice(report.Namespaces, func(i, j int) bool {
+		a, b := report.Namespaces[i], report.Namespaces[j]
+		if a.cpuMillicores != b.cpuMillicores {
+			return a.cpuMillicores > b.cpuMillicores
+		}
+		return a.Namespace < b.Namespace
+	})
+
+	return report, nil
+}
+
+// idleNamespace 判斷命名空間是否閒置，閒置時回傳結果
+// 期間內有新建立的 Pod (部署、CronJob 或重新排程) 表示命名空間仍有活動；缺少任一 Pod 的使用量時不判斷
+// 記憶體不會因為沒有流量而釋放，只以 CPU 判斷是否閒置
+func (s *Service) idleNamespace(namespace string, pods []gke.Pod, usage map[string]*gke.MetricsSummary, since time.Time) *IdleNamespace {
+	idle := &IdleNamespace{Namespace: namespace, Pods: len(pods), Workloads: []string{}}
+	workloads := make(map[string]bool)
+	var cpuPeak, memoryRequest int64
+
+	for _, pod := range pods {
+		if pod.CreatedAt.After(since) {
+			return nil
+		}
+		history := usage[pod.Namespace+"/"+pod.Name]
+		if history == nil {
+			return nil
+		}
+
+		var cpuRequest int64
+		for _, container := range pod.Containers {
+			if quantity, err := resource.ParseQuantity(container.Resources.CPURequest); err == nil {
+				cpuRequest += quantity.MilliValue()
+			}
+			if quantity, err := resource.ParseQuantity(container.Resources.MemoryRequest); err == nil {
+				memoryRequest += quantity.Value()
+			}
+		}
+
+		peak := history.CPUMillicores.Max
+		if cpuRequest > 0 {
+			if float64(peak)/float64(cpuRequest)*100 >= s.criteria.IdleThreshold {
+				return nil
+			}
+		} else if peak >= idleCPUMillicoresWithoutRequest {
+			return nil
+		}
+
+		cpuPeak = max(cpuPeak, peak)
+		idle.cpuMillicores += cpuRequest
+		if idle.LastPodCreated.Before(pod.CreatedAt) {
+			idle.LastPodCreated = pod.CreatedAt
+		}
+
+		kind, name := gke.PodWorkload(pod)
+		workloads[kind+"/"+name] = true
+	}
+
+	for workload := range workloads {
+		idle.Workloads = append(idle.Workloads, workload)
+	}
+	sort.Strings(idle.Workloads)
+
+	idle.CPURequested = fmt.Sprintf("%dm", idle.cpuMillicores)
+	idle.MemoryRequested = fmt.Sprintf("%dMi", memoryRequest/(1024*1024))
+	idle.PeakCPU = fmt.Sprintf("%dm", cpuPeak)
+	idle.Action = fmt.Sprintf("確認命名空間 %s 是否仍在使用；不再使用時匯出設定 (kubectl get all,cm,secret,pvc -n %s -o yaml) 後刪除命名空間，暫時不用時可將工作負載縮減為 0 個副本",
+		namespace, namespace)
+	return idle
+}
+
+// systemNamespace 判斷是否為叢集元件的命名空間
+func systemNamespace(namespace string) bool {
+	for _, prefix := range systemNamespacePrefixes {
+		if strings.HasPrefix(namespace, prefix) {
+			return true
+		}
+	}
+	return false
+}
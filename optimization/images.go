@@ -0,0 +1,97 @@
+package optimization
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"mcp-gke-monitor/gke"
+)
+
+// largeImageBytes 映像檔超過此大小 (1 GiB) 視為過大，拉取時間會拖慢 Pod 啟動與擴充
+const largeImageBytes = 1 << 30
+
+// SetAllowedRegistries 設定允許的映像檔 registry (例如 gcr.io、asia-east1-docker.pkg.dev)，未設定時不檢查 registry
+// registry 與設定值相同或為其子網域時視為允許
+func (s *Service) SetAllowedRegistries(registries []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.allowedRegistries = registries
+}
+
+// imageIssues 檢查容器映像檔：以 tag 而非 digest 拉取、映像檔過大，以及來自非預期 registry 的映像檔
+// imageSizes 為節點回報的映像檔大小，無法取得時不檢查大小
+func (s *Service) imageIssues(pod gke.Pod, imageSizes map[string]int64) []OptimizationIssue {
+	var mutable, unpinned, large, untrusted []string
+	for _, container := range pod.Containers {
+		image := container.Image
+		if !strings.Contains(image, "@sha256:") {
+			if tag := gke.ImageTag(image); tag == "" || tag == "latest" {
+				mutable = append(mutable, fmt.Sprintf("%s (%s)", container.Name, image))
+			} else {
+				unpinned = append(unpinned, fmt.Sprintf("%s (%s)", container.Name, image))
+			}
+		}
+
+		if size, ok := gke.ImageSize(imageSizes, image); ok && size >= largeImageBytes {
+			large = append(large, fmt.Sprintf("%s (%s, %.1f GiB)", container.Name, image, float64(size)/(1<<30)))
+		}
+
+		if registry := gke.ImageRegistry(image); !s.registryAllowed(registry) {
+			untrusted = append(untrusted, fmt.Sprintf("%s (%s)", container.Name, registry))
+		}
+	}
+
+	var issues []OptimizationIssue
+	if len(mutable) > 0 {
+		issues = append(issues, OptimizationIssue{
+			Type:        "IMAGE_NOT_PINNED",
+			Severity:    PriorityMedium,
+			Description: fmt.Sprintf("映像檔使用 latest 或未指定 tag: %s", strings.Join(mutable, "，")),
+			Suggestion:  "latest tag 會隨時指向不同版本，重新排程的副本可能執行不同的程式碼，建議以 digest (image@sha256:...) 或固定版本的 tag 部署",
+		})
+	} else if len(unpinned) > 0 {
+		issues = append(issues, OptimizationIssue{
+			Type:        "IMAGE_NOT_PINNED",
+			Severity:    PriorityLow,
+			Description: fmt.Sprintf("映像檔以 tag 而非 digest 拉取: %s", strings.Join(unpinned, "，")),
+			Suggestion:  "tag 可以被重新推送覆蓋，建議以 digest (image@sha256:...) 部署，確保所有副本執行相同且經過驗證的映像檔",
+		})
+	}
+	if len(large) > 0 {
+		// 啟動緩慢時映像檔大小很可能是原因之一，提高優先級
+		severity := PriorityLow
+		if podStartupDuration(pod) >= slowStartSeconds*time.Second {
+			severity = PriorityMedium
+		}
+		issues = append(issues, OptimizationIssue{
+			Type:        "IMAGE_TOO_LARGE",
+			Severity:    severity,
+			Description: fmt.Sprintf("映像檔過大: %s", strings.Join(large, "，")),
+			Suggestion:  "大型映像檔在新節點上拉取耗時，拖慢 Pod 啟動與擴充，建議使用多階段建置與精簡的基底映像檔 (例如 distroless)，或啟用 GKE Image streaming",
+		})
+	}
+	if len(untrusted) > 0 {
+		issues = append(issues, OptimizationIssue{
+			Type:        "IMAGE_UNTRUSTED_REGISTRY",
+			Severity:    PriorityMedium,
+			Description: fmt.Sprintf("映像檔來自非預期的 registry: %s", strings.Join(untrusted, "，")),
+			Suggestion:  "將映像檔移到允許的 registry (例如組織的 Artifact Registry)，並以 Binary Authorization 限制可部署的映像檔來源",
+		})
+	}
+
+	return issues
+}
+
+// registryAllowed 判斷 registry 是否在允許清單中，未設定允許清單時全部允許
+func (s *Service) registryAllowed(registry string) bool {
+	if len(s.allowedRegistries) == 0 {
+		return true
+	}
+	for _, allowed := range s.allowedRegistries {
+		if registry == allowed || strings.HasSuffix(registry, "."+allowed) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+package optimization
+
+import (
+	"fmt"
+	"strings"
+
+	"mcp-gke-monitor/gke"
+)
+
+// recommendedNameLabel Kubernetes 建議的應用程式名稱標籤，所有工作負載都會檢查
+const recommendedNameLabel = "app.kubernetes.io/name"
+
+// SetRequiredLabels 設定工作負載必須有的標籤 (例如 team、cost-center)，用於成本歸屬與依標籤彙總
+// app.kubernetes.io/name 一律檢查，不需要另外設定
+func (s *Service) SetRequiredLabels(labels []string) {
+	required := []string{recommendedNameLabel}
+	for _, label := range labels {
+		if label = strings.TrimSpace(label); label != "" && label != recommendedNameLabel {
+			required = append(required, label)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requiredLabels = required
+}
+
+// labelIssues 檢查 Pod 是否有必須的標籤；Pod 的標籤來自工作負載的 Pod template，問題會以所屬工作負載合併
+func (s *Service) labelIssues(pod gke.Pod) []OptimizationIssue {
+	required := s.requiredLabels
+	if len(required) == 0 {
+		required = []string{recommendedNameLabel}
+	}
+
+	var missing []string
+	for _, label := range required {
+		if pod.Labels[label] == "" {
+			missing = append(missing, label)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	suggestion := fmt.Sprintf("在工作負載的 Pod template (spec.template.metadata.labels) 加上 %s", strings.Join(missing, "、"))
+	if app := pod.Labels["app"]; app != "" && pod.Labels[recommendedNameLabel] == "" {
+		suggestion += fmt.Sprintf("；%s 可以沿用 app 標籤的值 %s", recommendedNameLabel, app)
+	}
+	return []OptimizationIssue{{
+		Type:        "MISSING_LABELS",
+		Severity:    PriorityLow,
+		Description: fmt.Sprintf("缺少標籤: %s", strings.Join(missing, "、")),
+		Suggestion:  suggestion,
+	}}
+}
@@ -0,0 +1,150 @@
+package optimization
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// ReportFormatJSON 以 JSON 回傳報告
+	ReportFormatJSON = "json"
+
+	// ReportFormatMarkdown 以 Markdown 回傳報告，適合直接貼到工單
+	ReportFormatMarkdown = "markdown"
+)
+
+// RenderReportMarkdown 將優化報告轉換為 Markdown：摘要、依優先級分段的建議、工作負載分數與資源浪費
+// 建議維持報告的排序 (估算節省成本與可用性影響)
+func RenderReportMarkdown(report *OptimizationReport) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# 優化報告: %s\n\n", report.Namespace)
+	fmt.Fprintf(&b, "- 叢集: %s\n", report.ClusterName)
+	fmt.Fprintf(&b, "- 產生時間: %s\n", report.GeneratedAt.Format("2006-01-02 15:04:05"))
+	if report.Window != "" {
+		fmt.Fprintf(&b, "- 分析期間: %s\n", report.Window)
+	}
+	if report.ID != "" {
+		fmt.Fprintf(&b, "- 報告 ID: `%s`\n", report.ID)
+	}
+
+	summary := report.Summary
+	b.WriteString("\n## 摘要\n\n")
+	writeMarkdownTable(&b, []string{"項目", "數值"}, [][]string{
+		{"整體分數", fmt.Sprintf("%.1f / 100", summary.OverallScore)},
+		{"分析的 Pod", fmt.Sprintf("%d", summary.TotalPods)},
+		{"需要優化的 Pod", fmt.Sprintf("%d", summary.PodsNeedingOptimization)},
+		{"建議數量", fmt.Sprintf("%d", len(report.Recommendations))},
+		{"估算每月可節省", fmt.Sprintf("%.2f %s", summary.EstimatedMonthlySavings, summary.Currency)},
+	})
+
+	b.WriteString("\n## 建議\n")
+	if len(report.Recommendations) == 0 {
+		b.WriteString("\n目前沒有優化建議。\n")
+	}
+	for _, priority := range []Priority{PriorityHigh, PriorityMedium, PriorityLow} {
+		var rows [][]string
+		for _, rec := range report.Recommendations {
+			if rec.Priority != priority {
+				continue
+			}
+			rows = append(rows, []string{
+				rec.Title,
+				string(rec.Type),
+				string(rec.Confidence),
+				recommendationTarget(rec),
+				fmt.Sprintf("%.2f", rec.EstimatedMonthlySavings),
+				rec.Action,
+			})
+		}
+		if len(rows) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n### %s 優先級 (%d)\n\n", priority, len(rows))
+		writeMarkdownTable(&b, []string{"建議", "類型", "信心", "對象", "每月節省 (" + summary.Currency + ")", "行動"}, rows)
+	}
+
+	if len(report.Workloads) > 0 {
+		b.WriteString("\n## 工作負載\n\n")
+		rows := make([][]string, 0, len(report.Workloads))
+		for _, workload := range report.Workloads {
+			rows = append(rows, []string{
+				workload.Kind + "/" + workload.Name,
+				fmt.Sprintf("%d", workload.PodCount),
+				fmt.Sprintf("%.1f", workload.OptimizationScore),
+				formatIssueCounts(workload.IssueCounts),
+			})
+		}
+		writeMarkdownTable(&b, []string{"工作負載", "Pod 數", "分數", "問題"}, rows)
+	}
+
+	waste := report.ResourceWaste
+	b.WriteString("\n## 資源浪費\n\n")
+	fmt.Fprintf(&b, "- 估算成本: %s\n", waste.TotalWastage.EstimatedCost)
+	if len(waste.IdlePods) > 0 {
+		fmt.Fprintf(&b, "- 閒置 Pod: %s\n", strings.Join(waste.IdlePods, ", "))
+	}
+	if nodes := waste.Nodes; nodes != nil {
+		fmt.Fprintf(&b, "- 使用不足的節點: %d，只有單一小型 Pod 的節點: %d，節點池可減少的節點: %d\n",
+			len(nodes.UnderutilizedNodes), len(nodes.SinglePodNodes), nodes.RemovableNodes)
+		if nodes.Consolidation != nil {
+			fmt.Fprintf(&b, "- 裝箱模擬: %d 個節點可整併為 %d 個，可釋放 %d 個\n",
+				nodes.Consolidation.CurrentNodes, nodes.Consolidation.RequiredNodes, nodes.Consolidation.FreedNodes)
+		}
+	}
+	if storage := waste.Storage; storage != nil {
+		fmt.Fprintf(&b, "- PVC 每月成本: %.2f %s，容量過大: %d，沒有使用: %d，無法繫結: %d，高價磁碟: %d，可節省: %.2f %s/月\n",
+			storage.MonthlyCost, storage.Currency, len(storage.OversizedClaims), len(storage.UnusedClaims),
+			len(storage.UnboundClaims), len(storage.PremiumClaims), storage.EstimatedMonthlySavings, storage.Currency)
+	}
+	if len(waste.OverProvisionedPods) > 0 {
+		b.WriteString("\n### 過度配置的 Pod\n\n")
+		rows := make([][]string, 0, len(waste.OverProvisionedPods))
+		for _, pod := range waste.OverProvisionedPods {
+			rows = append(rows, []string{pod.PodName, pod.ResourceType, pod.Allocated, pod.Used, pod.WasteAmount})
+		}
+		writeMarkdownTable(&b, []string{"Pod", "資源", "配置", "使用", "浪費"}, rows)
+	}
+
+	if len(report.Snoozed) > 0 {
+		b.WriteString("\n## 暫停的建議\n\n")
+		rows := make([][]string, 0, len(report.Snoozed))
+		for _, snoozed := range report.Snoozed {
+			rows = append(rows, []string{snoozed.Title, string(snoozed.Priority), snoozed.Until.Format("2006-01-02"), snoozed.Reason})
+		}
+		writeMarkdownTable(&b, []string{"建議", "優先級", "暫停至", "原因"}, rows)
+	}
+
+	if len(report.ExcludedPods) > 0 {
+		b.WriteString("\n## 排除的 Pod\n\n")
+		rows := make([][]string, 0, len(report.ExcludedPods))
+		for _, pod := range report.ExcludedPods {
+			rows = append(rows, []string{pod.PodName, pod.Reason})
+		}
+		writeMarkdownTable(&b, []string{"Pod", "原因"}, rows)
+	}
+
+	return b.String()
+}
+
+// recommendationTarget 建議的對象，工作負載建議為 Kind/name，其餘為 Pod 名稱
+func recommendationTarget(rec Recommendation) string {
+	if rec.WorkloadName != "" && rec.PodName == "" {
+		return rec.WorkloadKind + "/" + rec.WorkloadName
+	}
+	return rec.PodName
+}
+
+// writeMarkdownTable 輸出 Markdown 表格，儲存格中的直線與換行會被跳脫
+func writeMarkdownTable(b *strings.Builder, headers []string, rows [][]string) {
+	b.WriteString("| " + strings.Join(headers, " | ") + " |\n")
+	b.WriteString("|" + strings.Repeat(" --- |", len(headers)) + "\n")
+	replacer := strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>")
+	for _, row := range rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = replacer.Replace(cell)
+		}
+		b.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+}
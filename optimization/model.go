@@ -1,16 +1,122 @@
 package optimization
 
-import "time"
+import (
+	"time"
+
+	"mcp-gke-monitor/gke"
+)
 
 // OptimizationReport 優化報告
 type OptimizationReport struct {
-	ClusterName     string                `json:"clusterName"`
-	Namespace       string                `json:"namespace"`
-	GeneratedAt     time.Time             `json:"generatedAt"`
-	Summary         OptimizationSummary   `json:"summary"`
-	Recommendations []Recommendation      `json:"recommendations"`
-	PodAnalysis     []PodOptimization     `json:"podAnalysis"`
-	ResourceWaste   ResourceWasteAnalysis `json:"resourceWaste"`
+	ID              string                  `json:"id,omitempty"` // 保存到報告歷史時的 ID (namespace/時間)
+	ClusterName     string                  `json:"clusterName"`
+	Namespace       string                  `json:"namespace"`
+	GeneratedAt     time.Time               `json:"generatedAt"`
+	Window          string                  `json:"window,omitempty"` // 分析使用量的期間 (例如 7d)，使用量資料來源只有目前取樣時為空
+	Summary         OptimizationSummary     `json:"summary"`
+	Recommendations []Recommendation        `json:"recommendations"`
+	PodAnalysis     []PodOptimization       `json:"podAnalysis"`
+	Workloads       []WorkloadOptimization  `json:"workloads"`
+	ResourceWaste   ResourceWasteAnalysis   `json:"resourceWaste"`
+	ExcludedPods    []ExcludedPod           `json:"excludedPods,omitempty"` // 以註解或標籤選擇器排除、未分析的 Pod
+	Snoozed         []SnoozedRecommendation `json:"snoozed,omitempty"`      // 以 snooze_recommendation 暫停、未列入建議的項目
+}
+
+// Snooze 暫停建議的設定，到期後建議會重新出現
+type Snooze struct {
+	ID               string    `json:"id"` // namespace/建議 ID 或 namespace/Kind/name[/問題類型]
+	Namespace        string    `json:"namespace"`
+	RecommendationID string    `json:"recommendationId,omitempty"` // 只暫停這個建議
+	WorkloadKind     string    `json:"workloadKind,omitempty"`     // 未指定時符合同名的所有工作負載類型
+	WorkloadName     string    `json:"workloadName,omitempty"`     // 暫停工作負載的建議
+	Issue            string    `json:"issue,omitempty"`            // 只暫停這個問題類型，例如 CPU_OVER_PROVISIONED
+	Until            time.Time `json:"until"`
+	Reason           string    `json:"reason,omitempty"`
+	CreatedAt        time.Time `json:"createdAt"`
+}
+
+// SnoozedRecommendation 報告中被暫停的建議
+type SnoozedRecommendation struct {
+	RecommendationID string    `json:"recommendationId"`
+	Title            string    `json:"title"`
+	Priority         Priority  `json:"priority"`
+	SnoozeID         string    `json:"snoozeId"`
+	Until            time.Time `json:"until"`
+	Reason           string    `json:"reason,omitempty"`
+}
+
+// StoredReport 已保存的報告摘要
+type StoredReport struct {
+	ID                      string    `json:"id"`
+	Namespace               string    `json:"namespace"`
+	GeneratedAt             time.Time `json:"generatedAt"`
+	Window                  string    `json:"window,omitempty"`
+	OverallScore            float64   `json:"overallScore"`
+	Recommendations         int       `json:"recommendations"`
+	EstimatedMonthlySavings float64   `json:"estimatedMonthlySavings"`
+}
+
+// ReportExport 匯出的報告檔案
+type ReportExport struct {
+	ReportID    string    `json:"reportId,omitempty"`
+	Namespace   string    `json:"namespace"`
+	GeneratedAt time.Time `json:"generatedAt"`
+	Format      string    `json:"format"`
+	Files       []string  `json:"files"`
+
+	// Columns CSV 匯出時各檔案 (檔名) 的欄位
+	Columns map[string][]string `json:"columns,omitempty"`
+}
+
+// NotificationResult 傳送報告通知的結果
+type NotificationResult struct {
+	ReportID  string    `json:"reportId,omitempty"`
+	Namespace string    `json:"namespace"`
+	Format    string    `json:"format"`
+	Findings  int       `json:"findings"` // 通知中列出的高優先級建議數量
+	SentAt    time.Time `json:"sentAt"`
+}
+
+// ReportComparison 兩份報告的差異
+type ReportComparison struct {
+	BaselineID          string                `json:"baselineId"`
+	CurrentID           string                `json:"currentId"`
+	BaselineGeneratedAt time.Time             `json:"baselineGeneratedAt"`
+	CurrentGeneratedAt  time.Time             `json:"currentGeneratedAt"`
+	ScoreChange         float64               `json:"scoreChange"`   // 整體分數的變化
+	SavingsChange       float64               `json:"savingsChange"` // 估算每月可節省成本的變化，負值表示浪費減少
+	ResolvedIssues      []IssueChange         `json:"resolvedIssues"`
+	NewIssues           []IssueChange         `json:"newIssues"`
+	Workloads           []WorkloadScoreChange `json:"workloads"` // 兩份報告都有的工作負載，依分數變化幅度由大到小排序
+}
+
+// IssueChange 已解決或新出現的問題
+type IssueChange struct {
+	Issue        string             `json:"issue"` // 問題類型，工作負載層級的建議為建議 ID
+	Type         RecommendationType `json:"type"`
+	Priority     Priority           `json:"priority"`
+	Title        string             `json:"title"`
+	Namespace    string             `json:"namespace,omitempty"`
+	WorkloadKind string             `json:"workloadKind,omitempty"`
+	WorkloadName string             `json:"workloadName,omitempty"`
+	Container    string             `json:"container,omitempty"`
+}
+
+// WorkloadScoreChange 工作負載優化分數的變化
+type WorkloadScoreChange struct {
+	Kind          string  `json:"kind"`
+	Name          string  `json:"name"`
+	Namespace     string  `json:"namespace"`
+	BaselineScore float64 `json:"baselineScore"`
+	CurrentScore  float64 `json:"currentScore"`
+	Change        float64 `json:"change"`
+}
+
+// ExcludedPod 排除在優化分析之外的 Pod
+type ExcludedPod struct {
+	PodName   string `json:"podName"`
+	Namespace string `json:"namespace"`
+	Reason    string `json:"reason"`
 }
 
 // OptimizationSummary 優化摘要
@@ -19,6 +125,8 @@ type OptimizationSummary struct {
 	PodsNeedingOptimization int     `json:"podsNeedingOptimization"`
 	PotentialCPUSavings     string  `json:"potentialCPUSavings"`
 	PotentialMemorySavings  string  `json:"potentialMemorySavings"`
+	EstimatedMonthlySavings float64 `json:"estimatedMonthlySavings"` // 各建議可節省成本的總和，不含增加成本的建議
+	Currency                string  `json:"currency"`
 	OverallScore            float64 `json:"overallScore"` // 0-100 分
 }
 
@@ -26,6 +134,7 @@ type OptimizationSummary struct {
 type Recommendation struct {
 	ID          string             `json:"id"`
 	Type        RecommendationType `json:"type"`
+	Issue       string             `json:"issue,omitempty"` // 對應的問題類型，例如 CPU_OVER_PROVISIONED
 	Priority    Priority           `json:"priority"`
 	Title       string             `json:"title"`
 	Description string             `json:"description"`
@@ -33,6 +142,125 @@ type Recommendation struct {
 	Action      string             `json:"action"`
 	PodName     string             `json:"podName,omitempty"`
 	Namespace   string             `json:"namespace,omitempty"`
+	Container   string             `json:"container,omitempty"` // 多容器 Pod 中建議針對的容器，建議值只調整此容器
+
+	// EstimatedMonthlySavings 依成本模型估算每月可節省的成本，負值表示需要增加的成本
+	EstimatedMonthlySavings float64 `json:"estimatedMonthlySavings"`
+
+	// AvailabilityImpact 建議對可用性的影響，不影響可用性時為空
+	AvailabilityImpact Priority `json:"availabilityImpact,omitempty"`
+
+	// Confidence 建議的信心程度，依使用量資料涵蓋的時間、波動程度與資料來源評估；HIGH 的建議可以直接套用
+	Confidence       Confidence `json:"confidence"`
+	ConfidenceReason string     `json:"confidenceReason,omitempty"`
+
+	WorkloadKind string `json:"workloadKind,omitempty"` // Pod 所屬的工作負載類型，沒有控制器時為 Pod
+	WorkloadName string `json:"workloadName,omitempty"`
+
+	// InsufficientData 使用量資料涵蓋的時間不足 minObservationHours，只標記問題而不提供建議值
+	InsufficientData bool `json:"insufficientData,omitempty"`
+
+	// Suggested 建議的容器 requests 與 limits，僅 CPU 與記憶體建議提供
+	Suggested []ResourceSuggestion `json:"suggested,omitempty"`
+
+	// Evidence 合併為工作負載建議前，各 Pod 的原始問題
+	Evidence []RecommendationEvidence `json:"evidence,omitempty"`
+
+	// Autoscaling 建議的 HPA 參數，僅 HPA 建議提供
+	Autoscaling *AutoscalingSuggestion `json:"autoscaling,omitempty"`
+
+	// Replicas 建議的副本數，僅副本數建議提供
+	Replicas *ReplicaSuggestion `json:"replicas,omitempty"`
+
+	// DisruptionBudget 建議的 PodDisruptionBudget，僅 PDB 建議提供
+	DisruptionBudget *DisruptionBudgetSuggestion `json:"disruptionBudget,omitempty"`
+
+	// Spread 副本分散的現況與建議的 topologySpreadConstraints，僅高可用分散建議提供
+	Spread *SpreadSuggestion `json:"spread,omitempty"`
+
+	// Crash 重啟容器上一次執行的日誌摘要與最近的 Warning 事件，僅重啟次數過多的建議提供
+	Crash *gke.CrashDiagnosis `json:"crash,omitempty"`
+
+	// Quota 限制副本擴展的 ResourceQuota 資源，僅配額建議提供
+	Quota *QuotaCheck `json:"quota,omitempty"`
+
+	// Storage PVC 的容量、使用量與可節省的成本，僅 PVC 浪費的建議提供
+	Storage *ClaimWaste `json:"storage,omitempty"`
+
+	// Startup 工作負載各 Pod 的啟動時間，僅啟動緩慢或不穩定的建議提供
+	Startup *WorkloadStartup `json:"startup,omitempty"`
+
+	// Cloud Google Cloud Recommender 對同一目標的建議；本地沒有對應的建議時，建議的內容直接來自 Recommender
+	Cloud *gke.CloudRecommendation `json:"cloud,omitempty"`
+}
+
+// RecommendationEvidence 工作負載建議中單一 Pod 的佐證
+type RecommendationEvidence struct {
+	PodName     string `json:"podName"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+// WorkloadOptimization 工作負載層級的分析摘要
+type WorkloadOptimization struct {
+	Kind              string         `json:"kind"` // 沒有控制器的 Pod 為 Pod
+	Name              string         `json:"name"`
+	Namespace         string         `json:"namespace"`
+	PodCount          int            `json:"podCount"`
+	Pods              []string       `json:"pods"`
+	OptimizationScore float64        `json:"optimizationScore"` // 各 Pod 優化分數的平均
+	IssueCounts       map[string]int `json:"issueCounts"`       // 各問題類型出現的 Pod 數
+}
+
+// ReplicaSuggestion 依整體使用量建議的副本數
+type ReplicaSuggestion struct {
+	CurrentReplicas   int     `json:"currentReplicas"`
+	SuggestedReplicas int     `json:"suggestedReplicas"`
+	MinReplicas       int32   `json:"minReplicas"`       // 建議副本數的下限 (優化標準的 minReplicas)
+	CPUUtilization    float64 `json:"cpuUtilization"`    // 整體 CPU 使用量佔 requests 總和的百分比
+	MemoryUtilization float64 `json:"memoryUtilization"` // 整體記憶體使用量佔 requests 總和的百分比
+}
+
+// AutoscalingSuggestion 依歷史使用量建議的 HPA 參數
+type AutoscalingSuggestion struct {
+	Metric                 string  `json:"metric"`                 // 擴縮依據的資源，目前為 cpu
+	TargetUtilization      int32   `json:"targetUtilization"`      // 目標使用率 (佔 requests 的百分比)
+	MinReplicas            int32   `json:"minReplicas"`            // 足以承擔離峰使用量的副本數
+	MaxReplicas            int32   `json:"maxReplicas"`            // 足以承擔尖峰使用量 (含餘裕係數) 的副本數
+	CurrentReplicas        int     `json:"currentReplicas"`        // 目前執行中的副本數
+	PodCPURequest          string  `json:"podCPURequest"`          // 計算時使用的 Pod CPU request，未設定時為建議值
+	CoefficientOfVariation float64 `json:"coefficientOfVariation"` // CPU 使用量的變異係數 (標準差 / 平均)
+	PeakToAverage          float64 `json:"peakToAverage"`          // 尖峰與平均使用量的比例
+	Manifest               string  `json:"manifest,omitempty"`     // autoscaling/v2 HPA 的 YAML
+}
+
+// DisruptionBudgetSuggestion 建議的 PodDisruptionBudget 設定
+type DisruptionBudgetSuggestion struct {
+	Existing       *gke.PDBStatus    `json:"existing,omitempty"` // 目前選取此工作負載的 PDB，沒有 PDB 時為空
+	Selector       map[string]string `json:"selector"`           // 建議的 Pod 選擇器，取自 Pod 標籤
+	MaxUnavailable int32             `json:"maxUnavailable"`
+	Manifest       string            `json:"manifest"` // policy/v1 PodDisruptionBudget 的 YAML
+}
+
+// SpreadSuggestion 工作負載副本在節點與可用區的分布，以及建議的 topologySpreadConstraints
+type SpreadSuggestion struct {
+	Replicas     int            `json:"replicas"`
+	ByNode       map[string]int `json:"byNode"`       // 各節點上的副本數
+	ByZone       map[string]int `json:"byZone"`       // 各可用區的副本數
+	ClusterZones int            `json:"clusterZones"` // 叢集節點分布的可用區數量
+	TopologyKeys []string       `json:"topologyKeys"` // 建議分散的拓撲標籤
+	Patch        string         `json:"patch"`        // 加上 topologySpreadConstraints 的 strategic-merge patch YAML
+	Command      string         `json:"command"`      // 可直接執行的 kubectl patch 指令
+}
+
+// QuotaCheck 可增加副本數最少的 ResourceQuota 資源
+type QuotaCheck struct {
+	Quota              string `json:"quota"`
+	Resource           string `json:"resource"` // 例如 requests.cpu、limits.memory、pods
+	Hard               string `json:"hard"`
+	Used               string `json:"used"`
+	PerReplica         string `json:"perReplica"`         // 每個副本需要的量
+	AdditionalReplicas int    `json:"additionalReplicas"` // 配額剩餘量還能增加的副本數
 }
 
 // RecommendationType 建議類型
@@ -45,6 +273,10 @@ const (
 	RecommendationStorage  RecommendationType = "STORAGE"
 	RecommendationHealth   RecommendationType = "HEALTH"
 	RecommendationSecurity RecommendationType = "SECURITY"
+	RecommendationGPU      RecommendationType = "GPU"
+	RecommendationCleanup  RecommendationType = "CLEANUP" // 不再使用但仍留在叢集中的工作負載
+	RecommendationLabels   RecommendationType = "LABELS"  // 缺少成本歸屬與管理需要的標籤
+	RecommendationNode     RecommendationType = "NODE"    // 節點與節點池，來自 Google Cloud Recommender
 )
 
 // Priority 優先級
@@ -56,30 +288,124 @@ const (
 	PriorityLow    Priority = "LOW"
 )
 
+// Confidence 建議的信心程度
+type Confidence string
+
+const (
+	ConfidenceHigh   Confidence = "HIGH"   // 資料充足且穩定，可以直接套用
+	ConfidenceMedium Confidence = "MEDIUM" // 套用前需要人工確認
+	ConfidenceLow    Confidence = "LOW"    // 資料不足或波動過大，只作為參考
+)
+
 // PodOptimization Pod 優化分析
 type PodOptimization struct {
 	PodName           string              `json:"podName"`
 	Namespace         string              `json:"namespace"`
 	Status            string              `json:"status"`
-	OptimizationScore float64             `json:"optimizationScore"` // 0-100 分
+	WorkloadKind      string              `json:"workloadKind"` // 所屬工作負載類型，沒有控制器時為 Pod
+	WorkloadName      string              `json:"workloadName"`
+	NodeName          string              `json:"nodeName,omitempty"`
+	OptimizationScore float64             `json:"optimizationScore"`          // 0-100 分
+	UsageSource       string              `json:"usageSource"`                // 使用量來源: metrics-api (目前使用量) 或 cloud-monitoring、prometheus、collector (歷史尖峰)
+	ObservedHours     float64             `json:"observedHours"`              // 使用量資料涵蓋的時間 (小時)，只有目前使用量時為 0
+	InsufficientData  bool                `json:"insufficientData,omitempty"` // 工作負載的使用量資料少於 minObservationHours，不提供資源建議值
+	UsageConfidence   Confidence          `json:"usageConfidence"`            // 使用量資料的信心程度，依使用量判斷的建議採用此值
+	ConfidenceReason  string              `json:"confidenceReason,omitempty"`
 	Issues            []OptimizationIssue `json:"issues"`
-	ResourceAnalysis  ResourceAnalysis    `json:"resourceAnalysis"`
+	ResourceAnalysis  ResourceAnalysis    `json:"resourceAnalysis"` // Pod 所有容器的合計
+	Containers        []ContainerAnalysis `json:"containers,omitempty"`
 	HealthStatus      HealthStatus        `json:"healthStatus"`
+
+	// SuggestedResources 依 P95 與尖峰使用量乘上餘裕係數計算的各容器建議值
+	SuggestedResources []ResourceSuggestion `json:"suggestedResources,omitempty"`
+
+	labels map[string]string   // Pod 標籤，用於比對 PodDisruptionBudget 等工作負載層級的設定
+	crash  *gke.CrashDiagnosis // 重啟次數過多時的日誌摘要
+}
+
+// ContainerAnalysis 單一容器的 CPU 與記憶體分析，sidecar 與主容器的用量差異很大，需要分別判斷
+type ContainerAnalysis struct {
+	Name   string         `json:"name"`
+	CPU    ResourceMetric `json:"cpu"`
+	Memory ResourceMetric `json:"memory"`
+}
+
+// ResourceSuggestion 單一容器的資源建議值
+type ResourceSuggestion struct {
+	Container string             `json:"container"`
+	Current   ContainerResources `json:"current"`
+	Suggested ContainerResources `json:"suggested"`
+
+	// RemoveCPULimit 依 cpuLimitPolicy 建議移除目前的 CPU limit，僅 CPU 節流建議提供
+	RemoveCPULimit bool `json:"removeCpuLimit,omitempty"`
+
+	SLOTier        string  `json:"sloTier,omitempty"` // 命名空間所屬的可用性等級
+	HeadroomFactor float64 `json:"headroomFactor"`    // 計算建議值使用的餘裕係數
+}
+
+// RecommendationPatchReport 建議對應的 patch 列表
+type RecommendationPatchReport struct {
+	Namespace   string                `json:"namespace"`
+	GeneratedAt time.Time             `json:"generatedAt"`
+	Format      string                `json:"format"` // patch 或 resources
+	Patches     []RecommendationPatch `json:"patches"`
+}
+
+// RecommendationPatch 單一建議可直接套用的 YAML
+type RecommendationPatch struct {
+	RecommendationID string `json:"recommendationId"`
+	PodName          string `json:"podName,omitempty"` // 僅未合併的 Pod 建議提供
+	Namespace        string `json:"namespace"`
+	WorkloadKind     string `json:"workloadKind"`
+	WorkloadName     string `json:"workloadName"`
+	Resource         string `json:"resource"`          // 調整的資源: cpu 或 memory
+	YAML             string `json:"yaml"`              // strategic-merge patch 或 resources 區塊
+	Command          string `json:"command,omitempty"` // 套用 patch 的 kubectl 指令，僅 patch 格式提供
+	Note             string `json:"note,omitempty"`
+}
+
+// RecommendationApplyResult 套用建議的結果
+type RecommendationApplyResult struct {
+	Recommendation Recommendation   `json:"recommendation"`
+	Result         *gke.PatchResult `json:"result"` // dry-run 時為預覽，changes 列出會變更的欄位
+}
+
+// ContainerResources 容器的 requests 與 limits
+type ContainerResources struct {
+	CPURequest    string `json:"cpuRequest,omitempty"`
+	CPULimit      string `json:"cpuLimit,omitempty"`
+	MemoryRequest string `json:"memoryRequest,omitempty"`
+	MemoryLimit   string `json:"memoryLimit,omitempty"`
 }
 
 // OptimizationIssue 優化問題
 type OptimizationIssue struct {
 	Type        string   `json:"type"`
 	Severity    Priority `json:"severity"`
+	Container   string   `json:"container,omitempty"` // 多容器 Pod 中問題所屬的容器，Pod 層級的問題為空
 	Description string   `json:"description"`
 	Suggestion  string   `json:"suggestion"`
 }
 
 // ResourceAnalysis 資源分析
 type ResourceAnalysis struct {
-	CPU    ResourceMetric `json:"cpu"`
-	Memory ResourceMetric `json:"memory"`
-	Disk   ResourceMetric `json:"disk"`
+	CPU     ResourceMetric   `json:"cpu"`
+	Memory  ResourceMetric   `json:"memory"`
+	Disk    ResourceMetric   `json:"disk"`              // ephemeral storage
+	Volumes []VolumeAnalysis `json:"volumes,omitempty"` // 有獨立容量的卷 (PVC 與設定 sizeLimit 的 emptyDir)
+	GPU     *ResourceMetric  `json:"gpu,omitempty"`     // 僅在 Pod 請求 GPU 時提供
+}
+
+// VolumeAnalysis 卷的使用率分析
+type VolumeAnalysis struct {
+	Name        string  `json:"name"`
+	Type        string  `json:"type"` // PVC 或 EmptyDir
+	MountPath   string  `json:"mountPath,omitempty"`
+	Used        string  `json:"used"`
+	Total       string  `json:"total"`
+	Utilization float64 `json:"utilization"`
+	Status      string  `json:"status"` // "OPTIMAL" 或 "NEAR_FULL"
+	Suggestion  string  `json:"suggestion"`
 }
 
 // ResourceMetric 資源指標
@@ -87,9 +413,14 @@ type ResourceMetric struct {
 	Current     string  `json:"current"`
 	Request     string  `json:"request"`
 	Limit       string  `json:"limit"`
-	Utilization float64 `json:"utilization"` // 使用率百分比
-	Status      string  `json:"status"`      // "OPTIMAL", "OVER_PROVISIONED", "UNDER_PROVISIONED"
+	Utilization float64 `json:"utilization"` // 使用率百分比，有 requests 時為對 requests 的比例，否則為對 limits 的比例
+	Status      string  `json:"status"`      // "OPTIMAL", "IDLE", "OVER_PROVISIONED", "UNDER_PROVISIONED", "THROTTLED", "NEAR_FULL" (磁碟), "NOT_CONFIGURED", "UNKNOWN"
 	Suggestion  string  `json:"suggestion"`
+
+	RequestUtilization float64 `json:"requestUtilization,omitempty"` // 使用量對 requests 的比例 (%)
+	LimitUtilization   float64 `json:"limitUtilization,omitempty"`   // 使用量對 limits 的比例 (%)
+
+	ThrottledPercentage float64 `json:"throttledPercentage,omitempty"` // CPU 被節流的 CFS 週期比例 (%)
 }
 
 // HealthStatus 健康狀態
@@ -99,6 +430,9 @@ type HealthStatus struct {
 	LastRestart  time.Time `json:"lastRestart,omitempty"`
 	HealthScore  float64   `json:"healthScore"` // 0-100 分
 	HealthIssues []string  `json:"healthIssues,omitempty"`
+
+	// RestartCauses 依原因分類的重啟次數 (oom-killed、liveness-probe、node-preemption、error-exit、completed、unknown)，僅重啟次數過多時提供
+	RestartCauses map[string]int32 `json:"restartCauses,omitempty"`
 }
 
 // ResourceWasteAnalysis 資源浪費分析
@@ -107,6 +441,118 @@ type ResourceWasteAnalysis struct {
 	UnderUtilizedPods   []ResourceWaste `json:"underUtilizedPods"`
 	IdlePods            []string        `json:"idlePods"`
 	TotalWastage        WastageStats    `json:"totalWastage"`
+
+	// Nodes 節點層級的浪費分析，涵蓋整個叢集；無法取得節點資訊時為空
+	Nodes *NodeWasteAnalysis `json:"nodes,omitempty"`
+
+	// Storage PVC 的浪費分析；無法取得 PVC 時為空
+	Storage *StorageWasteAnalysis `json:"storage,omitempty"`
+}
+
+// StorageWasteAnalysis PVC 的浪費分析，同一個 PVC 只列在一個分類中
+type StorageWasteAnalysis struct {
+	OversizedClaims []ClaimWaste `json:"oversizedClaims"` // 使用量遠低於容量，依可節省的成本由高到低排序
+	UnusedClaims    []ClaimWaste `json:"unusedClaims"`    // 已繫結但沒有 Pod 掛載
+	UnboundClaims   []ClaimWaste `json:"unboundClaims"`   // Pending 或 Lost
+	PremiumClaims   []ClaimWaste `json:"premiumClaims"`   // 使用高價磁碟類型 (pd-ssd、pd-extreme)
+
+	MonthlyCost             float64 `json:"monthlyCost"` // 所有已繫結 PVC 依容量估算的每月成本
+	EstimatedMonthlySavings float64 `json:"estimatedMonthlySavings"`
+	Currency                string  `json:"currency"`
+}
+
+// ClaimWaste 單一 PVC 的浪費
+type ClaimWaste struct {
+	Name         string  `json:"name"`
+	Namespace    string  `json:"namespace"`
+	Phase        string  `json:"phase"`
+	StorageClass string  `json:"storageClass,omitempty"`
+	DiskType     string  `json:"diskType,omitempty"`
+	Capacity     string  `json:"capacity"`
+	Used         string  `json:"used,omitempty"`
+	Utilization  float64 `json:"utilization,omitempty"`
+
+	SuggestedCapacity string `json:"suggestedCapacity,omitempty"` // 容量過大時建議的容量
+	SuggestedDiskType string `json:"suggestedDiskType,omitempty"` // 使用高價磁碟類型時建議的磁碟類型
+
+	MonthlyCost             float64 `json:"monthlyCost"`
+	EstimatedMonthlySavings float64 `json:"estimatedMonthlySavings"`
+	Reason                  string  `json:"reason"`
+}
+
+// NodeWasteAnalysis 節點層級的浪費分析
+type NodeWasteAnalysis struct {
+	UnderutilizedNodes []NodeWaste  `json:"underutilizedNodes"` // 依請求比例由低到高排序
+	SinglePodNodes     []NodeWaste  `json:"singlePodNodes"`     // 只因單一小型 Pod 而存在的節點
+	ShrinkablePools    []PoolShrink `json:"shrinkablePools"`    // 依可減少的節點數由多到少排序
+	RemovableNodes     int          `json:"removableNodes"`     // 各節點池可減少的節點數總和
+
+	Consolidation *ConsolidationSimulation `json:"consolidation,omitempty"` // 裝箱模擬的結果
+}
+
+// ConsolidationSimulation 將 Pod 請求量重新裝箱到最少節點的模擬結果
+type ConsolidationSimulation struct {
+	GeneratedAt   time.Time           `json:"generatedAt,omitempty"`
+	CurrentNodes  int                 `json:"currentNodes"`
+	RequiredNodes int                 `json:"requiredNodes"`
+	FreedNodes    int                 `json:"freedNodes"`
+	Pools         []PoolConsolidation `json:"pools"` // 依可釋放的節點數由多到少排序
+}
+
+// IdleNamespaceReport 閒置命名空間偵測結果
+type IdleNamespaceReport struct {
+	GeneratedAt   time.Time       `json:"generatedAt"`
+	Days          int             `json:"days"`          // 判斷閒置的期間 (天)
+	IdleThreshold float64         `json:"idleThreshold"` // 尖峰 CPU 使用量佔 requests 的比例低於此值 (%) 視為閒置
+	UsageSource   string          `json:"usageSource"`
+	Namespaces    []IdleNamespace `json:"namespaces"` // 依 CPU 請求量由多到少排序
+}
+
+// IdleNamespace 所有工作負載在期間內都閒置的命名空間
+type IdleNamespace struct {
+	Namespace       string    `json:"namespace"`
+	Pods            int       `json:"pods"`
+	Workloads       []string  `json:"workloads"` // Kind/name
+	CPURequested    string    `json:"cpuRequested"`
+	MemoryRequested string    `json:"memoryRequested"`
+	PeakCPU         string    `json:"peakCpu"` // 期間內單一 Pod 的最高 CPU 使用量
+	LastPodCreated  time.Time `json:"lastPodCreated"`
+	Action          string    `json:"action"`
+
+	cpuMillicores int64
+}
+
+// PoolConsolidation 單一節點池的裝箱模擬結果
+type PoolConsolidation struct {
+	NodePool      string   `json:"nodePool"`
+	CurrentNodes  int      `json:"currentNodes"`
+	RequiredNodes int      `json:"requiredNodes"` // 以 100% 請求量裝箱所需的節點數
+	FreedNodes    int      `json:"freedNodes"`
+	FreeableNodes []string `json:"freeableNodes"` // 模擬後沒有工作負載 Pod 的節點
+	MovedPods     int      `json:"movedPods"`     // 需要重新排程到其他節點的 Pod 數
+	UnplacedPods  []string `json:"unplacedPods"`  // 放不進其他節點而留在原節點的 Pod (namespace/name)
+}
+
+// NodeWaste 單一節點的浪費
+type NodeWaste struct {
+	NodeName           string   `json:"nodeName"`
+	NodePool           string   `json:"nodePool,omitempty"`
+	CPURequestedPct    float64  `json:"cpuRequestedPct"`
+	MemoryRequestedPct float64  `json:"memoryRequestedPct"`
+	CPUUsagePct        float64  `json:"cpuUsagePct,omitempty"`
+	MemoryUsagePct     float64  `json:"memoryUsagePct,omitempty"`
+	WorkloadPods       []string `json:"workloadPods"` // 非 DaemonSet 與靜態 Pod 的 namespace/name
+	Reason             string   `json:"reason"`
+}
+
+// PoolShrink 可以減少節點數的節點池
+type PoolShrink struct {
+	NodePool           string  `json:"nodePool"`
+	CurrentNodes       int     `json:"currentNodes"`
+	RequiredNodes      int     `json:"requiredNodes"` // 以目標請求比例 80% 估算所需的節點數
+	RemovableNodes     int     `json:"removableNodes"`
+	CPURequestedPct    float64 `json:"cpuRequestedPct"`
+	MemoryRequestedPct float64 `json:"memoryRequestedPct"`
 }
 
 // ResourceWaste 資源浪費
@@ -134,4 +580,172 @@ type OptimizationCriteria struct {
 	MemoryThreshold float64 `json:"memoryThreshold"` // 記憶體使用率閾值
 	HealthThreshold int32   `json:"healthThreshold"` // 重啟次數閾值
 	IdleThreshold   float64 `json:"idleThreshold"`   // 閒置閾值
+	HeadroomFactor  float64 `json:"headroomFactor"`  // 建議值的餘裕係數 (建議值 = 使用量 × 係數)
+	MinReplicas     int32   `json:"minReplicas"`     // 副本數建議的下限 (高可用)
+	DiskThreshold   float64 `json:"diskThreshold"`   // ephemeral storage 與卷的使用率超過此值 (%) 視為即將用盡
+
+	MinObservationHours float64 `json:"minObservationHours"` // 提供資源建議值前需要的使用量資料時間長度 (小時)，0 表示不檢查
+	CPULimitPolicy      string  `json:"cpuLimitPolicy"`      // CPU 節流時的建議: raise (提高 CPU limit) 或 remove (移除 CPU limit)
+
+	Scoring ScoringWeights `json:"scoring"` // 優化分數與健康分數的權重
+}
+
+// ScoringWeights 優化分數與健康分數的權重
+// 優化分數 = (100 - 各問題扣分) × (1 - healthWeight) + 健康分數 × healthWeight，結果不低於 0
+type ScoringWeights struct {
+	HighPenalty   float64 `json:"highPenalty"`   // 每個 HIGH 問題扣除的分數
+	MediumPenalty float64 `json:"mediumPenalty"` // 每個 MEDIUM 問題扣除的分數
+	LowPenalty    float64 `json:"lowPenalty"`    // 每個 LOW 問題扣除的分數
+	HealthWeight  float64 `json:"healthWeight"`  // 健康分數佔優化分數的比例 (0-1)
+
+	RestartPenalty    float64 `json:"restartPenalty"`    // 健康分數: 重啟次數超過 healthThreshold 時每次扣除的分數
+	NotReadyPenalty   float64 `json:"notReadyPenalty"`   // 健康分數: Pod 未就緒時扣除的分數
+	NotRunningPenalty float64 `json:"notRunningPenalty"` // 健康分數: Pod 不在 Running 狀態時扣除的分數
+	MemoryLeakPenalty float64 `json:"memoryLeakPenalty"` // 健康分數: 每個疑似記憶體洩漏的容器扣除的分數
+}
+
+// AutopilotAssessment 命名空間改用 GKE Autopilot 的可行性與成本比較
+type AutopilotAssessment struct {
+	GeneratedAt           time.Time           `json:"generatedAt"`
+	Namespace             string              `json:"namespace"`
+	Summary               string              `json:"summary"`
+	CompatibleWorkloads   int                 `json:"compatibleWorkloads"`
+	IncompatibleWorkloads int                 `json:"incompatibleWorkloads"`
+	Workloads             []AutopilotWorkload `json:"workloads"` // 無法執行的工作負載在前，其餘依 Autopilot 增加的成本由高到低排序
+
+	StandardMonthlyCost  float64 `json:"standardMonthlyCost"`
+	AutopilotMonthlyCost float64 `json:"autopilotMonthlyCost"`
+	MonthlyDifference    float64 `json:"monthlyDifference"` // Autopilot 減去 Standard，負值表示 Autopilot 較便宜
+	Currency             string  `json:"currency"`
+
+	// ClusterRequestRatio 叢集 requests 佔節點可分配量的比例，Standard 成本以 requests 成本除以此比例分攤節點上未被請求的容量
+	ClusterRequestRatio float64  `json:"clusterRequestRatio"`
+	Notes               []string `json:"notes,omitempty"`
+}
+
+// AutopilotWorkload 單一工作負載在 Autopilot 上的可行性
+type AutopilotWorkload struct {
+	Kind        string   `json:"kind"`
+	Name        string   `json:"name"`
+	Namespace   string   `json:"namespace"`
+	Pods        int      `json:"pods"`
+	Compatible  bool     `json:"compatible"`
+	Blockers    []string `json:"blockers,omitempty"`    // 無法在 Autopilot 執行的原因，需要修改後才能遷移
+	Adjustments []string `json:"adjustments,omitempty"` // Autopilot 會自動調整的 requests 與需要確認的設定
+
+	StandardMonthlyCost  float64 `json:"standardMonthlyCost"`
+	AutopilotMonthlyCost float64 `json:"autopilotMonthlyCost"`
+}
+
+// ClusterComparison 多個叢集同一命名空間的優化結果比較
+type ClusterComparison struct {
+	GeneratedAt time.Time                    `json:"generatedAt"`
+	Namespace   string                       `json:"namespace"`
+	Clusters    []ClusterOptimizationSummary `json:"clusters"` // 依優化分數由低到高排序，分析失敗的叢集在最後
+
+	AverageScore                 float64 `json:"averageScore"`
+	TotalEstimatedMonthlySavings float64 `json:"totalEstimatedMonthlySavings"`
+	TotalNodeMonthlyCost         float64 `json:"totalNodeMonthlyCost"`
+	FailedClusters               int     `json:"failedClusters"`
+	Currency                     string  `json:"currency"`
+}
+
+// ClusterOptimizationSummary 單一叢集的優化結果摘要
+type ClusterOptimizationSummary struct {
+	Cluster string `json:"cluster"`
+	Error   string `json:"error,omitempty"` // 無法分析此叢集的原因
+
+	OverallScore            float64  `json:"overallScore"`
+	TotalPods               int      `json:"totalPods"`
+	PodsNeedingOptimization int      `json:"podsNeedingOptimization"`
+	Recommendations         int      `json:"recommendations"`
+	HighPriority            int      `json:"highPriority"`
+	TopRecommendations      []string `json:"topRecommendations,omitempty"`
+
+	CPUWaste                string  `json:"cpuWaste"`
+	MemoryWaste             string  `json:"memoryWaste"`
+	WastePercentage         float64 `json:"wastePercentage"`
+	RemovableNodes          int     `json:"removableNodes"`
+	StorageMonthlyCost      float64 `json:"storageMonthlyCost"`      // PVC 磁碟的每月成本
+	EstimatedMonthlySavings float64 `json:"estimatedMonthlySavings"` // 各建議可節省成本的總和
+
+	Nodes           int     `json:"nodes"`
+	NodeMonthlyCost float64 `json:"nodeMonthlyCost"` // 依節點可分配量與成本模型估算的每月成本
+}
+
+// StartupReport 命名空間各工作負載從 Pod 建立到就緒的時間
+type StartupReport struct {
+	GeneratedAt time.Time         `json:"generatedAt"`
+	Namespace   string            `json:"namespace"`
+	Workloads   []WorkloadStartup `json:"workloads"` // 啟動緩慢或不穩定的工作負載在前，其餘依啟動時間中位數由長到短排序
+	Skipped     int               `json:"skipped"`   // 尚未就緒、容器重啟過或就緒時間無法代表啟動的 Pod 數
+}
+
+// WorkloadStartup 工作負載各 Pod 的啟動時間統計
+type WorkloadStartup struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Samples   int    `json:"samples"` // 有啟動紀錄的 Pod 數
+
+	MedianSeconds          float64 `json:"medianSeconds"`
+	MinSeconds             float64 `json:"minSeconds"`
+	MaxSeconds             float64 `json:"maxSeconds"`
+	CoefficientOfVariation float64 `json:"coefficientOfVariation"` // 啟動時間的標準差 / 平均
+
+	// Phases 各階段時間的中位數，用來判斷時間花在排程、init 容器或容器啟動
+	Phases StartupPhases `json:"phases"`
+
+	Slow        bool         `json:"slow"`     // 啟動時間中位數超過門檻
+	Variable    bool         `json:"variable"` // 各 Pod 的啟動時間差異大
+	Suggestions []string     `json:"suggestions,omitempty"`
+	Pods        []PodStartup `json:"pods"`
+}
+
+// StartupPhases Pod 啟動各階段的時間 (秒)
+type StartupPhases struct {
+	SchedulingSeconds     float64 `json:"schedulingSeconds"`     // 建立到排程到節點
+	InitializationSeconds float64 `json:"initializationSeconds"` // 排程到 init 容器完成
+	ContainerSeconds      float64 `json:"containerSeconds"`      // init 容器完成到 Pod 就緒，包含拉取映像檔、應用程式啟動與 readiness probe
+}
+
+// PodStartup 單一 Pod 從建立到就緒的時間
+type PodStartup struct {
+	PodName      string        `json:"podName"`
+	NodeName     string        `json:"nodeName,omitempty"`
+	CreatedAt    time.Time     `json:"createdAt"`
+	TotalSeconds float64       `json:"totalSeconds"`
+	Phases       StartupPhases `json:"phases"`
+}
+
+// PodSecurityReport 依 Pod Security Standards 評估 Pod 的結果
+type PodSecurityReport struct {
+	GeneratedAt   time.Time                     `json:"generatedAt"`
+	Namespace     string                        `json:"namespace"` // 分析的命名空間，all 表示所有命名空間
+	Level         string                        `json:"level"`     // baseline 或 restricted
+	TotalPods     int                           `json:"totalPods"`
+	CompliantPods int                           `json:"compliantPods"`
+	Namespaces    []NamespaceSecurityCompliance `json:"namespaces"` // 違規 Pod 最多的命名空間在前
+}
+
+// NamespaceSecurityCompliance 命名空間的 Pod Security Standards 合規狀況
+type NamespaceSecurityCompliance struct {
+	Namespace     string                 `json:"namespace"`
+	EnforceLevel  string                 `json:"enforceLevel,omitempty"` // pod-security.kubernetes.io/enforce 標籤，未設定時為空
+	TotalPods     int                    `json:"totalPods"`
+	CompliantPods int                    `json:"compliantPods"`
+	Violations    []PodSecurityViolation `json:"violations"`
+	Suggestion    string                 `json:"suggestion,omitempty"` // 命名空間 Pod Security Admission 標籤的建議
+}
+
+// PodSecurityViolation 工作負載違反的 Pod Security Standards 檢查項目
+type PodSecurityViolation struct {
+	Check        string   `json:"check"` // Pod Security Admission 的檢查 ID，例如 privileged、runAsNonRoot
+	Level        string   `json:"level"` // 檢查所屬的等級，baseline 或 restricted
+	WorkloadKind string   `json:"workloadKind"`
+	WorkloadName string   `json:"workloadName"`
+	Pods         []string `json:"pods"`
+	Containers   []string `json:"containers,omitempty"` // 違規的容器，Pod 層級的設定 (例如 hostNetwork) 為空
+	Detail       string   `json:"detail"`
+	Remediation  string   `json:"remediation"`
 }
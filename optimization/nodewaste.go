@@ -0,0 +1,142 @@
+package optimization
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"mcp-gke-monitor/gke"
+)
+
+const (
+	// underutilizedNodeThreshold CPU 與記憶體請求量都低於可分配量的此比例 (%) 時視為使用不足，與 cluster autoscaler 縮減節點的預設門檻相同
+	underutilizedNodeThreshold = 50.0
+
+	// smallPodFraction Pod 的 CPU 與記憶體請求量都低於節點可分配量的此比例時視為小型 Pod
+	smallPodFraction = 0.1
+
+	// poolTargetUtilization 估算節點池所需節點數時的目標請求比例，保留擴充與排程的空間
+	poolTargetUtilization = 0.8
+)
+
+// analyzeNodeWaste 分析節點層級的浪費：請求量偏低的節點、只因單一小型 Pod 而存在的節點，以及可以減少節點數的節點池
+// Pod 層級的浪費要讓節點數減少才會真正節省成本；DaemonSet 與靜態 Pod 每個節點都有，不視為讓節點存在的工作負載
+func analyzeNodeWaste(nodes []gke.NodeUtilization) *NodeWasteAnalysis {
+	analysis := &NodeWasteAnalysis{
+		UnderutilizedNodes: []NodeWaste{},
+		SinglePodNodes:     []NodeWaste{},
+		ShrinkablePools:    []PoolShrink{},
+	}
+
+	pools := make(map[string][]gke.NodeUtilization)
+	for _, node := range nodes {
+		// 已停止排程的節點通常正在排空或維護，不列入分析
+		if node.Unschedulable || node.CPUAllocatable == 0 || node.MemoryAllocatable == 0 {
+			continue
+		}
+		pools[node.NodePool] = append(pools[node.NodePool], node)
+
+		waste := NodeWaste{
+			NodeName:           node.NodeName,
+			NodePool:           node.NodePool,
+			CPURequestedPct:    percentage(node.CPURequested, node.CPUAllocatable),
+			MemoryRequestedPct: percentage(node.MemoryRequested, node.MemoryAllocatable),
+			WorkloadPods:       []string{},
+		}
+		if node.MetricsAvailable {
+			waste.CPUUsagePct = percentage(node.CPUUsage, node.CPUAllocatable)
+			waste.MemoryUsagePct = percentage(node.MemoryUsage, node.MemoryAllocatable)
+		}
+
+		var workloadPods []gke.NodePod
+		for _, pod := range node.Pods {
+			if !pod.DaemonSet && !pod.Mirror {
+				workloadPods = append(workloadPods, pod)
+				waste.WorkloadPods = append(waste.WorkloadPods, pod.Namespace+"/"+pod.Name)
+			}
+		}
+
+		if len(workloadPods) == 1 &&
+			float64(workloadPods[0].CPURequest) < float64(node.CPUAllocatable)*smallPodFraction &&
+			float64(workloadPods[0].MemoryRequest) < float64(node.MemoryAllocatable)*smallPodFraction {
+			waste.Reason = fmt.Sprintf("節點上只有 %s 一個工作負載 Pod，且請求量不到節點的 %.0f%%，移到其他節點後即可移除此節點",
+				waste.WorkloadPods[0], smallPodFraction*100)
+			analysis.SinglePodNodes = append(analysis.SinglePodNodes, waste)
+			continue
+		}
+
+		if waste.CPURequestedPct < underutilizedNodeThreshold && waste.MemoryRequestedPct < underutilizedNodeThreshold {
+			waste.Reason = fmt.Sprintf("CPU 與記憶體請求量都低於可分配量的 %.0f%%", underutilizedNodeThreshold)
+			if len(workloadPods) == 0 {
+				waste.Reason = "節點上沒有工作負載 Pod，只有 DaemonSet 或靜態 Pod"
+			}
+			analysis.UnderutilizedNodes = append(analysis.UnderutilizedNodes, waste)
+		}
+	}
+
+	for name, poolNodes := range pools {
+		if shrink := poolShrink(name, poolNodes); shrink != nil {
+			analysis.ShrinkablePools = append(analysis.ShrinkablePools, *shrink)
+			analysis.RemovableNodes += shrink.RemovableNodes
+		}
+	}
+
+	sort.Slice(analysis.UnderutilizedNodes, func(i, j int) bool {
+		a, b := analysis.UnderutilizedNodes[i], analysis.UnderutilizedNodes[j]
+		return a.CPURequestedPct+a.MemoryRequestedPct < b.CPURequestedPct+b.MemoryRequestedPct
+	})
+	sort.Slice(analysis.ShrinkablePools, func(i, j int) bool {
+		return analysis.ShrinkablePools[i].RemovableNodes > analysis.ShrinkablePools[j].RemovableNodes
+	})
+
+	return analysis
+}
+
+// poolShrink 估算節點池所需的節點數，可以減少節點時回傳結果
+// 所需節點數為非 DaemonSet Pod 的請求量總和除以單一節點扣除 DaemonSet 後的可用量，並以目標請求比例保留空間
+func poolShrink(name string, nodes []gke.NodeUtilization) *PoolShrink {
+	var cpuAllocatable, memoryAllocatable, cpuRequested, memoryRequested, cpuDaemon, memoryDaemon int64
+	for _, node := range nodes {
+		cpuAllocatable += node.CPUAllocatable
+		memoryAllocatable += node.MemoryAllocatable
+		for _, pod := range node.Pods {
+			if pod.DaemonSet || pod.Mirror {
+				cpuDaemon += pod.CPURequest
+				memoryDaemon += pod.MemoryRequest
+				continue
+			}
+			cpuRequested += pod.CPURequest
+			memoryRequested += pod.MemoryRequest
+		}
+	}
+
+	count := float64(len(nodes))
+	cpuPerNode := float64(cpuAllocatable-cpuDaemon) / count * poolTargetUtilization
+	memoryPerNode := float64(memoryAllocatable-memoryDaemon) / count * poolTargetUtilization
+	if cpuPerNode <= 0 || memoryPerNode <= 0 {
+		return nil
+	}
+
+	required := int(math.Ceil(max(float64(cpuRequested)/cpuPerNode, float64(memoryRequested)/memoryPerNode)))
+	required = max(required, 1)
+	if required >= len(nodes) {
+		return nil
+	}
+
+	return &PoolShrink{
+		NodePool:           name,
+		CurrentNodes:       len(nodes),
+		RequiredNodes:      required,
+		RemovableNodes:     len(nodes) - required,
+		CPURequestedPct:    percentage(cpuRequested+cpuDaemon, cpuAllocatable),
+		MemoryRequestedPct: percentage(memoryRequested+memoryDaemon, memoryAllocatable),
+	}
+}
+
+// percentage 計算百分比並四捨五入到小數點後一位，分母為 0 時回傳 0
+func percentage(value, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(value)/float64(total)*1000) / 10
+}
@@ -0,0 +1,207 @@
+package optimization
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// WebhookFormatSlack 以 Slack incoming webhook 的 mrkdwn 文字傳送
+	WebhookFormatSlack = "slack"
+
+	// WebhookFormatGeneric 以 JSON 傳送摘要與高優先級建議，供其他系統處理
+	WebhookFormatGeneric = "generic"
+
+	// notificationFindings 通知中最多列出的高優先級建議數量
+	notificationFindings = 10
+)
+
+// NotifierConfig 報告通知的設定
+type NotifierConfig struct {
+	WebhookURL string
+	Format     string        // slack 或 generic，預設為 slack
+	Interval   time.Duration // 定期傳送的間隔，0 表示只在呼叫 send_report 時傳送
+	Namespaces []string      // 定期傳送報告的命名空間
+}
+
+// SetNotifier 設定報告通知的 webhook
+func (s *Service) SetNotifier(config NotifierConfig) error {
+	if config.Format == "" {
+		config.Format = WebhookFormatSlack
+	}
+	if config.Format != WebhookFormatSlack && config.Format != WebhookFormatGeneric {
+		return fmt.Errorf("不支援的 webhook 格式 %q (slack 或 generic)", config.Format)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.notifier = config
+	return nil
+}
+
+// StartNotificationSchedule 依設定的間隔定期產生報告並傳送到 webhook，直到 ctx 結束
+// 未設定 webhook 或間隔時不啟動
+func (s *Service) StartNotificationSchedule(ctx context.Context) bool {
+	s.mu.RLock()
+	config := s.notifier
+	s.mu.RUnlock()
+	if config.WebhookURL == "" || config.Interval <= 0 {
+		return false
+	}
+
+	namespaces := config.Namespaces
+	if len(namespaces) == 0 {
+		namespaces = []string{""}
+	}
+
+	go func() {
+		ticker := time.NewTicker(config.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			for _, namespace := range namespaces {
+				if _, err := s.SendReport(ctx, namespace); err != nil && s.logger != nil {
+					s.logger.Printf("警告: 定期傳送優化報告失敗: %v", err)
+				}
+			}
+		}
+	}()
+	return true
+}
+
+// SendReport 產生命名空間的優化報告並將摘要與高優先級建議傳送到 webhook，報告會保存到報告歷史
+func (s *Service) SendReport(ctx context.Context, namespace string) (*NotificationResult, error) {
+	s.mu.RLock()
+	config := s.notifier
+	s.mu.RUnlock()
+	if config.WebhookURL == "" {
+		return nil, fmt.Errorf("未設定通知 webhook (notification.webhookURL)")
+	}
+
+	report, err := s.GenerateOptimizationReport(ctx, namespace, 0)
+	if err != nil {
+		return nil, err
+	}
+	s.archiveReport(ctx, report)
+
+	findings := highPriorityFindings(report.Recommendations)
+	var payload interface{}
+	if config.Format == WebhookFormatGeneric {
+		payload = genericNotification{
+			Event:       "optimization_report",
+			ReportID:    report.ID,
+			ClusterName: report.ClusterName,
+			Namespace:   report.Namespace,
+			GeneratedAt: report.GeneratedAt,
+			Summary:     report.Summary,
+			Findings:    findings,
+		}
+	} else {
+		payload = map[string]string{"text": slackNotificationText(report, findings)}
+	}
+
+	if err := postWebhook(ctx, config.WebhookURL, payload); err != nil {
+		return nil, err
+	}
+
+	return &NotificationResult{
+		ReportID:  report.ID,
+		Namespace: report.Namespace,
+		Format:    config.Format,
+		Findings:  len(findings),
+		SentAt:    time.Now(),
+	}, nil
+}
+
+// genericNotification generic 格式的 webhook 內容
+type genericNotification struct {
+	Event       string              `json:"event"`
+	ReportID    string              `json:"reportId,omitempty"`
+	ClusterName string              `json:"clusterName"`
+	Namespace   string              `json:"namespace"`
+	GeneratedAt time.Time           `json:"generatedAt"`
+	Summary     OptimizationSummary `json:"summary"`
+	Findings    []Recommendation    `json:"findings"`
+}
+
+// highPriorityFindings 取出高優先級的建議，維持報告的排序
+func highPriorityFindings(recommendations []Recommendation) []Recommendation {
+	findings := []Recommendation{}
+	for _, rec := range recommendations {
+		if rec.Priority == PriorityHigh {
+			findings = append(findings, rec)
+		}
+	}
+	if len(findings) > notificationFindings {
+		findings = findings[:notificationFindings]
+	}
+	return findings
+}
+
+// slackNotificationText Slack mrkdwn 格式的摘要與高優先級建議
+func slackNotificationText(report *OptimizationReport, findings []Recommendation) string {
+	summary := report.Summary
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "*GKE 優化報告: %s*", report.Namespace)
+	if report.ClusterName != "" {
+		fmt.Fprintf(&b, " (%s)", report.ClusterName)
+	}
+	fmt.Fprintf(&b, "\n整體分數 *%.1f*/100，%d/%d 個 Pod 需要優化，%d 項建議，估算每月可節省 *%.2f %s*",
+		summary.OverallScore, summary.PodsNeedingOptimization, summary.TotalPods,
+		len(report.Recommendations), summary.EstimatedMonthlySavings, summary.Currency)
+
+	if len(findings) == 0 {
+		b.WriteString("\n沒有高優先級的問題 :white_check_mark:")
+		return b.String()
+	}
+
+	b.WriteString("\n\n*高優先級問題*")
+	for _, rec := range findings {
+		fmt.Fprintf(&b, "\n• `%s` %s", recommendationTarget(rec), rec.Title)
+		if rec.EstimatedMonthlySavings > 0 {
+			fmt.Fprintf(&b, " (每月 %.2f %s)", rec.EstimatedMonthlySavings, summary.Currency)
+		}
+	}
+	if report.ID != "" {
+		fmt.Fprintf(&b, "\n\n報告 ID: `%s`", report.ID)
+	}
+	return b.String()
+}
+
+// postWebhook 以 JSON 傳送內容到 webhook，非 2xx 回應視為失敗
+func postWebhook(ctx context.Context, url string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("序列化通知內容失敗: %w", err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("建立 webhook 請求失敗: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	response, err := client.Do(request)
+	if err != nil {
+		return fmt.Errorf("傳送 webhook 失敗: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		return fmt.Errorf("webhook 回應 %d: %s", response.StatusCode, strings.TrimSpace(string(message)))
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package optimization
+
+import (
+	"fmt"
+	"math"
+)
+
+// defaultMinObservationHours 產生資源建議值前需要的使用量資料時間長度
+const defaultMinObservationHours = 24.0
+
+// sizingIssues 依使用量判斷的資源配置問題，使用量資料不足時不提供建議值
+var sizingIssues = map[string]bool{
+	"CPU_OVER_PROVISIONED":     true,
+	"CPU_UNDER_PROVISIONED":    true,
+	"MEMORY_OVER_PROVISIONED":  true,
+	"MEMORY_UNDER_PROVISIONED": true,
+}
+
+// markInsufficientData 以工作負載中資料涵蓋時間最長的 Pod 判斷工作負載的使用量資料是否足夠
+// 滾動更新後新的 Pod 資料較短，但同一工作負載其他副本的資料仍可代表工作負載的使用模式
+func (s *Service) markInsufficientData(podAnalysis []PodOptimization) {
+	minHours := s.criteria.MinObservationHours
+	if minHours <= 0 {
+		return
+	}
+
+	observed := make(map[string]float64)
+	for _, pod := range podAnalysis {
+		key := pod.Namespace + "/" + pod.WorkloadKind + "/" + pod.WorkloadName
+		observed[key] = math.Max(observed[key], pod.ObservedHours)
+	}
+	for i := range podAnalysis {
+		pod := &podAnalysis[i]
+		pod.InsufficientData = observed[pod.Namespace+"/"+pod.WorkloadKind+"/"+pod.WorkloadName] < minHours
+	}
+}
+
+// insufficientDataAction 使用量資料不足時的處理方式
+func (s *Service) insufficientDataAction(observedHours float64) string {
+	return fmt.Sprintf("使用量資料只涵蓋 %.1f 小時 (需要 %.0f 小時)，累積足夠的資料後再依建議值調整，避免依短暫的取樣調整資源",
+		observedHours, s.criteria.MinObservationHours)
+}
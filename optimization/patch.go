@@ -0,0 +1,282 @@
+package optimization
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// PatchFormatStrategicMerge 以工作負載為目標的 strategic-merge patch，可直接用 kubectl patch 套用
+	PatchFormatStrategicMerge = "patch"
+
+	// PatchFormatResources 各容器完整的 resources 區塊，可貼回 manifest
+	PatchFormatResources = "resources"
+)
+
+// workloadAPIVersions 可以直接修改 Pod template 的工作負載類型與其 API 版本
+var workloadAPIVersions = map[string]string{
+	"Deployment":  "apps/v1",
+	"StatefulSet": "apps/v1",
+	"DaemonSet":   "apps/v1",
+	"ReplicaSet":  "apps/v1",
+	"CronJob":     "batch/v1",
+}
+
+// GetRecommendationPatches 將 CPU 與記憶體建議轉換為可直接套用的 YAML
+// recommendationID 不為空時只處理該建議，format 為 patch (預設) 或 resources，window 為產生建議的分析期間
+func (s *Service) GetRecommendationPatches(ctx context.Context, namespace, recommendationID, format string, window time.Duration) (*RecommendationPatchReport, error) {
+	if format == "" {
+		format = PatchFormatStrategicMerge
+	}
+	if format != PatchFormatStrategicMerge && format != PatchFormatResources {
+		return nil, fmt.Errorf("不支援的格式 %q (patch 或 resources)", format)
+	}
+
+	report, err := s.GenerateOptimizationReport(ctx, namespace, window)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &RecommendationPatchReport{
+		Namespace:   report.Namespace,
+		GeneratedAt: report.GeneratedAt,
+		Format:      format,
+		Patches:     []RecommendationPatch{},
+	}
+
+	found := false
+	for _, rec := range report.Recommendations {
+		if recommendationID != "" && rec.ID != recommendationID {
+			continue
+		}
+		found = true
+		if len(rec.Suggested) == 0 {
+			if recommendationID != "" {
+				return nil, fmt.Errorf("建議 %s 沒有可套用的資源建議值", recommendationID)
+			}
+			continue
+		}
+
+		patch, err := renderRecommendationPatch(rec, format)
+		if err != nil {
+			return nil, err
+		}
+		result.Patches = append(result.Patches, *patch)
+	}
+	if recommendationID != "" && !found {
+		return nil, fmt.Errorf("找不到建議 %s", recommendationID)
+	}
+
+	return result, nil
+}
+
+// ApplyRecommendation 以伺服器端套用 (server-side apply) 將建議的 requests 與 limits 寫入所屬工作負載
+// 每次都套用容器完整的 resources 區塊 (未調整的資源沿用目前設定)，避免同一欄位管理者前一次套用的欄位因這次未列出而被移除
+// commit 為 false 時只進行 dry-run；commit 為 true 時需啟用寫入模式，且 confirm 必須與建議 ID 相同
+// 建議 ID 依分析結果產生，window 需與取得建議時的分析期間相同
+func (s *Service) ApplyRecommendation(ctx context.Context, namespace, recommendationID, confirm string, commit bool, window time.Duration) (*RecommendationApplyResult, error) {
+	if commit && confirm != recommendationID {
+		return nil, fmt.Errorf("確認參數不符，請將 confirm 設為要套用的建議 ID %q", recommendationID)
+	}
+
+	report, err := s.GenerateOptimizationReport(ctx, namespace, window)
+	if err != nil {
+		return nil, err
+	}
+
+	var rec *Recommendation
+	for i := range report.Recommendations {
+		if report.Recommendations[i].ID == recommendationID {
+			rec = &report.Recommendations[i]
+			break
+		}
+	}
+	if rec == nil {
+		return nil, fmt.Errorf("找不到建議 %s", recommendationID)
+	}
+	if len(rec.Suggested) == 0 {
+		return nil, fmt.Errorf("建議 %s 沒有可套用的資源建議值", recommendationID)
+	}
+
+	// server-side apply 不會移除其他欄位管理者設定的欄位，移除 CPU limit 時改用 strategic merge patch 以 null 刪除
+	patchType := "apply"
+	if removesCPULimit(rec.Suggested) {
+		patchType = "strategic"
+	}
+
+	body, ok := workloadPatchBody(rec.WorkloadKind, recommendationContainers(*rec, patchType == "apply"))
+	if !ok {
+		return nil, fmt.Errorf("%s 的 Pod template 無法直接修改，請將 resources 更新到建立 %s 的設定檔", rec.WorkloadKind, rec.WorkloadName)
+	}
+	if patchType == "apply" {
+		body["apiVersion"] = workloadAPIVersions[rec.WorkloadKind]
+		body["kind"] = rec.WorkloadKind
+		body["metadata"] = map[string]interface{}{
+			"name":      rec.WorkloadName,
+			"namespace": rec.Namespace,
+		}
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("無法產生套用內容: %w", err)
+	}
+
+	result, err := s.gkeService.ApplyPatch(ctx, strings.ToLower(rec.WorkloadKind), rec.WorkloadName, rec.Namespace, patchType, data, commit)
+	if err != nil {
+		return nil, err
+	}
+
+	if commit && s.logger != nil {
+		s.logger.Printf("已套用建議 %s 到 %s %s/%s", rec.ID, rec.WorkloadKind, rec.Namespace, rec.WorkloadName)
+	}
+
+	return &RecommendationApplyResult{
+		Recommendation: *rec,
+		Result:         result,
+	}, nil
+}
+
+// recommendationResource 建議調整的資源名稱 (cpu、memory，同時調整兩者 requests 的 requests，或同時調整兩者 requests 與 limits 的 guaranteed)
+func recommendationResource(rec Recommendation) string {
+	switch {
+	case rec.Issue == "MISSING_REQUESTS" || rec.Issue == "BESTEFFORT_IN_PRODUCTION":
+		return "requests"
+	case rec.Issue == "QOS_NOT_GUARANTEED":
+		return "guaranteed"
+	case rec.Type == RecommendationMemory || rec.Issue == "MISSING_LIMITS":
+		return "memory"
+	}
+	return "cpu"
+}
+
+// recommendationContainers 產生建議中各容器的 name 與 resources
+func recommendationContainers(rec Recommendation, full bool) []map[string]interface{} {
+	resourceName := recommendationResource(rec)
+	containers := make([]map[string]interface{}, 0, len(rec.Suggested))
+	for _, suggestion := range rec.Suggested {
+		containers = append(containers, map[string]interface{}{
+			"name":      suggestion.Container,
+			"resources": containerResourcesBlock(suggestion, resourceName, full),
+		})
+	}
+	return containers
+}
+
+// renderRecommendationPatch 依建議類型只修改對應的資源 (cpu、memory、requests 或 guaranteed)，其他資源維持原設定
+func renderRecommendationPatch(rec Recommendation, format string) (*RecommendationPatch, error) {
+	patch := &RecommendationPatch{
+		RecommendationID: rec.ID,
+		PodName:          rec.PodName,
+		Namespace:        rec.Namespace,
+		WorkloadKind:     rec.WorkloadKind,
+		WorkloadName:     rec.WorkloadName,
+		Resource:         recommendationResource(rec),
+	}
+
+	containers := recommendationContainers(rec, format == PatchFormatResources)
+
+	if format == PatchFormatResources {
+		content, err := yaml.Marshal(map[string]interface{}{"containers": containers})
+		if err != nil {
+			return nil, fmt.Errorf("無法產生 resources YAML: %w", err)
+		}
+		patch.YAML = string(content)
+		return patch, nil
+	}
+
+	// 直接修改 Pod 或 Job 的 Pod template 會被 API 伺服器拒絕，只提供 resources 區塊的內容
+	body, ok := workloadPatchBody(rec.WorkloadKind, containers)
+	if !ok {
+		content, err := yaml.Marshal(map[string]interface{}{"containers": containers})
+		if err != nil {
+			return nil, fmt.Errorf("無法產生 resources YAML: %w", err)
+		}
+		patch.YAML = string(content)
+		patch.Note = fmt.Sprintf("%s 的 Pod template 無法直接修改，請將 resources 更新到建立 %s 的設定檔", rec.WorkloadKind, rec.WorkloadName)
+		return patch, nil
+	}
+
+	content, err := yaml.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("無法產生 patch YAML: %w", err)
+	}
+	inline, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("無法產生 patch JSON: %w", err)
+	}
+
+	patch.YAML = string(content)
+	patch.Command = fmt.Sprintf("kubectl patch %s %s -n %s --type strategic -p '%s'",
+		strings.ToLower(rec.WorkloadKind), rec.WorkloadName, rec.Namespace, inline)
+	return patch, nil
+}
+
+// workloadPatchBody 將容器列表放到工作負載的 Pod template 路徑下，不支援修改 Pod template 的類型回傳 false
+func workloadPatchBody(kind string, containers []map[string]interface{}) (map[string]interface{}, bool) {
+	if _, ok := workloadAPIVersions[kind]; !ok {
+		return nil, false
+	}
+
+	podSpec := map[string]interface{}{"template": map[string]interface{}{"spec": map[string]interface{}{"containers": containers}}}
+	if kind == "CronJob" {
+		return map[string]interface{}{"spec": map[string]interface{}{"jobTemplate": map[string]interface{}{"spec": podSpec}}}, true
+	}
+	return map[string]interface{}{"spec": podSpec}, true
+}
+
+// containerResourcesBlock 產生容器的 resources 內容
+// full 為 true 時包含 cpu 與記憶體的完整設定，未調整的資源沿用目前設定；否則只包含調整的資源
+func containerResourcesBlock(suggestion ResourceSuggestion, resourceName string, full bool) map[string]interface{} {
+	requests := map[string]interface{}{}
+	limits := map[string]interface{}{}
+	set := func(values map[string]interface{}, key, value string) {
+		// 未設定的 request 或 limit 以 "0" 表示，不放進 resources
+		if value != "" && value != "0" {
+			values[key] = value
+		}
+	}
+
+	switch resourceName {
+	case "guaranteed":
+		set(requests, "cpu", suggestion.Suggested.CPURequest)
+		set(limits, "cpu", suggestion.Suggested.CPULimit)
+		set(requests, "memory", suggestion.Suggested.MemoryRequest)
+		set(limits, "memory", suggestion.Suggested.MemoryLimit)
+	case "requests":
+		set(requests, "cpu", suggestion.Suggested.CPURequest)
+		set(requests, "memory", suggestion.Suggested.MemoryRequest)
+		if full {
+			set(limits, "cpu", suggestion.Current.CPULimit)
+			set(limits, "memory", suggestion.Current.MemoryLimit)
+		}
+	case "cpu":
+		set(requests, "cpu", suggestion.Suggested.CPURequest)
+		set(limits, "cpu", suggestion.Suggested.CPULimit)
+		if suggestion.RemoveCPULimit && !full {
+			// strategic merge patch 以 null 移除欄位；完整的 resources 區塊省略即可
+			limits["cpu"] = nil
+		}
+		if full {
+			set(requests, "memory", suggestion.Current.MemoryRequest)
+			set(limits, "memory", suggestion.Current.MemoryLimit)
+		}
+	default:
+		set(requests, "memory", suggestion.Suggested.MemoryRequest)
+		set(limits, "memory", suggestion.Suggested.MemoryLimit)
+		if full {
+			set(requests, "cpu", suggestion.Current.CPURequest)
+			set(limits, "cpu", suggestion.Current.CPULimit)
+		}
+	}
+
+	return map[string]interface{}{
+		"requests": requests,
+		"limits":   limits,
+	}
+}
@@ -0,0 +1,149 @@
+package optimization
+
+import (
+	"fmt"
+
+	"mcp-gke-monitor/gke"
+
+	"sigs.k8s.io/yaml"
+)
+
+// generatedPodLabels 控制器自動加上、每個 Pod 或每個版本不同的標籤，不能作為 PDB 的選擇器
+var generatedPodLabels = map[string]bool{
+	"pod-template-hash":                  true,
+	"controller-revision-hash":           true,
+	"statefulset.kubernetes.io/pod-name": true,
+	"apps.kubernetes.io/pod-index":       true,
+}
+
+// recommendDisruptionBudgets 檢查 Deployment 與 StatefulSet 的 PodDisruptionBudget
+// 正式環境中多副本但沒有 PDB 的工作負載在節點升級或排空時可能同時失去所有副本；
+// 所有副本都健康仍不允許任何中斷的 PDB 則會讓節點排空永遠無法完成
+func (s *Service) recommendDisruptionBudgets(workloads []*workloadGroup, pdbs []gke.PodDisruptionBudget) []Recommendation {
+	var recommendations []Recommendation
+	for _, workload := range workloads {
+		if workload.kind != "Deployment" && workload.kind != "StatefulSet" {
+			continue
+		}
+
+		var matched []gke.PodDisruptionBudget
+		for _, pdb := range pdbs {
+			if pdb.Namespace == workload.namespace && pdb.Matches(workload.pods[0].labels) {
+				matched = append(matched, pdb)
+			}
+		}
+
+		rec := Recommendation{
+			ID:           fmt.Sprintf("REC-%s-pdb", workload.name),
+			Type:         RecommendationHealth,
+			Namespace:    workload.namespace,
+			WorkloadKind: workload.kind,
+			WorkloadName: workload.name,
+		}
+		pdbName := workload.name + "-pdb"
+
+		switch {
+		case len(matched) == 0:
+			if len(workload.pods) < 2 || !s.productionNamespaces[workload.namespace] {
+				continue
+			}
+			rec.Priority = PriorityMedium
+			rec.Title = fmt.Sprintf("%s %s 有 %d 個副本但沒有 PodDisruptionBudget", workload.kind, workload.name, len(workload.pods))
+			rec.Description = "節點升級、自動縮減或排空時會同時驅逐多個副本，可能造成服務中斷"
+			rec.Impact = "節點維護時維持足夠的可用副本"
+		default:
+			blocking := blockingDisruptionBudget(matched)
+			if blocking == nil {
+				continue
+			}
+			pdbName = blocking.Name
+			rec.Priority = PriorityHigh
+			rec.Title = fmt.Sprintf("PodDisruptionBudget %s 不允許任何中斷，會阻擋節點排空", blocking.Name)
+			rec.Description = fmt.Sprintf("%d 個副本都健康但允許的中斷數為 0 (minAvailable %s、maxUnavailable %s)，節點升級與 drain_node 會一直等待",
+				blocking.CurrentHealthy, valueOrDash(blocking.MinAvailable), valueOrDash(blocking.MaxUnavailable))
+			rec.Impact = "讓節點升級與排空可以逐一驅逐副本完成，同時維持服務可用"
+		}
+
+		suggestion := &DisruptionBudgetSuggestion{
+			Selector:       stablePodLabels(workload.pods[0].labels),
+			MaxUnavailable: 1,
+		}
+		if len(matched) > 0 {
+			existing := matched[0].PDBStatus
+			suggestion.Existing = &existing
+		}
+		manifest, err := disruptionBudgetManifest(pdbName, workload.namespace, suggestion)
+		if err != nil {
+			if s.logger != nil {
+				s.logger.Printf("警告: 無法產生 %s %s 的 PDB 設定: %v", workload.kind, workload.name, err)
+			}
+		}
+		suggestion.Manifest = manifest
+
+		rec.Action = fmt.Sprintf("以 kubectl apply 建立 PodDisruptionBudget %s (maxUnavailable 1)", pdbName)
+		if len(matched) > 0 {
+			rec.Action = fmt.Sprintf("將 PodDisruptionBudget %s 改為 maxUnavailable 1", pdbName)
+		}
+		if len(workload.pods) < 2 {
+			rec.Action += "；單一副本的工作負載無法同時兼顧可用性與排空，建議增加到至少 2 個副本"
+		}
+		rec.DisruptionBudget = suggestion
+
+		recommendations = append(recommendations, rec)
+	}
+
+	return recommendations
+}
+
+// blockingDisruptionBudget 找出所有選取的 Pod 都健康，卻仍不允許任何中斷的 PDB
+func blockingDisruptionBudget(pdbs []gke.PodDisruptionBudget) *gke.PodDisruptionBudget {
+	for i, pdb := range pdbs {
+		if pdb.ExpectedPods > 0 && pdb.CurrentHealthy >= pdb.ExpectedPods && pdb.DisruptionsAllowed == 0 {
+			return &pdbs[i]
+		}
+	}
+	return nil
+}
+
+// stablePodLabels 去除控制器自動產生的標籤，留下可作為 PDB 選擇器的 Pod 標籤
+func stablePodLabels(podLabels map[string]string) map[string]string {
+	result := make(map[string]string, len(podLabels))
+	for key, value := range podLabels {
+		if !generatedPodLabels[key] {
+			result[key] = value
+		}
+	}
+	return result
+}
+
+// disruptionBudgetManifest 產生 policy/v1 PodDisruptionBudget 的 YAML
+func disruptionBudgetManifest(name, namespace string, suggestion *DisruptionBudgetSuggestion) (string, error) {
+	manifest := map[string]interface{}{
+		"apiVersion": "policy/v1",
+		"kind":       "PodDisruptionBudget",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": namespace,
+		},
+		"spec": map[string]interface{}{
+			"maxUnavailable": suggestion.MaxUnavailable,
+			"selector": map[string]interface{}{
+				"matchLabels": suggestion.Selector,
+			},
+		},
+	}
+
+	content, err := yaml.Marshal(manifest)
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+// valueOrDash 空字串以 "-" 顯示
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -0,0 +1,590 @@
+package optimization
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"mcp-gke-monitor/gke"
+)
+
+const (
+	// PodSecurityBaseline Pod Security Standards 的 baseline 等級，禁止已知的權限提升
+	PodSecurityBaseline = "baseline"
+
+	// PodSecurityRestricted Pod Security Standards 的 restricted 等級，包含 baseline 並要求強化 Pod 的安全設定
+	PodSecurityRestricted = "restricted"
+)
+
+// podSecurityLevelRank Pod Security Admission 等級的嚴格程度，未設定標籤等同 privileged
+var podSecurityLevelRank = map[string]int{
+	"":                    0,
+	"privileged":          0,
+	PodSecurityBaseline:   1,
+	PodSecurityRestricted: 2,
+}
+
+// baselineCapabilities baseline 等級允許容器加入的 Linux capabilities
+var baselineCapabilities = map[string]bool{
+	"AUDIT_WRITE": true, "CHOWN": true, "DAC_OVERRIDE": true, "FOWNER": true, "FSETID": true,
+	"KILL": true, "MKNOD": true, "NET_BIND_SERVICE": true, "SETFCAP": true, "SETGID": true,
+	"SETPCAP": true, "SETUID": true, "SYS_CHROOT": true,
+}
+
+// allowedSELinuxTypes baseline 等級允許的 SELinux 類型
+var allowedSELinuxTypes = map[string]bool{
+	"":                   true,
+	"container_t":        true,
+	"container_init_t":   true,
+	"container_kvm_t":    true,
+	"container_engine_t": true,
+}
+
+// safeSysctls baseline 等級允許的 sysctl
+var safeSysctls = map[string]bool{
+	"kernel.shm_rmid_forced":              true,
+	"net.ipv4.ip_local_port_range":        true,
+	"net.ipv4.ip_unprivileged_port_start": true,
+	"net.ipv4.tcp_syncookies":             true,
+	"net.ipv4.ping_group_range":           true,
+	"net.ipv4.ip_local_reserved_ports":    true,
+	"net.ipv4.tcp_keepalive_time":         true,
+	"net.ipv4.tcp_fin_timeout":            true,
+	"net.ipv4.tcp_keepalive_intvl":        true,
+	"net.ipv4.tcp_keepalive_probes":       true,
+}
+
+// restrictedVolumeTypes restricted 等級允許的卷類型
+var restrictedVolumeTypes = map[string]bool{
+	"configMap":             true,
+	"csi":                   true,
+	"downwardAPI":           true,
+	"emptyDir":              true,
+	"ephemeral":             true,
+	"persistentVolumeClaim": true,
+	"projected":             true,
+	"secret":                true,
+}
+
+// podSecurityRemediations 各檢查項目的修正方式
+var podSecurityRemediations = map[string]string{
+	"hostProcess":               "移除 windowsOptions.hostProcess，改以一般的 Windows 容器執行",
+	"hostNamespaces":            "移除 hostNetwork、hostPID 與 hostIPC；需要對外提供服務時改用 Service",
+	"privileged":                "移除 securityContext.privileged，改為只加入需要的 capabilities",
+	"capabilities_baseline":     "移除 capabilities.add 中不在 baseline 允許清單的 capability (例如 SYS_ADMIN、NET_ADMIN)",
+	"hostPathVolumes":           "以 emptyDir、ConfigMap 或 PVC 取代 hostPath 卷；需要存取節點的代理程式改放在獨立且不強制 baseline 的命名空間",
+	"hostPorts":                 "移除 hostPort，改以 Service (NodePort 或 LoadBalancer) 對外提供服務",
+	"appArmorProfile":           "移除 Unconfined 的 AppArmor 設定，使用 RuntimeDefault",
+	"seLinuxOptions":            "移除 seLinuxOptions 的 user 與 role，type 只使用 container_t 等允許的類型",
+	"procMount":                 "移除 securityContext.procMount 或設為 Default",
+	"seccompProfile_baseline":   "不要將 seccompProfile 設為 Unconfined，改用 RuntimeDefault",
+	"sysctls":                   "只使用安全的 sysctl (例如 net.ipv4.ip_local_port_range)，其餘改在節點設定",
+	"restrictedVolumes":         "只使用 configMap、csi、downwardAPI、emptyDir、ephemeral、persistentVolumeClaim、projected 與 secret 卷",
+	"allowPrivilegeEscalation":  "在各容器的 securityContext 設定 allowPrivilegeEscalation: false",
+	"runAsNonRoot":              "在 Pod 的 securityContext 設定 runAsNonRoot: true，映像檔需以非 root 使用者執行 (Dockerfile 的 USER)",
+	"runAsUser":                 "將 runAsUser 設為非 0 的 UID",
+	"seccompProfile_restricted": "在 Pod 的 securityContext 設定 seccompProfile.type: RuntimeDefault",
+	"capabilities_restricted":   "在各容器設定 capabilities.drop: [\"ALL\"]，只在需要綁定 1024 以下的連接埠時加入 NET_BIND_SERVICE",
+}
+
+// restrictedOverrides restricted 等級取代的 baseline 檢查項目，評估 restricted 時不重複列出
+var restrictedOverrides = map[string]bool{
+	"capabilities_baseline":   true,
+	"seccompProfile_baseline": true,
+}
+
+// podSecurityFinding 單一 Pod 違反的檢查項目
+type podSecurityFinding struct {
+	check      string
+	level      string
+	containers []string
+	detail     string
+}
+
+// AnalyzePodSecurity 依 Pod Security Standards 評估命名空間的 Pod，依命名空間列出違規的工作負載與修正方式
+// level 為 baseline (預設) 或 restricted；安全檢查涵蓋所有 Pod，不套用優化分析的排除條件
+func (s *Service) AnalyzePodSecurity(ctx context.Context, namespace, level string) (*PodSecurityReport, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if namespace == "" {
+		namespace = "default"
+	}
+	if level == "" {
+		level = PodSecurityBaseline
+	}
+	if level != PodSecurityBaseline && level != PodSecurityRestricted {
+		return nil, fmt.Errorf("Pod Security Standards 等級必須是 %s 或 %s: %s", PodSecurityBaseline, PodSecurityRestricted, level)
+	}
+
+	profiles, err := s.gkeService.GetPodSecurityProfiles(ctx, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("無法取得 Pod 的安全設定: %w", err)
+	}
+
+	// 命名空間的 Pod Security Admission 標籤，無法取得時只列出違規，不提供標籤建議
+	enforced := make(map[string]string)
+	labelsAvailable := true
+	namespaceLevels, err := s.gkeService.GetNamespacePodSecurity(ctx, namespace)
+	if err != nil {
+		labelsAvailable = false
+		if s.logger != nil {
+			s.logger.Printf("警告: 無法取得命名空間的 Pod Security Admission 標籤: %v", err)
+		}
+	}
+	for _, ns := range namespaceLevels {
+		enforced[ns.Namespace] = ns.Enforce
+	}
+
+	report := &PodSecurityReport{
+		GeneratedAt: time.Now(),
+		Namespace:   namespace,
+		Level:       level,
+		Namespaces:  []NamespaceSecurityCompliance{},
+	}
+
+	byNamespace := make(map[string]*NamespaceSecurityCompliance)
+	violationIndex := make(map[string]int) // 工作負載與檢查項目在命名空間 Violations 中的位置
+	for _, profile := range profiles {
+		compliance, ok := byNamespace[profile.Namespace]
+		if !ok {
+			compliance = &NamespaceSecurityCompliance{
+				Namespace:    profile.Namespace,
+				EnforceLevel: enforced[profile.Namespace],
+				Violations:   []PodSecurityViolation{},
+			}
+			byNamespace[profile.Namespace] = compliance
+		}
+		compliance.TotalPods++
+		report.TotalPods++
+
+		findings := evaluatePodSecurity(profile, level)
+		if len(findings) == 0 {
+			compliance.CompliantPods++
+			report.CompliantPods++
+			continue
+		}
+		for _, finding := range findings {
+			key := strings.Join([]string{profile.Namespace, profile.WorkloadKind, profile.WorkloadName, finding.check}, "/")
+			index, ok := violationIndex[key]
+			if !ok {
+				index = len(compliance.Violations)
+				violationIndex[key] = index
+				compliance.Violations = append(compliance.Violations, PodSecurityViolation{
+					Check:        finding.check,
+					Level:        finding.level,
+					WorkloadKind: profile.WorkloadKind,
+					WorkloadName: profile.WorkloadName,
+					Detail:       finding.detail,
+					Remediation:  podSecurityRemediations[finding.check],
+				})
+			}
+			violation := &compliance.Violations[index]
+			violation.Pods = append(violation.Pods, profile.Name)
+			for _, container := range finding.containers {
+				if !slices.Contains(violation.Containers, container) {
+					violation.Containers = append(violation.Containers, container)
+				}
+			}
+		}
+	}
+
+	for _, compliance := range byNamespace {
+		sort.SliceStable(compliance.Violations, func(i, j int) bool {
+			a, b := compliance.Violations[i], compliance.Violations[j]
+			if a.Level != b.Level {
+				return a.Level == PodSecurityBaseline
+			}
+			if a.WorkloadKind+"/"+a.WorkloadName != b.WorkloadKind+"/"+b.WorkloadName {
+				return a.WorkloadKind+"/"+a.WorkloadName < b.WorkloadKind+"/"+b.WorkloadName
+			}
+			return a.Check < b.Check
+		})
+		if labelsAvailable {
+			compliance.Suggestion = podSecurityLabelSuggestion(*compliance, level)
+		}
+		report.Namespaces = append(report.Namespaces, *compliance)
+	}
+
+	// 違規 Pod 最多的命名空間在前
+	sort.Slice(report.Namespaces, func(i, j int) bool {
+		a, b := report.Namespaces[i], report.Namespaces[j]
+		violatingA, violatingB := a.TotalPods-a.CompliantPods, b.TotalPods-b.CompliantPods
+		if violatingA != violatingB {
+			return violatingA > violatingB
+		}
+		return a.Namespace < b.Namespace
+	})
+
+	return report, nil
+}
+
+// podSecurityLabelSuggestion 依合規狀況與目前的 enforce 標籤建議命名空間的 Pod Security Admission 標籤
+func podSecurityLabelSuggestion(compliance NamespaceSecurityCompliance, level string) string {
+	enforcing := podSecurityLevelRank[compliance.EnforceLevel] >= podSecurityLevelRank[level]
+	switch {
+	case len(compliance.Violations) == 0 && !enforcing:
+		return fmt.Sprintf("所有 Pod 都符合 %s，可以強制執行避免之後部署違規的 Pod: kubectl label namespace %s pod-security.kubernetes.io/enforce=%s", level, compliance.Namespace, level)
+	case len(compliance.Violations) > 0 && !enforcing:
+		return fmt.Sprintf("修正違規前先加上 warn 與 audit 標籤，部署違規的 Pod 時會收到警告而不會被拒絕: kubectl label namespace %s pod-security.kubernetes.io/warn=%s pod-security.kubernetes.io/audit=%s", compliance.Namespace, level, level)
+	case len(compliance.Violations) > 0:
+		return fmt.Sprintf("命名空間已強制 %s，違規的 Pod 在加上標籤前建立，重新建立時會被拒絕，需要先修正工作負載的設定", compliance.EnforceLevel)
+	default:
+		return ""
+	}
+}
+
+// evaluatePodSecurity 依 Pod Security Standards 評估 Pod，level 為 restricted 時同時評估 baseline 的檢查項目
+func evaluatePodSecurity(profile gke.PodSecurityProfile, level string) []podSecurityFinding {
+	findings := baselineFindings(profile)
+	if level != PodSecurityRestricted {
+		return findings
+	}
+
+	result := findings[:0]
+	for _, finding := range findings {
+		if !restrictedOverrides[finding.check] {
+			result = append(result, finding)
+		}
+	}
+	return append(result, restrictedFindings(profile)...)
+}
+
+// baselineFindings baseline 等級的檢查項目
+func baselineFindings(profile gke.PodSecurityProfile) []podSecurityFinding {
+	var findings []podSecurityFinding
+	add := func(check, detail string, containers []string) {
+		findings = append(findings, podSecurityFinding{check: check, level: PodSecurityBaseline, containers: containers, detail: detail})
+	}
+
+	hostProcess := containerViolations(profile, func(c gke.ContainerSecurity) string {
+		if c.HostProcess {
+			return "HostProcess"
+		}
+		return ""
+	})
+	if profile.HostProcess || len(hostProcess) > 0 {
+		add("hostProcess", listContainers("以 Windows HostProcess 執行", hostProcess), violatingContainers(hostProcess))
+	}
+
+	var namespaces []string
+	if profile.HostNetwork {
+		namespaces = append(namespaces, "hostNetwork")
+	}
+	if profile.HostPID {
+		namespaces = append(namespaces, "hostPID")
+	}
+	if profile.HostIPC {
+		namespaces = append(namespaces, "hostIPC")
+	}
+	if len(namespaces) > 0 {
+		add("hostNamespaces", "使用節點的命名空間: "+strings.Join(namespaces, ", "), nil)
+	}
+
+	if privileged := containerViolations(profile, func(c gke.ContainerSecurity) string {
+		if c.Privileged {
+			return "privileged"
+		}
+		return ""
+	}); len(privileged) > 0 {
+		add("privileged", listContainers("特權容器", privileged), violatingContainers(privileged))
+	}
+
+	if capabilities := containerViolations(profile, func(c gke.ContainerSecurity) string {
+		var disallowed []string
+		for _, capability := range c.AddedCapabilities {
+			if !baselineCapabilities[strings.TrimPrefix(capability, "CAP_")] {
+				disallowed = append(disallowed, capability)
+			}
+		}
+		return strings.Join(disallowed, ", ")
+	}); len(capabilities) > 0 {
+		add("capabilities_baseline", joinDetails("加入不允許的 capabilities", capabilities), violatingContainers(capabilities))
+	}
+
+	var hostPaths []string
+	for _, volume := range profile.Volumes {
+		if volume.Type == "hostPath" {
+			hostPaths = append(hostPaths, volume.Name)
+		}
+	}
+	if len(hostPaths) > 0 {
+		add("hostPathVolumes", "掛載 hostPath 卷: "+strings.Join(hostPaths, ", "), nil)
+	}
+
+	if hostPorts := containerViolations(profile, func(c gke.ContainerSecurity) string {
+		ports := make([]string, 0, len(c.HostPorts))
+		for _, port := range c.HostPorts {
+			ports = append(ports, fmt.Sprint(port))
+		}
+		return strings.Join(ports, ", ")
+	}); len(hostPorts) > 0 {
+		add("hostPorts", joinDetails("使用 hostPort", hostPorts), violatingContainers(hostPorts))
+	}
+
+	appArmor := containerViolations(profile, func(c gke.ContainerSecurity) string {
+		if c.AppArmor == "Unconfined" {
+			return "Unconfined"
+		}
+		return ""
+	})
+	if profile.AppArmor == "Unconfined" || len(appArmor) > 0 {
+		add("appArmorProfile", joinDetails("AppArmor 設為 Unconfined", appArmor), violatingContainers(appArmor))
+	}
+
+	seLinux := containerViolations(profile, func(c gke.ContainerSecurity) string {
+		return seLinuxViolation(c.SELinux)
+	})
+	if detail := seLinuxViolation(profile.SELinux); detail != "" || len(seLinux) > 0 {
+		if detail != "" {
+			seLinux = append([]string{"Pod: " + detail}, seLinux...)
+		}
+		add("seLinuxOptions", joinDetails("不允許的 SELinux 設定", seLinux), violatingContainers(seLinux))
+	}
+
+	if procMount := containerViolations(profile, func(c gke.ContainerSecurity) string {
+		if c.ProcMount != "" && c.ProcMount != "Default" {
+			return c.ProcMount
+		}
+		return ""
+	}); len(procMount) > 0 {
+		add("procMount", joinDetails("procMount 不是 Default", procMount), violatingContainers(procMount))
+	}
+
+	seccomp := containerViolations(profile, func(c gke.ContainerSecurity) string {
+		if c.Seccomp == "Unconfined" {
+			return "Unconfined"
+		}
+		return ""
+	})
+	if profile.Seccomp == "Unconfined" || len(seccomp) > 0 {
+		add("seccompProfile_baseline", joinDetails("seccomp 設為 Unconfined", seccomp), violatingContainers(seccomp))
+	}
+
+	var sysctls []string
+	for _, sysctl := range profile.Sysctls {
+		if !safeSysctls[sysctl] {
+			sysctls = append(sysctls, sysctl)
+		}
+	}
+	if len(sysctls) > 0 {
+		add("sysctls", "設定不安全的 sysctl: "+strings.Join(sysctls, ", "), nil)
+	}
+
+	return findings
+}
+
+// restrictedFindings restricted 等級額外的檢查項目，容器未設定的欄位沿用 Pod 層級的設定
+func restrictedFindings(profile gke.PodSecurityProfile) []podSecurityFinding {
+	var findings []podSecurityFinding
+	add := func(check, detail string, containers []string) {
+		findings = append(findings, podSecurityFinding{check: check, level: PodSecurityRestricted, containers: containers, detail: detail})
+	}
+
+	var volumes []string
+	for _, volume := range profile.Volumes {
+		if !restrictedVolumeTypes[volume.Type] {
+			volumes = append(volumes, fmt.Sprintf("%s (%s)", volume.Name, volume.Type))
+		}
+	}
+	if len(volumes) > 0 {
+		add("restrictedVolumes", "使用不允許的卷類型: "+strings.Join(volumes, ", "), nil)
+	}
+
+	if escalation := containerViolations(profile, func(c gke.ContainerSecurity) string {
+		if c.AllowPrivilegeEscalation == nil || *c.AllowPrivilegeEscalation {
+			return "未設為 false"
+		}
+		return ""
+	}); len(escalation) > 0 {
+		add("allowPrivilegeEscalation", listContainers("allowPrivilegeEscalation 未設為 false", escalation), violatingContainers(escalation))
+	}
+
+	if nonRoot := containerViolations(profile, func(c gke.ContainerSecurity) string {
+		runAsNonRoot := c.RunAsNonRoot
+		if runAsNonRoot == nil {
+			runAsNonRoot = profile.RunAsNonRoot
+		}
+		if runAsNonRoot == nil || !*runAsNonRoot {
+			return "未設定 runAsNonRoot: true"
+		}
+		return ""
+	}); len(nonRoot) > 0 {
+		add("runAsNonRoot", listContainers("未設定 runAsNonRoot: true，可能以 root 執行", nonRoot), violatingContainers(nonRoot))
+	}
+
+	rootUser := containerViolations(profile, func(c gke.ContainerSecurity) string {
+		if c.RunAsUser != nil && *c.RunAsUser == 0 {
+			return "runAsUser 0"
+		}
+		return ""
+	})
+	if profile.RunAsUser != nil && *profile.RunAsUser == 0 {
+		rootUser = append([]string{"Pod: runAsUser 0"}, rootUser...)
+	}
+	if len(rootUser) > 0 {
+		add("runAsUser", joinDetails("以 UID 0 執行", rootUser), violatingContainers(rootUser))
+	}
+
+	if seccomp := containerViolations(profile, func(c gke.ContainerSecurity) string {
+		seccomp := c.Seccomp
+		if seccomp == "" {
+			seccomp = profile.Seccomp
+		}
+		switch seccomp {
+		case "RuntimeDefault", "Localhost":
+			return ""
+		case "":
+			return "未設定"
+		default:
+			return seccomp
+		}
+	}); len(seccomp) > 0 {
+		add("seccompProfile_restricted", joinDetails("seccomp 不是 RuntimeDefault 或 Localhost", seccomp), violatingContainers(seccomp))
+	}
+
+	if capabilities := containerViolations(profile, func(c gke.ContainerSecurity) string {
+		var problems []string
+		dropsAll := false
+		for _, capability := range c.DroppedCapabilities {
+			dropsAll = dropsAll || capability == "ALL"
+		}
+		if !dropsAll {
+			problems = append(problems, "未 drop ALL")
+		}
+		for _, capability := range c.AddedCapabilities {
+			if strings.TrimPrefix(capability, "CAP_") != "NET_BIND_SERVICE" {
+				problems = append(problems, "加入 "+capability)
+			}
+		}
+		return strings.Join(problems, ", ")
+	}); len(capabilities) > 0 {
+		add("capabilities_restricted", joinDetails("capabilities 不符合 restricted", capabilities), violatingContainers(capabilities))
+	}
+
+	return findings
+}
+
+// containerViolations 逐一檢查容器，check 回傳違規說明 (不違規時為空字串)，結果為 "容器: 說明"
+func containerViolations(profile gke.PodSecurityProfile, check func(gke.ContainerSecurity) string) []string {
+	var violations []string
+	for _, container := range profile.Containers {
+		if detail := check(container); detail != "" {
+			violations = append(violations, container.Name+": "+detail)
+		}
+	}
+	return violations
+}
+
+// violatingContainers 從 containerViolations 的結果取出容器名稱，Pod 層級的設定不列入
+func violatingContainers(violations []string) []string {
+	var containers []string
+	for _, violation := range violations {
+		name, _, _ := strings.Cut(violation, ": ")
+		if name != "Pod" {
+			containers = append(containers, name)
+		}
+	}
+	return containers
+}
+
+// joinDetails 組合檢查項目的說明與各容器的違規內容
+func joinDetails(summary string, violations []string) string {
+	if len(violations) == 0 {
+		return summary
+	}
+	return summary + " (" + strings.Join(violations, "；") + ")"
+}
+
+// listContainers 只需要列出容器名稱的檢查項目說明，例如特權容器
+func listContainers(summary string, violations []string) string {
+	containers := violatingContainers(violations)
+	if len(containers) == 0 {
+		return summary
+	}
+	return summary + ": " + strings.Join(containers, ", ")
+}
+
+// seLinuxViolation SELinux 設定違反 baseline 的內容，符合時為空字串
+func seLinuxViolation(options *gke.SELinuxOptions) string {
+	if options == nil {
+		return ""
+	}
+	var problems []string
+	if !allowedSELinuxTypes[options.Type] {
+		problems = append(problems, "type "+options.Type)
+	}
+	if options.User != "" {
+		problems = append(problems, "user "+options.User)
+	}
+	if options.Role != "" {
+		problems = append(problems, "role "+options.Role)
+	}
+	return strings.Join(problems, ", ")
+}
+
+// recommendPodSecurity 為違反 baseline 等級的工作負載產生安全性建議，只評估優化分析涵蓋的 Pod
+func recommendPodSecurity(profiles []gke.PodSecurityProfile, pods []gke.Pod) []Recommendation {
+	analyzed := make(map[string]bool, len(pods))
+	for _, pod := range pods {
+		analyzed[pod.Namespace+"/"+pod.Name] = true
+	}
+
+	type workloadFindings struct {
+		namespace, kind, name string
+		checks                []string
+		details               []string
+		pods                  int
+	}
+	var order []string
+	byWorkload := make(map[string]*workloadFindings)
+	for _, profile := range profiles {
+		if !analyzed[profile.Namespace+"/"+profile.Name] {
+			continue
+		}
+		findings := baselineFindings(profile)
+		if len(findings) == 0 {
+			continue
+		}
+		key := profile.Namespace + "/" + profile.WorkloadKind + "/" + profile.WorkloadName
+		workload, ok := byWorkload[key]
+		if !ok {
+			workload = &workloadFindings{namespace: profile.Namespace, kind: profile.WorkloadKind, name: profile.WorkloadName}
+			byWorkload[key] = workload
+			order = append(order, key)
+		}
+		workload.pods++
+		for _, finding := range findings {
+			if !slices.Contains(workload.checks, finding.check) {
+				workload.checks = append(workload.checks, finding.check)
+				workload.details = append(workload.details, finding.detail)
+			}
+		}
+	}
+
+	recommendations := make([]Recommendation, 0, len(order))
+	for _, key := range order {
+		workload := byWorkload[key]
+		remediations := make([]string, 0, len(workload.checks))
+		for _, check := range workload.checks {
+			remediations = append(remediations, podSecurityRemediations[check])
+		}
+		recommendations = append(recommendations, Recommendation{
+			ID:           fmt.Sprintf("REC-%s-pod-security", workload.name),
+			Type:         RecommendationSecurity,
+			Issue:        "POD_SECURITY_BASELINE",
+			Priority:     PriorityHigh,
+			Title:        fmt.Sprintf("%s %s 違反 Pod Security Standards baseline: %s", workload.kind, workload.name, strings.Join(workload.checks, ", ")),
+			Description:  fmt.Sprintf("%d 個 Pod 違規：%s", workload.pods, strings.Join(workload.details, "；")),
+			Impact:       "降低容器逃逸與存取節點的風險，讓命名空間可以強制執行 baseline 等級",
+			Action:       strings.Join(remediations, "；"),
+			Namespace:    workload.namespace,
+			WorkloadKind: workload.kind,
+			WorkloadName: workload.name,
+		})
+	}
+	return recommendations
+}
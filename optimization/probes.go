@@ -0,0 +1,139 @@
+package optimization
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"mcp-gke-monitor/gke"
+)
+
+const (
+	// aggressiveProbeToleranceSeconds liveness probe 容許連續失敗的時間 (period × failureThreshold) 低於此值視為過於嚴格
+	aggressiveProbeToleranceSeconds = 15
+
+	// slowStartSeconds 容器啟動到就緒的時間，或 liveness probe 的 initialDelaySeconds 超過此值視為啟動緩慢
+	slowStartSeconds = 30
+)
+
+// probeIssues 檢查容器的健康檢查設定
+// 缺少 readiness 或 liveness probe、liveness probe 過於嚴格且容器有重啟紀錄，以及啟動緩慢但沒有 startup probe 的容器
+// Job 與 CronJob 的 Pod 執行完就結束，不需要健康檢查
+func probeIssues(pod gke.Pod) []OptimizationIssue {
+	if kind, _ := gke.PodWorkload(pod); kind == "Job" || kind == "CronJob" {
+		return nil
+	}
+
+	startup := podStartupDuration(pod)
+
+	var missingReadiness, missingLiveness, aggressive, missingStartup []string
+	severity := PriorityMedium
+	for _, container := range pod.Containers {
+		if container.ReadinessProbe == nil {
+			missingReadiness = append(missingReadiness, container.Name)
+		}
+
+		liveness := container.LivenessProbe
+		if liveness == nil {
+			missingLiveness = append(missingLiveness, container.Name)
+			continue
+		}
+
+		tolerance := probeTolerance(liveness)
+		if container.Restart > 0 && (liveness.FailureThreshold <= 1 || tolerance < aggressiveProbeToleranceSeconds) {
+			aggressive = append(aggressive, fmt.Sprintf("%s (連續失敗 %d 秒即重啟，已重啟 %d 次)", container.Name, tolerance, container.Restart))
+		}
+
+		// liveness probe 在啟動期間就開始檢查，啟動較慢的容器以很長的 initialDelaySeconds 規避，或在啟動完成前就被重啟
+		if container.StartupProbe == nil {
+			switch {
+			case liveness.InitialDelaySeconds >= slowStartSeconds:
+				missingStartup = append(missingStartup, fmt.Sprintf("%s (initialDelaySeconds %d)", container.Name, liveness.InitialDelaySeconds))
+			case startup >= slowStartSeconds*time.Second:
+				missingStartup = append(missingStartup, fmt.Sprintf("%s (啟動花費 %s)", container.Name, startup.Round(time.Second)))
+				if startup > time.Duration(liveness.InitialDelaySeconds+tolerance)*time.Second {
+					severity = PriorityHigh
+				}
+			}
+		}
+	}
+
+	var issues []OptimizationIssue
+	if len(aggressive) > 0 {
+		issues = append(issues, OptimizationIssue{
+			Type:        "AGGRESSIVE_LIVENESS_PROBE",
+			Severity:    PriorityHigh,
+			Description: fmt.Sprintf("liveness probe 過於嚴格且容器有重啟紀錄: %s", strings.Join(aggressive, "，")),
+			Suggestion:  fmt.Sprintf("短暫的延遲 (例如 GC 停頓或尖峰負載) 就會讓 liveness probe 失敗並重啟容器，建議將 periodSeconds × failureThreshold 提高到至少 %d 秒並放寬 timeoutSeconds", aggressiveProbeToleranceSeconds*2),
+		})
+	}
+	if len(missingStartup) > 0 {
+		issues = append(issues, OptimizationIssue{
+			Type:        "MISSING_STARTUP_PROBE",
+			Severity:    severity,
+			Description: fmt.Sprintf("啟動緩慢的容器沒有 startup probe: %s", strings.Join(missingStartup, "，")),
+			Suggestion:  "加上 startup probe，讓 liveness probe 在啟動完成後才開始檢查，不需要以很長的 initialDelaySeconds 規避，也避免啟動期間被重啟",
+		})
+	}
+	if len(missingReadiness) > 0 {
+		issues = append(issues, OptimizationIssue{
+			Type:        "MISSING_READINESS_PROBE",
+			Severity:    PriorityMedium,
+			Description: fmt.Sprintf("容器沒有 readiness probe: %s", strings.Join(missingReadiness, "，")),
+			Suggestion:  "沒有 readiness probe 時容器一啟動就會接收流量，啟動中或暫時無法服務時請求會失敗，建議加上檢查應用程式是否可服務的 readiness probe",
+		})
+	}
+	if len(missingLiveness) > 0 {
+		issues = append(issues, OptimizationIssue{
+			Type:        "MISSING_LIVENESS_PROBE",
+			Severity:    PriorityLow,
+			Description: fmt.Sprintf("容器沒有 liveness probe: %s", strings.Join(missingLiveness, "，")),
+			Suggestion:  "沒有 liveness probe 時卡住 (例如死結) 的容器不會被重啟，建議加上只檢查程序本身是否存活的 liveness probe，不要檢查外部相依服務",
+		})
+	}
+
+	return issues
+}
+
+// probeTolerance 健康檢查容許連續失敗的時間 (秒)
+func probeTolerance(probe *gke.ProbeInfo) int32 {
+	return probe.PeriodSeconds * probe.FailureThreshold
+}
+
+// podStartupDuration 估計 Pod 從初始化完成到所有容器就緒的時間，容器重啟過或尚未就緒時無法估計，回傳 0
+func podStartupDuration(pod gke.Pod) time.Duration {
+	for _, container := range pod.Containers {
+		if container.Restart > 0 || !container.Ready {
+			return 0
+		}
+	}
+
+	var initialized, containersReady time.Time
+	for _, condition := range pod.Conditions {
+		switch condition.Type {
+		case "Initialized":
+			initialized = condition.LastTransitionTime
+		case "ContainersReady":
+			containersReady = condition.LastTransitionTime
+		}
+	}
+	if initialized.IsZero() || containersReady.Before(initialized) {
+		return 0
+	}
+	return containersReady.Sub(initialized)
+}
+
+// restartSuggestion 依健康檢查設定說明重啟次數過多的可能原因
+// liveness probe 過於嚴格時重啟是由健康檢查造成，修改應用程式不一定有幫助
+func restartSuggestion(pod gke.Pod) string {
+	for _, container := range pod.Containers {
+		liveness := container.LivenessProbe
+		if container.Restart == 0 || liveness == nil {
+			continue
+		}
+		if liveness.FailureThreshold <= 1 || probeTolerance(liveness) < aggressiveProbeToleranceSeconds {
+			return fmt.Sprintf("容器 %s 的 liveness probe 過於嚴格，重啟可能是健康檢查失敗造成，先放寬 liveness probe 再檢查應用程式日誌", container.Name)
+		}
+	}
+	return "檢查應用程式日誌，修復導致重啟的問題"
+}
@@ -0,0 +1,188 @@
+package optimization
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"mcp-gke-monitor/gke"
+)
+
+const (
+	// oversizedClaimThreshold 使用量低於容量的此比例 (%) 視為容量過大
+	oversizedClaimThreshold = 20.0
+
+	// claimTargetUtilization 建議容量讓使用量約佔此比例，保留成長空間
+	claimTargetUtilization = 0.5
+
+	// minClaimGiB 建議容量的下限，也是永久磁碟的最小容量
+	minClaimGiB = 10
+
+	// staleClaimAge Pending 超過此時間的 PVC 才視為無法繫結，剛建立的 PVC 可能正在等待佈建
+	staleClaimAge = time.Hour
+
+	// standardDiskType 高價磁碟類型建議改用的磁碟類型，對應 GKE 內建的 standard-rwo StorageClass
+	standardDiskType = "pd-balanced"
+)
+
+// premiumDiskTypes 高價的永久磁碟類型
+var premiumDiskTypes = map[string]bool{
+	"pd-ssd":     true,
+	"pd-extreme": true,
+}
+
+// analyzeStorageWaste 分析 PVC 的浪費：未繫結的 PVC、沒有 Pod 掛載的 PVC、使用量遠低於容量的 PVC，以及使用高價磁碟類型的 PVC
+// 同一個 PVC 只列在第一個符合的分類；容量過大且使用高價磁碟時，以縮減後的容量計算改用標準磁碟可節省的成本
+func (s *Service) analyzeStorageWaste(claims []gke.PersistentVolumeClaimUsage, now time.Time) *StorageWasteAnalysis {
+	analysis := &StorageWasteAnalysis{
+		OversizedClaims: []ClaimWaste{},
+		UnusedClaims:    []ClaimWaste{},
+		UnboundClaims:   []ClaimWaste{},
+		PremiumClaims:   []ClaimWaste{},
+		Currency:        s.costModel.Currency,
+	}
+
+	for _, claim := range claims {
+		waste := ClaimWaste{
+			Name:         claim.Name,
+			Namespace:    claim.Namespace,
+			Phase:        claim.Phase,
+			StorageClass: claim.StorageClass,
+			DiskType:     claim.DiskType,
+			Capacity:     claim.Capacity,
+			Used:         claim.Used,
+		}
+
+		if claim.Phase != "Bound" {
+			if claim.Phase == "Pending" && now.Sub(claim.CreatedAt) < staleClaimAge {
+				continue
+			}
+			waste.Reason = fmt.Sprintf("PVC 處於 %s 狀態，沒有可用的卷", claim.Phase)
+			if claim.Phase == "Lost" {
+				waste.Reason = fmt.Sprintf("PVC 繫結的 PV %s 已不存在", claim.VolumeName)
+			}
+			analysis.UnboundClaims = append(analysis.UnboundClaims, waste)
+			continue
+		}
+
+		waste.MonthlyCost = roundCost(s.costModel.storageMonthlyCost(claim.DiskType, claim.CapacityBytes))
+		analysis.MonthlyCost += waste.MonthlyCost
+
+		if len(claim.MountedBy) == 0 {
+			waste.EstimatedMonthlySavings = waste.MonthlyCost
+			waste.Reason = "PVC 已繫結但沒有任何 Pod 掛載，磁碟仍持續計費"
+			analysis.UnusedClaims = append(analysis.UnusedClaims, waste)
+			continue
+		}
+
+		capacity := claim.CapacityBytes
+		if claim.UsedBytes != nil && claim.CapacityBytes > 0 {
+			waste.Utilization = math.Round(float64(*claim.UsedBytes)/float64(claim.CapacityBytes)*1000) / 10
+			if suggested := suggestedClaimBytes(*claim.UsedBytes); waste.Utilization < oversizedClaimThreshold && suggested < claim.CapacityBytes {
+				capacity = suggested
+				waste.SuggestedCapacity = fmt.Sprintf("%dGi", suggested>>30)
+			}
+		}
+		if premiumDiskTypes[claim.DiskType] {
+			waste.SuggestedDiskType = standardDiskType
+		}
+		if waste.SuggestedCapacity == "" && waste.SuggestedDiskType == "" {
+			continue
+		}
+
+		diskType := claim.DiskType
+		if waste.SuggestedDiskType != "" {
+			diskType = waste.SuggestedDiskType
+		}
+		waste.EstimatedMonthlySavings = roundCost(waste.MonthlyCost - s.costModel.storageMonthlyCost(diskType, capacity))
+
+		if waste.SuggestedCapacity != "" {
+			waste.Reason = fmt.Sprintf("使用量 %s 只佔容量 %s 的 %.1f%%", claim.Used, claim.Capacity, waste.Utilization)
+			if waste.SuggestedDiskType != "" {
+				waste.Reason += fmt.Sprintf("，且使用高價的 %s 磁碟", claim.DiskType)
+			}
+			analysis.OversizedClaims = append(analysis.OversizedClaims, waste)
+			continue
+		}
+		waste.Reason = fmt.Sprintf("使用高價的 %s 磁碟，%s 的成本較低", claim.DiskType, standardDiskType)
+		analysis.PremiumClaims = append(analysis.PremiumClaims, waste)
+	}
+
+	for _, claims := range [][]ClaimWaste{analysis.OversizedClaims, analysis.UnusedClaims, analysis.PremiumClaims} {
+		sort.SliceStable(claims, func(i, j int) bool {
+			return claims[i].EstimatedMonthlySavings > claims[j].EstimatedMonthlySavings
+		})
+		for _, claim := range claims {
+			analysis.EstimatedMonthlySavings += claim.EstimatedMonthlySavings
+		}
+	}
+	analysis.MonthlyCost = roundCost(analysis.MonthlyCost)
+	analysis.EstimatedMonthlySavings = roundCost(analysis.EstimatedMonthlySavings)
+
+	return analysis
+}
+
+// suggestedClaimBytes 依使用量與目標使用比例建議的容量，以 GiB 為單位無條件進位且不低於 minClaimGiB
+func suggestedClaimBytes(used int64) int64 {
+	gib := int64(math.Ceil(float64(used) / claimTargetUtilization / (1 << 30)))
+	return max(gib, minClaimGiB) << 30
+}
+
+// recommendStorageWaste 將 PVC 的浪費轉換為建議；PVC 無法縮小或直接變更磁碟類型，需要建立新的 PVC 並遷移資料
+func recommendStorageWaste(analysis *StorageWasteAnalysis) []Recommendation {
+	var recommendations []Recommendation
+	recommend := func(waste ClaimWaste, issue string, priority Priority, title, impact, action string) {
+		recommendations = append(recommendations, Recommendation{
+			ID:           fmt.Sprintf("REC-%s-%s", waste.Name, strings.ToLower(strings.ReplaceAll(issue, "_", "-"))),
+			Type:         RecommendationStorage,
+			Issue:        issue,
+			Priority:     priority,
+			Title:        title,
+			Description:  waste.Reason,
+			Impact:       impact,
+			Action:       action,
+			Namespace:    waste.Namespace,
+			WorkloadKind: "PersistentVolumeClaim",
+			WorkloadName: waste.Name,
+			Storage:      &waste,
+		})
+	}
+
+	for _, waste := range analysis.OversizedClaims {
+		action := fmt.Sprintf("PVC 無法縮小容量：建立 %s 的新 PVC", waste.SuggestedCapacity)
+		if waste.SuggestedDiskType != "" {
+			action += fmt.Sprintf(" (使用 %s 的 StorageClass，例如 standard-rwo)", waste.SuggestedDiskType)
+		}
+		action += "，停止寫入後複製資料並切換工作負載，確認無誤後刪除舊的 PVC (reclaimPolicy 為 Retain 時需另外刪除 PV 與磁碟)"
+		recommend(waste, "PVC_OVERSIZED", PriorityMedium,
+			fmt.Sprintf("PVC %s 容量過大 (使用率 %.1f%%)", waste.Name, waste.Utilization),
+			"縮減永久磁碟的容量，減少儲存空間的費用", action)
+	}
+	for _, waste := range analysis.UnusedClaims {
+		recommend(waste, "PVC_UNUSED", PriorityMedium,
+			fmt.Sprintf("PVC %s 沒有被任何 Pod 使用", waste.Name),
+			"刪除不再使用的永久磁碟，停止持續計費",
+			fmt.Sprintf("確認資料不再需要 (或先建立 VolumeSnapshot 備份) 後刪除：kubectl delete pvc %s -n %s；StatefulSet 縮減副本後留下的 PVC 在重新擴充時會被沿用", waste.Name, waste.Namespace))
+	}
+	for _, waste := range analysis.UnboundClaims {
+		recommend(waste, "PVC_UNBOUND", PriorityLow,
+			fmt.Sprintf("PVC %s 處於 %s 狀態", waste.Name, waste.Phase),
+			"清除無法使用的 PVC，避免使用它的 Pod 無法排程",
+			fmt.Sprintf("以 kubectl describe pvc %s -n %s 查看原因 (StorageClass 不存在、配額不足或 WaitForFirstConsumer 尚無 Pod 使用)；不再需要時刪除 PVC", waste.Name, waste.Namespace))
+	}
+	for _, waste := range analysis.PremiumClaims {
+		recommend(waste, "PVC_PREMIUM_DISK", PriorityLow,
+			fmt.Sprintf("PVC %s 使用高價的 %s 磁碟", waste.Name, waste.DiskType),
+			fmt.Sprintf("改用 %s 降低每 GiB 的費用", waste.SuggestedDiskType),
+			fmt.Sprintf("確認工作負載的 IOPS 與延遲需求不需要 %s 後，以 %s 的 StorageClass (例如 standard-rwo) 建立新的 PVC 並遷移資料", waste.DiskType, waste.SuggestedDiskType))
+	}
+
+	return recommendations
+}
+
+// roundCost 將成本四捨五入到小數點後兩位
+func roundCost(cost float64) float64 {
+	return math.Round(cost*100) / 100
+}
@@ -0,0 +1,60 @@
+package optimization
+
+import (
+	"fmt"
+	"strconv"
+
+	"mcp-gke-monitor/gke"
+)
+
+// LatencyCriticalAnnotation Pod 加上此註解且值為 "true" 時視為延遲敏感，建議使用 Guaranteed QoS (可設定在工作負載的 Pod template)
+const LatencyCriticalAnnotation = "mcp-optimizer/latency-critical"
+
+// SetProductionNamespaces 設定正式環境的命名空間，這些命名空間中的 BestEffort Pod 會被列為問題
+func (s *Service) SetProductionNamespaces(namespaces []string) {
+	production := make(map[string]bool, len(namespaces))
+	for _, namespace := range namespaces {
+		production[namespace] = true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.productionNamespaces = production
+}
+
+// qosIssues 依 Pod 的 QoS 類別找出問題
+// BestEffort Pod 在節點資源不足時最先被驅逐，不應出現在正式環境；延遲敏感的 Pod 使用 Guaranteed 可避免與其他 Pod 爭用資源
+func (s *Service) qosIssues(pod gke.Pod) []OptimizationIssue {
+	var issues []OptimizationIssue
+
+	if pod.QOSClass == "BestEffort" && s.productionNamespaces[pod.Namespace] {
+		issues = append(issues, OptimizationIssue{
+			Type:        "BESTEFFORT_IN_PRODUCTION",
+			Severity:    PriorityHigh,
+			Description: fmt.Sprintf("正式環境命名空間 %s 中的 Pod 為 BestEffort QoS", pod.Namespace),
+			Suggestion:  "BestEffort Pod 沒有任何資源保障，節點資源不足時最先被驅逐，建議至少設定 requests 使其成為 Burstable",
+		})
+	}
+
+	if critical, err := strconv.ParseBool(pod.Annotations[LatencyCriticalAnnotation]); err == nil && critical && pod.QOSClass != "Guaranteed" {
+		issues = append(issues, OptimizationIssue{
+			Type:        "QOS_NOT_GUARANTEED",
+			Severity:    PriorityMedium,
+			Description: fmt.Sprintf("延遲敏感的 Pod 為 %s QoS", pod.QOSClass),
+			Suggestion:  "將所有容器的 CPU 與記憶體 requests 設為與 limits 相同，使 Pod 成為 Guaranteed，避免在節點資源緊張時被節流或驅逐",
+		})
+	}
+
+	return issues
+}
+
+// guaranteedSuggestions 將建議的 requests 調整為與 limits 相同，使 Pod 成為 Guaranteed QoS
+func guaranteedSuggestions(suggestions []ResourceSuggestion) []ResourceSuggestion {
+	result := make([]ResourceSuggestion, 0, len(suggestions))
+	for _, suggestion := range suggestions {
+		suggestion.Suggested.CPURequest = suggestion.Suggested.CPULimit
+		suggestion.Suggested.MemoryRequest = suggestion.Suggested.MemoryLimit
+		result = append(result, suggestion)
+	}
+	return result
+}
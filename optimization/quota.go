@@ -0,0 +1,208 @@
+package optimization
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"mcp-gke-monitor/gke"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// quotaScaleHeadroom 配額剩餘可增加的副本數少於目前副本數的此比例時，視為擴展即將被配額阻擋
+const quotaScaleHeadroom = 0.25
+
+// recommendQuotaCompliance 以命名空間的 ResourceQuota 與 LimitRange 檢查工作負載：
+// 配額剩餘量不足以增加副本 (擴展、HPA 與使用 maxSurge 的滾動更新都需要額外配額)，
+// 以及 sizing 建議值超出 LimitRange 範圍 (套用時會被拒絕)
+func (s *Service) recommendQuotaCompliance(workloads []*workloadGroup, quotas *gke.NamespaceQuotas) []Recommendation {
+	var recommendations []Recommendation
+	for _, workload := range workloads {
+		if rec := quotaRecommendation(workload, quotas.ResourceQuotas); rec != nil {
+			recommendations = append(recommendations, *rec)
+		}
+		if rec := limitRangeRecommendation(workload, quotas.LimitRanges); rec != nil {
+			recommendations = append(recommendations, *rec)
+		}
+	}
+	return recommendations
+}
+
+// quotaRecommendation 找出可增加副本數最少的配額資源，不足以依比例擴展時產生建議
+func quotaRecommendation(workload *workloadGroup, quotas []gke.ResourceQuotaStatus) *Recommendation {
+	if len(workload.pods) == 0 || workload.kind == "Pod" || workload.kind == "Job" {
+		return nil
+	}
+	perReplica := replicaQuotaUsage(workload.pods[0])
+
+	var tightest *QuotaCheck
+	for _, quota := range quotas {
+		names := make([]string, 0, len(quota.Hard))
+		for name := range quota.Hard {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			need := perReplica[name]
+			if need <= 0 {
+				continue
+			}
+			hard, err := resource.ParseQuantity(quota.Hard[name])
+			if err != nil {
+				continue
+			}
+			var used int64
+			if quantity, err := resource.ParseQuantity(quota.Used[name]); err == nil {
+				used = quantity.MilliValue()
+			}
+			remaining := hard.MilliValue() - used
+			additional := int(math.Max(0, float64(remaining/need)))
+			if tightest == nil || additional < tightest.AdditionalReplicas {
+				tightest = &QuotaCheck{
+					Quota:              quota.Name,
+					Resource:           name,
+					Hard:               quota.Hard[name],
+					Used:               quota.Used[name],
+					PerReplica:         formatQuotaAmount(name, need),
+					AdditionalReplicas: additional,
+				}
+			}
+		}
+	}
+
+	threshold := int(math.Ceil(float64(len(workload.pods)) * quotaScaleHeadroom))
+	if tightest == nil || tightest.AdditionalReplicas >= threshold {
+		return nil
+	}
+
+	rec := &Recommendation{
+		ID:           fmt.Sprintf("REC-%s-quota", workload.name),
+		Type:         RecommendationReplica,
+		Issue:        "QUOTA_EXHAUSTED",
+		Namespace:    workload.namespace,
+		WorkloadKind: workload.kind,
+		WorkloadName: workload.name,
+		Impact:       "避免擴展、HPA 與滾動更新因配額不足無法建立新的 Pod",
+		Action: fmt.Sprintf("提高 ResourceQuota %s 的 %s 上限 (目前 %s，已使用 %s)，或先套用縮減 requests 的建議釋放配額",
+			tightest.Quota, tightest.Resource, tightest.Hard, tightest.Used),
+		Quota: tightest,
+	}
+	if tightest.AdditionalReplicas == 0 {
+		rec.Priority = PriorityHigh
+		rec.Title = fmt.Sprintf("ResourceQuota %s 的 %s 不足以再增加一個 %s %s 副本", tightest.Quota, tightest.Resource, workload.kind, workload.name)
+		rec.Description = fmt.Sprintf("每個副本需要 %s，配額已使用 %s / %s，擴展副本與使用 maxSurge 的滾動更新都會因配額不足而無法建立 Pod",
+			tightest.PerReplica, tightest.Used, tightest.Hard)
+	} else {
+		rec.Priority = PriorityMedium
+		rec.Title = fmt.Sprintf("ResourceQuota %s 的 %s 只夠 %s %s 再增加 %d 個副本", tightest.Quota, tightest.Resource, workload.kind, workload.name, tightest.AdditionalReplicas)
+		rec.Description = fmt.Sprintf("每個副本需要 %s，配額已使用 %s / %s，流量增加時擴展很快會被配額阻擋",
+			tightest.PerReplica, tightest.Used, tightest.Hard)
+	}
+	return rec
+}
+
+// replicaQuotaUsage 單一副本佔用的配額 (milli 單位)，鍵與 ResourceQuota 的資源名稱相同
+func replicaQuotaUsage(pod PodOptimization) map[string]int64 {
+	usage := map[string]int64{"pods": 1000}
+	add := func(names []string, value string) {
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return
+		}
+		for _, name := range names {
+			usage[name] += quantity.MilliValue()
+		}
+	}
+	for _, suggestion := range pod.SuggestedResources {
+		add([]string{"requests.cpu", "cpu"}, suggestion.Current.CPURequest)
+		add([]string{"requests.memory", "memory"}, suggestion.Current.MemoryRequest)
+		add([]string{"limits.cpu"}, suggestion.Current.CPULimit)
+		add([]string{"limits.memory"}, suggestion.Current.MemoryLimit)
+	}
+	return usage
+}
+
+// formatQuotaAmount 將 milli 單位的用量轉換為易讀的格式
+func formatQuotaAmount(name string, milli int64) string {
+	switch {
+	case name == "pods":
+		return fmt.Sprintf("%d 個 Pod", milli/1000)
+	case strings.HasSuffix(name, "cpu"):
+		return fmt.Sprintf("%dm", milli)
+	case strings.HasSuffix(name, "memory"):
+		return fmt.Sprintf("%dMi", milli/1000/(1024*1024))
+	}
+	return resource.NewMilliQuantity(milli, resource.DecimalSI).String()
+}
+
+// limitRangeRecommendation 檢查 sizing 建議值是否超出 LimitRange 的 min/max，超出時套用建議的 patch 會被拒絕
+func limitRangeRecommendation(workload *workloadGroup, limitRanges []gke.ContainerLimitRange) *Recommendation {
+	if len(workload.pods) == 0 || len(limitRanges) == 0 || workload.pods[0].InsufficientData {
+		return nil
+	}
+
+	var violations []string
+	resourceType := RecommendationCPU
+	for _, suggestion := range workload.pods[0].SuggestedResources {
+		if suggestion.Suggested == suggestion.Current {
+			continue
+		}
+		for _, limitRange := range limitRanges {
+			for _, check := range []struct {
+				field    string
+				resource string
+				value    string
+			}{
+				{"CPU request", "cpu", suggestion.Suggested.CPURequest},
+				{"CPU limit", "cpu", suggestion.Suggested.CPULimit},
+				{"記憶體 request", "memory", suggestion.Suggested.MemoryRequest},
+				{"記憶體 limit", "memory", suggestion.Suggested.MemoryLimit},
+			} {
+				violation := limitRangeViolation(check.value, limitRange.Min[check.resource], limitRange.Max[check.resource])
+				if violation == "" {
+					continue
+				}
+				if len(violations) == 0 && check.resource == "memory" {
+					resourceType = RecommendationMemory
+				}
+				violations = append(violations, fmt.Sprintf("容器 %s 建議的 %s %s %s (LimitRange %s)",
+					suggestion.Container, check.field, check.value, violation, limitRange.Name))
+			}
+		}
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+
+	return &Recommendation{
+		ID:           fmt.Sprintf("REC-%s-limitrange", workload.name),
+		Type:         resourceType,
+		Issue:        "LIMITRANGE_CONFLICT",
+		Priority:     PriorityMedium,
+		Title:        fmt.Sprintf("%s %s 的資源建議值超出 LimitRange 允許的範圍", workload.kind, workload.name),
+		Description:  strings.Join(violations, "；"),
+		Impact:       "避免套用資源建議時被 LimitRange 拒絕，造成新的 Pod 無法建立",
+		Action:       "套用資源建議前將數值調整到 LimitRange 允許的範圍內，或與平台管理員調整 LimitRange",
+		Namespace:    workload.namespace,
+		WorkloadKind: workload.kind,
+		WorkloadName: workload.name,
+	}
+}
+
+// limitRangeViolation 數值低於 min 或高於 max 時回傳說明，未設定的值與限制不檢查
+func limitRangeViolation(value, min, max string) string {
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil {
+		return ""
+	}
+	if minQuantity, err := resource.ParseQuantity(min); err == nil && quantity.Cmp(minQuantity) < 0 {
+		return "低於下限 " + min
+	}
+	if maxQuantity, err := resource.ParseQuantity(max); err == nil && quantity.Cmp(maxQuantity) > 0 {
+		return "高於上限 " + max
+	}
+	return ""
+}
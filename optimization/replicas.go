@@ -0,0 +1,134 @@
+package optimization
+
+import (
+	"fmt"
+	"math"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	// defaultMinReplicas 副本數建議預設的下限，維持高可用
+	defaultMinReplicas = 2
+
+	// replicaScaleUpUtilization 整體使用量超過 requests 總和的此比例 (%) 時建議增加副本
+	replicaScaleUpUtilization = 80.0
+)
+
+// recommendReplicaCounts 評估多副本 Deployment 的整體使用量，建議減少或增加副本數
+// 所需副本數為各資源總使用量乘上餘裕係數後除以單一 Pod 的 requests，取最大者且不低於設定的最少副本數；由 HPA 管理的 Deployment 不在此列
+func (s *Service) recommendReplicaCounts(workloads []*workloadGroup, autoscaled map[string]string) []Recommendation {
+	var recommendations []Recommendation
+	for _, workload := range workloads {
+		if workload.kind != "Deployment" || len(workload.pods) < 2 {
+			continue
+		}
+		if _, ok := autoscaled[workload.key()]; ok {
+			continue
+		}
+		// 使用量資料涵蓋的時間太短時無法代表尖峰使用量
+		if workload.pods[0].InsufficientData {
+			continue
+		}
+
+		suggestion := s.suggestReplicas(workload.pods)
+		if suggestion == nil || suggestion.SuggestedReplicas == suggestion.CurrentReplicas {
+			continue
+		}
+
+		rec := Recommendation{
+			ID:           fmt.Sprintf("REC-%s-replicas", workload.name),
+			Type:         RecommendationReplica,
+			Namespace:    workload.namespace,
+			WorkloadKind: workload.kind,
+			WorkloadName: workload.name,
+			Action: fmt.Sprintf("以 scale_workload 將 Deployment %s 的副本數從 %d 調整為 %d",
+				workload.name, suggestion.CurrentReplicas, suggestion.SuggestedReplicas),
+			Replicas: suggestion,
+		}
+		if suggestion.SuggestedReplicas < suggestion.CurrentReplicas {
+			rec.Priority = PriorityMedium
+			rec.Title = fmt.Sprintf("Deployment %s 的副本數過多 (%d 個副本只需要 %d 個)", workload.name, suggestion.CurrentReplicas, suggestion.SuggestedReplicas)
+			rec.Impact = "減少閒置副本佔用的 requests，降低節點成本"
+		} else {
+			rec.Priority = PriorityHigh
+			rec.Title = fmt.Sprintf("Deployment %s 的副本數不足 (%d 個副本需要 %d 個)", workload.name, suggestion.CurrentReplicas, suggestion.SuggestedReplicas)
+			rec.Impact = "避免整體使用量超過 requests 造成效能下降或被驅逐"
+		}
+		rec.Description = fmt.Sprintf("整體 CPU 使用量為 requests 總和的 %.1f%%，記憶體為 %.1f%% (使用量來源: %s)",
+			suggestion.CPUUtilization, suggestion.MemoryUtilization, workload.pods[0].UsageSource)
+		rec.Confidence, rec.ConfidenceReason = workloadConfidence(workload.pods)
+
+		recommendations = append(recommendations, rec)
+	}
+
+	return recommendations
+}
+
+// suggestReplicas 計算工作負載所需的副本數，Pod 未設定 CPU 與記憶體 requests 或缺少使用量時回傳 nil
+// 使用量為分析時採用的值，有歷史資料來源時為尖峰使用量
+func (s *Service) suggestReplicas(pods []PodOptimization) *ReplicaSuggestion {
+	var cpuUsage, memoryUsage float64
+	var cpuRequest, memoryRequest int64
+	for _, podOpt := range pods {
+		// 缺少任一 Pod 的使用量時總量會被低估，不做建議
+		if podOpt.ResourceAnalysis.CPU.Current == "" || podOpt.ResourceAnalysis.Memory.Current == "" {
+			return nil
+		}
+		cpuUsage += s.parseResourceValue(podOpt.ResourceAnalysis.CPU.Current)
+		memoryUsage += s.parseResourceValue(podOpt.ResourceAnalysis.Memory.Current)
+
+		cpu, memory := podRequests(podOpt.SuggestedResources)
+		cpuRequest = max(cpuRequest, cpu)
+		memoryRequest = max(memoryRequest, memory)
+	}
+	if cpuRequest == 0 && memoryRequest == 0 {
+		return nil
+	}
+
+	policy := s.sizingPolicyFor(pods[0].Namespace)
+	headroom, minReplicas := policy.headroom, policy.minReplicas
+
+	current := len(pods)
+	suggestion := &ReplicaSuggestion{
+		CurrentReplicas: current,
+		MinReplicas:     minReplicas,
+	}
+
+	needed := 0.0
+	if cpuRequest > 0 {
+		suggestion.CPUUtilization = math.Round(cpuUsage/float64(cpuRequest*int64(current))*1000) / 10
+		needed = max(needed, cpuUsage*headroom/float64(cpuRequest))
+	}
+	if memoryRequest > 0 {
+		suggestion.MemoryUtilization = math.Round(memoryUsage/float64(memoryRequest*int64(current))*1000) / 10
+		needed = max(needed, memoryUsage*headroom/float64(memoryRequest))
+	}
+
+	suggested := max(int(math.Ceil(needed)), int(minReplicas))
+	switch {
+	case suggested < current:
+		suggestion.SuggestedReplicas = suggested
+	case max(suggestion.CPUUtilization, suggestion.MemoryUtilization) > replicaScaleUpUtilization:
+		suggestion.SuggestedReplicas = max(suggested, current+1)
+	default:
+		// 使用量在合理範圍內，維持目前副本數
+		suggestion.SuggestedReplicas = current
+	}
+
+	return suggestion
+}
+
+// podRequests 計算 Pod 目前設定的 CPU (millicores) 與記憶體 (MiB) requests 總和
+func podRequests(suggestions []ResourceSuggestion) (int64, int64) {
+	var cpu, memory int64
+	for _, suggestion := range suggestions {
+		if quantity, err := resource.ParseQuantity(suggestion.Current.CPURequest); err == nil {
+			cpu += quantity.MilliValue()
+		}
+		if quantity, err := resource.ParseQuantity(suggestion.Current.MemoryRequest); err == nil {
+			memory += quantity.Value() / (1024 * 1024)
+		}
+	}
+	return cpu, memory
+}
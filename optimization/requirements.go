@@ -0,0 +1,87 @@
+package optimization
+
+import (
+	"fmt"
+	"strings"
+
+	"mcp-gke-monitor/gke"
+)
+
+// missingResourceIssues 找出未設定 requests 或記憶體 limits 的容器
+// 未設定 CPU limit 不列為問題，CPU 超用只會被節流，不會影響其他 Pod 的穩定性
+func missingResourceIssues(pod gke.Pod) []OptimizationIssue {
+	var missingRequests, missingLimits []string
+	for _, container := range pod.Containers {
+		var resources []string
+		if container.Resources.CPURequest == "" {
+			resources = append(resources, "cpu")
+		}
+		if container.Resources.MemoryRequest == "" {
+			resources = append(resources, "memory")
+		}
+		if len(resources) > 0 {
+			missingRequests = append(missingRequests, fmt.Sprintf("%s (%s)", container.Name, strings.Join(resources, "、")))
+		}
+		if container.Resources.MemoryLimit == "" {
+			missingLimits = append(missingLimits, container.Name)
+		}
+	}
+
+	var issues []OptimizationIssue
+	if len(missingRequests) > 0 {
+		issues = append(issues, OptimizationIssue{
+			Type:        "MISSING_REQUESTS",
+			Severity:    PriorityHigh,
+			Description: fmt.Sprintf("容器未設定 requests: %s", strings.Join(missingRequests, "，")),
+			Suggestion:  "未設定 requests 時排程器無法評估 Pod 的需求，節點資源不足時這些 Pod 會最先被驅逐，建議依使用量設定 requests",
+		})
+	}
+	if len(missingLimits) > 0 {
+		issues = append(issues, OptimizationIssue{
+			Type:        "MISSING_LIMITS",
+			Severity:    PriorityMedium,
+			Description: fmt.Sprintf("容器未設定記憶體 limits: %s", strings.Join(missingLimits, "，")),
+			Suggestion:  "未設定記憶體 limits 的容器可能耗盡節點記憶體，導致同節點的其他 Pod 被 OOMKilled，建議依尖峰使用量設定 limits",
+		})
+	}
+	return issues
+}
+
+// resourceUnset 判斷請求量或限制量是否未設定，未設定的值在使用量資料中以 "0" 表示
+func resourceUnset(value string) bool {
+	return value == "" || value == "0"
+}
+
+// missingRequestSuggestions 只保留未設定 CPU 或記憶體 requests 的容器建議，已設定的 requests 維持不變
+func missingRequestSuggestions(suggestions []ResourceSuggestion) []ResourceSuggestion {
+	var result []ResourceSuggestion
+	for _, suggestion := range suggestions {
+		cpuUnset, memoryUnset := resourceUnset(suggestion.Current.CPURequest), resourceUnset(suggestion.Current.MemoryRequest)
+		if !cpuUnset && !memoryUnset {
+			continue
+		}
+		if !cpuUnset {
+			suggestion.Suggested.CPURequest = suggestion.Current.CPURequest
+		}
+		if !memoryUnset {
+			suggestion.Suggested.MemoryRequest = suggestion.Current.MemoryRequest
+		}
+		result = append(result, suggestion)
+	}
+	return result
+}
+
+// missingLimitSuggestions 只保留未設定記憶體 limits 的容器建議，已設定的記憶體 requests 維持不變
+func missingLimitSuggestions(suggestions []ResourceSuggestion) []ResourceSuggestion {
+	var result []ResourceSuggestion
+	for _, suggestion := range suggestions {
+		if !resourceUnset(suggestion.Current.MemoryLimit) {
+			continue
+		}
+		if !resourceUnset(suggestion.Current.MemoryRequest) {
+			suggestion.Suggested.MemoryRequest = suggestion.Current.MemoryRequest
+		}
+		result = append(result, suggestion)
+	}
+	return result
+}
@@ -0,0 +1,69 @@
+package optimization
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// defaultScoringWeights 預設的評分權重
+func defaultScoringWeights() ScoringWeights {
+	return ScoringWeights{
+		HighPenalty:       20,
+		MediumPenalty:     10,
+		LowPenalty:        5,
+		HealthWeight:      0.5,
+		RestartPenalty:    10,
+		NotReadyPenalty:   30,
+		NotRunningPenalty: 40,
+		MemoryLeakPenalty: 20,
+	}
+}
+
+// validate 檢查評分權重，扣分不可為負值，健康分數權重介於 0 與 1 之間
+func (w ScoringWeights) validate() error {
+	for name, penalty := range map[string]float64{
+		"highPenalty":       w.HighPenalty,
+		"mediumPenalty":     w.MediumPenalty,
+		"lowPenalty":        w.LowPenalty,
+		"restartPenalty":    w.RestartPenalty,
+		"notReadyPenalty":   w.NotReadyPenalty,
+		"notRunningPenalty": w.NotRunningPenalty,
+		"memoryLeakPenalty": w.MemoryLeakPenalty,
+	} {
+		if penalty < 0 {
+			return fmt.Errorf("scoring.%s 不可為負值", name)
+		}
+	}
+	if w.HealthWeight < 0 || w.HealthWeight > 1 {
+		return fmt.Errorf("scoring.healthWeight 必須介於 0 與 1 之間")
+	}
+	return nil
+}
+
+// issuePenalty 依問題嚴重程度扣除的分數
+func (w ScoringWeights) issuePenalty(severity Priority) float64 {
+	switch severity {
+	case PriorityHigh:
+		return w.HighPenalty
+	case PriorityMedium:
+		return w.MediumPenalty
+	case PriorityLow:
+		return w.LowPenalty
+	}
+	return 0
+}
+
+// mergeScoringWeights 以 update_optimization_criteria 傳入的部分欄位覆寫目前的評分權重
+func mergeScoringWeights(current ScoringWeights, update map[string]interface{}) (ScoringWeights, error) {
+	data, err := json.Marshal(update)
+	if err != nil {
+		return current, fmt.Errorf("解析 scoring 參數失敗: %w", err)
+	}
+	if err := json.Unmarshal(data, &current); err != nil {
+		return current, fmt.Errorf("解析 scoring 參數失敗: %w", err)
+	}
+	if err := current.validate(); err != nil {
+		return current, err
+	}
+	return current, nil
+}
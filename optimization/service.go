@@ -1,13 +1,32 @@
 package optimization
 
 import (
+	"context"
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"mcp-gke-monitor/gke"
+
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	// historicalUsageWindow 未指定分析期間時，從使用量資料來源取得歷史使用量的時間範圍
+	historicalUsageWindow = 7 * 24 * time.Hour
+
+	// cpuThrottlingThreshold 被節流的 CFS 週期比例超過此值 (%) 視為 CPU 限制過低
+	cpuThrottlingThreshold = 25.0
+
+	// defaultAnalysisWorkers 預設並行分析 Pod 的 worker 數量，避免同時對 API 伺服器發出過多請求
+	defaultAnalysisWorkers = 10
+
+	// reportPreparationSteps 與 reportRecommendationSteps 優化報告在分析 Pod 之前與之後回報進度的階段數
+	reportPreparationSteps    = 2
+	reportRecommendationSteps = 3
 )
 
 // Logger 接口，用於可選的日誌記錄
@@ -19,9 +38,31 @@ type Logger interface {
 // Service 優化服務
 type Service struct {
 	gkeService *gke.Service
+	metrics    gke.MetricsProvider // 使用量資料來源
 	mu         sync.RWMutex
 	criteria   OptimizationCriteria
 	logger     Logger // 可選的 logger
+
+	excludeSelectors []labels.Selector // 符合任一選擇器的 Pod 不納入優化分析
+	criteriaPath     string            // 優化標準的狀態檔，空字串表示只保存在記憶體中
+	analysisWorkers  int               // 並行分析 Pod 的 worker 數量
+
+	productionNamespaces map[string]bool    // 正式環境的命名空間，用於 QoS 建議
+	allowedRegistries    []string           // 允許的映像檔 registry，空白時不檢查
+	requiredLabels       []string           // 工作負載必須有的標籤
+	idleNamespaceDays    int                // 命名空間持續閒置多少天才視為閒置命名空間
+	costModel            CostModel          // 估算節省成本的單價
+	reportHistoryDir     string             // 保存優化報告的目錄，空字串表示不保存
+	exportDir            string             // 匯出報告的目錄，空字串表示不允許匯出
+	notifier             NotifierConfig     // 報告通知的 webhook
+	reportBucket         string             // 上傳優化報告的 Cloud Storage bucket，空字串表示不上傳
+	reportBucketPrefix   string             // bucket 中的路徑前綴
+	snoozes              []Snooze           // 暫停中的建議
+	snoozePath           string             // 暫停建議的狀態檔，空字串表示只保存在記憶體中
+	clusterName          string             // 報告中顯示的叢集名稱
+	clusters             []fleetCluster     // 跨叢集比較的其他叢集
+	sloTiers             map[string]SLOTier // 命名空間所屬的可用性等級
+	severityOverrides    []SeverityOverride // 問題類型的嚴重程度覆寫
 }
 
 // NewService 創建一個新的優化服務
@@ -29,87 +70,424 @@ func NewService(gkeService *gke.Service) (*Service, error) {
 	return NewServiceWithLogger(gkeService, nil)
 }
 
-// NewServiceWithLogger 創建一個帶有 logger 的優化服務
+// NewServiceWithLogger 創建一個帶有 logger 的優化服務，自動選擇使用量資料來源
 func NewServiceWithLogger(gkeService *gke.Service, logger Logger) (*Service, error) {
 	if gkeService == nil {
 		return nil, fmt.Errorf("GKE 服務不能為空")
 	}
 
+	metrics, err := gkeService.NewMetricsProvider("")
+	if err != nil {
+		return nil, fmt.Errorf("無法建立使用量資料來源: %w", err)
+	}
+	return NewServiceWithProvider(gkeService, metrics, logger)
+}
+
+// NewServiceWithProvider 創建一個使用指定使用量資料來源的優化服務
+func NewServiceWithProvider(gkeService *gke.Service, metrics gke.MetricsProvider, logger Logger) (*Service, error) {
+	if gkeService == nil {
+		return nil, fmt.Errorf("GKE 服務不能為空")
+	}
+	if metrics == nil {
+		return nil, fmt.Errorf("使用量資料來源不能為空")
+	}
+
 	return &Service{
 		gkeService: gkeService,
+		metrics:    metrics,
 		criteria: OptimizationCriteria{
 			CPUThreshold:    20.0, // CPU 使用率低於 20% 視為過度配置
 			MemoryThreshold: 30.0, // 記憶體使用率低於 30% 視為過度配置
 			HealthThreshold: 5,    // 重啟次數超過 5 次視為不健康
 			IdleThreshold:   5.0,  // 使用率低於 5% 視為閒置
+			HeadroomFactor:  defaultHeadroomFactor,
+			MinReplicas:     defaultMinReplicas,
+
+			DiskThreshold:       defaultDiskThreshold,
+			MinObservationHours: defaultMinObservationHours,
+			CPULimitPolicy:      CPULimitPolicyRaise,
+
+			Scoring: defaultScoringWeights(),
 		},
-		logger: logger,
+		logger:            logger,
+		analysisWorkers:   defaultAnalysisWorkers,
+		requiredLabels:    []string{recommendedNameLabel},
+		idleNamespaceDays: defaultIdleNamespaceDays,
+		costModel:         defaultCostModel(),
+		clusterName:       defaultClusterName,
 	}, nil
 }
 
-// GenerateOptimizationReport 生成完整的優化報告
-func (s *Service) GenerateOptimizationReport(namespace string) (*OptimizationReport, error) {
+// GenerateOptimizationReport 生成完整的優化報告，各 Pod 的使用量查詢與分析由有限數量的 worker 並行處理
+// window 為分析使用量的期間，0 時使用預設的 7 天；指定期間時使用量資料來源必須有歷史資料
+// ctx 取消時停止分派尚未分析的 Pod 並回傳錯誤
+func (s *Service) GenerateOptimizationReport(ctx context.Context, namespace string, window time.Duration) (*OptimizationReport, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
 	if namespace == "" {
 		namespace = "default"
 	}
+	if window < 0 {
+		return nil, fmt.Errorf("分析期間必須大於 0: %s", window)
+	}
+	if window > 0 && s.metrics.Name() == gke.UsageSourceMetricsAPI {
+		return nil, fmt.Errorf("使用量資料來源 %s 只有目前取樣，無法分析 %s 的期間，請啟用 Cloud Monitoring、Prometheus 或指標收集器", s.metrics.Name(), formatWindow(window))
+	}
+	if window == 0 {
+		window = historicalUsageWindow
+	}
 
 	if s.logger != nil {
 		s.logger.Printf("正在生成 %s 命名空間的優化報告...", namespace)
 	}
 
 	// 取得所有 Pod
-	pods, err := s.gkeService.GetAllPods(namespace)
+	pods, err := s.gkeService.GetAllPods(ctx, namespace)
 	if err != nil {
 		return nil, fmt.Errorf("無法取得 Pod 列表: %w", err)
 	}
 
-	// 分析所有 Pod
-	var podAnalysis []PodOptimization
-	var recommendations []Recommendation
-	var resourceWaste ResourceWasteAnalysis
-
+	// 排除以註解或標籤選擇器指定不分析的 Pod，例如批次工作與 canary
+	var excludedPods []ExcludedPod
+	analyzedPods := make([]gke.Pod, 0, len(pods))
 	for _, pod := range pods {
-		// 分析每個 Pod
-		podOpt, err := s.analyzePod(pod)
+		if reason := s.exclusionReason(pod); reason != "" {
+			excludedPods = append(excludedPods, ExcludedPod{PodName: pod.Name, Namespace: pod.Namespace, Reason: reason})
+			continue
+		}
+		analyzedPods = append(analyzedPods, pod)
+	}
+	pods = analyzedPods
+	progress := newReportProgress(ctx, len(pods))
+
+	// 從使用量資料來源一次取得整個命名空間的使用量，以尖峰值取代單次取樣
+	progress.step(fmt.Sprintf("正在從 %s 取得 %d 個 Pod 的使用量", s.metrics.Name(), len(pods)))
+	usageHistory, err := s.metrics.NamespaceUsage(ctx, namespace, window)
+	if err != nil && s.logger != nil {
+		s.logger.Printf("警告: 無法從 %s 取得使用量，改用目前使用量: %v", s.metrics.Name(), err)
+	}
+
+	// 有歷史使用量時偵測記憶體洩漏，納入健康分析
+	progress.step("正在偵測記憶體洩漏、OOM 風險與映像檔大小")
+	memoryLeaks := make(map[string][]gke.MemoryLeak)
+	if s.gkeService.UsageHistoryAvailable() {
+		leakReport, err := s.gkeService.DetectMemoryLeaks(ctx, namespace, "", 0)
 		if err != nil {
 			if s.logger != nil {
-				s.logger.Printf("警告: 分析 Pod %s 失敗: %v", pod.Name, err)
+				s.logger.Printf("警告: 無法偵測記憶體洩漏: %v", err)
+			}
+		} else {
+			for _, leak := range leakReport.Leaks {
+				key := leak.Namespace + "/" + leak.PodName
+				memoryLeaks[key] = append(memoryLeaks[key], leak)
 			}
-			continue
 		}
-		podAnalysis = append(podAnalysis, *podOpt)
+	}
 
-		// 生成建議
-		podRecommendations := s.generatePodRecommendations(*podOpt)
-		recommendations = append(recommendations, podRecommendations...)
+	// 有歷史使用量時找出記憶體接近限制且仍在成長的容器，在 OOMKilled 之前提出警告
+	oomRisks := make(map[string][]gke.OOMRisk)
+	if s.gkeService.UsageHistoryAvailable() {
+		riskReport, err := s.gkeService.DetectOOMRisks(ctx, namespace, "", 0)
+		if err != nil {
+			if s.logger != nil {
+				s.logger.Printf("警告: 無法偵測 OOM 風險: %v", err)
+			}
+		} else {
+			for _, risk := range riskReport.Risks {
+				key := risk.Namespace + "/" + risk.PodName
+				oomRisks[key] = append(oomRisks[key], risk)
+			}
+		}
 	}
 
+	// 節點回報的映像檔大小，用於找出過大的映像檔
+	imageSizes, err := s.gkeService.GetImageSizes(ctx)
+	if err != nil && s.logger != nil {
+		s.logger.Printf("警告: 無法取得映像檔大小: %v", err)
+	}
+
+	// 分析所有 Pod
+	podAnalysis, err := s.analyzePods(ctx, pods, usageHistory, memoryLeaks, oomRisks, imageSizes, progress)
+	if err != nil {
+		return nil, err
+	}
+
+	// 使用量資料涵蓋的時間不足 minObservationHours 的工作負載不提供資源建議值
+	s.markInsufficientData(podAnalysis)
+
+	var recommendations []Recommendation
+	var resourceWaste ResourceWasteAnalysis
+	for _, podOpt := range podAnalysis {
+		recommendations = append(recommendations, s.generatePodRecommendations(podOpt)...)
+	}
+
+	// 同一工作負載的副本通常有相同的問題，合併為一筆建議並附上各 Pod 的佐證
+	workloads := groupByWorkload(podAnalysis)
+	recommendations = aggregateRecommendations(recommendations, workloads)
+
+	// 工作負載層級的建議：副本數，以及有歷史使用量時為使用量波動大的工作負載建議 HPA 參數
+	// 由 HPA 管理的工作負載不需要這兩種建議
+	progress.step(fmt.Sprintf("正在產生 %d 個工作負載的建議", len(workloads)))
+	autoscaled, err := s.gkeService.GetAutoscaledWorkloads(ctx, namespace)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Printf("警告: 無法取得 HPA，略過副本數與自動擴縮建議: %v", err)
+		}
+	} else {
+		recommendations = append(recommendations, s.recommendReplicaCounts(workloads, autoscaled)...)
+		if s.metrics.Name() != gke.UsageSourceMetricsAPI && len(usageHistory) > 0 {
+			recommendations = append(recommendations, s.recommendAutoscaling(workloads, usageHistory, autoscaled)...)
+		}
+	}
+
+	// PodDisruptionBudget：正式環境多副本工作負載缺少 PDB，或 PDB 不允許任何中斷而阻擋節點排空
+	pdbs, err := s.gkeService.ListPodDisruptionBudgets(ctx, namespace)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Printf("警告: 無法取得 PodDisruptionBudget，略過 PDB 建議: %v", err)
+		}
+	} else {
+		recommendations = append(recommendations, s.recommendDisruptionBudgets(workloads, pdbs)...)
+	}
+
+	// 不再使用的工作負載：長期縮減為 0 的 Deployment、未清除或不斷重試的 Job，以及遺留的 ReplicaSet
+	lifecycle, err := s.gkeService.GetWorkloadLifecycle(ctx, namespace)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Printf("警告: 無法取得工作負載狀態，略過清理建議: %v", err)
+		}
+	} else {
+		recommendations = append(recommendations, recommendCleanup(lifecycle, time.Now())...)
+	}
+
+	// ResourceQuota 與 LimitRange：配額不足以擴展副本，或資源建議值超出 LimitRange 範圍
+	quotas, err := s.gkeService.GetNamespaceQuotas(ctx, namespace)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Printf("警告: 無法取得 ResourceQuota 與 LimitRange，略過配額建議: %v", err)
+		}
+	} else {
+		recommendations = append(recommendations, s.recommendQuotaCompliance(workloads, quotas)...)
+	}
+
+	// 高可用分散：副本集中在單一節點或單一可用區
+	nodeZones, err := s.gkeService.GetNodeZones(ctx)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Printf("警告: 無法取得節點可用區，略過副本分散建議: %v", err)
+		}
+	} else {
+		recommendations = append(recommendations, s.recommendSpread(workloads, nodeZones)...)
+	}
+
+	// Pod Security Standards：違反 baseline 等級的工作負載
+	securityProfiles, err := s.gkeService.GetPodSecurityProfiles(ctx, namespace)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Printf("警告: 無法取得 Pod 的安全設定，略過 Pod Security Standards 建議: %v", err)
+		}
+	} else {
+		recommendations = append(recommendations, recommendPodSecurity(securityProfiles, pods)...)
+	}
+
+	// 從 Pod 建立到就緒的時間：啟動緩慢或各 Pod 差異大的工作負載
+	startups, _ := analyzeStartup(pods, imageSizes)
+	recommendations = append(recommendations, recommendStartup(startups)...)
+
 	// 分析資源浪費
+	progress.step("正在分析節點與儲存空間的浪費")
 	resourceWaste = s.analyzeResourceWaste(podAnalysis)
 
+	// 節點層級的浪費，Pod 層級的浪費要讓節點數減少才會真正節省成本
+	nodes, err := s.gkeService.GetNodeUtilization(ctx)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Printf("警告: 無法取得節點使用狀況，略過節點浪費分析: %v", err)
+		}
+	} else {
+		resourceWaste.Nodes = analyzeNodeWaste(nodes)
+		resourceWaste.Nodes.Consolidation = simulateConsolidation(nodes)
+	}
+
+	// PVC 的浪費：容量過大、沒有使用或無法繫結的 PVC，以及使用高價磁碟類型的 PVC
+	claims, err := s.gkeService.GetPersistentVolumeClaims(ctx, namespace)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Printf("警告: 無法取得 PVC，略過儲存空間浪費分析: %v", err)
+		}
+	} else {
+		resourceWaste.Storage = s.analyzeStorageWaste(claims, time.Now())
+		recommendations = append(recommendations, recommendStorageWaste(resourceWaste.Storage)...)
+	}
+
+	// Google Cloud Recommender 的節點、節點池與閒置磁碟建議，與本地建議重疊時只保留一筆
+	if s.gkeService.CloudRecommendationsAvailable() {
+		cloudRecommendations, err := s.gkeService.GetCloudRecommendations(ctx)
+		if err != nil {
+			if s.logger != nil {
+				s.logger.Printf("警告: 無法取得 Recommender API 的建議: %v", err)
+			}
+		} else {
+			recommendations = s.mergeCloudRecommendations(recommendations, cloudRecommendations, claims)
+		}
+	}
+
+	// Pod 的問題已在分析時覆寫嚴重程度，其餘有問題類型的建議在此覆寫
+	progress.step(fmt.Sprintf("正在排序 %d 筆建議並產生摘要", len(recommendations)))
+	s.applyRecommendationSeverity(recommendations)
+
+	// 依使用量判斷的建議已依使用量資料評估信心程度，其餘建議依叢集設定判斷
+	assignDefaultConfidence(recommendations)
+
+	// 依估算的每月節省成本與對可用性的影響排序建議
+	s.rankRecommendations(recommendations, workloads)
+
+	// 移除暫停中的建議，不計入摘要的節省成本
+	recommendations, snoozed := s.applySnoozes(recommendations, time.Now())
+
 	// 生成摘要
 	summary := s.generateSummary(podAnalysis, resourceWaste)
+	summary.Currency = s.costModel.Currency
+	for _, rec := range recommendations {
+		if rec.EstimatedMonthlySavings > 0 {
+			summary.EstimatedMonthlySavings += rec.EstimatedMonthlySavings
+		}
+	}
+	summary.EstimatedMonthlySavings = math.Round(summary.EstimatedMonthlySavings*100) / 100
+	resourceWaste.TotalWastage.EstimatedCost = fmt.Sprintf("%.2f %s/月", summary.EstimatedMonthlySavings, summary.Currency)
 
 	report := &OptimizationReport{
-		ClusterName:     "GKE-Cluster", // 可以從配置中取得
+		ClusterName:     s.clusterName,
 		Namespace:       namespace,
 		GeneratedAt:     time.Now(),
+		Window:          s.reportWindow(window),
 		Summary:         summary,
 		Recommendations: recommendations,
 		PodAnalysis:     podAnalysis,
+		Workloads:       workloadOptimizations(workloads),
+		ExcludedPods:    excludedPods,
+		Snoozed:         snoozed,
 		ResourceWaste:   resourceWaste,
 	}
 
 	return report, nil
 }
 
-// analyzePod 分析單個 Pod
-func (s *Service) analyzePod(pod gke.Pod) (*PodOptimization, error) {
+// reportWindow 報告記錄的分析期間，使用量資料來源只有目前取樣時為空字串
+func (s *Service) reportWindow(window time.Duration) string {
+	if s.metrics.Name() == gke.UsageSourceMetricsAPI {
+		return ""
+	}
+	return formatWindow(window)
+}
+
+// formatWindow 將分析期間格式化為 window 參數的寫法，整天數以 "d" 表示 (例如 7d)
+func formatWindow(window time.Duration) string {
+	day := 24 * time.Hour
+	if window >= day && window%day == 0 {
+		return fmt.Sprintf("%dd", window/day)
+	}
+	return window.String()
+}
+
+// reportProgress 回報優化報告的進度，分析 Pod 之前與之後的各階段與每個 Pod 的分析各計為一步
+type reportProgress struct {
+	ctx      context.Context
+	mu       sync.Mutex
+	done     int
+	total    int
+	pods     int
+	analyzed int
+}
+
+// newReportProgress 建立分析 pods 個 Pod 的報告進度
+func newReportProgress(ctx context.Context, pods int) *reportProgress {
+	return &reportProgress{
+		ctx:   ctx,
+		total: reportPreparationSteps + pods + reportRecommendationSteps,
+		pods:  pods,
+	}
+}
+
+// step 進入下一個階段
+func (p *reportProgress) step(message string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.done++
+	gke.ReportProgress(p.ctx, p.done, p.total, message)
+}
+
+// podAnalyzed 完成一個 Pod 的分析 (包含分析失敗的 Pod)，由各 worker 並行呼叫
+func (p *reportProgress) podAnalyzed() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.analyzed++
+	p.done++
+	gke.ReportProgress(p.ctx, p.done, p.total, fmt.Sprintf("已分析 %d/%d 個 Pod", p.analyzed, p.pods))
+}
+
+// analyzePods 以 s.analysisWorkers 個 worker 並行分析 Pod，結果維持 pods 的順序，分析失敗的 Pod 不列入結果
+// 每個 Pod 都需要查詢 Metrics API 與 Pod 規格，逐一查詢在數百個 Pod 的命名空間會花上數分鐘，每分析完一個 Pod 回報一次進度
+func (s *Service) analyzePods(ctx context.Context, pods []gke.Pod, usageHistory map[string]*gke.MetricsSummary, memoryLeaks map[string][]gke.MemoryLeak, oomRisks map[string][]gke.OOMRisk, imageSizes map[string]int64, progress *reportProgress) ([]PodOptimization, error) {
+	workers := s.analysisWorkers
+	if workers < 1 {
+		workers = defaultAnalysisWorkers
+	}
+	workers = min(workers, len(pods))
+
+	results := make([]*PodOptimization, len(pods))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				pod := pods[index]
+				key := pod.Namespace + "/" + pod.Name
+				podOpt, err := s.analyzePod(ctx, pod, usageHistory[key], memoryLeaks[key], oomRisks[key], imageSizes)
+				progress.podAnalyzed()
+				if err != nil {
+					if s.logger != nil {
+						s.logger.Printf("警告: 分析 Pod %s 失敗: %v", pod.Name, err)
+					}
+					continue
+				}
+				results[index] = podOpt
+			}
+		}()
+	}
+
+	// ctx 取消後不再分派新的 Pod，等待進行中的查詢結束
+dispatch:
+	for index := range pods {
+		select {
+		case <-ctx.Done():
+			break dispatch
+		case indexes <- index:
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("優化分析已取消: %w", err)
+	}
+
+	podAnalysis := make([]PodOptimization, 0, len(pods))
+	for _, podOpt := range results {
+		if podOpt != nil {
+			podAnalysis = append(podAnalysis, *podOpt)
+		}
+	}
+	return podAnalysis, nil
+}
+
+// analyzePod 分析單個 Pod，history 不為 nil 時以歷史尖峰使用量進行分析，leaks 為偵測到的記憶體洩漏，imageSizes 為節點回報的映像檔大小
+func (s *Service) analyzePod(ctx context.Context, pod gke.Pod, history *gke.MetricsSummary, leaks []gke.MemoryLeak, risks []gke.OOMRisk, imageSizes map[string]int64) (*PodOptimization, error) {
 	// 取得 Pod 的資源使用狀況
-	resourceUsage, err := s.gkeService.GetPodResourceUsage(pod.Name, pod.Namespace)
+	resourceUsage, err := s.gkeService.GetPodResourceUsage(ctx, pod.Name, pod.Namespace)
 	if err != nil {
 		// 如果無法取得 metrics，創建一個基本的分析
 		if s.logger != nil {
@@ -122,26 +500,97 @@ func (s *Service) analyzePod(pod gke.Pod) (*PodOptimization, error) {
 		}
 	}
 
+	// 以資料來源的尖峰使用量取代單次取樣，避免在離峰時段誤判為過度配置
+	usageSource := gke.UsageSourceMetricsAPI
+	if history != nil {
+		resourceUsage.CPU.Current = fmt.Sprintf("%dm", history.CPUMillicores.Max)
+		resourceUsage.Memory.Current = fmt.Sprintf("%dMi", history.MemoryBytes.Max/(1024*1024))
+		usageSource = s.metrics.Name()
+	}
+
 	// 分析資源使用
 	resourceAnalysis := s.analyzeResourceUsage(*resourceUsage)
+	containers := s.analyzeContainers(*resourceUsage, history)
 
 	// 分析健康狀態
 	healthStatus := s.analyzeHealthStatus(pod)
+	for _, leak := range leaks {
+		healthStatus.HealthIssues = append(healthStatus.HealthIssues, fmt.Sprintf("容器 %s 疑似記憶體洩漏: %s", leak.Container, leak.Description))
+		healthStatus.HealthScore -= s.criteria.Scoring.MemoryLeakPenalty
+	}
+	if healthStatus.HealthScore < 0 {
+		healthStatus.HealthScore = 0
+	}
 
 	// 找出優化問題
-	issues := s.identifyOptimizationIssues(resourceAnalysis, healthStatus, pod)
+	issues := s.identifyOptimizationIssues(resourceAnalysis, containers, healthStatus, pod)
+	// 多容器 Pod 的記憶體問題標示所屬容器，建議值只調整該容器
+	containerScope := func(container string) string {
+		if len(pod.Containers) < 2 {
+			return ""
+		}
+		return container
+	}
+	for _, leak := range leaks {
+		issues = append(issues, OptimizationIssue{
+			Type:        "MEMORY_LEAK",
+			Severity:    PriorityHigh,
+			Container:   containerScope(leak.Container),
+			Description: fmt.Sprintf("容器 %s 疑似記憶體洩漏 (每日成長 %.1f%%)", leak.Container, leak.GrowthPercentPerDay),
+			Suggestion:  leak.Description,
+		})
+	}
+	for _, risk := range risks {
+		issues = append(issues, OptimizationIssue{
+			Type:        "OOM_IMMINENT",
+			Severity:    PriorityHigh,
+			Container:   containerScope(risk.Container),
+			Description: fmt.Sprintf("容器 %s 即將 OOM (記憶體已達限制的 %.1f%% 且仍在成長)", risk.Container, risk.UsagePercent),
+			Suggestion:  risk.Description,
+		})
+	}
+	issues = append(issues, s.imageIssues(pod, imageSizes)...)
+	issues = append(issues, s.labelIssues(pod)...)
+
+	// 依設定覆寫問題的嚴重程度 (例如在開發環境降低過度配置的優先級)，優化分數依覆寫後的嚴重程度計算
+	s.applySeverityOverrides(pod.Namespace, issues)
+
+	// 重啟次數過多時附上上一次執行的日誌摘要與最近的 Warning 事件
+	crash := s.diagnoseRestarts(ctx, pod, issues)
+	if crash != nil {
+		healthStatus.RestartCauses = crash.RestartCauses
+	}
 
 	// 計算優化分數
 	optimizationScore := s.calculateOptimizationScore(resourceAnalysis, healthStatus, issues)
 
+	// 依使用量統計計算各容器建議的 requests 與 limits
+	suggestions := s.suggestResources(*resourceUsage, history, resourceAnalysis.CPU.Status == "THROTTLED")
+
+	workloadKind, workloadName := gke.PodWorkload(pod)
+	confidence, confidenceReason := usageConfidence(usageSource, history)
+
 	podOpt := &PodOptimization{
 		PodName:           pod.Name,
 		Namespace:         pod.Namespace,
 		Status:            pod.Status,
+		WorkloadKind:      workloadKind,
+		WorkloadName:      workloadName,
+		NodeName:          pod.NodeName,
 		OptimizationScore: optimizationScore,
+		UsageSource:       usageSource,
+		ObservedHours:     history.Coverage().Hours(),
+		UsageConfidence:   confidence,
+		ConfidenceReason:  confidenceReason,
 		Issues:            issues,
 		ResourceAnalysis:  resourceAnalysis,
+		Containers:        containers,
 		HealthStatus:      healthStatus,
+
+		SuggestedResources: suggestions,
+
+		labels: pod.Labels,
+		crash:  crash,
 	}
 
 	return podOpt, nil
@@ -150,26 +599,69 @@ func (s *Service) analyzePod(pod gke.Pod) (*PodOptimization, error) {
 // analyzeResourceUsage 分析資源使用狀況
 func (s *Service) analyzeResourceUsage(usage gke.ResourceUsage) ResourceAnalysis {
 	cpuMetric := s.analyzeResourceMetric(usage.CPU.Current, usage.CPU.Request, usage.CPU.Limit, "CPU")
+	s.applyCPUThrottling(&cpuMetric, usage.CPU.Throttling)
 	memoryMetric := s.analyzeResourceMetric(usage.Memory.Current, usage.Memory.Request, usage.Memory.Limit, "MEMORY")
 
-	// 磁碟分析（簡化版）
-	diskMetric := ResourceMetric{
-		Current:     usage.Disk.Used,
-		Request:     "-",
-		Limit:       usage.Disk.Total,
-		Utilization: s.calculateDiskUtilization(usage.Disk.Used, usage.Disk.Total),
-		Status:      "OPTIMAL",
-		Suggestion:  "磁碟使用正常",
+	return ResourceAnalysis{
+		CPU:     cpuMetric,
+		Memory:  memoryMetric,
+		Disk:    s.analyzeDiskMetric(usage.Disk),
+		Volumes: s.analyzeVolumes(usage.Disk.Volumes),
+		GPU:     s.analyzeGPUMetric(usage.GPU),
+	}
+}
+
+// applyCPUThrottling 依 CPU 節流狀況修正分析結果，持續節流視為 CPU 不足，即使平均使用率很低也不會判定為過度配置
+// 平均使用率低但頻繁節流代表突發負載被限制住，此時縮減 CPU 只會讓情況更糟
+func (s *Service) applyCPUThrottling(metric *ResourceMetric, throttling *gke.CPUThrottling) {
+	if throttling == nil {
+		return
 	}
 
-	return ResourceAnalysis{
-		CPU:    cpuMetric,
-		Memory: memoryMetric,
-		Disk:   diskMetric,
+	metric.ThrottledPercentage = throttling.ThrottledPercentage
+	if throttling.ThrottledPercentage < cpuThrottlingThreshold || metric.Status == "UNDER_PROVISIONED" {
+		return
 	}
+
+	metric.Status = "THROTTLED"
+	metric.Suggestion = fmt.Sprintf("CPU 限制過低：容器 %s 有 %.1f%% 的 CFS 週期被節流 (%s 內節流 %.1f 秒)，儘管平均使用率為 %.1f%%，建議%s",
+		throttling.Container, throttling.ThrottledPercentage, throttling.Window, throttling.ThrottledSeconds, metric.Utilization, s.throttlingAdvice())
+}
+
+// analyzeGPUMetric 分析 GPU 使用狀況
+func (s *Service) analyzeGPUMetric(gpu *gke.GPUUsage) *ResourceMetric {
+	if gpu == nil {
+		return nil
+	}
+
+	metric := &ResourceMetric{
+		Request: gpu.Request,
+		Limit:   gpu.Limit,
+	}
+
+	if !gpu.MetricsAvailable {
+		metric.Status = "UNKNOWN"
+		metric.Suggestion = "已配置 GPU 但無法取得 DCGM 使用率，請確認已安裝 DCGM exporter 與 custom metrics adapter"
+		return metric
+	}
+
+	metric.Current = fmt.Sprintf("%.1f%%", gpu.Utilization)
+	metric.Utilization = gpu.Utilization
+
+	if gpu.Utilization < s.criteria.IdleThreshold {
+		metric.Status = "IDLE"
+		metric.Suggestion = fmt.Sprintf("GPU 使用率極低 (%.1f%%)，考慮釋放 GPU 或改用共享 GPU (time-sharing)", gpu.Utilization)
+	} else {
+		metric.Status = "OPTIMAL"
+		metric.Suggestion = fmt.Sprintf("GPU 使用率正常 (%.1f%%)", gpu.Utilization)
+	}
+
+	return metric
 }
 
 // analyzeResourceMetric 分析單個資源指標
+// 過度配置以使用量對 requests 的比例判斷 (排程保留的是 requests)，未設定 requests 時改用 limits；
+// 資源不足以使用量對 limits 的比例判斷，未設定 limits 時以使用量超過 requests 判斷
 func (s *Service) analyzeResourceMetric(current, request, limit, resourceType string) ResourceMetric {
 	metric := ResourceMetric{
 		Current: current,
@@ -177,31 +669,50 @@ func (s *Service) analyzeResourceMetric(current, request, limit, resourceType st
 		Limit:   limit,
 	}
 
+	if current == "" {
+		metric.Status = "UNKNOWN"
+		metric.Suggestion = "無法計算使用率，缺少當前使用量資訊"
+		return metric
+	}
+	if request == "" && limit == "" {
+		metric.Status = "NOT_CONFIGURED"
+		metric.Suggestion = fmt.Sprintf("未設定 %s requests 與 limits，排程器無法正確放置 Pod，建議依使用量設定", resourceType)
+		return metric
+	}
+
+	if request != "" {
+		metric.RequestUtilization = s.calculateUtilization(current, request)
+	}
+	if limit != "" {
+		metric.LimitUtilization = s.calculateUtilization(current, limit)
+	}
+
 	// 計算使用率
-	if limit != "" && current != "" {
-		utilization := s.calculateUtilization(current, limit)
-		metric.Utilization = utilization
-
-		// 判斷狀態和建議
-		if utilization < s.criteria.IdleThreshold {
-			metric.Status = "IDLE"
-			metric.Suggestion = fmt.Sprintf("%s 使用率極低 (%.1f%%)，考慮縮減資源", resourceType, utilization)
-		} else if utilization < s.criteria.CPUThreshold && resourceType == "CPU" {
-			metric.Status = "OVER_PROVISIONED"
-			metric.Suggestion = fmt.Sprintf("CPU 過度配置，使用率僅 %.1f%%，建議減少 CPU 限制", utilization)
-		} else if utilization < s.criteria.MemoryThreshold && resourceType == "MEMORY" {
-			metric.Status = "OVER_PROVISIONED"
-			metric.Suggestion = fmt.Sprintf("記憶體過度配置，使用率僅 %.1f%%，建議減少記憶體限制", utilization)
-		} else if utilization > 80 {
-			metric.Status = "UNDER_PROVISIONED"
-			metric.Suggestion = fmt.Sprintf("%s 使用率過高 (%.1f%%)，建議增加資源限制", resourceType, utilization)
-		} else {
-			metric.Status = "OPTIMAL"
-			metric.Suggestion = fmt.Sprintf("%s 使用率正常 (%.1f%%)", resourceType, utilization)
-		}
+	utilization, basis := metric.LimitUtilization, "limits"
+	if request != "" {
+		utilization, basis = metric.RequestUtilization, "requests"
+	}
+	metric.Utilization = utilization
+
+	// 判斷狀態和建議
+	if utilization < s.criteria.IdleThreshold {
+		metric.Status = "IDLE"
+		metric.Suggestion = fmt.Sprintf("%s 使用率極低 (%s 的 %.1f%%)，考慮縮減資源", resourceType, basis, utilization)
+	} else if utilization < s.criteria.CPUThreshold && resourceType == "CPU" {
+		metric.Status = "OVER_PROVISIONED"
+		metric.Suggestion = fmt.Sprintf("CPU 過度配置，使用量僅為 %s 的 %.1f%%，建議減少 CPU %s", basis, utilization, basis)
+	} else if utilization < s.criteria.MemoryThreshold && resourceType == "MEMORY" {
+		metric.Status = "OVER_PROVISIONED"
+		metric.Suggestion = fmt.Sprintf("記憶體過度配置，使用量僅為 %s 的 %.1f%%，建議減少記憶體 %s", basis, utilization, basis)
+	} else if limit != "" && metric.LimitUtilization > 80 {
+		metric.Status = "UNDER_PROVISIONED"
+		metric.Suggestion = fmt.Sprintf("%s 使用率過高 (limits 的 %.1f%%)，建議增加資源限制", resourceType, metric.LimitUtilization)
+	} else if limit == "" && metric.RequestUtilization > 100 {
+		metric.Status = "UNDER_PROVISIONED"
+		metric.Suggestion = fmt.Sprintf("%s 使用量超過 requests (%.1f%%)，節點資源緊張時會互相爭用，建議提高 requests", resourceType, metric.RequestUtilization)
 	} else {
-		metric.Status = "UNKNOWN"
-		metric.Suggestion = "無法計算使用率，缺少限制或當前使用量資訊"
+		metric.Status = "OPTIMAL"
+		metric.Suggestion = fmt.Sprintf("%s 使用率正常 (%s 的 %.1f%%)", resourceType, basis, utilization)
 	}
 
 	return metric
@@ -284,15 +795,16 @@ func (s *Service) analyzeHealthStatus(pod gke.Pod) HealthStatus {
 	}
 
 	// 計算健康分數
+	weights := s.criteria.Scoring
 	healthScore := 100.0
 	if totalRestarts > s.criteria.HealthThreshold {
-		healthScore -= float64(totalRestarts-s.criteria.HealthThreshold) * 10
+		healthScore -= float64(totalRestarts-s.criteria.HealthThreshold) * weights.RestartPenalty
 	}
 	if !pod.Ready {
-		healthScore -= 30
+		healthScore -= weights.NotReadyPenalty
 	}
 	if pod.Status != "Running" {
-		healthScore -= 40
+		healthScore -= weights.NotRunningPenalty
 	}
 
 	if healthScore < 0 {
@@ -309,41 +821,42 @@ func (s *Service) analyzeHealthStatus(pod gke.Pod) HealthStatus {
 }
 
 // identifyOptimizationIssues 識別優化問題
-func (s *Service) identifyOptimizationIssues(resourceAnalysis ResourceAnalysis, healthStatus HealthStatus, pod gke.Pod) []OptimizationIssue {
+func (s *Service) identifyOptimizationIssues(resourceAnalysis ResourceAnalysis, containers []ContainerAnalysis, healthStatus HealthStatus, pod gke.Pod) []OptimizationIssue {
 	var issues []OptimizationIssue
 
-	// CPU 問題
-	if resourceAnalysis.CPU.Status == "OVER_PROVISIONED" {
-		issues = append(issues, OptimizationIssue{
-			Type:        "CPU_OVER_PROVISIONED",
-			Severity:    PriorityMedium,
-			Description: "CPU 資源過度配置",
-			Suggestion:  resourceAnalysis.CPU.Suggestion,
-		})
-	} else if resourceAnalysis.CPU.Status == "UNDER_PROVISIONED" {
-		issues = append(issues, OptimizationIssue{
-			Type:        "CPU_UNDER_PROVISIONED",
-			Severity:    PriorityHigh,
-			Description: "CPU 資源不足",
-			Suggestion:  resourceAnalysis.CPU.Suggestion,
-		})
-	}
+	// CPU 與記憶體配置問題，多容器 Pod 依各容器判斷
+	issues = append(issues, resourceIssues(resourceAnalysis, containers)...)
 
-	// 記憶體問題
-	if resourceAnalysis.Memory.Status == "OVER_PROVISIONED" {
-		issues = append(issues, OptimizationIssue{
-			Type:        "MEMORY_OVER_PROVISIONED",
-			Severity:    PriorityMedium,
-			Description: "記憶體資源過度配置",
-			Suggestion:  resourceAnalysis.Memory.Suggestion,
-		})
-	} else if resourceAnalysis.Memory.Status == "UNDER_PROVISIONED" {
-		issues = append(issues, OptimizationIssue{
-			Type:        "MEMORY_UNDER_PROVISIONED",
-			Severity:    PriorityHigh,
-			Description: "記憶體資源不足",
-			Suggestion:  resourceAnalysis.Memory.Suggestion,
-		})
+	// ephemeral storage 與卷即將用盡
+	issues = append(issues, diskIssues(resourceAnalysis)...)
+
+	// 未設定 requests 或記憶體 limits 的容器
+	issues = append(issues, missingResourceIssues(pod)...)
+
+	// QoS 類別問題
+	issues = append(issues, s.qosIssues(pod)...)
+
+	// 健康檢查設定問題
+	issues = append(issues, probeIssues(pod)...)
+
+	// GPU 問題
+	if resourceAnalysis.GPU != nil {
+		switch resourceAnalysis.GPU.Status {
+		case "IDLE":
+			issues = append(issues, OptimizationIssue{
+				Type:        "GPU_IDLE",
+				Severity:    PriorityHigh,
+				Description: "GPU 資源閒置",
+				Suggestion:  resourceAnalysis.GPU.Suggestion,
+			})
+		case "UNKNOWN":
+			issues = append(issues, OptimizationIssue{
+				Type:        "GPU_METRICS_UNAVAILABLE",
+				Severity:    PriorityLow,
+				Description: "無法取得 GPU 使用率",
+				Suggestion:  resourceAnalysis.GPU.Suggestion,
+			})
+		}
 	}
 
 	// 健康問題
@@ -352,7 +865,7 @@ func (s *Service) identifyOptimizationIssues(resourceAnalysis ResourceAnalysis,
 			Type:        "HIGH_RESTART_COUNT",
 			Severity:    PriorityHigh,
 			Description: fmt.Sprintf("容器重啟次數過多 (%d 次)", healthStatus.RestartCount),
-			Suggestion:  "檢查應用程式日誌，修復導致重啟的問題",
+			Suggestion:  restartSuggestion(pod),
 		})
 	}
 
@@ -368,24 +881,18 @@ func (s *Service) identifyOptimizationIssues(resourceAnalysis ResourceAnalysis,
 	return issues
 }
 
-// calculateOptimizationScore 計算優化分數
+// calculateOptimizationScore 計算優化分數，扣分與健康分數的比例依優化標準的評分權重
 func (s *Service) calculateOptimizationScore(resourceAnalysis ResourceAnalysis, healthStatus HealthStatus, issues []OptimizationIssue) float64 {
+	weights := s.criteria.Scoring
 	score := 100.0
 
 	// 根據問題減分
 	for _, issue := range issues {
-		switch issue.Severity {
-		case PriorityHigh:
-			score -= 20
-		case PriorityMedium:
-			score -= 10
-		case PriorityLow:
-			score -= 5
-		}
+		score -= weights.issuePenalty(issue.Severity)
 	}
 
 	// 根據健康分數調整
-	score = (score + healthStatus.HealthScore) / 2
+	score = score*(1-weights.HealthWeight) + healthStatus.HealthScore*weights.HealthWeight
 
 	if score < 0 {
 		score = 0
@@ -403,27 +910,113 @@ func (s *Service) generatePodRecommendations(podOpt PodOptimization) []Recommend
 		rec := Recommendation{
 			ID:          fmt.Sprintf("REC-%s-%d", podOpt.PodName, idCounter),
 			Type:        s.mapIssueTypeToRecommendationType(issue.Type),
+			Issue:       issue.Type,
 			Priority:    issue.Severity,
 			Title:       issue.Description,
 			Description: issue.Suggestion,
 			PodName:     podOpt.PodName,
 			Namespace:   podOpt.Namespace,
+			Container:   issue.Container,
+
+			WorkloadKind: podOpt.WorkloadKind,
+			WorkloadName: podOpt.WorkloadName,
 		}
+		rec.Confidence, rec.ConfidenceReason = issueConfidence(podOpt, issue.Type)
 
 		// 設定影響和行動
 		switch issue.Type {
 		case "CPU_OVER_PROVISIONED":
 			rec.Impact = "減少 CPU 成本，提高資源利用率"
 			rec.Action = "調整 CPU requests 和 limits"
+		case "CPU_UNDER_PROVISIONED":
+			rec.Impact = "避免 CPU 不足造成回應變慢"
+			rec.Action = "提高 CPU requests 和 limits"
+		case "CPU_THROTTLED":
+			rec.Impact = "降低延遲與逾時，避免突發負載被 CPU 限制卡住"
+			rec.Action = s.throttlingAdvice()
 		case "MEMORY_OVER_PROVISIONED":
 			rec.Impact = "減少記憶體成本，提高資源利用率"
 			rec.Action = "調整記憶體 requests 和 limits"
+		case "MEMORY_UNDER_PROVISIONED":
+			rec.Impact = "避免容器因記憶體不足被 OOMKilled"
+			rec.Action = "提高記憶體 requests 和 limits"
+		case "MISSING_REQUESTS":
+			rec.Impact = "讓排程器依實際需求放置 Pod，避免節點超賣時被優先驅逐"
+			rec.Action = "為所有容器設定 CPU 與記憶體 requests"
+		case "MISSING_LIMITS":
+			rec.Impact = "避免單一容器記憶體失控耗盡節點記憶體，影響同節點的其他 Pod"
+			rec.Action = "為所有容器設定記憶體 limits"
+		case "BESTEFFORT_IN_PRODUCTION":
+			rec.Impact = "避免正式環境的服務在節點資源不足時最先被驅逐"
+			rec.Action = "為所有容器設定 CPU 與記憶體 requests"
+		case "QOS_NOT_GUARANTEED":
+			rec.Impact = "確保延遲敏感的服務有專屬資源，不會被節流或驅逐"
+			rec.Action = "將所有容器的 CPU 與記憶體 requests 設為與 limits 相同"
+		case "AGGRESSIVE_LIVENESS_PROBE":
+			rec.Impact = "避免短暫延遲觸發不必要的重啟，減少重啟造成的請求失敗"
+			rec.Action = "提高 liveness probe 的 periodSeconds、failureThreshold 與 timeoutSeconds"
+		case "MISSING_STARTUP_PROBE":
+			rec.Impact = "避免啟動緩慢的容器在啟動完成前被 liveness probe 重啟"
+			rec.Action = "加上 startup probe，並移除 liveness probe 過長的 initialDelaySeconds"
+		case "MISSING_READINESS_PROBE":
+			rec.Impact = "避免流量送到尚未就緒或暫時無法服務的容器"
+			rec.Action = "加上 readiness probe"
+		case "MISSING_LIVENESS_PROBE":
+			rec.Impact = "讓卡住的容器自動重啟恢復服務"
+			rec.Action = "加上只檢查程序存活的 liveness probe"
+		case "IMAGE_NOT_PINNED":
+			rec.Impact = "確保所有副本執行相同的映像檔，部署結果可重現"
+			rec.Action = "以映像檔 digest 或固定版本的 tag 部署"
+		case "IMAGE_TOO_LARGE":
+			rec.Impact = "縮短映像檔拉取時間，加快 Pod 啟動與擴充"
+			rec.Action = "精簡映像檔或啟用 GKE Image streaming"
+		case "IMAGE_UNTRUSTED_REGISTRY":
+			rec.Impact = "降低執行未經審核映像檔的供應鏈風險"
+			rec.Action = "將映像檔移到允許的 registry"
+		case "MISSING_LABELS":
+			rec.Impact = "讓成本歸屬與依標籤彙總 (aggregate_pods_by_label) 能涵蓋此工作負載"
+			rec.Action = "在 Pod template 加上缺少的標籤；修改 Pod template 會觸發滾動更新，不需要變更 selector"
+		case "DISK_NEAR_FULL":
+			rec.Impact = "避免 ephemeral storage 用盡造成 Pod 被驅逐或節點進入 DiskPressure"
+			rec.Action = "清理容器內的暫存檔與日誌，設定 ephemeral-storage requests 與 limits，需要保存的資料改存到 PVC"
+		case "VOLUME_NEAR_FULL":
+			rec.Impact = "避免卷寫滿造成應用程式寫入失敗或資料損毀"
+			rec.Action = "擴充 PVC 容量 (StorageClass 需允許 allowVolumeExpansion) 或清理舊資料；emptyDir 則提高 sizeLimit 或定期清理"
+		case "OOM_IMMINENT":
+			rec.Impact = "在容器被 OOMKilled 之前處理，避免重啟造成的請求失敗與資料遺失"
+			rec.Action = "提高記憶體 limit，或先重啟或擴展副本分散負載；持續成長時一併檢查是否有記憶體洩漏"
+		case "MEMORY_LEAK":
+			rec.Impact = "避免容器在記憶體耗盡時被 OOMKilled 造成服務中斷"
+			rec.Action = "以 heap profile 找出持續成長的記憶體，修復前不要只調高記憶體限制"
 		case "HIGH_RESTART_COUNT":
 			rec.Impact = "提高應用程式穩定性和可用性"
 			rec.Action = "檢查應用程式日誌並修復問題"
+			if podOpt.crash != nil {
+				rec.Action = crashAction(podOpt.crash)
+				rec.Crash = podOpt.crash
+			}
 		case "POD_NOT_READY":
 			rec.Impact = "確保服務正常運行"
 			rec.Action = "檢查 Pod 狀態和健康檢查"
+		case "GPU_IDLE":
+			rec.Impact = "釋放昂貴的 GPU 資源，大幅降低成本"
+			rec.Action = "移除 GPU 請求、縮減副本數或改用 GPU 共享"
+		case "GPU_METRICS_UNAVAILABLE":
+			rec.Impact = "取得 GPU 使用率以評估 GPU 成本效益"
+			rec.Action = "安裝 DCGM exporter 並設定 custom metrics adapter"
+		}
+
+		// CPU 與記憶體配置問題附上依使用量計算的具體建議值，使用量資料不足時只標記問題；容器的問題只附上該容器的建議值
+		suggestions := containerSuggestions(podOpt.SuggestedResources, issue.Container)
+		if podOpt.InsufficientData {
+			if sizingIssues[issue.Type] {
+				rec.InsufficientData = true
+				rec.Action = s.insufficientDataAction(podOpt.ObservedHours)
+			}
+		} else if issue.Type == "CPU_THROTTLED" {
+			applySuggestions(&rec, s.applyCPULimitPolicy(suggestions))
+		} else {
+			applySuggestions(&rec, suggestions)
 		}
 
 		recommendations = append(recommendations, rec)
@@ -436,6 +1029,16 @@ func (s *Service) generatePodRecommendations(podOpt PodOptimization) []Recommend
 // mapIssueTypeToRecommendationType 將問題類型映射到建議類型
 func (s *Service) mapIssueTypeToRecommendationType(issueType string) RecommendationType {
 	switch {
+	case strings.Contains(issueType, "GPU"):
+		return RecommendationGPU
+	case issueType == "OOM_IMMINENT":
+		return RecommendationMemory
+	case issueType == "DISK_NEAR_FULL" || issueType == "VOLUME_NEAR_FULL":
+		return RecommendationStorage
+	case issueType == "IMAGE_NOT_PINNED" || issueType == "IMAGE_UNTRUSTED_REGISTRY":
+		return RecommendationSecurity
+	case issueType == "MISSING_LABELS":
+		return RecommendationLabels
 	case strings.Contains(issueType, "CPU"):
 		return RecommendationCPU
 	case strings.Contains(issueType, "MEMORY"):
@@ -486,8 +1089,9 @@ func (s *Service) analyzeResourceWaste(podAnalyses []PodOptimization) ResourceWa
 			totalMemoryWaste += wastePercentage
 		}
 
-		// 檢查閒置 Pod
-		if podAnalysis.ResourceAnalysis.CPU.Utilization < s.criteria.IdleThreshold &&
+		// 檢查閒置 Pod (被節流的 Pod 使用率低是受限制所致，不視為閒置)
+		if podAnalysis.ResourceAnalysis.CPU.Status != "THROTTLED" &&
+			podAnalysis.ResourceAnalysis.CPU.Utilization < s.criteria.IdleThreshold &&
 			podAnalysis.ResourceAnalysis.Memory.Utilization < s.criteria.IdleThreshold {
 			idlePods = append(idlePods, podAnalysis.PodName)
 		}
@@ -544,6 +1148,17 @@ func (s *Service) generateSummary(podAnalyses []PodOptimization, resourceWaste R
 	}
 }
 
+// SetAnalysisWorkers 設定並行分析 Pod 的 worker 數量，小於 1 時使用預設值
+func (s *Service) SetAnalysisWorkers(workers int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if workers < 1 {
+		workers = defaultAnalysisWorkers
+	}
+	s.analysisWorkers = workers
+}
+
 // GetOptimizationCriteria 取得優化標準
 func (s *Service) GetOptimizationCriteria() OptimizationCriteria {
 	s.mu.RLock()
@@ -551,9 +1166,16 @@ func (s *Service) GetOptimizationCriteria() OptimizationCriteria {
 	return s.criteria
 }
 
-// UpdateOptimizationCriteria 更新優化標準
-func (s *Service) UpdateOptimizationCriteria(criteria OptimizationCriteria) {
+// UpdateOptimizationCriteria 更新優化標準，設定狀態檔時先寫入檔案，寫入失敗時不會變更目前的標準
+func (s *Service) UpdateOptimizationCriteria(criteria OptimizationCriteria) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+
+	if s.criteriaPath != "" {
+		if err := s.saveCriteria(criteria); err != nil {
+			return err
+		}
+	}
 	s.criteria = criteria
+	return nil
 }
@@ -0,0 +1,101 @@
+package optimization
+
+import (
+	"fmt"
+	"sort"
+)
+
+// SeverityOverride 覆寫問題類型的嚴重程度，Namespaces 為空時套用在所有命名空間
+// 同一個問題類型可以同時有全域與命名空間的覆寫，命名空間的覆寫優先
+type SeverityOverride struct {
+	Issue      string   `json:"issue"` // 問題類型，例如 MEMORY_OVER_PROVISIONED
+	Severity   Priority `json:"severity"`
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+
+// SetSeverityOverrides 設定問題類型的嚴重程度覆寫，同一個問題類型在同一個命名空間 (或全域) 只能有一個覆寫
+func (s *Service) SetSeverityOverrides(overrides []SeverityOverride) error {
+	seen := make(map[string]bool)
+	for _, override := range overrides {
+		if override.Issue == "" {
+			return fmt.Errorf("嚴重程度覆寫必須設定問題類型")
+		}
+		if _, ok := priorityRank[override.Severity]; !ok {
+			return fmt.Errorf("問題類型 %s 的嚴重程度必須是 HIGH、MEDIUM 或 LOW: %s", override.Issue, override.Severity)
+		}
+		if len(override.Namespaces) == 0 {
+			if seen["/"+override.Issue] {
+				return fmt.Errorf("問題類型 %s 重複設定全域的嚴重程度覆寫", override.Issue)
+			}
+			seen["/"+override.Issue] = true
+		}
+		for _, namespace := range override.Namespaces {
+			if seen[namespace+"/"+override.Issue] {
+				return fmt.Errorf("問題類型 %s 在命名空間 %s 重複設定嚴重程度覆寫", override.Issue, namespace)
+			}
+			seen[namespace+"/"+override.Issue] = true
+		}
+	}
+
+	sorted := append([]SeverityOverride(nil), overrides...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Issue < sorted[j].Issue
+	})
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.severityOverrides = sorted
+	return nil
+}
+
+// SeverityOverrides 取得設定的嚴重程度覆寫，依問題類型排序
+func (s *Service) SeverityOverrides() []SeverityOverride {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]SeverityOverride(nil), s.severityOverrides...)
+}
+
+// severityFor 問題在命名空間的嚴重程度：命名空間的覆寫優先，其次為全域的覆寫，都沒有時沿用 severity
+// 呼叫端需持有 s.mu
+func (s *Service) severityFor(namespace, issue string, severity Priority) Priority {
+	global := severity
+	for _, override := range s.severityOverrides {
+		if override.Issue != issue {
+			continue
+		}
+		if len(override.Namespaces) == 0 {
+			global = override.Severity
+			continue
+		}
+		for _, ns := range override.Namespaces {
+			if ns == namespace {
+				return override.Severity
+			}
+		}
+	}
+	return global
+}
+
+// applySeverityOverrides 依嚴重程度覆寫調整 Pod 的問題，在計算優化分數之前套用
+// 呼叫端需持有 s.mu
+func (s *Service) applySeverityOverrides(namespace string, issues []OptimizationIssue) {
+	if len(s.severityOverrides) == 0 {
+		return
+	}
+	for i := range issues {
+		issues[i].Severity = s.severityFor(namespace, issues[i].Type, issues[i].Severity)
+	}
+}
+
+// applyRecommendationSeverity 依嚴重程度覆寫調整有問題類型的建議 (例如配額與 PVC 建議) 的優先級
+// 呼叫端需持有 s.mu
+func (s *Service) applyRecommendationSeverity(recommendations []Recommendation) {
+	if len(s.severityOverrides) == 0 {
+		return
+	}
+	for i := range recommendations {
+		if recommendations[i].Issue != "" {
+			recommendations[i].Priority = s.severityFor(recommendations[i].Namespace, recommendations[i].Issue, recommendations[i].Priority)
+		}
+	}
+}
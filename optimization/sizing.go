@@ -0,0 +1,217 @@
+package optimization
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"mcp-gke-monitor/gke"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	// defaultHeadroomFactor 建議值預設的餘裕係數
+	defaultHeadroomFactor = 1.2
+
+	// minSuggestedCPUMillicores 建議 CPU 值的下限 (millicores)
+	minSuggestedCPUMillicores = 10
+
+	// cpuSuggestionStep 建議 CPU 值的進位單位 (millicores)
+	cpuSuggestionStep = 5
+
+	// minSuggestedMemoryMi 建議記憶體值的下限 (MiB)
+	minSuggestedMemoryMi = 32
+
+	// throttledCPULimitFactor CPU 節流嚴重時，尖峰使用量已被限制住，建議的 CPU limit 至少為目前 limit 的倍數
+	throttledCPULimitFactor = 1.5
+)
+
+// suggestResources 依使用量統計與餘裕係數計算各容器建議的 requests 與 limits
+// request 取 P95 使用量 (可用性等級的 requestBasis 為 peak 時取尖峰)、limit 取尖峰使用量，皆乘上命名空間的餘裕係數；Pod 總量依各容器目前使用量的比例分配
+// history 為 nil 時以目前取樣同時作為 P95 與尖峰
+func (s *Service) suggestResources(usage gke.ResourceUsage, history *gke.MetricsSummary, throttled bool) []ResourceSuggestion {
+	if len(usage.Containers) == 0 {
+		return nil
+	}
+
+	cpuShares := make([]float64, len(usage.Containers))
+	memoryShares := make([]float64, len(usage.Containers))
+	var cpuTotal, memoryTotal float64
+	for i, container := range usage.Containers {
+		cpuShares[i] = s.parseResourceValue(container.CPU.Current)
+		memoryShares[i] = s.parseResourceValue(container.Memory.Current)
+		cpuTotal += cpuShares[i]
+		memoryTotal += memoryShares[i]
+	}
+
+	// 以 millicores 與 MiB 表示 Pod 總量
+	cpuP95, cpuPeak := cpuTotal, cpuTotal
+	memoryP95, memoryPeak := memoryTotal, memoryTotal
+	if history != nil {
+		cpuP95 = float64(history.CPUMillicores.P95)
+		cpuPeak = float64(history.CPUMillicores.Max)
+		memoryP95 = float64(history.MemoryBytes.P95) / (1024 * 1024)
+		memoryPeak = float64(history.MemoryBytes.Max) / (1024 * 1024)
+	}
+
+	policy := s.sizingPolicyFor(usage.Namespace)
+	headroom := policy.headroom
+	if policy.requestBasis == RequestBasisPeak {
+		cpuP95, memoryP95 = cpuPeak, memoryPeak
+	}
+
+	suggestions := make([]ResourceSuggestion, 0, len(usage.Containers))
+	for i, container := range usage.Containers {
+		cpuShare := share(cpuShares[i], cpuTotal, len(usage.Containers))
+		memoryShare := share(memoryShares[i], memoryTotal, len(usage.Containers))
+
+		cpuRequest := roundCPU(cpuP95 * cpuShare * headroom)
+		cpuLimit := roundCPU(cpuPeak * cpuShare * headroom)
+		if throttled {
+			// 節流時觀察到的尖峰被 limit 截斷，依目前 limit 往上調整；使用量被壓低，request 也不往下調
+			if current, err := resource.ParseQuantity(container.CPU.Limit); err == nil {
+				cpuLimit = max(cpuLimit, roundCPU(float64(current.MilliValue())*throttledCPULimitFactor))
+			}
+			if current, err := resource.ParseQuantity(container.CPU.Request); err == nil {
+				cpuRequest = max(cpuRequest, roundCPU(float64(current.MilliValue())))
+			}
+		}
+		memoryRequest := roundMemory(memoryP95 * memoryShare * headroom)
+		memoryLimit := roundMemory(memoryPeak * memoryShare * headroom)
+
+		suggestions = append(suggestions, ResourceSuggestion{
+			Container: container.Name,
+			Current: ContainerResources{
+				CPURequest:    container.CPU.Request,
+				CPULimit:      container.CPU.Limit,
+				MemoryRequest: container.Memory.Request,
+				MemoryLimit:   container.Memory.Limit,
+			},
+			Suggested: ContainerResources{
+				CPURequest:    fmt.Sprintf("%dm", cpuRequest),
+				CPULimit:      fmt.Sprintf("%dm", max(cpuLimit, cpuRequest)),
+				MemoryRequest: fmt.Sprintf("%dMi", memoryRequest),
+				MemoryLimit:   fmt.Sprintf("%dMi", max(memoryLimit, memoryRequest)),
+			},
+			SLOTier:        policy.tier,
+			HeadroomFactor: headroom,
+		})
+	}
+
+	return suggestions
+}
+
+// share 計算容器佔 Pod 使用量的比例，無法取得使用量時平均分配
+func share(value, total float64, count int) float64 {
+	if total <= 0 {
+		return 1 / float64(count)
+	}
+	return value / total
+}
+
+// roundCPU 將 CPU 值 (millicores) 無條件進位到 5m，並套用下限
+func roundCPU(millicores float64) int64 {
+	rounded := int64(math.Ceil(millicores/cpuSuggestionStep)) * cpuSuggestionStep
+	return max(rounded, minSuggestedCPUMillicores)
+}
+
+// roundMemory 將記憶體值 (MiB) 無條件進位到整數 MiB，並套用下限
+func roundMemory(mi float64) int64 {
+	return max(int64(math.Ceil(mi)), minSuggestedMemoryMi)
+}
+
+// applySuggestions 依問題類型將建議值附加到 CPU 與記憶體配置建議，並改寫為具體的行動說明
+func applySuggestions(rec *Recommendation, suggestions []ResourceSuggestion) {
+	if len(suggestions) == 0 {
+		return
+	}
+
+	switch rec.Issue {
+	case "CPU_OVER_PROVISIONED", "CPU_UNDER_PROVISIONED":
+		rec.Action = formatSuggestions(suggestions, "cpu")
+	case "CPU_THROTTLED":
+		rec.Action = formatThrottlingSuggestions(suggestions)
+	case "MEMORY_OVER_PROVISIONED", "MEMORY_UNDER_PROVISIONED":
+		rec.Action = formatSuggestions(suggestions, "memory")
+	case "OOM_IMMINENT":
+		rec.Action = formatSuggestions(suggestions, "memory") + "，持續成長時一併檢查是否有記憶體洩漏"
+	case "MISSING_REQUESTS", "BESTEFFORT_IN_PRODUCTION":
+		if suggestions = missingRequestSuggestions(suggestions); len(suggestions) == 0 {
+			return
+		}
+		rec.Action = formatSuggestions(suggestions, "requests")
+	case "MISSING_LIMITS":
+		if suggestions = missingLimitSuggestions(suggestions); len(suggestions) == 0 {
+			return
+		}
+		rec.Action = formatSuggestions(suggestions, "memory")
+	case "QOS_NOT_GUARANTEED":
+		suggestions = guaranteedSuggestions(suggestions)
+		rec.Action = formatSuggestions(suggestions, "guaranteed")
+	default:
+		return
+	}
+	rec.Suggested = suggestions
+}
+
+// mergeSuggestions 合併同一工作負載多個 Pod 的建議值，各欄位取較大者，確保每個副本都足夠
+func mergeSuggestions(a, b []ResourceSuggestion) []ResourceSuggestion {
+	merged := append([]ResourceSuggestion(nil), a...)
+	for _, suggestion := range b {
+		found := false
+		for i := range merged {
+			if merged[i].Container != suggestion.Container {
+				continue
+			}
+			found = true
+			target := &merged[i].Suggested
+			target.CPURequest = largerQuantity(target.CPURequest, suggestion.Suggested.CPURequest)
+			target.CPULimit = largerQuantity(target.CPULimit, suggestion.Suggested.CPULimit)
+			target.MemoryRequest = largerQuantity(target.MemoryRequest, suggestion.Suggested.MemoryRequest)
+			target.MemoryLimit = largerQuantity(target.MemoryLimit, suggestion.Suggested.MemoryLimit)
+		}
+		if !found {
+			merged = append(merged, suggestion)
+		}
+	}
+	return merged
+}
+
+// largerQuantity 回傳兩個資源量中較大者，無法解析的值視為較小
+func largerQuantity(a, b string) string {
+	qa, errA := resource.ParseQuantity(a)
+	qb, errB := resource.ParseQuantity(b)
+	switch {
+	case errB != nil:
+		return a
+	case errA != nil || qb.Cmp(qa) > 0:
+		return b
+	default:
+		return a
+	}
+}
+
+// formatSuggestions 將建議值轉換為可直接套用的行動說明，例如「設定容器 app 的 cpu request 150m、limit 300m」
+// resourceName 為 requests 時只說明 CPU 與記憶體的 requests，為 guaranteed 時說明相同的 requests 與 limits
+func formatSuggestions(suggestions []ResourceSuggestion, resourceName string) string {
+	actions := make([]string, 0, len(suggestions))
+	for _, suggestion := range suggestions {
+		switch resourceName {
+		case "requests":
+			actions = append(actions, fmt.Sprintf("設定容器 %s 的 requests cpu %s、memory %s",
+				suggestion.Container, suggestion.Suggested.CPURequest, suggestion.Suggested.MemoryRequest))
+			continue
+		case "guaranteed":
+			actions = append(actions, fmt.Sprintf("設定容器 %s 的 requests 與 limits 皆為 cpu %s、memory %s",
+				suggestion.Container, suggestion.Suggested.CPULimit, suggestion.Suggested.MemoryLimit))
+			continue
+		}
+		request, limit := suggestion.Suggested.CPURequest, suggestion.Suggested.CPULimit
+		if resourceName == "memory" {
+			request, limit = suggestion.Suggested.MemoryRequest, suggestion.Suggested.MemoryLimit
+		}
+		actions = append(actions, fmt.Sprintf("設定容器 %s 的 %s request %s、limit %s", suggestion.Container, resourceName, request, limit))
+	}
+	return strings.Join(actions, "；")
+}
@@ -0,0 +1,113 @@
+package optimization
+
+import (
+	"fmt"
+	"sort"
+)
+
+const (
+	// RequestBasisP95 建議的 request 依 P95 使用量計算
+	RequestBasisP95 = "p95"
+
+	// RequestBasisPeak 建議的 request 依尖峰使用量計算，request 足以承擔尖峰，適合不能被節流或驅逐的服務
+	RequestBasisPeak = "peak"
+)
+
+// SLOTier 可用性等級，套用在所屬命名空間的資源與副本數建議
+// 未設定的欄位沿用優化標準 (headroomFactor、minReplicas) 與 P95
+type SLOTier struct {
+	Name           string   `json:"name"`
+	Namespaces     []string `json:"namespaces"`
+	HeadroomFactor float64  `json:"headroomFactor,omitempty"` // 取代優化標準的 headroomFactor，需大於或等於 1
+	RequestBasis   string   `json:"requestBasis,omitempty"`   // p95 或 peak
+	MinReplicas    int32    `json:"minReplicas,omitempty"`    // 取代優化標準的 minReplicas
+}
+
+// sizingPolicy 命名空間的建議值設定，依所屬的可用性等級與優化標準決定
+type sizingPolicy struct {
+	tier         string // 可用性等級名稱，沒有所屬等級時為空字串
+	headroom     float64
+	requestBasis string
+	minReplicas  int32
+}
+
+// SetSLOTiers 設定可用性等級，同一個命名空間只能屬於一個等級
+func (s *Service) SetSLOTiers(tiers []SLOTier) error {
+	byNamespace := make(map[string]SLOTier)
+	for _, tier := range tiers {
+		if tier.Name == "" {
+			return fmt.Errorf("可用性等級必須設定名稱")
+		}
+		if tier.HeadroomFactor != 0 && tier.HeadroomFactor < 1 {
+			return fmt.Errorf("可用性等級 %s 的 headroomFactor 必須大於或等於 1: %.2f", tier.Name, tier.HeadroomFactor)
+		}
+		if tier.RequestBasis != "" && tier.RequestBasis != RequestBasisP95 && tier.RequestBasis != RequestBasisPeak {
+			return fmt.Errorf("可用性等級 %s 的 requestBasis 必須是 %s 或 %s: %s", tier.Name, RequestBasisP95, RequestBasisPeak, tier.RequestBasis)
+		}
+		if tier.MinReplicas < 0 {
+			return fmt.Errorf("可用性等級 %s 的 minReplicas 不可小於 0: %d", tier.Name, tier.MinReplicas)
+		}
+		for _, namespace := range tier.Namespaces {
+			if existing, ok := byNamespace[namespace]; ok {
+				return fmt.Errorf("命名空間 %s 同時屬於可用性等級 %s 與 %s", namespace, existing.Name, tier.Name)
+			}
+			byNamespace[namespace] = tier
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sloTiers = byNamespace
+	return nil
+}
+
+// SLOTiers 取得設定的可用性等級，依名稱排序
+func (s *Service) SLOTiers() []SLOTier {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	seen := make(map[string]bool)
+	var tiers []SLOTier
+	for _, tier := range s.sloTiers {
+		if !seen[tier.Name] {
+			seen[tier.Name] = true
+			tiers = append(tiers, tier)
+		}
+	}
+	sort.Slice(tiers, func(i, j int) bool {
+		return tiers[i].Name < tiers[j].Name
+	})
+	return tiers
+}
+
+// sizingPolicyFor 命名空間的建議值設定：所屬可用性等級有設定的欄位取代優化標準
+// 呼叫端需持有 s.mu
+func (s *Service) sizingPolicyFor(namespace string) sizingPolicy {
+	policy := sizingPolicy{
+		headroom:     s.criteria.HeadroomFactor,
+		requestBasis: RequestBasisP95,
+		minReplicas:  s.criteria.MinReplicas,
+	}
+	if policy.headroom < 1 {
+		policy.headroom = defaultHeadroomFactor
+	}
+	if policy.minReplicas < 1 {
+		policy.minReplicas = defaultMinReplicas
+	}
+
+	tier, ok := s.sloTiers[namespace]
+	if !ok {
+		return policy
+	}
+	policy.tier = tier.Name
+	if tier.HeadroomFactor >= 1 {
+		policy.headroom = tier.HeadroomFactor
+	}
+	if tier.RequestBasis != "" {
+		policy.requestBasis = tier.RequestBasis
+	}
+	if tier.MinReplicas > 0 {
+		policy.minReplicas = tier.MinReplicas
+	}
+	return policy
+}
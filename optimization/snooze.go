@@ -0,0 +1,214 @@
+package optimization
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SetSnoozePath 設定暫停建議的狀態檔，檔案存在時載入尚未到期的暫停設定；path 為空時只保存在記憶體中
+func (s *Service) SetSnoozePath(path string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.snoozePath = path
+	if path == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("讀取暫停建議檔案失敗: %w", err)
+	}
+
+	var snoozes []Snooze
+	if err := json.Unmarshal(data, &snoozes); err != nil {
+		return fmt.Errorf("解析暫停建議檔案失敗: %w", err)
+	}
+	s.snoozes = activeSnoozes(snoozes, time.Now())
+	return nil
+}
+
+// ParseSnoozeUntil 解析暫停的到期時間，接受 RFC3339 或日期 (2006-01-02，視為當天結束時 UTC)
+func ParseSnoozeUntil(value string) (time.Time, error) {
+	if until, err := time.Parse(time.RFC3339, value); err == nil {
+		return until, nil
+	}
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("無效的到期時間 %q (2006-01-02 或 RFC3339)", value)
+	}
+	return date.Add(24*time.Hour - time.Second), nil
+}
+
+// SnoozeRecommendation 暫停建議直到指定時間：指定 recommendationID 時只暫停該建議，否則暫停工作負載的建議 (可限定問題類型)
+// 相同對象的暫停會被取代
+func (s *Service) SnoozeRecommendation(snooze Snooze) (*Snooze, error) {
+	if snooze.Namespace == "" {
+		snooze.Namespace = "default"
+	}
+	if snooze.RecommendationID == "" && snooze.WorkloadName == "" {
+		return nil, fmt.Errorf("必須指定 recommendationId 或 workloadName")
+	}
+	now := time.Now()
+	if !snooze.Until.After(now) {
+		return nil, fmt.Errorf("到期時間 %s 已經過去", snooze.Until.Format(time.RFC3339))
+	}
+	snooze.ID = snooze.key()
+	snooze.CreatedAt = now
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snoozes := []Snooze{snooze}
+	for _, existing := range activeSnoozes(s.snoozes, now) {
+		if existing.ID != snooze.ID {
+			snoozes = append(snoozes, existing)
+		}
+	}
+	if err := s.saveSnoozes(snoozes); err != nil {
+		return nil, err
+	}
+	s.snoozes = snoozes
+	return &snooze, nil
+}
+
+// UnsnoozeRecommendation 取消暫停
+func (s *Service) UnsnoozeRecommendation(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var snoozes []Snooze
+	found := false
+	for _, existing := range activeSnoozes(s.snoozes, time.Now()) {
+		if existing.ID == id {
+			found = true
+			continue
+		}
+		snoozes = append(snoozes, existing)
+	}
+	if !found {
+		return fmt.Errorf("找不到暫停設定 %s", id)
+	}
+	if err := s.saveSnoozes(snoozes); err != nil {
+		return err
+	}
+	s.snoozes = snoozes
+	return nil
+}
+
+// ListSnoozes 列出尚未到期的暫停設定，namespace 為空時列出所有命名空間
+func (s *Service) ListSnoozes(namespace string) []Snooze {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := []Snooze{}
+	for _, snooze := range activeSnoozes(s.snoozes, time.Now()) {
+		if namespace == "" || snooze.Namespace == namespace {
+			result = append(result, snooze)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Until.Before(result[j].Until) })
+	return result
+}
+
+// saveSnoozes 將暫停設定寫入狀態檔，先寫入暫存檔再取代 (呼叫端需持有 s.mu)
+func (s *Service) saveSnoozes(snoozes []Snooze) error {
+	if s.snoozePath == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(snoozes, "", "  ")
+	if err != nil {
+		return fmt.Errorf("序列化暫停建議失敗: %w", err)
+	}
+
+	tmpPath := s.snoozePath + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o644); err != nil {
+		return fmt.Errorf("寫入暫停建議暫存檔失敗: %w", err)
+	}
+	if err := os.Rename(tmpPath, s.snoozePath); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("取代暫停建議檔案失敗: %w", err)
+	}
+	return nil
+}
+
+// applySnoozes 移除暫停中的建議，回傳保留的建議與被暫停的建議 (呼叫端需持有 s.mu)
+func (s *Service) applySnoozes(recommendations []Recommendation, now time.Time) ([]Recommendation, []SnoozedRecommendation) {
+	snoozes := activeSnoozes(s.snoozes, now)
+	if len(snoozes) == 0 {
+		return recommendations, nil
+	}
+
+	kept := make([]Recommendation, 0, len(recommendations))
+	var snoozed []SnoozedRecommendation
+	for _, rec := range recommendations {
+		matched := false
+		for _, snooze := range snoozes {
+			if snooze.matches(rec) {
+				snoozed = append(snoozed, SnoozedRecommendation{
+					RecommendationID: rec.ID,
+					Title:            rec.Title,
+					Priority:         rec.Priority,
+					SnoozeID:         snooze.ID,
+					Until:            snooze.Until,
+					Reason:           snooze.Reason,
+				})
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			kept = append(kept, rec)
+		}
+	}
+	return kept, snoozed
+}
+
+// activeSnoozes 過濾掉已到期的暫停設定
+func activeSnoozes(snoozes []Snooze, now time.Time) []Snooze {
+	var active []Snooze
+	for _, snooze := range snoozes {
+		if snooze.Until.After(now) {
+			active = append(active, snooze)
+		}
+	}
+	return active
+}
+
+// key 暫停設定的識別: namespace/建議 ID 或 namespace/Kind/name[/問題類型]
+func (snooze Snooze) key() string {
+	if snooze.RecommendationID != "" {
+		return snooze.Namespace + "/" + snooze.RecommendationID
+	}
+	parts := []string{snooze.Namespace, snooze.WorkloadKind, snooze.WorkloadName}
+	if snooze.Issue != "" {
+		parts = append(parts, snooze.Issue)
+	}
+	return strings.Join(parts, "/")
+}
+
+// matches 建議是否符合暫停設定，未指定工作負載類型或問題類型時符合所有類型
+func (snooze Snooze) matches(rec Recommendation) bool {
+	if rec.Namespace != snooze.Namespace {
+		return false
+	}
+	if snooze.RecommendationID != "" {
+		return rec.ID == snooze.RecommendationID
+	}
+	if rec.WorkloadName != snooze.WorkloadName {
+		return false
+	}
+	if snooze.WorkloadKind != "" && rec.WorkloadKind != snooze.WorkloadKind {
+		return false
+	}
+	return snooze.Issue == "" || rec.Issue == snooze.Issue
+}
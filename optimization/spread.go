@@ -0,0 +1,140 @@
+package optimization
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// hostnameTopologyKey 以節點分散副本的拓撲標籤
+	hostnameTopologyKey = "kubernetes.io/hostname"
+
+	// zoneTopologyKey 以可用區分散副本的拓撲標籤
+	zoneTopologyKey = "topology.kubernetes.io/zone"
+)
+
+// recommendSpread 找出所有副本都在同一個節點，或叢集有多個可用區但副本都在同一個可用區的 Deployment 與 StatefulSet
+// 建議加上 topologySpreadConstraints，nodeZones 為各節點所在的可用區
+func (s *Service) recommendSpread(workloads []*workloadGroup, nodeZones map[string]string) []Recommendation {
+	clusterZones := make(map[string]bool)
+	for _, zone := range nodeZones {
+		if zone != "unknown" {
+			clusterZones[zone] = true
+		}
+	}
+
+	var recommendations []Recommendation
+	for _, workload := range workloads {
+		if workload.kind != "Deployment" && workload.kind != "StatefulSet" {
+			continue
+		}
+
+		suggestion := &SpreadSuggestion{
+			ByNode:       make(map[string]int),
+			ByZone:       make(map[string]int),
+			ClusterZones: len(clusterZones),
+		}
+		for _, podOpt := range workload.pods {
+			// 尚未排程的 Pod 不影響目前的分布
+			if podOpt.NodeName == "" {
+				continue
+			}
+			suggestion.Replicas++
+			suggestion.ByNode[podOpt.NodeName]++
+			if zone, ok := nodeZones[podOpt.NodeName]; ok {
+				suggestion.ByZone[zone]++
+			}
+		}
+		if suggestion.Replicas < 2 {
+			continue
+		}
+
+		singleNode := len(suggestion.ByNode) == 1
+		singleZone := len(suggestion.ByZone) == 1 && len(clusterZones) > 1 && suggestion.ByZone["unknown"] == 0
+		if !singleNode && !singleZone {
+			continue
+		}
+
+		rec := Recommendation{
+			ID:           fmt.Sprintf("REC-%s-spread", workload.name),
+			Type:         RecommendationReplica,
+			Namespace:    workload.namespace,
+			WorkloadKind: workload.kind,
+			WorkloadName: workload.name,
+		}
+		if singleNode {
+			suggestion.TopologyKeys = append(suggestion.TopologyKeys, hostnameTopologyKey)
+			rec.Priority = PriorityHigh
+			rec.Title = fmt.Sprintf("%s %s 的 %d 個副本都在節點 %s 上", workload.kind, workload.name, suggestion.Replicas, workload.pods[0].NodeName)
+			rec.Description = "節點故障、升級或被搶占時所有副本會同時中斷，多副本無法提供高可用"
+		} else {
+			rec.Priority = PriorityMedium
+			rec.Title = fmt.Sprintf("%s %s 的 %d 個副本都在同一個可用區", workload.kind, workload.name, suggestion.Replicas)
+			rec.Description = fmt.Sprintf("叢集節點分布在 %d 個可用區，但副本都集中在其中一個，可用區故障時服務會中斷", len(clusterZones))
+		}
+		if len(clusterZones) > 1 {
+			suggestion.TopologyKeys = append(suggestion.TopologyKeys, zoneTopologyKey)
+		}
+		rec.Impact = "副本分散到不同節點與可用區，單一節點或可用區故障時服務仍可運作"
+		rec.Action = fmt.Sprintf("加上以 %s 分散的 topologySpreadConstraints (whenUnsatisfiable: ScheduleAnyway)，並重新部署讓副本重新排程",
+			strings.Join(suggestion.TopologyKeys, "、"))
+
+		patch, inline, err := spreadPatch(suggestion.TopologyKeys, stablePodLabels(workload.pods[0].labels))
+		if err != nil {
+			if s.logger != nil {
+				s.logger.Printf("警告: 無法產生 %s %s 的 topologySpreadConstraints: %v", workload.kind, workload.name, err)
+			}
+		} else {
+			suggestion.Patch = patch
+			suggestion.Command = fmt.Sprintf("kubectl patch %s %s -n %s --type strategic -p '%s'",
+				strings.ToLower(workload.kind), workload.name, workload.namespace, inline)
+		}
+		rec.Spread = suggestion
+
+		recommendations = append(recommendations, rec)
+	}
+
+	return recommendations
+}
+
+// spreadPatch 產生在 Pod template 加上 topologySpreadConstraints 的 strategic-merge patch，回傳 YAML 與單行 JSON
+// 使用 ScheduleAnyway，節點或可用區不足時仍可排程，只是盡量分散
+func spreadPatch(topologyKeys []string, selector map[string]string) (string, string, error) {
+	keys := append([]string(nil), topologyKeys...)
+	sort.Strings(keys)
+
+	constraints := make([]interface{}, 0, len(keys))
+	for _, key := range keys {
+		constraints = append(constraints, map[string]interface{}{
+			"maxSkew":           1,
+			"topologyKey":       key,
+			"whenUnsatisfiable": "ScheduleAnyway",
+			"labelSelector": map[string]interface{}{
+				"matchLabels": selector,
+			},
+		})
+	}
+	body := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"topologySpreadConstraints": constraints,
+				},
+			},
+		},
+	}
+
+	content, err := yaml.Marshal(body)
+	if err != nil {
+		return "", "", fmt.Errorf("無法產生 patch YAML: %w", err)
+	}
+	inline, err := json.Marshal(body)
+	if err != nil {
+		return "", "", fmt.Errorf("無法產生 patch JSON: %w", err)
+	}
+	return string(content), string(inline), nil
+}
@@ -0,0 +1,306 @@
+package optimization
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"mcp-gke-monitor/gke"
+)
+
+const (
+	// slowStartupThreshold 工作負載的啟動時間中位數超過此值視為啟動緩慢
+	slowStartupThreshold = 2 * time.Minute
+
+	// variableStartupCV 啟動時間的變異係數超過此值，且最長與最短相差超過 variableStartupSpread 時視為不穩定
+	variableStartupCV     = 0.5
+	variableStartupSpread = time.Minute
+
+	// minVariableStartupSamples 至少要有這麼多個 Pod 的啟動紀錄才判斷是否不穩定
+	minVariableStartupSamples = 3
+
+	// maxStartupDuration 建立到就緒超過此時間時，Ready 條件多半是之後 readiness probe 失敗又恢復的時間，不代表啟動
+	maxStartupDuration = time.Hour
+)
+
+// AnalyzeStartupLatency 分析命名空間各工作負載從 Pod 建立到就緒的時間，找出啟動緩慢或不穩定的工作負載
+// 啟動時間取自 Pod 的 PodScheduled、Initialized 與 Ready 條件，分為排程、init 容器與容器啟動三個階段
+func (s *Service) AnalyzeStartupLatency(ctx context.Context, namespace string) (*StartupReport, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if namespace == "" {
+		namespace = "default"
+	}
+
+	pods, err := s.gkeService.GetAllPods(ctx, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("無法取得 Pod 列表: %w", err)
+	}
+	analyzedPods := make([]gke.Pod, 0, len(pods))
+	for _, pod := range pods {
+		if s.exclusionReason(pod) == "" {
+			analyzedPods = append(analyzedPods, pod)
+		}
+	}
+
+	imageSizes, err := s.gkeService.GetImageSizes(ctx)
+	if err != nil && s.logger != nil {
+		s.logger.Printf("警告: 無法取得映像檔大小: %v", err)
+	}
+
+	workloads, skipped := analyzeStartup(analyzedPods, imageSizes)
+	sort.SliceStable(workloads, func(i, j int) bool {
+		flaggedI := workloads[i].Slow || workloads[i].Variable
+		flaggedJ := workloads[j].Slow || workloads[j].Variable
+		if flaggedI != flaggedJ {
+			return flaggedI
+		}
+		return workloads[i].MedianSeconds > workloads[j].MedianSeconds
+	})
+
+	return &StartupReport{
+		GeneratedAt: time.Now(),
+		Namespace:   namespace,
+		Workloads:   workloads,
+		Skipped:     skipped,
+	}, nil
+}
+
+// analyzeStartup 依所屬工作負載彙總各 Pod 的啟動時間，回傳結果依命名空間、類型與名稱排序，以及無法估計啟動時間的 Pod 數
+// Job 與 CronJob 的 Pod 沒有就緒的概念，不列入分析
+func analyzeStartup(pods []gke.Pod, imageSizes map[string]int64) ([]WorkloadStartup, int) {
+	type group struct {
+		startup WorkloadStartup
+		spec    gke.Pod // 用於產生建議的 Pod 規格
+	}
+	index := make(map[string]*group)
+	var groups []*group
+	skipped := 0
+	for _, pod := range pods {
+		kind, name := gke.PodWorkload(pod)
+		if kind == "Job" || kind == "CronJob" {
+			continue
+		}
+		podStartup, ok := podStartupLatency(pod)
+		if !ok {
+			skipped++
+			continue
+		}
+
+		key := pod.Namespace + "/" + kind + "/" + name
+		g, exists := index[key]
+		if !exists {
+			g = &group{startup: WorkloadStartup{Kind: kind, Name: name, Namespace: pod.Namespace}, spec: pod}
+			index[key] = g
+			groups = append(groups, g)
+		}
+		g.startup.Pods = append(g.startup.Pods, podStartup)
+	}
+
+	result := make([]WorkloadStartup, 0, len(groups))
+	for _, g := range groups {
+		startup := g.startup
+		summarizeStartup(&startup)
+		if startup.Slow || startup.Variable {
+			startup.Suggestions = startupSuggestions(startup, g.spec, imageSizes)
+		}
+		result = append(result, startup)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Namespace+"/"+result[i].Kind+"/"+result[i].Name < result[j].Namespace+"/"+result[j].Kind+"/"+result[j].Name
+	})
+	return result, skipped
+}
+
+// podStartupLatency 由 Pod 條件計算建立到就緒的時間
+// Pod 尚未就緒、容器重啟過 (Ready 條件為重啟後的時間) 或超過 maxStartupDuration 時無法代表啟動，回傳 false
+func podStartupLatency(pod gke.Pod) (PodStartup, bool) {
+	if !pod.Ready || pod.CreatedAt.IsZero() {
+		return PodStartup{}, false
+	}
+	for _, container := range pod.Containers {
+		if container.Restart > 0 {
+			return PodStartup{}, false
+		}
+	}
+
+	var scheduled, initialized, ready time.Time
+	for _, condition := range pod.Conditions {
+		switch condition.Type {
+		case "PodScheduled":
+			scheduled = condition.LastTransitionTime
+		case "Initialized":
+			initialized = condition.LastTransitionTime
+		case "Ready":
+			ready = condition.LastTransitionTime
+		}
+	}
+	if scheduled.IsZero() || initialized.IsZero() || ready.IsZero() {
+		return PodStartup{}, false
+	}
+	total := ready.Sub(pod.CreatedAt)
+	if total < 0 || total > maxStartupDuration {
+		return PodStartup{}, false
+	}
+
+	return PodStartup{
+		PodName:      pod.Name,
+		NodeName:     pod.NodeName,
+		CreatedAt:    pod.CreatedAt,
+		TotalSeconds: total.Seconds(),
+		Phases: StartupPhases{
+			SchedulingSeconds:     max(scheduled.Sub(pod.CreatedAt), 0).Seconds(),
+			InitializationSeconds: max(initialized.Sub(scheduled), 0).Seconds(),
+			ContainerSeconds:      max(ready.Sub(initialized), 0).Seconds(),
+		},
+	}, true
+}
+
+// summarizeStartup 計算啟動時間的中位數、範圍、變異係數與各階段的中位數，並判斷是否緩慢或不穩定
+func summarizeStartup(startup *WorkloadStartup) {
+	sort.Slice(startup.Pods, func(i, j int) bool {
+		return startup.Pods[i].TotalSeconds > startup.Pods[j].TotalSeconds
+	})
+
+	totals := make([]float64, len(startup.Pods))
+	var scheduling, initialization, containers []float64
+	var sum float64
+	for i, pod := range startup.Pods {
+		totals[i] = pod.TotalSeconds
+		sum += pod.TotalSeconds
+		scheduling = append(scheduling, pod.Phases.SchedulingSeconds)
+		initialization = append(initialization, pod.Phases.InitializationSeconds)
+		containers = append(containers, pod.Phases.ContainerSeconds)
+	}
+
+	startup.Samples = len(totals)
+	startup.MedianSeconds = median(totals)
+	startup.MaxSeconds = totals[0]
+	startup.MinSeconds = totals[len(totals)-1]
+	startup.Phases = StartupPhases{
+		SchedulingSeconds:     median(scheduling),
+		InitializationSeconds: median(initialization),
+		ContainerSeconds:      median(containers),
+	}
+
+	mean := sum / float64(len(totals))
+	if mean > 0 {
+		var variance float64
+		for _, total := range totals {
+			variance += (total - mean) * (total - mean)
+		}
+		startup.CoefficientOfVariation = math.Round(math.Sqrt(variance/float64(len(totals)))/mean*100) / 100
+	}
+
+	startup.Slow = startup.MedianSeconds >= slowStartupThreshold.Seconds()
+	startup.Variable = startup.Samples >= minVariableStartupSamples &&
+		startup.CoefficientOfVariation > variableStartupCV &&
+		startup.MaxSeconds-startup.MinSeconds >= variableStartupSpread.Seconds()
+}
+
+// startupSuggestions 依花費最多時間的階段與 Pod 規格提出縮短啟動時間的建議
+func startupSuggestions(startup WorkloadStartup, pod gke.Pod, imageSizes map[string]int64) []string {
+	var suggestions []string
+	phases := startup.Phases
+	phaseThreshold := float64(slowStartSeconds)
+
+	if phases.SchedulingSeconds >= phaseThreshold {
+		suggestions = append(suggestions, fmt.Sprintf("Pod 等待排程 %s：節點容量不足時要等 cluster autoscaler 新增節點，可保留備用容量 (例如低優先級的佔位 Pod) 或確認 requests 沒有過大", formatSeconds(phases.SchedulingSeconds)))
+	}
+	if phases.InitializationSeconds >= phaseThreshold {
+		suggestions = append(suggestions, fmt.Sprintf("init 容器花費 %s：檢查 init 容器的工作 (例如下載資料或等待相依服務) 與映像檔大小", formatSeconds(phases.InitializationSeconds)))
+	}
+
+	if phases.ContainerSeconds >= phaseThreshold {
+		found := len(suggestions)
+		for _, container := range pod.Containers {
+			if size, ok := gke.ImageSize(imageSizes, container.Image); ok && size >= largeImageBytes {
+				suggestions = append(suggestions, fmt.Sprintf("容器 %s 的映像檔 %.1f GiB，拉取時間會拖慢啟動：改用較小的基礎映像檔 (distroless、alpine) 或多階段建置，或啟用 GKE Image streaming", container.Name, float64(size)/(1<<30)))
+			}
+			if container.LivenessProbe != nil && container.StartupProbe == nil {
+				suggestions = append(suggestions, fmt.Sprintf("容器 %s 加上 startup probe，避免啟動期間被 liveness probe 重啟", container.Name))
+			}
+			if readiness := container.ReadinessProbe; readiness != nil && readiness.InitialDelaySeconds >= slowStartSeconds {
+				suggestions = append(suggestions, fmt.Sprintf("容器 %s 的 readiness probe initialDelaySeconds 為 %d 秒，就緒前至少要等這段時間：縮短 initialDelaySeconds，以較短的 periodSeconds 檢查", container.Name, readiness.InitialDelaySeconds))
+			}
+		}
+		if len(suggestions) == found {
+			suggestions = append(suggestions, fmt.Sprintf("容器啟動到就緒花費 %s：檢查應用程式啟動時的工作 (例如載入設定、預熱快取或連線相依服務)，能延後的工作在就緒後再做", formatSeconds(phases.ContainerSeconds)))
+		}
+	}
+
+	if startup.Variable {
+		suggestions = append(suggestions, fmt.Sprintf("各 Pod 的啟動時間從 %s 到 %s：常見原因是部分節點沒有映像檔快取需要重新拉取，或新增節點後才能排程，可啟用 GKE Image streaming 或保留備用容量",
+			formatSeconds(startup.MinSeconds), formatSeconds(startup.MaxSeconds)))
+	}
+	return suggestions
+}
+
+// recommendStartup 為啟動緩慢或不穩定的工作負載產生建議
+// 啟動時間決定擴充與滾動更新時新 Pod 多久才能接收流量，啟動太慢時 HPA 擴充跟不上尖峰
+func recommendStartup(startups []WorkloadStartup) []Recommendation {
+	var recommendations []Recommendation
+	for i := range startups {
+		startup := &startups[i]
+		if !startup.Slow && !startup.Variable {
+			continue
+		}
+
+		rec := Recommendation{
+			Type:         RecommendationHealth,
+			Namespace:    startup.Namespace,
+			WorkloadKind: startup.Kind,
+			WorkloadName: startup.Name,
+			Description: fmt.Sprintf("%d 個 Pod 從建立到就緒的時間中位數為 %s (排程 %s、init 容器 %s、容器啟動 %s)",
+				startup.Samples, formatSeconds(startup.MedianSeconds), formatSeconds(startup.Phases.SchedulingSeconds),
+				formatSeconds(startup.Phases.InitializationSeconds), formatSeconds(startup.Phases.ContainerSeconds)),
+			Impact:  "縮短擴充與滾動更新時新 Pod 開始接收流量前的時間，讓 HPA 擴充跟得上尖峰",
+			Action:  strings.Join(startup.Suggestions, "；"),
+			Startup: startup,
+		}
+		if startup.Slow {
+			rec.ID = fmt.Sprintf("REC-%s-slow-startup", startup.Name)
+			rec.Issue = "SLOW_STARTUP"
+			rec.Priority = PriorityMedium
+			rec.Title = fmt.Sprintf("%s %s 啟動緩慢 (中位數 %s，最長 %s)", startup.Kind, startup.Name, formatSeconds(startup.MedianSeconds), formatSeconds(startup.MaxSeconds))
+		} else {
+			rec.ID = fmt.Sprintf("REC-%s-variable-startup", startup.Name)
+			rec.Issue = "VARIABLE_STARTUP"
+			rec.Priority = PriorityLow
+			rec.Title = fmt.Sprintf("%s %s 的啟動時間不穩定 (%s 到 %s)", startup.Kind, startup.Name, formatSeconds(startup.MinSeconds), formatSeconds(startup.MaxSeconds))
+		}
+		if startup.Samples < minVariableStartupSamples {
+			rec.Confidence = ConfidenceMedium
+			rec.ConfidenceReason = fmt.Sprintf("只有 %d 個 Pod 的啟動紀錄", startup.Samples)
+		} else {
+			rec.Confidence = ConfidenceHigh
+			rec.ConfidenceReason = fmt.Sprintf("依 %d 個 Pod 的啟動紀錄判斷", startup.Samples)
+		}
+
+		recommendations = append(recommendations, rec)
+	}
+	return recommendations
+}
+
+// median 計算中位數
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+	return sorted[middle]
+}
+
+// formatSeconds 將秒數格式化為 1m30s 的形式
+func formatSeconds(seconds float64) string {
+	return (time.Duration(seconds * float64(time.Second))).Round(time.Second).String()
+}
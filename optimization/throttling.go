@@ -0,0 +1,68 @@
+package optimization
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// CPULimitPolicyRaise CPU 節流時建議提高 CPU limit (預設)
+	CPULimitPolicyRaise = "raise"
+
+	// CPULimitPolicyRemove CPU 節流時建議移除 CPU limit，只保留 requests
+	CPULimitPolicyRemove = "remove"
+)
+
+// validCPULimitPolicy 檢查 CPU limit 政策
+func validCPULimitPolicy(policy string) error {
+	if policy != CPULimitPolicyRaise && policy != CPULimitPolicyRemove {
+		return fmt.Errorf("不支援的 cpuLimitPolicy %q (raise 或 remove)", policy)
+	}
+	return nil
+}
+
+// throttlingAdvice 依 CPU limit 政策說明節流時的處理方式
+func (s *Service) throttlingAdvice() string {
+	if s.criteria.CPULimitPolicy == CPULimitPolicyRemove {
+		return "移除 CPU limit，只保留 requests"
+	}
+	return "提高 CPU limit"
+}
+
+// applyCPULimitPolicy 依政策調整 CPU 節流的建議值：remove 政策移除 CPU limit，requests 維持建議值
+func (s *Service) applyCPULimitPolicy(suggestions []ResourceSuggestion) []ResourceSuggestion {
+	if s.criteria.CPULimitPolicy != CPULimitPolicyRemove {
+		return suggestions
+	}
+
+	result := make([]ResourceSuggestion, len(suggestions))
+	for i, suggestion := range suggestions {
+		suggestion.Suggested.CPULimit = ""
+		suggestion.RemoveCPULimit = suggestion.Current.CPULimit != "" && suggestion.Current.CPULimit != "0"
+		result[i] = suggestion
+	}
+	return result
+}
+
+// removesCPULimit 建議是否移除 CPU limit
+func removesCPULimit(suggestions []ResourceSuggestion) bool {
+	for _, suggestion := range suggestions {
+		if suggestion.RemoveCPULimit {
+			return true
+		}
+	}
+	return false
+}
+
+// formatThrottlingSuggestions 將 CPU 節流的建議值轉換為行動說明
+func formatThrottlingSuggestions(suggestions []ResourceSuggestion) string {
+	if !removesCPULimit(suggestions) {
+		return formatSuggestions(suggestions, "cpu")
+	}
+
+	actions := make([]string, 0, len(suggestions))
+	for _, suggestion := range suggestions {
+		actions = append(actions, fmt.Sprintf("設定容器 %s 的 cpu request %s 並移除 CPU limit", suggestion.Container, suggestion.Suggested.CPURequest))
+	}
+	return strings.Join(actions, "；")
+}
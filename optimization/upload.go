@@ -0,0 +1,62 @@
+package optimization
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// SetReportBucket 設定上傳優化報告的 Cloud Storage 位置 (gs://bucket/prefix)，空字串表示不上傳
+func (s *Service) SetReportBucket(location string) error {
+	var bucket, prefix string
+	if location != "" {
+		trimmed := strings.TrimPrefix(location, "gs://")
+		bucket, prefix, _ = strings.Cut(trimmed, "/")
+		if bucket == "" {
+			return fmt.Errorf("無效的報告 bucket %q (gs://bucket/prefix)", location)
+		}
+		prefix = strings.Trim(prefix, "/")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reportBucket = bucket
+	s.reportBucketPrefix = prefix
+	return nil
+}
+
+// archiveReport 將產生的報告保存到報告歷史，並在設定 bucket 時上傳 JSON 與 HTML 到 Cloud Storage
+func (s *Service) archiveReport(ctx context.Context, report *OptimizationReport) {
+	s.storeReport(report)
+
+	if err := s.uploadReport(ctx, report); err != nil && s.logger != nil {
+		s.logger.Printf("警告: 無法上傳優化報告: %v", err)
+	}
+}
+
+// uploadReport 上傳報告的 JSON 與 HTML 到 <prefix>/<namespace>/<時間>.json|.html，未設定 bucket 時不上傳
+func (s *Service) uploadReport(ctx context.Context, report *OptimizationReport) error {
+	s.mu.RLock()
+	bucket, prefix := s.reportBucket, s.reportBucketPrefix
+	s.mu.RUnlock()
+	if bucket == "" {
+		return nil
+	}
+
+	reportJSON, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("序列化優化報告失敗: %w", err)
+	}
+	reportHTML, err := RenderReportHTML(report)
+	if err != nil {
+		return err
+	}
+
+	base := path.Join(prefix, report.Namespace, report.GeneratedAt.UTC().Format(reportIDTimeFormat))
+	if err := s.gkeService.UploadObject(ctx, bucket, base+".json", "application/json", reportJSON); err != nil {
+		return err
+	}
+	return s.gkeService.UploadObject(ctx, bucket, base+".html", "text/html; charset=utf-8", reportHTML)
+}
@@ -0,0 +1,144 @@
+package optimization
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// aggregatedWorkloadKinds 會將同一工作負載各 Pod 的建議合併為一筆的工作負載類型
+var aggregatedWorkloadKinds = map[string]bool{
+	"Deployment":  true,
+	"StatefulSet": true,
+	"DaemonSet":   true,
+}
+
+// priorityRank 優先級排序，數字越小越優先
+var priorityRank = map[Priority]int{
+	PriorityHigh:   0,
+	PriorityMedium: 1,
+	PriorityLow:    2,
+}
+
+// workloadGroup 同一工作負載的 Pod 分析
+type workloadGroup struct {
+	kind      string
+	name      string
+	namespace string
+	pods      []PodOptimization
+}
+
+// key 工作負載的識別鍵 (namespace/Kind/name)，與 gke.GetAutoscaledWorkloads 的鍵相同
+func (w *workloadGroup) key() string {
+	return w.namespace + "/" + w.kind + "/" + w.name
+}
+
+// groupByWorkload 依所屬工作負載將 Pod 分析分組，結果依命名空間、類型與名稱排序
+func groupByWorkload(podAnalyses []PodOptimization) []*workloadGroup {
+	index := make(map[string]*workloadGroup)
+	var groups []*workloadGroup
+	for _, podOpt := range podAnalyses {
+		group := &workloadGroup{kind: podOpt.WorkloadKind, name: podOpt.WorkloadName, namespace: podOpt.Namespace}
+		if existing, ok := index[group.key()]; ok {
+			group = existing
+		} else {
+			index[group.key()] = group
+			groups = append(groups, group)
+		}
+		group.pods = append(group.pods, podOpt)
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].key() < groups[j].key()
+	})
+	return groups
+}
+
+// aggregateRecommendations 將 Deployment、StatefulSet 與 DaemonSet 各 Pod 相同問題 (多容器 Pod 為相同容器的相同問題) 的建議合併為一筆工作負載建議
+// 合併後的建議附上各 Pod 的原始建議作為佐證，優先級取最高者，信心程度取最低者，資源建議值取各 Pod 的較大者；其他 Pod 的建議維持不變
+func aggregateRecommendations(podRecommendations []Recommendation, workloads []*workloadGroup) []Recommendation {
+	podCounts := make(map[string]int, len(workloads))
+	for _, workload := range workloads {
+		podCounts[workload.key()] = len(workload.pods)
+	}
+
+	var result []Recommendation
+	merged := make(map[string]int)
+	for _, rec := range podRecommendations {
+		if !aggregatedWorkloadKinds[rec.WorkloadKind] || rec.Issue == "" {
+			result = append(result, rec)
+			continue
+		}
+
+		workloadKey := rec.Namespace + "/" + rec.WorkloadKind + "/" + rec.WorkloadName
+		evidence := RecommendationEvidence{
+			PodName:     rec.PodName,
+			Title:       rec.Title,
+			Description: rec.Description,
+		}
+
+		key := workloadKey + "/" + rec.Issue
+		if rec.Container != "" {
+			key += "/" + rec.Container
+		}
+		if i, ok := merged[key]; ok {
+			target := &result[i]
+			target.Evidence = append(target.Evidence, evidence)
+			if priorityRank[rec.Priority] < priorityRank[target.Priority] {
+				target.Priority = rec.Priority
+			}
+			if confidenceRank[rec.Confidence] > confidenceRank[target.Confidence] {
+				target.Confidence, target.ConfidenceReason = rec.Confidence, rec.ConfidenceReason
+			}
+			applySuggestions(target, mergeSuggestions(target.Suggested, rec.Suggested))
+			target.Title = fmt.Sprintf("%s %s: %s (%d/%d 個 Pod)", target.WorkloadKind, target.WorkloadName,
+				target.Evidence[0].Title, len(target.Evidence), podCounts[workloadKey])
+			continue
+		}
+
+		rec.ID = fmt.Sprintf("REC-%s-%s", rec.WorkloadName, strings.ToLower(strings.ReplaceAll(rec.Issue, "_", "-")))
+		if rec.Container != "" {
+			rec.ID = fmt.Sprintf("REC-%s-%s-%s", rec.WorkloadName, rec.Container, strings.ToLower(strings.ReplaceAll(rec.Issue, "_", "-")))
+		}
+		rec.Title = fmt.Sprintf("%s %s: %s (1/%d 個 Pod)", rec.WorkloadKind, rec.WorkloadName, rec.Title, podCounts[workloadKey])
+		rec.PodName = ""
+		rec.Evidence = []RecommendationEvidence{evidence}
+		merged[key] = len(result)
+		result = append(result, rec)
+	}
+
+	return result
+}
+
+// workloadOptimizations 彙總各工作負載的 Pod 分析
+func workloadOptimizations(workloads []*workloadGroup) []WorkloadOptimization {
+	result := make([]WorkloadOptimization, 0, len(workloads))
+	for _, workload := range workloads {
+		summary := WorkloadOptimization{
+			Kind:        workload.kind,
+			Name:        workload.name,
+			Namespace:   workload.namespace,
+			Pods:        make([]string, 0, len(workload.pods)),
+			IssueCounts: make(map[string]int),
+		}
+
+		var totalScore float64
+		for _, podOpt := range workload.pods {
+			summary.Pods = append(summary.Pods, podOpt.PodName)
+			totalScore += podOpt.OptimizationScore
+			// 多容器 Pod 的同一問題可能出現在多個容器，每個 Pod 只計算一次
+			counted := make(map[string]bool, len(podOpt.Issues))
+			for _, issue := range podOpt.Issues {
+				if !counted[issue.Type] {
+					counted[issue.Type] = true
+					summary.IssueCounts[issue.Type]++
+				}
+			}
+		}
+		summary.PodCount = len(workload.pods)
+		summary.OptimizationScore = totalScore / float64(len(workload.pods))
+
+		result = append(result, summary)
+	}
+	return result
+}
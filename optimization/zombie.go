@@ -0,0 +1,106 @@
+package optimization
+
+import (
+	"fmt"
+	"time"
+
+	"mcp-gke-monitor/gke"
+)
+
+const (
+	// scaledDownStaleAge Deployment 維持 0 個副本超過此時間視為不再使用
+	scaledDownStaleAge = 14 * 24 * time.Hour
+
+	// finishedJobStaleAge 結束超過此時間仍未清除的 Job 視為被遺留
+	finishedJobStaleAge = 7 * 24 * time.Hour
+
+	// retryingJobFailures 尚未結束的 Job 失敗次數達到此值視為持續重試
+	retryingJobFailures = 10
+)
+
+// recommendCleanup 找出不再使用但仍留在叢集中的工作負載：長期縮減為 0 的 Deployment、結束後未清除的 Job、
+// 不斷重試的 Job，以及 Deployment 刪除後仍有 Pod 的 ReplicaSet
+// 由 CronJob 建立的 Job 由 CronJob 的歷史數量上限清除，不列為遺留的 Job
+func recommendCleanup(lifecycle *gke.WorkloadLifecycle, now time.Time) []Recommendation {
+	var recommendations []Recommendation
+
+	for _, deployment := range lifecycle.ScaledDownDeployments {
+		idle := now.Sub(deployment.LastUpdate)
+		if idle < scaledDownStaleAge {
+			continue
+		}
+		recommendations = append(recommendations, Recommendation{
+			ID:           fmt.Sprintf("REC-%s-scaled-to-zero", deployment.Name),
+			Type:         RecommendationCleanup,
+			Priority:     PriorityLow,
+			Title:        fmt.Sprintf("Deployment %s 已縮減為 0 個副本 %d 天", deployment.Name, int(idle.Hours()/24)),
+			Description:  fmt.Sprintf("Deployment %s 自 %s 起沒有任何副本，可能已不再使用", deployment.Name, deployment.LastUpdate.Format("2006-01-02")),
+			Impact:       "清除不再使用的 Deployment 與其 Service、ConfigMap、Secret，以及 Service 可能佔用的負載平衡器",
+			Action:       fmt.Sprintf("確認不再使用後刪除：kubectl delete deployment %s -n %s，並一併清除相關的 Service 與設定", deployment.Name, deployment.Namespace),
+			Namespace:    deployment.Namespace,
+			WorkloadKind: "Deployment",
+			WorkloadName: deployment.Name,
+		})
+	}
+
+	for _, job := range lifecycle.Jobs {
+		finished := job.Complete || job.FailedPermanently
+		switch {
+		case finished && job.CronJob == "" && job.TTLSecondsAfterFinished == nil && !job.FinishedAt.IsZero() && now.Sub(job.FinishedAt) >= finishedJobStaleAge:
+			result := "完成"
+			if !job.Complete {
+				result = "失敗"
+			}
+			recommendations = append(recommendations, Recommendation{
+				ID:       fmt.Sprintf("REC-%s-finished-job", job.Name),
+				Type:     RecommendationCleanup,
+				Priority: PriorityLow,
+				Title:    fmt.Sprintf("Job %s 已%s %d 天但未清除", job.Name, result, int(now.Sub(job.FinishedAt).Hours()/24)),
+				Description: fmt.Sprintf("Job %s 於 %s %s，沒有設定 ttlSecondsAfterFinished，Job 與其 Pod 會一直留在叢集中",
+					job.Name, job.FinishedAt.Format("2006-01-02"), result),
+				Impact:       "減少 API 伺服器保存的物件與已結束的 Pod，避免佔用命名空間的配額",
+				Action:       fmt.Sprintf("刪除 Job：kubectl delete job %s -n %s；之後建立的 Job 設定 ttlSecondsAfterFinished (例如 86400) 讓 Kubernetes 自動清除", job.Name, job.Namespace),
+				Namespace:    job.Namespace,
+				WorkloadKind: "Job",
+				WorkloadName: job.Name,
+			})
+		case !finished && job.Failed >= retryingJobFailures:
+			deadline := "沒有設定 activeDeadlineSeconds"
+			if job.ActiveDeadlineSeconds != nil {
+				deadline = fmt.Sprintf("activeDeadlineSeconds 為 %d", *job.ActiveDeadlineSeconds)
+			}
+			recommendations = append(recommendations, Recommendation{
+				ID:       fmt.Sprintf("REC-%s-retrying-job", job.Name),
+				Type:     RecommendationCleanup,
+				Priority: PriorityHigh,
+				Title:    fmt.Sprintf("Job %s 已失敗 %d 次仍在重試", job.Name, job.Failed),
+				Description: fmt.Sprintf("Job %s 的 backoffLimit 為 %d、%s，每次重試都重新建立 Pod 並佔用資源",
+					job.Name, job.BackoffLimit, deadline),
+				Impact: "停止不斷失敗的重試，釋放每次重試佔用的資源並減少錯誤事件",
+				Action: fmt.Sprintf("以 kubectl logs job/%s -n %s 查看失敗原因後刪除 Job：kubectl delete job %s -n %s；修正後重新建立時設定較小的 backoffLimit 與 activeDeadlineSeconds",
+					job.Name, job.Namespace, job.Name, job.Namespace),
+				Namespace:    job.Namespace,
+				WorkloadKind: "Job",
+				WorkloadName: job.Name,
+			})
+		}
+	}
+
+	for _, replicaSet := range lifecycle.OrphanedReplicaSets {
+		recommendations = append(recommendations, Recommendation{
+			ID:       fmt.Sprintf("REC-%s-orphaned-replicaset", replicaSet.Name),
+			Type:     RecommendationCleanup,
+			Priority: PriorityMedium,
+			Title:    fmt.Sprintf("ReplicaSet %s 沒有所屬的 Deployment 但仍有 %d 個 Pod", replicaSet.Name, replicaSet.Replicas),
+			Description: fmt.Sprintf("ReplicaSet %s 建立於 %s，沒有控制器管理，通常是 Deployment 以 --cascade=orphan 刪除後遺留的 Pod，不會再收到更新",
+				replicaSet.Name, replicaSet.CreatedAt.Format("2006-01-02")),
+			Impact:       "清除不再被管理的 Pod，釋放其佔用的 requests",
+			Action:       fmt.Sprintf("確認流量已由其他工作負載承接後刪除：kubectl delete replicaset %s -n %s", replicaSet.Name, replicaSet.Namespace),
+			Namespace:    replicaSet.Namespace,
+			WorkloadKind: "ReplicaSet",
+			WorkloadName: replicaSet.Name,
+		})
+	}
+
+	return recommendations
+}
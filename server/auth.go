@@ -0,0 +1,123 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"mcp-gke-monitor/config"
+	"mcp-gke-monitor/logger"
+)
+
+// apiKeyHeader 以 Authorization 以外的標頭傳送 API key 時使用的標頭
+const apiKeyHeader = "X-API-Key"
+
+// authenticator 驗證請求帶上的 token，回傳用戶端身分
+type authenticator interface {
+	authenticate(ctx context.Context, token string) (string, error)
+}
+
+// newAuthenticators 依驗證配置建立驗證器，未設定 API key 與 OIDC 時回傳空列表
+func newAuthenticators(auth config.AuthConfig) ([]authenticator, error) {
+	var authenticators []authenticator
+	if len(auth.APIKeys) > 0 {
+		authenticators = append(authenticators, newAPIKeyAuthenticator(auth.APIKeys))
+	}
+	if auth.OIDC.Issuer != "" {
+		oidc, err := newOIDCAuthenticator(auth.OIDC)
+		if err != nil {
+			return nil, fmt.Errorf("無法建立 OIDC 驗證器: %w", err)
+		}
+		authenticators = append(authenticators, oidc)
+	}
+	return authenticators, nil
+}
+
+// authMiddleware 拒絕沒有通過任一驗證器的請求，通過驗證的用戶端身分會加入請求的 context
+func authMiddleware(next http.Handler, authenticators []authenticator, requestLogger *logger.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		client, err := authenticateRequest(r, authenticators)
+		if err != nil {
+			requestLogger.Printf("拒絕未通過驗證的請求: %s %s 來源:%s 原因: %v", r.Method, r.URL.Path, r.RemoteAddr, err)
+			w.Header().Set("WWW-Authenticate", `Bearer realm="mcp-gke-monitor"`)
+			http.Error(w, "未通過驗證", http.StatusUnauthorized)
+			return
+		}
+		if r.Method == http.MethodGet {
+			requestLogger.Printf("用戶端 %s 建立 SSE 連線 來源:%s", client, r.RemoteAddr)
+		}
+		next.ServeHTTP(w, r.WithContext(logger.WithClient(r.Context(), client)))
+	})
+}
+
+// authenticateRequest 依序以各驗證器驗證請求的 token，全部失敗時回傳各驗證器的錯誤
+func authenticateRequest(r *http.Request, authenticators []authenticator) (string, error) {
+	token := requestToken(r)
+	if token == "" {
+		return "", fmt.Errorf("缺少 Authorization: Bearer 或 %s 標頭", apiKeyHeader)
+	}
+
+	reasons := make([]string, 0, len(authenticators))
+	for _, a := range authenticators {
+		client, err := a.authenticate(r.Context(), token)
+		if err == nil {
+			return client, nil
+		}
+		reasons = append(reasons, err.Error())
+	}
+	return "", fmt.Errorf("%s", strings.Join(reasons, "; "))
+}
+
+// requestToken 取得請求的 Bearer token 或 X-API-Key 標頭，都沒有時為空字串
+func requestToken(r *http.Request) string {
+	if authorization := r.Header.Get("Authorization"); authorization != "" {
+		scheme, token, found := strings.Cut(authorization, " ")
+		if found && strings.EqualFold(scheme, "Bearer") {
+			return strings.TrimSpace(token)
+		}
+		return ""
+	}
+	return r.Header.Get(apiKeyHeader)
+}
+
+// apiKeyClient 用戶端名稱與其 API key 的雜湊
+type apiKeyClient struct {
+	name string
+	hash [sha256.Size]byte
+}
+
+// apiKeyAuthenticator 以靜態 API key 驗證用戶端，用戶端身分為設定的名稱
+type apiKeyAuthenticator struct {
+	clients []apiKeyClient
+}
+
+// newAPIKeyAuthenticator 建立 API key 驗證器，keys 為用戶端名稱對應的 API key
+func newAPIKeyAuthenticator(keys map[string]string) *apiKeyAuthenticator {
+	clients := make([]apiKeyClient, 0, len(keys))
+	for name, key := range keys {
+		clients = append(clients, apiKeyClient{name: name, hash: sha256.Sum256([]byte(key))})
+	}
+	sort.Slice(clients, func(i, j int) bool {
+		return clients[i].name < clients[j].name
+	})
+	return &apiKeyAuthenticator{clients: clients}
+}
+
+// authenticate 找出 token 對應的用戶端，比對所有 API key 的雜湊以避免從回應時間推測 key
+func (a *apiKeyAuthenticator) authenticate(_ context.Context, token string) (string, error) {
+	hash := sha256.Sum256([]byte(token))
+	client := ""
+	for _, candidate := range a.clients {
+		if subtle.ConstantTimeCompare(hash[:], candidate.hash[:]) == 1 {
+			client = candidate.name
+		}
+	}
+	if client == "" {
+		return "", fmt.Errorf("API key 不正確")
+	}
+	return client, nil
+}
@@ -26,6 +26,114 @@ type ToolHandler interface {
 
 	// 取得 Pod 的詳細資訊（包含資源使用狀況）
 	GetPodDetails(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// 取得 Pod 在節點與可用區上的分佈狀況
+	GetPodDistribution(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// 取得 Pod 的探針設定
+	GetPodProbes(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// 依標籤分組統計 Pod
+	AggregatePodsByLabel(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// 取得選取指定 Pod 的 Service
+	GetServicesForPod(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// 在 Pod 內執行 TCP/HTTP 連線檢查
+	CheckPodConnectivity(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// 取得 Pod 的綜合描述 (規格、狀態、條件、事件、容忍設定與卷)
+	DescribePod(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// 取得節點的綜合描述 (狀態條件、污點、容量、Pod 與事件)
+	DescribeNode(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// 取得 Pod 各容器的環境變數 (Secret 值遮蔽)
+	GetPodEnv(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// 取得 Pod 的卷定義與各容器的掛載資訊
+	GetPodVolumes(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// 診斷 Pod 的映像檔拉取失敗原因
+	DiagnoseImagePull(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// 取得已完成、失敗 (含被驅逐) 及近期刪除的 Pod
+	GetTerminatedPods(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// 取得節點事件 (預設僅 Warning 事件)
+	GetNodeEvents(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// 滾動重啟 Deployment/StatefulSet/DaemonSet (需啟用寫入模式)
+	RestartWorkload(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// 設定 Deployment/StatefulSet 的副本數 (需啟用寫入模式)
+	ScaleWorkload(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// 刪除 Pod (需啟用寫入模式並提供確認參數)
+	DeletePod(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// 將節點標記為不可排程 (需啟用寫入模式)
+	CordonNode(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// 將節點恢復為可排程 (需啟用寫入模式)
+	UncordonNode(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// 排空節點 (停止排程並依 PodDisruptionBudget 驅逐 Pod，需啟用寫入模式)
+	DrainNode(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// 透過 Eviction API 驅逐 Pod (遵守 PodDisruptionBudget，需啟用寫入模式)
+	EvictPod(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// 取得 Deployment 的版本歷史
+	GetRolloutHistory(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// 將 Deployment 回滾到指定版本 (需啟用寫入模式)
+	RollbackDeployment(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// ExecInPod 在容器內執行允許清單中的指令
+	ExecInPod(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// ProbePodEndpoint 透過 API 伺服器 proxy 探測 Pod 的 HTTP 端點
+	ProbePodEndpoint(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// ApplyPatch 對資源套用修補 (預設 dry-run)
+	ApplyPatch(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// GetMetricsHistory 取得 Pod 或節點的使用量歷史時間序列
+	GetMetricsHistory(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// GetHistoricalUsage 從 Cloud Monitoring 取得 Pod 的歷史使用量
+	GetHistoricalUsage(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// QueryPrometheus 執行 PromQL 查詢
+	QueryPrometheus(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// GetPrometheusUsage 從 Prometheus 取得 Pod 的使用量、CPU 節流與網路流量
+	GetPrometheusUsage(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// GetUsagePercentiles 計算 Pod 或工作負載的使用量百分位數
+	GetUsagePercentiles(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// DetectAnomalies 偵測使用量與重啟異常的 Pod
+	DetectAnomalies(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// ForecastUsage 預測命名空間或節點的使用量趨勢
+	ForecastUsage(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// GetOOMEvents 依工作負載彙總 OOM 事件
+	GetOOMEvents(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// GetNodeCommitment 比較節點請求量與可分配量
+	GetNodeCommitment(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// GetCapacityPlan 估算工作負載還能增加的副本數
+	GetCapacityPlan(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// DetectMemoryLeaks 偵測記憶體持續成長的容器
+	DetectMemoryLeaks(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// DetectOOMRisks 偵測記憶體接近限制且仍在成長的容器
+	DetectOOMRisks(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
 }
 
 type OptimizationHandler interface {
@@ -51,4 +159,49 @@ type OptimizationHandler interface {
 
 	// 更新優化標準
 	UpdateOptimizationCriteria(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// GetRecommendationPatch 將資源建議轉換為可直接套用的 patch YAML
+	GetRecommendationPatch(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// ApplyRecommendation 以伺服器端套用將資源建議寫入工作負載 (預設 dry-run)
+	ApplyRecommendation(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// SimulateNodeConsolidation 模擬將 Pod 重新裝箱到最少的節點
+	SimulateNodeConsolidation(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// DetectIdleNamespaces 找出所有工作負載都已閒置一段期間的命名空間
+	DetectIdleNamespaces(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// ListOptimizationReports 列出已保存的優化報告
+	ListOptimizationReports(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// CompareOptimizationReports 比較兩份已保存的優化報告
+	CompareOptimizationReports(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// ExportOptimizationReport 將優化報告匯出為檔案
+	ExportOptimizationReport(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// SendReport 產生優化報告並傳送到設定的 webhook
+	SendReport(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// SnoozeRecommendation 暫停建議直到指定時間
+	SnoozeRecommendation(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// UnsnoozeRecommendation 取消暫停建議
+	UnsnoozeRecommendation(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// ListSnoozedRecommendations 列出暫停中的建議設定
+	ListSnoozedRecommendations(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// AssessAutopilotSuitability 評估命名空間的工作負載能否改用 GKE Autopilot 並比較成本
+	AssessAutopilotSuitability(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// CompareClusters 在配置的叢集上執行優化分析並比較結果
+	CompareClusters(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// AnalyzeStartupLatency 分析各工作負載從 Pod 建立到就緒的時間
+	AnalyzeStartupLatency(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// AnalyzePodSecurity 依 Pod Security Standards 評估 Pod 並依命名空間列出違規
+	AnalyzePodSecurity(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
 }
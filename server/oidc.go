@@ -0,0 +1,231 @@
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"google.golang.org/api/idtoken"
+
+	"mcp-gke-monitor/config"
+)
+
+const (
+	// googleIssuer Google 簽發的 ID token 的 issuer，舊的 token 使用不含 https:// 的 accounts.google.com
+	googleIssuer = "https://accounts.google.com"
+
+	// oidcClockSkew 檢查 exp 與 nbf 時容許的時鐘誤差
+	oidcClockSkew = time.Minute
+)
+
+// idTokenVerifier 驗證 ID token 的簽章
+type idTokenVerifier interface {
+	verifySignature(ctx context.Context, token string) error
+}
+
+// idTokenClaims oidcAuthenticator 檢查的 ID token 欄位
+type idTokenClaims struct {
+	Issuer        string       `json:"iss"`
+	Subject       string       `json:"sub"`
+	Audience      audience     `json:"aud"`
+	Expires       int64        `json:"exp"`
+	NotBefore     int64        `json:"nbf"`
+	Email         string       `json:"email"`
+	EmailVerified flexibleBool `json:"email_verified"`
+	HostedDomain  string       `json:"hd"` // Google Workspace 網域
+}
+
+// audience ID token 的 aud，可以是字串或字串陣列
+type audience []string
+
+// UnmarshalJSON 同時接受字串與字串陣列
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return fmt.Errorf("aud 必須是字串或字串陣列: %w", err)
+	}
+	*a = multiple
+	return nil
+}
+
+// flexibleBool 布林值，部分 OIDC 提供者以字串 "true" 表示
+type flexibleBool bool
+
+// UnmarshalJSON 同時接受布林值與字串
+func (b *flexibleBool) UnmarshalJSON(data []byte) error {
+	var value bool
+	if err := json.Unmarshal(data, &value); err == nil {
+		*b = flexibleBool(value)
+		return nil
+	}
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return fmt.Errorf("email_verified 必須是布林值: %w", err)
+	}
+	*b = flexibleBool(strings.EqualFold(text, "true"))
+	return nil
+}
+
+// oidcAuthenticator 驗證 OIDC ID token，用戶端身分為 token 中的 email，沒有 email 時為 sub
+type oidcAuthenticator struct {
+	config   config.OIDCConfig
+	verifier idTokenVerifier
+}
+
+// newOIDCAuthenticator 建立 OIDC 驗證器，Google issuer 使用 Google 的公開憑證，其他 issuer 使用 OIDC 探索
+func newOIDCAuthenticator(cfg config.OIDCConfig) (*oidcAuthenticator, error) {
+	// go-oidc 要求探索文件的 issuer 與設定值完全相同，保留設定的原始值 (部分提供者的 issuer 以 / 結尾)
+	issuer := cfg.Issuer
+	cfg.Issuer = strings.TrimSuffix(cfg.Issuer, "/")
+
+	var verifier idTokenVerifier
+	if cfg.Issuer == googleIssuer {
+		validator, err := idtoken.NewValidator(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("無法建立 Google ID token 驗證器: %w", err)
+		}
+		verifier = googleVerifier{validator: validator}
+	} else {
+		verifier = &providerVerifier{
+			issuer:   issuer,
+			audience: cfg.Audience,
+			client:   &http.Client{Timeout: 10 * time.Second},
+		}
+	}
+	return &oidcAuthenticator{config: cfg, verifier: verifier}, nil
+}
+
+// authenticate 驗證 ID token 的簽章、issuer、audience 與有效期間，並檢查身分是否在允許的 email 或網域中
+func (a *oidcAuthenticator) authenticate(ctx context.Context, token string) (string, error) {
+	if strings.Count(token, ".") != 2 {
+		return "", fmt.Errorf("token 不是 ID token")
+	}
+	if err := a.verifier.verifySignature(ctx, token); err != nil {
+		return "", fmt.Errorf("ID token 驗證失敗: %w", err)
+	}
+
+	claims, err := parseIDTokenClaims(token)
+	if err != nil {
+		return "", err
+	}
+	if strings.TrimSuffix(claims.Issuer, "/") != a.config.Issuer && !(a.config.Issuer == googleIssuer && claims.Issuer == "accounts.google.com") {
+		return "", fmt.Errorf("ID token 的 issuer %s 不符", claims.Issuer)
+	}
+	if !slices.Contains(claims.Audience, a.config.Audience) {
+		return "", fmt.Errorf("ID token 的 audience %v 不包含 %s", []string(claims.Audience), a.config.Audience)
+	}
+	now := time.Now()
+	if claims.Expires == 0 || now.After(time.Unix(claims.Expires, 0).Add(oidcClockSkew)) {
+		return "", fmt.Errorf("ID token 已過期")
+	}
+	if claims.NotBefore != 0 && now.Add(oidcClockSkew).Before(time.Unix(claims.NotBefore, 0)) {
+		return "", fmt.Errorf("ID token 尚未生效")
+	}
+
+	identity := claims.Email
+	if identity == "" {
+		identity = claims.Subject
+	}
+	if !a.allowed(claims) {
+		return "", fmt.Errorf("身分 %s 不在允許的 email 或網域中", identity)
+	}
+	return identity, nil
+}
+
+// allowed 身分是否在 allowedEmails 或 allowedDomains 中，兩者都未設定時拒絕所有身分
+func (a *oidcAuthenticator) allowed(claims idTokenClaims) bool {
+	if claims.Email == "" || !bool(claims.EmailVerified) {
+		return false
+	}
+
+	email := strings.ToLower(claims.Email)
+	for _, allowed := range a.config.AllowedEmails {
+		if strings.EqualFold(allowed, email) {
+			return true
+		}
+	}
+	for _, domain := range a.config.AllowedDomains {
+		domain = strings.ToLower(strings.TrimPrefix(domain, "@"))
+		if strings.EqualFold(claims.HostedDomain, domain) || strings.HasSuffix(email, "@"+domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseIDTokenClaims 解析 ID token 的 payload，呼叫前必須先驗證簽章
+func parseIDTokenClaims(token string) (idTokenClaims, error) {
+	var claims idTokenClaims
+	parts := strings.Split(token, ".")
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return claims, fmt.Errorf("ID token 的 payload 格式錯誤: %w", err)
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return claims, fmt.Errorf("ID token 的 payload 格式錯誤: %w", err)
+	}
+	return claims, nil
+}
+
+// googleVerifier 以 Google 的公開憑證驗證 ID token
+type googleVerifier struct {
+	validator *idtoken.Validator
+}
+
+// verifySignature 驗證簽章，audience 由 oidcAuthenticator 檢查
+func (v googleVerifier) verifySignature(ctx context.Context, token string) error {
+	_, err := v.validator.Validate(ctx, token, "")
+	return err
+}
+
+// providerVerifier 以 go-oidc 驗證其他 issuer 簽發的 ID token，第一次驗證時才從 OIDC 探索文件取得簽章金鑰的位置
+// go-oidc 檢查簽章、簽章演算法、issuer、audience 與有效期間，並快取簽章金鑰
+type providerVerifier struct {
+	issuer   string
+	audience string
+	client   *http.Client
+
+	mu       sync.Mutex
+	verifier *oidc.IDTokenVerifier
+}
+
+// verifySignature 驗證 ID token，探索失敗時下次驗證會重試
+func (v *providerVerifier) verifySignature(ctx context.Context, token string) error {
+	verifier, err := v.idTokenVerifier(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = verifier.Verify(ctx, token)
+	return err
+}
+
+// idTokenVerifier 取得 go-oidc 的驗證器，尚未建立時從 OIDC 探索文件建立
+func (v *providerVerifier) idTokenVerifier(ctx context.Context) (*oidc.IDTokenVerifier, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.verifier != nil {
+		return v.verifier, nil
+	}
+	provider, err := oidc.NewProvider(oidc.ClientContext(ctx, v.client), v.issuer)
+	if err != nil {
+		return nil, fmt.Errorf("無法取得 OIDC 探索文件: %w", err)
+	}
+	v.verifier = provider.Verifier(&oidc.Config{
+		ClientID:             v.audience,
+		SupportedSigningAlgs: []string{oidc.RS256, oidc.ES256},
+	})
+	return v.verifier, nil
+}
@@ -0,0 +1,252 @@
+package server
+
+import (
+	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"mcp-gke-monitor/config"
+)
+
+const testAudience = "mcp-gke-monitor"
+
+// testIssuer 提供 OIDC 探索文件與 JWKS 的測試 issuer
+type testIssuer struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+	keyID  string
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	t.Helper()
+	issuer := &testIssuer{key: newRSAKey(t), keyID: "key-1"}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"issuer":                                issuer.server.URL,
+			"jwks_uri":                              issuer.server.URL + "/keys",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": issuer.keyID,
+				"use": "sig",
+				"alg": "RS256",
+				"n":   base64.RawURLEncoding.EncodeToString(issuer.key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(issuer.key.E)).Bytes()),
+			}},
+		})
+	})
+	issuer.server = httptest.NewServer(mux)
+	t.Cleanup(issuer.server.Close)
+	return issuer
+}
+
+func newRSAKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("無法產生 RSA 金鑰: %v", err)
+	}
+	return key
+}
+
+// claims 有效的 ID token 內容
+func (i *testIssuer) claims() map[string]interface{} {
+	now := time.Now()
+	return map[string]interface{}{
+		"iss":            i.server.URL,
+		"sub":            "1234567890",
+		"aud":            testAudience,
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Hour).Unix(),
+		"email":          "alice@example.com",
+		"email_verified": true,
+	}
+}
+
+// encodeSegment 以 base64url 編碼 JWT 的 header 或 payload
+func encodeSegment(t *testing.T, value interface{}) string {
+	t.Helper()
+	data, err := json.Marshal(value)
+	if err != nil {
+		t.Fatalf("無法編碼 JWT: %v", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// signRS256 以 RSA 金鑰簽署 token
+func signRS256(t *testing.T, key *rsa.PrivateKey, keyID string, claims map[string]interface{}) string {
+	t.Helper()
+	signingInput := encodeSegment(t, map[string]string{"alg": "RS256", "typ": "JWT", "kid": keyID}) + "." + encodeSegment(t, claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatalf("無法簽署 JWT: %v", err)
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestOIDCAuthenticator(t *testing.T) {
+	issuer := newTestIssuer(t)
+	authenticator, err := newOIDCAuthenticator(config.OIDCConfig{
+		Issuer:         issuer.server.URL,
+		Audience:       testAudience,
+		AllowedDomains: []string{"example.com"},
+	})
+	if err != nil {
+		t.Fatalf("無法建立 OIDC 驗證器: %v", err)
+	}
+
+	withClaims := func(change func(claims map[string]interface{})) string {
+		claims := issuer.claims()
+		change(claims)
+		return signRS256(t, issuer.key, issuer.keyID, claims)
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "valid token",
+			token: signRS256(t, issuer.key, issuer.keyID, issuer.claims()),
+			want:  "alice@example.com",
+		},
+		{
+			name:    "expired",
+			token:   withClaims(func(c map[string]interface{}) { c["exp"] = time.Now().Add(-time.Hour).Unix() }),
+			wantErr: true,
+		},
+		{
+			name:    "wrong audience",
+			token:   withClaims(func(c map[string]interface{}) { c["aud"] = "another-service" }),
+			wantErr: true,
+		},
+		{
+			name:    "wrong issuer",
+			token:   withClaims(func(c map[string]interface{}) { c["iss"] = "https://evil.example.com" }),
+			wantErr: true,
+		},
+		{
+			name: "alg none",
+			token: encodeSegment(t, map[string]string{"alg": "none", "typ": "JWT"}) + "." +
+				encodeSegment(t, issuer.claims()) + ".",
+			wantErr: true,
+		},
+		{
+			name: "alg mismatch HS256 with public key as secret",
+			token: func() string {
+				signingInput := encodeSegment(t, map[string]string{"alg": "HS256", "typ": "JWT", "kid": issuer.keyID}) + "." +
+					encodeSegment(t, issuer.claims())
+				mac := hmac.New(sha256.New, issuer.key.N.Bytes())
+				mac.Write([]byte(signingInput))
+				return signingInput + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "bad signature",
+			token: func() string {
+				token := signRS256(t, issuer.key, issuer.keyID, issuer.claims())
+				parts := strings.Split(token, ".")
+				claims := issuer.claims()
+				claims["email"] = "mallory@example.com"
+				return parts[0] + "." + encodeSegment(t, claims) + "." + parts[2]
+			}(),
+			wantErr: true,
+		},
+		{
+			name:    "signed by another key with the same kid",
+			token:   signRS256(t, newRSAKey(t), issuer.keyID, issuer.claims()),
+			wantErr: true,
+		},
+		{
+			name:    "unknown kid",
+			token:   signRS256(t, newRSAKey(t), "unknown-key", issuer.claims()),
+			wantErr: true,
+		},
+		{
+			name:    "email outside allowed domains",
+			token:   withClaims(func(c map[string]interface{}) { c["email"] = "bob@other.com" }),
+			wantErr: true,
+		},
+		{
+			name:    "email not verified",
+			token:   withClaims(func(c map[string]interface{}) { c["email_verified"] = false }),
+			wantErr: true,
+		},
+		{
+			name:    "not a JWT",
+			token:   "plain-api-key",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := authenticator.authenticate(context.Background(), tt.token)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("authenticate() = %q, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("authenticate() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("authenticate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOIDCAuthenticatorAllowed(t *testing.T) {
+	claims := idTokenClaims{Email: "alice@example.com", EmailVerified: true, HostedDomain: "example.com"}
+
+	tests := []struct {
+		name   string
+		config config.OIDCConfig
+		claims idTokenClaims
+		want   bool
+	}{
+		{name: "no allowlist", config: config.OIDCConfig{}, claims: claims, want: false},
+		{name: "allowed email", config: config.OIDCConfig{AllowedEmails: []string{"Alice@Example.com"}}, claims: claims, want: true},
+		{name: "other email", config: config.OIDCConfig{AllowedEmails: []string{"bob@example.com"}}, claims: claims, want: false},
+		{name: "allowed domain", config: config.OIDCConfig{AllowedDomains: []string{"@example.com"}}, claims: claims, want: true},
+		{name: "domain suffix is not a match", config: config.OIDCConfig{AllowedDomains: []string{"ample.com"}}, claims: claims, want: false},
+		{
+			name:   "unverified email",
+			config: config.OIDCConfig{AllowedEmails: []string{"alice@example.com"}},
+			claims: idTokenClaims{Email: "alice@example.com"},
+			want:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &oidcAuthenticator{config: tt.config}
+			if got := a.allowed(tt.claims); got != tt.want {
+				t.Errorf("allowed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
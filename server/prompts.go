@@ -0,0 +1,126 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterPrompts 註冊常見調查流程的提示，依序串連適合的工具並帶入參數
+func RegisterPrompts(s *mcpserver.MCPServer) []string {
+	var registeredPrompts []string
+
+	// 調查不斷重啟的 Pod
+	crashLoopPrompt := mcp.NewPrompt("investigate_crashlooping_pod",
+		mcp.WithPromptDescription("Investigate why a Pod is crash-looping: exit reasons, last-run logs, OOM kills, probes and recent rollouts"),
+		mcp.WithArgument("podName",
+			mcp.ArgumentDescription("Name of the crash-looping Pod"),
+			mcp.RequiredArgument(),
+		),
+		mcp.WithArgument("namespace",
+			mcp.ArgumentDescription("Namespace of the Pod (default: default)"),
+		),
+	)
+	s.AddPrompt(crashLoopPrompt, func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		podName := request.Params.Arguments["podName"]
+		if podName == "" {
+			return nil, errors.New("必須提供有效的 Pod 名稱")
+		}
+		namespace := promptNamespace(request, "default")
+
+		return promptResult("Investigate crash-looping Pod "+namespace+"/"+podName, fmt.Sprintf(`Pod %[1]s in namespace %[2]s keeps restarting. Find the root cause using the GKE monitoring tools, in this order:
+
+1. describe_pod (podName: %[1]s, namespace: %[2]s): note each container's restart count, last termination reason and exit code, and the Warning events.
+2. get_pod_optimization_analysis (podName: %[1]s, namespace: %[2]s): it includes the last run's log tail and the exit reason when restarts are high.
+3. If a container was OOMKilled or exited with 137: get_oom_events (namespace: %[2]s, since: 24h), get_pod_memory_usage (podName: %[1]s, namespace: %[2]s) and detect_oom_risks (namespace: %[2]s) to tell a memory limit that is too low from a leak.
+4. get_pod_probes (podName: %[1]s, namespace: %[2]s): check whether a liveness probe with a short timeout or no startup probe kills a slow-starting container.
+5. If the Pod is waiting in ErrImagePull or ImagePullBackOff: diagnose_image_pull (podName: %[1]s, namespace: %[2]s).
+6. If the Pod belongs to a Deployment: get_rollout_history (name: the Deployment, namespace: %[2]s) to see whether the restarts started with a new revision, and get_pod_env (podName: %[1]s, namespace: %[2]s) if the logs point to missing configuration.
+
+Skip steps that do not apply. Finish with the root cause, the evidence for it, and the fix (for example a resource or probe change, or a rollback). Do not call write tools such as rollback_deployment, restart_workload or delete_pod without asking me first.`, podName, namespace)), nil
+	})
+	registeredPrompts = append(registeredPrompts, "investigate_crashlooping_pod")
+
+	// 每週成本檢討
+	costReviewPrompt := mcp.NewPrompt("weekly_cost_review",
+		mcp.WithPromptDescription("Weekly cost review: optimization report, changes since last week, top savings, node consolidation and idle namespaces"),
+		mcp.WithArgument("namespace",
+			mcp.ArgumentDescription("Namespace to review, or \"all\" for all namespaces (default: all)"),
+		),
+	)
+	s.AddPrompt(costReviewPrompt, func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		namespace := promptNamespace(request, "all")
+
+		return promptResult("Weekly cost review for namespace "+namespace, fmt.Sprintf(`Run the weekly cost review for namespace %[1]s:
+
+1. generate_optimization_report (namespace: %[1]s, window: 7d, format: markdown): this also saves the report so next week's review can compare against it.
+2. compare_optimization_reports (namespace: %[1]s): list the issues resolved and introduced since the previous report and the change in score and estimated savings. If there is no previous report, say so and skip this step.
+3. get_optimization_recommendations (namespace: %[1]s, window: 7d, priority: HIGH): the recommendations to act on this week; prefer HIGH confidence ones.
+4. get_resource_waste_analysis (namespace: %[1]s, window: 7d): the largest over-provisioned workloads and idle resources.
+5. simulate_node_consolidation and get_node_commitment: how many nodes could be removed and which node pools have stranded capacity.
+6. detect_idle_namespaces (days: 7): namespaces that could be archived or torn down.
+7. list_snoozed_recommendations: snoozed recommendations that expire soon.
+
+Summarise as: total estimated monthly savings, the top 5 actions ranked by savings with their risk, what changed since last week, and node pool changes worth considering. Do not apply any recommendation without asking me first.`, namespace)), nil
+	})
+	registeredPrompts = append(registeredPrompts, "weekly_cost_review")
+
+	// 調查回應 503 的服務
+	service503Prompt := mcp.NewPrompt("investigate_service_503",
+		mcp.WithPromptDescription("Investigate why a Service returns 503: no ready endpoints, selector or port mismatches, failing readiness probes, restarts and recent rollouts"),
+		mcp.WithArgument("serviceName",
+			mcp.ArgumentDescription("Name of the Service returning 503"),
+			mcp.RequiredArgument(),
+		),
+		mcp.WithArgument("namespace",
+			mcp.ArgumentDescription("Namespace of the Service (default: default)"),
+		),
+		mcp.WithArgument("labelSelector",
+			mcp.ArgumentDescription("Label selector of the Service's Pods (default: app=<serviceName>)"),
+		),
+	)
+	s.AddPrompt(service503Prompt, func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		serviceName := request.Params.Arguments["serviceName"]
+		if serviceName == "" {
+			return nil, errors.New("必須提供有效的 Service 名稱")
+		}
+		namespace := promptNamespace(request, "default")
+		labelSelector := request.Params.Arguments["labelSelector"]
+		if labelSelector == "" {
+			labelSelector = "app=" + serviceName
+		}
+
+		return promptResult("Investigate 503 responses from Service "+namespace+"/"+serviceName, fmt.Sprintf(`Service %[1]s in namespace %[2]s returns 503 errors. A 503 usually means the Service has no ready endpoints, so work through these steps:
+
+1. search_pods (namespace: %[2]s, labelSelector: %[3]s): list the backing Pods with their status, readiness and restarts. If no Pods match, the selector may be wrong; ask me for the Service's actual selector.
+2. get_services_for_pod (podName: one of the Pods, namespace: %[2]s): confirm that %[1]s selects the Pod and that its targetPort matches a container port.
+3. get_pod_probes and describe_pod for Pods that are not ready: look for "Readiness probe failed" events, OOMKilled or evicted containers.
+4. probe_pod_endpoint (podName: a Pod that is not ready, namespace: %[2]s): call the readiness endpoint directly to see the status code and latency the probe gets.
+5. check_pod_connectivity (podName: a healthy Pod in the cluster, host: %[1]s.%[2]s.svc.cluster.local, port: the Service port): check that the Service is reachable inside the cluster, which separates a Service problem from an ingress or load balancer health check problem.
+6. get_pod_cpu_usage and get_pod_memory_usage for the backing Pods: saturated Pods fail readiness under load.
+7. get_rollout_history (name: the Deployment behind the Service, namespace: %[2]s) and get_terminated_pods (namespace: %[2]s, since: 1h): did a rollout, scale-down or eviction coincide with the errors?
+
+Skip steps that do not apply. Finish with the most likely cause, the evidence, and the fix. Do not call write tools such as rollback_deployment, scale_workload or restart_workload without asking me first.`, serviceName, namespace, labelSelector)), nil
+	})
+	registeredPrompts = append(registeredPrompts, "investigate_service_503")
+
+	return registeredPrompts
+}
+
+// promptNamespace 取得提示的 namespace 參數，未提供時使用 defaultNamespace
+func promptNamespace(request mcp.GetPromptRequest, defaultNamespace string) string {
+	if namespace := request.Params.Arguments["namespace"]; namespace != "" {
+		return namespace
+	}
+	return defaultNamespace
+}
+
+// promptResult 建立只有一則使用者訊息的提示結果
+func promptResult(description, text string) *mcp.GetPromptResult {
+	return mcp.NewGetPromptResult(description, []mcp.PromptMessage{
+		mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(text)),
+	})
+}
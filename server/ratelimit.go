@@ -0,0 +1,127 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"mcp-gke-monitor/config"
+	"mcp-gke-monitor/logger"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+)
+
+// rateLimiterIdleTimeout 用戶端超過此時間沒有呼叫工具時移除其限制器
+const rateLimiterIdleTimeout = 10 * time.Minute
+
+// remoteIPKey 請求來源 IP 在 context 中的鍵
+type remoteIPKey struct{}
+
+// withRemoteIP 在 context 中記錄請求的來源 IP，作為 SSE 伺服器的 context 函式
+func withRemoteIP(ctx context.Context, r *http.Request) context.Context {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return context.WithValue(ctx, remoteIPKey{}, host)
+}
+
+// clientLimiter 用戶端的限制器與最後一次呼叫的時間
+type clientLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// toolRateLimiter 依用戶端限制工具呼叫的頻率
+type toolRateLimiter struct {
+	limit  rate.Limit
+	burst  int
+	logger *logger.Logger
+
+	mu        sync.Mutex
+	clients   map[string]*clientLimiter
+	lastSweep time.Time
+}
+
+// newToolRateLimiter 建立工具呼叫頻率限制器
+func newToolRateLimiter(cfg config.RateLimitConfig, logger *logger.Logger) *toolRateLimiter {
+	burst := cfg.Burst
+	if burst == 0 {
+		burst = cfg.RequestsPerMinute
+	}
+	return &toolRateLimiter{
+		limit:   rate.Limit(float64(cfg.RequestsPerMinute) / 60),
+		burst:   burst,
+		logger:  logger,
+		clients: make(map[string]*clientLimiter),
+	}
+}
+
+// middleware 超過頻率限制的工具呼叫直接回傳錯誤，不會執行工具
+func (l *toolRateLimiter) middleware(next mcpserver.ToolHandlerFunc) mcpserver.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		client := rateLimitKey(ctx)
+		if wait, ok := l.allow(client); !ok {
+			l.logger.Printf("用戶端 %s 呼叫 %s 超過頻率限制", client, request.Params.Name)
+			return nil, fmt.Errorf("工具呼叫過於頻繁 (每分鐘最多 %.0f 次)，請在 %s 後重試", float64(l.limit)*60, wait.Round(time.Second))
+		}
+		return next(ctx, request)
+	}
+}
+
+// allow 用戶端是否可以呼叫工具，不行時回傳需要等待的時間
+func (l *toolRateLimiter) allow(client string) (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.sweep(now)
+
+	entry, ok := l.clients[client]
+	if !ok {
+		entry = &clientLimiter{limiter: rate.NewLimiter(l.limit, l.burst)}
+		l.clients[client] = entry
+	}
+	entry.lastSeen = now
+
+	reservation := entry.limiter.ReserveN(now, 1)
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		reservation.CancelAt(now)
+		return delay, false
+	}
+	return 0, true
+}
+
+// sweep 移除閒置的用戶端，最多每分鐘執行一次
+// 呼叫端需持有 l.mu
+func (l *toolRateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < time.Minute {
+		return
+	}
+	l.lastSweep = now
+	for client, entry := range l.clients {
+		if now.Sub(entry.lastSeen) > rateLimiterIdleTimeout {
+			delete(l.clients, client)
+		}
+	}
+}
+
+// rateLimitKey 計算頻率限制的用戶端：通過驗證的身分，其次為來源 IP，都沒有時為 session
+func rateLimitKey(ctx context.Context) string {
+	if client := logger.ClientFromContext(ctx); client != "" {
+		return client
+	}
+	if ip, _ := ctx.Value(remoteIPKey{}).(string); ip != "" {
+		return ip
+	}
+	if session := mcpserver.ClientSessionFromContext(ctx); session != nil {
+		return "session:" + session.SessionID()
+	}
+	return "unknown"
+}
@@ -3,10 +3,12 @@ package server
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
 
 	"mcp-gke-monitor/config"
+	"mcp-gke-monitor/gke"
 	"mcp-gke-monitor/logger"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -14,9 +16,10 @@ import (
 )
 
 type MCPConfig struct {
-	Name    string
-	Version string
-	Logger  *logger.Logger
+	Name     string
+	Version  string
+	Logger   *logger.Logger
+	Timeouts config.TimeoutConfig // 工具執行時間上限
 }
 
 func NewMCPServer(cfg MCPConfig) *mcpserver.MCPServer {
@@ -28,6 +31,8 @@ func NewMCPServer(cfg MCPConfig) *mcpserver.MCPServer {
 		mcpserver.WithLogging(),
 		mcpserver.WithHooks(loggingHooks),
 		mcpserver.WithResourceCapabilities(true, true), // 啟用資源功能
+		mcpserver.WithToolHandlerMiddleware(newToolTimeouts(cfg.Timeouts, cfg.Logger).middleware),
+		mcpserver.WithToolHandlerMiddleware(gke.ProgressMiddleware), // 客戶端提供 progressToken 時回報長時間操作的進度
 	)
 
 	return s
@@ -43,7 +48,22 @@ func RegisterTools(s *mcpserver.MCPServer, handler ToolHandler, optimizationHand
 	getAllPodsTool := mcp.NewTool("get_all_pods",
 		mcp.WithDescription("Get all GKE Pod list"),
 		mcp.WithString("namespace",
-			mcp.Description("Namespace (default: default)"),
+			mcp.Description("Namespace (default: default, use \"all\" for all namespaces)"),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of pods to return; enables paginated response with a continue cursor"),
+		),
+		mcp.WithString("continue",
+			mcp.Description("Continue cursor returned by the previous page"),
+		),
+		mcp.WithString("fields",
+			mcp.Description("Comma-separated fields to return (e.g. name,status,nodeName,restartCount); returns full pod objects when omitted"),
+		),
+		mcp.WithString("sortBy",
+			mcp.Description("Sort field (name, age, restartCount, status, node); with limit, all matching pods are sorted before paging and the continue cursor is an offset"),
+		),
+		mcp.WithString("order",
+			mcp.Description("Sort order (asc, desc; default: asc)"),
 		),
 	)
 
@@ -51,7 +71,11 @@ func RegisterTools(s *mcpserver.MCPServer, handler ToolHandler, optimizationHand
 	searchPodsTool := mcp.NewTool("search_pods",
 		mcp.WithDescription("Search GKE Pods by criteria"),
 		mcp.WithString("namespace",
-			mcp.Description("Namespace"),
+			mcp.Description("Namespace (use \"all\" for all namespaces)"),
+		),
+		mcp.WithArray("namespaces",
+			mcp.Description("Search several namespaces in one call and merge results (overrides namespace; pagination not supported)"),
+			mcp.Items(map[string]interface{}{"type": "string"}),
 		),
 		mcp.WithString("labelSelector",
 			mcp.Description("Label selector"),
@@ -62,6 +86,42 @@ func RegisterTools(s *mcpserver.MCPServer, handler ToolHandler, optimizationHand
 		mcp.WithString("status",
 			mcp.Description("Pod status (Running, Pending, Succeeded, Failed, Unknown)"),
 		),
+		mcp.WithString("nodeName",
+			mcp.Description("Only pods scheduled on this node"),
+		),
+		mcp.WithString("qosClass",
+			mcp.Description("Pod QoS class (Guaranteed, Burstable, BestEffort)"),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of pods to list per page; enables paginated response with a continue cursor"),
+		),
+		mcp.WithString("continue",
+			mcp.Description("Continue cursor returned by the previous page"),
+		),
+		mcp.WithString("fields",
+			mcp.Description("Comma-separated fields to return (e.g. name,status,nodeName,restartCount); returns full pod objects when omitted"),
+		),
+		mcp.WithString("sortBy",
+			mcp.Description("Sort field (name, age, restartCount, status, node); with limit, all matching pods are sorted before paging and the continue cursor is an offset"),
+		),
+		mcp.WithString("order",
+			mcp.Description("Sort order (asc, desc; default: asc)"),
+		),
+		mcp.WithString("namePattern",
+			mcp.Description("Pod name pattern (e.g. payment-*)"),
+		),
+		mcp.WithString("patternType",
+			mcp.Description("Name pattern type (glob, regex; default: glob)"),
+		),
+		mcp.WithString("createdAfter",
+			mcp.Description("Only pods created after this time (RFC3339 or relative like 1h, 7d)"),
+		),
+		mcp.WithString("createdBefore",
+			mcp.Description("Only pods created before this time (RFC3339 or relative like 1h, 7d)"),
+		),
+		mcp.WithString("restartedSince",
+			mcp.Description("Only pods with a container restarted since this time (RFC3339 or relative like 1h, 7d)"),
+		),
 	)
 
 	// 建立取得 Pod CPU 使用狀況的工具
@@ -112,14 +172,602 @@ func RegisterTools(s *mcpserver.MCPServer, handler ToolHandler, optimizationHand
 		),
 	)
 
+	// 建立取得 Pod 分佈狀況的工具
+	getPodDistributionTool := mcp.NewTool("get_pod_distribution",
+		mcp.WithDescription("Get Pod distribution by node and zone, flagging single-node or single-zone concentration"),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace (default: default)"),
+		),
+		mcp.WithString("labelSelector",
+			mcp.Description("Label selector identifying the workload (e.g. app=nginx)"),
+		),
+	)
+
+	// 建立取得 Pod 探針設定的工具
+	getPodProbesTool := mcp.NewTool("get_pod_probes",
+		mcp.WithDescription("Get liveness/readiness/startup probe settings per container, including missing probes"),
+		mcp.WithString("podName",
+			mcp.Required(),
+			mcp.Description("Pod name"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace (default: default)"),
+		),
+	)
+
+	// 建立依標籤分組統計 Pod 的工具
+	aggregatePodsByLabelTool := mcp.NewTool("aggregate_pods_by_label",
+		mcp.WithDescription("Group pods by a label key and return per-group counts, ready ratio, restart totals and summed usage"),
+		mcp.WithString("labelKey",
+			mcp.Required(),
+			mcp.Description("Label key to group by (e.g. app)"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace (default: default, use \"all\" for all namespaces)"),
+		),
+	)
+
+	// 建立取得 Pod 對應 Service 的工具
+	getServicesForPodTool := mcp.NewTool("get_services_for_pod",
+		mcp.WithDescription("Find Services whose selectors match a Pod's labels and return their ports"),
+		mcp.WithString("podName",
+			mcp.Required(),
+			mcp.Description("Pod name"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace (default: default)"),
+		),
+	)
+
+	// 建立 Pod 內部連線檢查的工具
+	checkPodConnectivityTool := mcp.NewTool("check_pod_connectivity",
+		mcp.WithDescription("Run a lightweight TCP/HTTP probe from inside a Pod and report reachability and latency"),
+		mcp.WithString("podName",
+			mcp.Required(),
+			mcp.Description("Pod name"),
+		),
+		mcp.WithString("host",
+			mcp.Required(),
+			mcp.Description("Target host or IP (e.g. my-service.default.svc.cluster.local)"),
+		),
+		mcp.WithNumber("port",
+			mcp.Required(),
+			mcp.Description("Target port"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace (default: default)"),
+		),
+		mcp.WithString("container",
+			mcp.Description("Container name (default: first container)"),
+		),
+		mcp.WithString("protocol",
+			mcp.Description("Probe protocol (TCP, HTTP; default: HTTP when path is set, otherwise TCP)"),
+		),
+		mcp.WithString("path",
+			mcp.Description("HTTP path (default: /)"),
+		),
+		mcp.WithNumber("timeoutSeconds",
+			mcp.Description("Probe timeout in seconds (default: 5)"),
+		),
+	)
+
+	// 建立取得 Pod 綜合描述的工具
+	describePodTool := mcp.NewTool("describe_pod",
+		mcp.WithDescription("Get a kubectl-describe style view of a Pod: spec, status, conditions, events, tolerations and volumes in one response"),
+		mcp.WithString("podName",
+			mcp.Required(),
+			mcp.Description("Pod name"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace (default: default)"),
+		),
+	)
+
+	// 建立取得節點綜合描述的工具
+	describeNodeTool := mcp.NewTool("describe_node",
+		mcp.WithDescription("Get a kubectl-describe style view of a node: conditions, taints, capacity, allocatable, system info, events, and the allocated resources breakdown (requests/limits committed per non-terminated pod)"),
+		mcp.WithString("nodeName",
+			mcp.Required(),
+			mcp.Description("Node name"),
+		),
+	)
+
+	// 建立取得 Pod 環境變數的工具
+	getPodEnvTool := mcp.NewTool("get_pod_env",
+		mcp.WithDescription("List environment variables per container with ConfigMap values resolved and Secret values redacted; reports missing ConfigMaps or keys"),
+		mcp.WithString("podName",
+			mcp.Required(),
+			mcp.Description("Pod name"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace (default: default)"),
+		),
+	)
+
+	// 建立取得 Pod 卷與掛載資訊的工具
+	getPodVolumesTool := mcp.NewTool("get_pod_volumes",
+		mcp.WithDescription("Get a Pod's volume definitions with per-container mount paths, read-only flags and the backing PVC/ConfigMap/Secret, including PVC binding status"),
+		mcp.WithString("podName",
+			mcp.Required(),
+			mcp.Description("Pod name"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace (default: default)"),
+		),
+	)
+
+	// 建立診斷映像檔拉取失敗的工具
+	diagnoseImagePullTool := mcp.NewTool("diagnose_image_pull",
+		mcp.WithDescription("Diagnose ErrImagePull/ImagePullBackOff containers in a Pod using container state, events and the image registry; classifies failures as AUTH, NOT_FOUND, RATE_LIMIT, NETWORK or INVALID_NAME"),
+		mcp.WithString("podName",
+			mcp.Required(),
+			mcp.Description("Pod name"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace (default: default)"),
+		),
+	)
+
+	// 建立取得已終止與近期刪除 Pod 的工具
+	getTerminatedPodsTool := mcp.NewTool("get_terminated_pods",
+		mcp.WithDescription("List Succeeded and Failed pods (Job pods, evicted pods) and, via events, recently deleted pods with their deletion reasons"),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace (default: default, use 'all' for all namespaces)"),
+		),
+		mcp.WithString("since",
+			mcp.Description("Only include pods finished or deleted after this time (RFC3339 or relative like 1h, 30m)"),
+		),
+		mcp.WithBoolean("includeDeleted",
+			mcp.Description("Include recently deleted pods inferred from events (default: true)"),
+		),
+	)
+
+	// 建立取得節點事件的工具
+	getNodeEventsTool := mcp.NewTool("get_node_events",
+		mcp.WithDescription("Get node events grouped per node (Warning events by default, e.g. SystemOOM, NodeNotReady, disk pressure) over a selectable time window"),
+		mcp.WithString("nodeName",
+			mcp.Description("Node name (default: all nodes)"),
+		),
+		mcp.WithString("since",
+			mcp.Description("Only include events after this time (RFC3339 or relative like 1h, 7d; default: 24h)"),
+		),
+		mcp.WithString("type",
+			mcp.Description("Event type (Warning, Normal, all; default: Warning)"),
+		),
+	)
+
+	// 建立滾動重啟工作負載的工具 (需啟用寫入模式)
+	restartWorkloadTool := mcp.NewTool("restart_workload",
+		mcp.WithDescription("Perform a rollout restart of a Deployment, StatefulSet or DaemonSet by patching the restartedAt annotation (requires write mode enabled in config.json)"),
+		mcp.WithString("kind",
+			mcp.Required(),
+			mcp.Description("Workload kind (Deployment, StatefulSet, DaemonSet)"),
+		),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Workload name"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace (default: default)"),
+		),
+	)
+
+	// 建立擴縮工作負載的工具 (需啟用寫入模式)
+	scaleWorkloadTool := mcp.NewTool("scale_workload",
+		mcp.WithDescription("Set the replica count of a Deployment or StatefulSet within the min/max bounds configured in config.json (requires write mode enabled)"),
+		mcp.WithString("kind",
+			mcp.Required(),
+			mcp.Description("Workload kind (Deployment, StatefulSet)"),
+		),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Workload name"),
+		),
+		mcp.WithNumber("replicas",
+			mcp.Required(),
+			mcp.Description("Desired replica count"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace (default: default)"),
+		),
+	)
+
+	// 建立刪除 Pod 的工具 (需啟用寫入模式)
+	deletePodTool := mcp.NewTool("delete_pod",
+		mcp.WithDescription("Delete a Pod, e.g. to clear stuck or evicted pods. Requires write mode enabled and confirm set to the Pod name; respects the Pod's grace period unless overridden"),
+		mcp.WithString("podName",
+			mcp.Required(),
+			mcp.Description("Pod name"),
+		),
+		mcp.WithString("confirm",
+			mcp.Required(),
+			mcp.Description("Confirmation: must be exactly the Pod name"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace (default: default)"),
+		),
+		mcp.WithNumber("gracePeriodSeconds",
+			mcp.Description("Grace period in seconds (default: the Pod's terminationGracePeriodSeconds)"),
+		),
+	)
+
+	// 建立將節點標記為不可排程的工具 (需啟用寫入模式)
+	cordonNodeTool := mcp.NewTool("cordon_node",
+		mcp.WithDescription("Mark a node as unschedulable so no new pods are scheduled on it (requires write mode enabled)"),
+		mcp.WithString("nodeName",
+			mcp.Required(),
+			mcp.Description("Node name"),
+		),
+	)
+
+	// 建立將節點恢復為可排程的工具 (需啟用寫入模式)
+	uncordonNodeTool := mcp.NewTool("uncordon_node",
+		mcp.WithDescription("Mark a node as schedulable again (requires write mode enabled)"),
+		mcp.WithString("nodeName",
+			mcp.Required(),
+			mcp.Description("Node name"),
+		),
+	)
+
+	// 建立排空節點的工具 (需啟用寫入模式)
+	drainNodeTool := mcp.NewTool("drain_node",
+		mcp.WithDescription("Cordon a node and evict its pods through the Eviction API, respecting PodDisruptionBudgets; sends progress notifications when the client provides a progressToken (requires write mode enabled and confirm set to the node name)"),
+		mcp.WithString("nodeName",
+			mcp.Required(),
+			mcp.Description("Node name"),
+		),
+		mcp.WithString("confirm",
+			mcp.Required(),
+			mcp.Description("Confirmation: must be exactly the node name"),
+		),
+		mcp.WithBoolean("ignoreDaemonSets",
+			mcp.Description("Skip DaemonSet-managed pods instead of blocking the drain (default: true)"),
+		),
+		mcp.WithBoolean("deleteEmptyDirData",
+			mcp.Description("Allow evicting pods that use emptyDir volumes; their data is lost (default: false)"),
+		),
+		mcp.WithBoolean("force",
+			mcp.Description("Allow evicting pods not managed by a controller; they will not be recreated (default: false)"),
+		),
+		mcp.WithNumber("gracePeriodSeconds",
+			mcp.Description("Grace period for each pod (default: each pod's terminationGracePeriodSeconds)"),
+		),
+		mcp.WithNumber("timeoutSeconds",
+			mcp.Description("Overall timeout in seconds (default: 300)"),
+		),
+	)
+
+	// 建立驅逐 Pod 的工具 (需啟用寫入模式)
+	evictPodTool := mcp.NewTool("evict_pod",
+		mcp.WithDescription("Evict a Pod through the policy/v1 Eviction API so PodDisruptionBudgets are respected, unlike a raw delete; reports the matching PDBs when the eviction is refused (requires write mode enabled)"),
+		mcp.WithString("podName",
+			mcp.Required(),
+			mcp.Description("Pod name"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace (default: default)"),
+		),
+		mcp.WithNumber("gracePeriodSeconds",
+			mcp.Description("Grace period in seconds (default: the Pod's terminationGracePeriodSeconds)"),
+		),
+	)
+
+	// 建立取得 Deployment 版本歷史的工具
+	getRolloutHistoryTool := mcp.NewTool("get_rollout_history",
+		mcp.WithDescription("Get a Deployment's rollout history: revisions with their ReplicaSet, images, change cause and replica counts"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Deployment name"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace (default: default)"),
+		),
+	)
+
+	// 建立回滾 Deployment 的工具 (需啟用寫入模式)
+	rollbackDeploymentTool := mcp.NewTool("rollback_deployment",
+		mcp.WithDescription("Roll a Deployment back to a chosen revision, like kubectl rollout undo (requires write mode enabled)"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Deployment name"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace (default: default)"),
+		),
+		mcp.WithNumber("toRevision",
+			mcp.Description("Revision to roll back to (default: the previous revision)"),
+		),
+	)
+
+	// 建立在容器內執行允許清單指令的工具
+	execInPodTool := mcp.NewTool("exec_in_pod",
+		mcp.WithDescription("Run an allowlisted command (configured in config.json exec.allowedCommands) inside a Pod container without a shell and return stdout, stderr and exit code"),
+		mcp.WithString("podName",
+			mcp.Required(),
+			mcp.Description("Pod name"),
+		),
+		mcp.WithArray("command",
+			mcp.Required(),
+			mcp.Description("Command and arguments, e.g. [\"df\", \"-h\"]; the program must be in the allowlist"),
+			mcp.Items(map[string]interface{}{"type": "string"}),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace (default: default)"),
+		),
+		mcp.WithString("container",
+			mcp.Description("Container name (default: first container)"),
+		),
+		mcp.WithNumber("timeoutSeconds",
+			mcp.Description("Command timeout in seconds (default: 30)"),
+		),
+	)
+
+	// 建立透過 API 伺服器 proxy 探測 Pod HTTP 端點的工具
+	probePodEndpointTool := mcp.NewTool("probe_pod_endpoint",
+		mcp.WithDescription("Send an HTTP GET to a Pod endpoint through the API server proxy and return status code, latency and a body snippet; defaults to the container's readiness (or liveness) probe endpoint"),
+		mcp.WithString("podName",
+			mcp.Required(),
+			mcp.Description("Pod name"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace (default: default)"),
+		),
+		mcp.WithString("container",
+			mcp.Description("Container whose HTTP probe is used when port is not set (default: first container with an HTTP probe)"),
+		),
+		mcp.WithNumber("port",
+			mcp.Description("Pod port (default: port of the readiness/liveness HTTP probe)"),
+		),
+		mcp.WithString("path",
+			mcp.Description("HTTP path, may include a query string (default: probe path or /)"),
+		),
+		mcp.WithString("scheme",
+			mcp.Description("http or https (default: probe scheme or http)"),
+		),
+		mcp.WithNumber("timeoutSeconds",
+			mcp.Description("Request timeout in seconds (default: 5)"),
+		),
+	)
+
+	// 建立修補資源的工具 (預設僅 dry-run)
+	applyPatchTool := mcp.NewTool("apply_patch",
+		mcp.WithDescription("Apply a strategic-merge, merge, JSON patch or server-side apply configuration to a named resource. Runs a server-side dry-run and returns the changed fields unless commit is true (commit requires write mode)"),
+		mcp.WithString("resource",
+			mcp.Required(),
+			mcp.Description("Resource type (e.g. deployment, statefulsets.apps, hpa, configmap)"),
+		),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Resource name"),
+		),
+		mcp.WithString("patch",
+			mcp.Required(),
+			mcp.Description("Patch document as JSON (object for strategic/merge, array of operations for json)"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace for namespaced resources (default: default)"),
+		),
+		mcp.WithString("patchType",
+			mcp.Description("Patch type (strategic, merge, json, apply; default: strategic). apply performs a forced server-side apply and needs apiVersion, kind and metadata.name in the patch"),
+		),
+		mcp.WithBoolean("commit",
+			mcp.Description("Persist the patch instead of a dry-run (default: false)"),
+		),
+	)
+
+	// 建立取得使用量歷史時間序列的工具
+	getMetricsHistoryTool := mcp.NewTool("get_metrics_history",
+		mcp.WithDescription("Get the CPU/memory usage time series of a Pod or node sampled by the background metrics collector, with min/max/avg summary (requires metrics collection in config.json)"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Pod or node name"),
+		),
+		mcp.WithString("kind",
+			mcp.Description("Object kind (pod, node; default: pod)"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Pod namespace (default: default)"),
+		),
+		mcp.WithString("container",
+			mcp.Description("Only return usage of this container"),
+		),
+		mcp.WithString("duration",
+			mcp.Description("Time range to return, e.g. 30m, 6h (default: 1h)"),
+		),
+	)
+
+	// 建立從 Cloud Monitoring 取得歷史使用量的工具
+	getHistoricalUsageTool := mcp.NewTool("get_historical_usage",
+		mcp.WithDescription("Query Cloud Monitoring kubernetes.io/container metrics for a Pod's per-container CPU and memory usage over an arbitrary time range (requires Google Cloud credentials)"),
+		mcp.WithString("podName",
+			mcp.Required(),
+			mcp.Description("Pod name"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace (default: default)"),
+		),
+		mcp.WithString("duration",
+			mcp.Description("Time range ending now, e.g. 24h, 168h (default: 24h)"),
+		),
+		mcp.WithString("alignment",
+			mcp.Description("Alignment period of each data point, e.g. 5m, 1h (default: 5m)"),
+		),
+	)
+
+	// 建立執行 PromQL 查詢的工具
+	queryPrometheusTool := mcp.NewTool("query_prometheus",
+		mcp.WithDescription("Run a PromQL query against the configured Prometheus or Google Cloud Managed Prometheus backend (instant query, or range query when duration is set)"),
+		mcp.WithString("query",
+			mcp.Required(),
+			mcp.Description("PromQL expression"),
+		),
+		mcp.WithString("duration",
+			mcp.Description("Run a range query over this window ending now, e.g. 1h, 24h (default: instant query)"),
+		),
+		mcp.WithString("step",
+			mcp.Description("Range query resolution, e.g. 1m, 5m (default: 5m)"),
+		),
+	)
+
+	// 建立從 Prometheus 取得 Pod 使用量的工具
+	getPrometheusUsageTool := mcp.NewTool("get_prometheus_usage",
+		mcp.WithDescription("Get a Pod's per-container CPU, memory and CPU throttling plus network throughput over time from Prometheus cAdvisor metrics"),
+		mcp.WithString("podName",
+			mcp.Required(),
+			mcp.Description("Pod name"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace (default: default)"),
+		),
+		mcp.WithString("duration",
+			mcp.Description("Time range ending now, e.g. 6h, 168h (default: 24h)"),
+		),
+		mcp.WithString("step",
+			mcp.Description("Resolution of each data point, e.g. 1m, 5m (default: 5m)"),
+		),
+	)
+
+	// 建立計算使用量百分位數的工具
+	getUsagePercentilesTool := mcp.NewTool("get_usage_percentiles",
+		mcp.WithDescription("Compute p50/p90/p95/p99/max CPU and memory usage of a Pod or workload (all current Pods pooled) over a window from the available history backend"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Pod or workload name"),
+		),
+		mcp.WithString("kind",
+			mcp.Description("Object kind (Pod, Deployment, StatefulSet, DaemonSet; default: Pod)"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace (default: default)"),
+		),
+		mcp.WithString("window",
+			mcp.Description("Time window ending now, e.g. 24h, 168h (default: 168h)"),
+		),
+		mcp.WithString("source",
+			mcp.Description("History backend (cloud-monitoring, prometheus, collector; default: first available in that order)"),
+		),
+	)
+
+	// 建立偵測使用量異常的工具
+	detectAnomaliesTool := mcp.NewTool("detect_anomalies",
+		mcp.WithDescription("Find Pods whose recent CPU/memory usage deviates from their rolling baseline (robust z-score using median absolute deviation) or that restarted recently"),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace (use \"all\" for all namespaces; default: default)"),
+		),
+		mcp.WithString("baseline",
+			mcp.Description("Baseline window ending now, e.g. 24h (default: 24h)"),
+		),
+		mcp.WithString("recent",
+			mcp.Description("Recent window compared against the baseline, e.g. 30m (default: 30m)"),
+		),
+		mcp.WithNumber("threshold",
+			mcp.Description("Absolute robust z-score above which usage is reported (default: 3.5)"),
+		),
+		mcp.WithString("source",
+			mcp.Description("History backend (cloud-monitoring, prometheus, collector; default: first available)"),
+		),
+	)
+
+	// 建立使用量趨勢預測的工具
+	forecastUsageTool := mcp.NewTool("forecast_usage",
+		mcp.WithDescription("Fit a linear trend on historical namespace or node CPU/memory usage and project when it will exceed current requests and allocatable capacity"),
+		mcp.WithString("scope",
+			mcp.Description("Forecast scope (namespace, node; default: namespace)"),
+		),
+		mcp.WithString("name",
+			mcp.Description("Namespace or node name (default: default namespace)"),
+		),
+		mcp.WithString("window",
+			mcp.Description("History window used to fit the trend, e.g. 168h (default: 168h)"),
+		),
+		mcp.WithNumber("horizonDays",
+			mcp.Description("How many days ahead to project (default: 30)"),
+		),
+		mcp.WithString("source",
+			mcp.Description("History backend for namespaces (cloud-monitoring, prometheus, collector; default: first available)"),
+		),
+	)
+
+	// 建立 OOM 事件彙總的工具
+	getOOMEventsTool := mcp.NewTool("get_oom_events",
+		mcp.WithDescription("Aggregate OOMKilled container terminations per workload and SystemOOM node events over a time window"),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace (default: default, use \"all\" for all namespaces)"),
+		),
+		mcp.WithString("since",
+			mcp.Description("Only include OOM kills after this time (RFC3339 or relative like 1h, 7d; default: 24h)"),
+		),
+	)
+
+	// 建立節點請求量承諾比例報告的工具
+	getNodeCommitmentTool := mcp.NewTool("get_node_commitment",
+		mcp.WithDescription("Compare the sum of pod requests on each node to its allocatable CPU, memory and pod slots, reporting commitment ratios and stranded capacity per node, node pool and cluster"),
+		mcp.WithString("nodePool",
+			mcp.Description("Only include nodes in this GKE node pool (default: all nodes)"),
+		),
+	)
+
+	// 建立工作負載副本容量規劃的工具
+	getCapacityPlanTool := mcp.NewTool("get_capacity_plan",
+		mcp.WithDescription("Estimate how many more replicas of a Deployment or StatefulSet fit in the cluster or a node pool, based on pod requests, node allocatable capacity, node selectors, taints, anti-affinity and topology spread constraints"),
+		mcp.WithString("kind",
+			mcp.Required(),
+			mcp.Description("Workload kind (Deployment, StatefulSet)"),
+		),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Workload name"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace (default: default)"),
+		),
+		mcp.WithString("nodePool",
+			mcp.Description("Only consider nodes in this GKE node pool (default: all nodes)"),
+		),
+	)
+
+	// 建立記憶體洩漏偵測的工具
+	detectMemoryLeaksTool := mcp.NewTool("detect_memory_leaks",
+		mcp.WithDescription("Detect containers whose memory usage grows monotonically over the history window and flag probable leaks with growth rate and projected OOM time"),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace (default: default, use \"all\" for all namespaces)"),
+		),
+		mcp.WithString("window",
+			mcp.Description("History window to analyze, e.g. 24h, 72h (default: 24h)"),
+		),
+		mcp.WithString("source",
+			mcp.Description("History backend (cloud-monitoring, prometheus, collector; default: first available)"),
+		),
+	)
+
+	// 建立 OOM 風險偵測的工具
+	detectOOMRisksTool := mcp.NewTool("detect_oom_risks",
+		mcp.WithDescription("Warn about containers whose memory usage is above 85% of their limit and still growing, with the projected OOM time, before they are OOMKilled"),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace (default: default, use \"all\" for all namespaces)"),
+		),
+		mcp.WithString("window",
+			mcp.Description("Recent history window used for the trend, e.g. 6h, 12h (default: 6h)"),
+		),
+		mcp.WithString("source",
+			mcp.Description("History backend (cloud-monitoring, prometheus, collector; default: first available)"),
+		),
+	)
+
 	// ========== GKE 優化建議工具 ==========
 
 	// 建立生成優化報告的工具
 	generateOptimizationReportTool := mcp.NewTool("generate_optimization_report",
-		mcp.WithDescription("Generate comprehensive GKE optimization report with resource analysis and recommendations"),
+		mcp.WithDescription("Generate comprehensive GKE optimization report with resource analysis and recommendations; sends progress notifications (current phase, pods analyzed / total) when the client provides a progressToken"),
 		mcp.WithString("namespace",
 			mcp.Description("Namespace (default: default)"),
 		),
+		mcp.WithString("format",
+			mcp.Description("Output format: json (default) or markdown (human-readable tables, recommendations grouped by priority)"),
+		),
+		mcp.WithString("window",
+			mcp.Description("Usage history window to analyze, e.g. 1h, 24h, 7d (default: 7d). Requires a historical metric source (Cloud Monitoring, Prometheus or the collector); the window is recorded in the report so it can be reproduced"),
+		),
 	)
 
 	// 建立取得優化摘要的工具
@@ -128,11 +776,14 @@ func RegisterTools(s *mcpserver.MCPServer, handler ToolHandler, optimizationHand
 		mcp.WithString("namespace",
 			mcp.Description("Namespace (default: default)"),
 		),
+		mcp.WithString("window",
+			mcp.Description("Usage history window to analyze, e.g. 1h, 24h, 7d (default: 7d). Requires a historical metric source (Cloud Monitoring, Prometheus or the collector)"),
+		),
 	)
 
 	// 建立取得優化建議的工具
 	getOptimizationRecommendationsTool := mcp.NewTool("get_optimization_recommendations",
-		mcp.WithDescription("Get GKE optimization recommendations filtered by priority and type"),
+		mcp.WithDescription("Get GKE optimization recommendations filtered by priority, type and confidence. Each recommendation has a confidence (HIGH, MEDIUM, LOW) based on the usage data window, usage variance and metric source; HIGH recommendations are safe to apply automatically"),
 		mcp.WithString("namespace",
 			mcp.Description("Namespace (default: default)"),
 		),
@@ -140,7 +791,13 @@ func RegisterTools(s *mcpserver.MCPServer, handler ToolHandler, optimizationHand
 			mcp.Description("Priority filter (HIGH, MEDIUM, LOW)"),
 		),
 		mcp.WithString("type",
-			mcp.Description("Recommendation type filter (CPU, MEMORY, HEALTH, STORAGE, REPLICA, SECURITY)"),
+			mcp.Description("Recommendation type filter (CPU, MEMORY, GPU, HEALTH, STORAGE, REPLICA, SECURITY, CLEANUP, LABELS)"),
+		),
+		mcp.WithString("minConfidence",
+			mcp.Description("Minimum confidence (HIGH, MEDIUM, LOW): only return recommendations at or above this level, e.g. HIGH for recommendations safe to auto-apply"),
+		),
+		mcp.WithString("window",
+			mcp.Description("Usage history window to analyze, e.g. 1h, 24h, 7d (default: 7d). Requires a historical metric source (Cloud Monitoring, Prometheus or the collector)"),
 		),
 	)
 
@@ -150,6 +807,9 @@ func RegisterTools(s *mcpserver.MCPServer, handler ToolHandler, optimizationHand
 		mcp.WithString("namespace",
 			mcp.Description("Namespace (default: default)"),
 		),
+		mcp.WithString("window",
+			mcp.Description("Usage history window to analyze, e.g. 1h, 24h, 7d (default: 7d). Requires a historical metric source (Cloud Monitoring, Prometheus or the collector)"),
+		),
 	)
 
 	// 建立取得 Pod 優化分析的工具
@@ -162,6 +822,9 @@ func RegisterTools(s *mcpserver.MCPServer, handler ToolHandler, optimizationHand
 		mcp.WithString("namespace",
 			mcp.Description("Namespace (default: default)"),
 		),
+		mcp.WithString("window",
+			mcp.Description("Usage history window to analyze, e.g. 1h, 24h, 7d (default: 7d). Requires a historical metric source (Cloud Monitoring, Prometheus or the collector)"),
+		),
 	)
 
 	// 建立取得優化標準的工具
@@ -184,6 +847,207 @@ func RegisterTools(s *mcpserver.MCPServer, handler ToolHandler, optimizationHand
 		mcp.WithNumber("idleThreshold",
 			mcp.Description("Idle threshold (default: 5.0)"),
 		),
+		mcp.WithNumber("headroomFactor",
+			mcp.Description("Headroom factor applied to p95/peak usage when suggesting requests and limits, must be >= 1 (default: 1.2)"),
+		),
+		mcp.WithNumber("minReplicas",
+			mcp.Description("Lowest replica count suggested by replica right-sizing, for high availability (default: 2)"),
+		),
+		mcp.WithNumber("diskThreshold",
+			mcp.Description("Ephemeral storage and volume utilization (%) above which a disk is reported as near full (default: 85.0)"),
+		),
+		mcp.WithString("cpuLimitPolicy",
+			mcp.Description("What to recommend for sustained CPU throttling: raise (raise the CPU limit) or remove (drop the CPU limit and keep only requests) (default: raise)"),
+		),
+		mcp.WithNumber("minObservationHours",
+			mcp.Description("Hours of usage history a workload needs before sizing, replica and HPA values are suggested; shorter histories are marked as insufficient data, 0 disables the check (default: 24)"),
+		),
+		mcp.WithObject("scoring",
+			mcp.Description("Scoring weights to change; omitted fields keep their current value. highPenalty/mediumPenalty/lowPenalty (points deducted per issue, default 20/10/5), healthWeight (share of the health score in the optimization score, 0-1, default 0.5), restartPenalty/notReadyPenalty/notRunningPenalty/memoryLeakPenalty (health score deductions, default 10/30/40/20)"),
+		),
+	)
+
+	// 建立產生建議 patch 的工具
+	getRecommendationPatchTool := mcp.NewTool("get_recommendation_patch",
+		mcp.WithDescription("Render CPU/memory sizing recommendations as ready-to-apply YAML: a strategic-merge patch for the owning workload (with a kubectl patch command) or the full container resources block"),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace (default: default)"),
+		),
+		mcp.WithString("recommendationId",
+			mcp.Description("Only render this recommendation ID (e.g. REC-web-cpu-over-provisioned); all sizing recommendations when omitted"),
+		),
+		mcp.WithString("format",
+			mcp.Description("Output format: patch (strategic-merge patch, default) or resources (full container resources block)"),
+		),
+		mcp.WithString("window",
+			mcp.Description("Usage history window the recommendations are computed over, e.g. 1h, 24h, 7d (default: 7d). Use the same window as get_optimization_recommendations"),
+		),
+	)
+
+	// 建立套用建議的工具
+	applyRecommendationTool := mcp.NewTool("apply_recommendation",
+		mcp.WithDescription("Apply a CPU/memory sizing recommendation to its owning workload via server-side apply of the complete container resources block (field manager mcp-gke-monitor). Runs a server-side dry-run and returns the changed fields unless commit is true (commit requires write mode and confirm set to the recommendation ID)"),
+		mcp.WithString("recommendationId",
+			mcp.Required(),
+			mcp.Description("Recommendation ID from get_optimization_recommendations (e.g. REC-web-cpu-over-provisioned)"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace (default: default)"),
+		),
+		mcp.WithBoolean("commit",
+			mcp.Description("Persist the change instead of a dry-run (default: false)"),
+		),
+		mcp.WithString("confirm",
+			mcp.Description("Must equal recommendationId when commit is true"),
+		),
+		mcp.WithString("window",
+			mcp.Description("Usage history window the recommendation was generated with, e.g. 1h, 24h, 7d (default: 7d). Recommendation IDs depend on the analysis, so use the same window as get_optimization_recommendations"),
+		),
+	)
+
+	// 建立節點整併模擬的工具
+	simulateNodeConsolidationTool := mcp.NewTool("simulate_node_consolidation",
+		mcp.WithDescription("Simulate packing current pod requests onto the fewest nodes of each node pool (first-fit decreasing, respecting required hostname anti-affinity, DaemonSet overhead and pod capacity) and report how many nodes could be freed"),
+		mcp.WithString("nodePool",
+			mcp.Description("Only simulate this node pool (default: all node pools)"),
+		),
+	)
+
+	// 建立閒置命名空間偵測的工具
+	detectIdleNamespacesTool := mcp.NewTool("detect_idle_namespaces",
+		mcp.WithDescription("Find namespaces whose workloads have all stayed below the idle threshold (peak CPU as a percentage of requests) for the whole period, with no pods created during it, and recommend archiving or tearing them down. Requires a historical usage source"),
+		mcp.WithNumber("days",
+			mcp.Description("Idle period in days (default: optimization.idleNamespaceDays, 7)"),
+		),
+	)
+
+	// 建立列出已保存報告的工具
+	listOptimizationReportsTool := mcp.NewTool("list_optimization_reports",
+		mcp.WithDescription("List optimization reports saved by generate_optimization_report for a namespace, newest first, with their IDs, overall score and estimated monthly savings"),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace (default: default)"),
+		),
+	)
+
+	// 建立比較報告的工具
+	compareOptimizationReportsTool := mcp.NewTool("compare_optimization_reports",
+		mcp.WithDescription("Diff two saved optimization reports of a namespace: resolved issues, new issues, overall score and estimated savings change, and score movement per workload. Defaults to the latest report compared with the one before it"),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace (default: default)"),
+		),
+		mcp.WithString("baselineId",
+			mcp.Description("Baseline report ID from list_optimization_reports (default: the report before currentId)"),
+		),
+		mcp.WithString("currentId",
+			mcp.Description("Current report ID from list_optimization_reports (default: the latest report)"),
+		),
+	)
+
+	// 建立匯出報告的工具
+	exportOptimizationReportTool := mcp.NewTool("export_optimization_report",
+		mcp.WithDescription("Export an optimization report to the configured export directory (optimization.exportDir) as a styled HTML page that can be printed to PDF from a browser, or as CSV files. Generates a new report unless reportId is given"),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace (default: default)"),
+		),
+		mcp.WithString("reportId",
+			mcp.Description("Saved report ID from list_optimization_reports (default: generate a new report)"),
+		),
+		mcp.WithString("format",
+			mcp.Description("Export format: html (default) or csv (pods, waste and recommendations files with fixed columns for spreadsheets and BI tools)"),
+		),
+		mcp.WithString("window",
+			mcp.Description("Usage history window to analyze when generating a new report, e.g. 1h, 24h, 7d (default: 7d); ignored when reportId is given"),
+		),
+	)
+
+	// 建立傳送報告通知的工具
+	sendReportTool := mcp.NewTool("send_report",
+		mcp.WithDescription("Generate an optimization report and post its summary and high-priority findings to the configured Slack or generic webhook (notification.webhookURL). The report is also saved to the report history"),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace (default: default)"),
+		),
+	)
+
+	// 建立暫停建議的工具
+	snoozeRecommendationTool := mcp.NewTool("snooze_recommendation",
+		mcp.WithDescription("Suppress a recommendation until a given date, e.g. when a fix is already planned. Snooze a single recommendation ID, or all recommendations of a workload (optionally only one issue type). Snoozed items are listed under snoozed in the report and come back after the date"),
+		mcp.WithString("until",
+			mcp.Required(),
+			mcp.Description("Snooze until this date (2006-01-02, end of day UTC) or RFC3339 time"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace (default: default)"),
+		),
+		mcp.WithString("recommendationId",
+			mcp.Description("Recommendation ID to snooze (e.g. REC-web-cpu-over-provisioned)"),
+		),
+		mcp.WithString("workloadName",
+			mcp.Description("Snooze recommendations of this workload when recommendationId is omitted"),
+		),
+		mcp.WithString("workloadKind",
+			mcp.Description("Workload kind (Deployment, StatefulSet, ...); all kinds when omitted"),
+		),
+		mcp.WithString("issue",
+			mcp.Description("Only snooze this issue type of the workload (e.g. CPU_OVER_PROVISIONED)"),
+		),
+		mcp.WithString("reason",
+			mcp.Description("Why the recommendation is snoozed, e.g. a ticket number"),
+		),
+	)
+
+	// 建立取消暫停建議的工具
+	unsnoozeRecommendationTool := mcp.NewTool("unsnooze_recommendation",
+		mcp.WithDescription("Cancel a snooze created by snooze_recommendation so the recommendations show up again"),
+		mcp.WithString("snoozeId",
+			mcp.Required(),
+			mcp.Description("Snooze ID from snooze_recommendation or list_snoozed_recommendations"),
+		),
+	)
+
+	// 建立列出暫停建議的工具
+	listSnoozedRecommendationsTool := mcp.NewTool("list_snoozed_recommendations",
+		mcp.WithDescription("List active snoozes created by snooze_recommendation, soonest to expire first"),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace; all namespaces when omitted"),
+		),
+	)
+
+	// 建立評估 Autopilot 可行性的工具
+	assessAutopilotSuitabilityTool := mcp.NewTool("assess_autopilot_suitability",
+		mcp.WithDescription("Assess whether the workloads in a namespace would run on GKE Autopilot (no privileged containers, host namespaces, writable or non-/var/log hostPath, disallowed capabilities or unsupported node selectors; general-purpose resource ranges) and compare the current Standard cost, with unrequested node capacity allocated, to the Autopilot cost of the adjusted pod requests"),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace (default: default, or all)"),
+		),
+	)
+
+	// 建立跨叢集比較的工具
+	compareClustersTool := mcp.NewTool("compare_clusters",
+		mcp.WithDescription("Run the optimization analysis for a namespace on the current cluster and every cluster configured in clusters, and compare optimization score, resource waste, estimated savings and node cost per cluster (lowest score first); sends a progress notification as each cluster completes when the client provides a progressToken"),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace (default: default, or all)"),
+		),
+		mcp.WithString("window",
+			mcp.Description("Usage history window to analyze, e.g. 1h, 24h, 7d (default: 7d). Requires a historical metric source (Cloud Monitoring, Prometheus or the collector)"),
+		),
+	)
+
+	// 建立分析啟動時間的工具
+	analyzeStartupLatencyTool := mcp.NewTool("analyze_startup_latency",
+		mcp.WithDescription("Measure time from pod creation to Ready per workload (from pod conditions), split into scheduling, init containers and container startup, and flag workloads with slow (median >= 2m) or highly variable startup with suggestions such as startup probes, image slimming or spare capacity"),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace (default: default, or all)"),
+		),
+	)
+
+	// 建立評估 Pod Security Standards 的工具
+	analyzePodSecurityTool := mcp.NewTool("analyze_pod_security",
+		mcp.WithDescription("Evaluate pods against the Kubernetes Pod Security Standards (baseline or restricted) and report violations per namespace and workload with the failed check (Pod Security Admission check IDs such as privileged, hostPathVolumes, runAsNonRoot), remediation hints, and a suggestion for the namespace pod-security.kubernetes.io labels"),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace (default: default, or all)"),
+		),
+		mcp.WithString("level",
+			mcp.Description("Pod Security Standards level: baseline (default) or restricted (includes baseline)"),
+		),
 	)
 
 	// 將所有 GKE Pod 監控工具註冊到伺服器並記錄工具名稱
@@ -205,6 +1069,114 @@ func RegisterTools(s *mcpserver.MCPServer, handler ToolHandler, optimizationHand
 	s.AddTool(getPodDetailsTool, handler.GetPodDetails)
 	registeredTools = append(registeredTools, "get_pod_details")
 
+	s.AddTool(getPodDistributionTool, handler.GetPodDistribution)
+	registeredTools = append(registeredTools, "get_pod_distribution")
+
+	s.AddTool(getPodProbesTool, handler.GetPodProbes)
+	registeredTools = append(registeredTools, "get_pod_probes")
+
+	s.AddTool(aggregatePodsByLabelTool, handler.AggregatePodsByLabel)
+	registeredTools = append(registeredTools, "aggregate_pods_by_label")
+
+	s.AddTool(getServicesForPodTool, handler.GetServicesForPod)
+	registeredTools = append(registeredTools, "get_services_for_pod")
+
+	s.AddTool(checkPodConnectivityTool, handler.CheckPodConnectivity)
+	registeredTools = append(registeredTools, "check_pod_connectivity")
+
+	s.AddTool(describePodTool, handler.DescribePod)
+	registeredTools = append(registeredTools, "describe_pod")
+
+	s.AddTool(describeNodeTool, handler.DescribeNode)
+	registeredTools = append(registeredTools, "describe_node")
+
+	s.AddTool(getPodEnvTool, handler.GetPodEnv)
+	registeredTools = append(registeredTools, "get_pod_env")
+
+	s.AddTool(getPodVolumesTool, handler.GetPodVolumes)
+	registeredTools = append(registeredTools, "get_pod_volumes")
+
+	s.AddTool(diagnoseImagePullTool, handler.DiagnoseImagePull)
+	registeredTools = append(registeredTools, "diagnose_image_pull")
+
+	s.AddTool(getTerminatedPodsTool, handler.GetTerminatedPods)
+	registeredTools = append(registeredTools, "get_terminated_pods")
+
+	s.AddTool(getNodeEventsTool, handler.GetNodeEvents)
+	registeredTools = append(registeredTools, "get_node_events")
+
+	s.AddTool(restartWorkloadTool, handler.RestartWorkload)
+	registeredTools = append(registeredTools, "restart_workload")
+
+	s.AddTool(scaleWorkloadTool, handler.ScaleWorkload)
+	registeredTools = append(registeredTools, "scale_workload")
+
+	s.AddTool(deletePodTool, handler.DeletePod)
+	registeredTools = append(registeredTools, "delete_pod")
+
+	s.AddTool(cordonNodeTool, handler.CordonNode)
+	registeredTools = append(registeredTools, "cordon_node")
+
+	s.AddTool(uncordonNodeTool, handler.UncordonNode)
+	registeredTools = append(registeredTools, "uncordon_node")
+
+	s.AddTool(drainNodeTool, handler.DrainNode)
+	registeredTools = append(registeredTools, "drain_node")
+
+	s.AddTool(evictPodTool, handler.EvictPod)
+	registeredTools = append(registeredTools, "evict_pod")
+
+	s.AddTool(getRolloutHistoryTool, handler.GetRolloutHistory)
+	registeredTools = append(registeredTools, "get_rollout_history")
+
+	s.AddTool(rollbackDeploymentTool, handler.RollbackDeployment)
+	registeredTools = append(registeredTools, "rollback_deployment")
+
+	s.AddTool(execInPodTool, handler.ExecInPod)
+	registeredTools = append(registeredTools, "exec_in_pod")
+
+	s.AddTool(probePodEndpointTool, handler.ProbePodEndpoint)
+	registeredTools = append(registeredTools, "probe_pod_endpoint")
+
+	s.AddTool(applyPatchTool, handler.ApplyPatch)
+	registeredTools = append(registeredTools, "apply_patch")
+
+	s.AddTool(getMetricsHistoryTool, handler.GetMetricsHistory)
+	registeredTools = append(registeredTools, "get_metrics_history")
+
+	s.AddTool(getHistoricalUsageTool, handler.GetHistoricalUsage)
+	registeredTools = append(registeredTools, "get_historical_usage")
+
+	s.AddTool(queryPrometheusTool, handler.QueryPrometheus)
+	registeredTools = append(registeredTools, "query_prometheus")
+
+	s.AddTool(getPrometheusUsageTool, handler.GetPrometheusUsage)
+	registeredTools = append(registeredTools, "get_prometheus_usage")
+
+	s.AddTool(getUsagePercentilesTool, handler.GetUsagePercentiles)
+	registeredTools = append(registeredTools, "get_usage_percentiles")
+
+	s.AddTool(detectAnomaliesTool, handler.DetectAnomalies)
+	registeredTools = append(registeredTools, "detect_anomalies")
+
+	s.AddTool(forecastUsageTool, handler.ForecastUsage)
+	registeredTools = append(registeredTools, "forecast_usage")
+
+	s.AddTool(getOOMEventsTool, handler.GetOOMEvents)
+	registeredTools = append(registeredTools, "get_oom_events")
+
+	s.AddTool(getNodeCommitmentTool, handler.GetNodeCommitment)
+	registeredTools = append(registeredTools, "get_node_commitment")
+
+	s.AddTool(getCapacityPlanTool, handler.GetCapacityPlan)
+	registeredTools = append(registeredTools, "get_capacity_plan")
+
+	s.AddTool(detectMemoryLeaksTool, handler.DetectMemoryLeaks)
+	registeredTools = append(registeredTools, "detect_memory_leaks")
+
+	s.AddTool(detectOOMRisksTool, handler.DetectOOMRisks)
+	registeredTools = append(registeredTools, "detect_oom_risks")
+
 	// 將所有 GKE 優化建議工具註冊到伺服器並記錄工具名稱
 	s.AddTool(generateOptimizationReportTool, optimizationHandler.GenerateOptimizationReport)
 	registeredTools = append(registeredTools, "generate_optimization_report")
@@ -227,6 +1199,51 @@ func RegisterTools(s *mcpserver.MCPServer, handler ToolHandler, optimizationHand
 	s.AddTool(updateOptimizationCriteriaTool, optimizationHandler.UpdateOptimizationCriteria)
 	registeredTools = append(registeredTools, "update_optimization_criteria")
 
+	s.AddTool(getRecommendationPatchTool, optimizationHandler.GetRecommendationPatch)
+	registeredTools = append(registeredTools, "get_recommendation_patch")
+
+	s.AddTool(applyRecommendationTool, optimizationHandler.ApplyRecommendation)
+	registeredTools = append(registeredTools, "apply_recommendation")
+
+	s.AddTool(simulateNodeConsolidationTool, optimizationHandler.SimulateNodeConsolidation)
+	registeredTools = append(registeredTools, "simulate_node_consolidation")
+
+	s.AddTool(detectIdleNamespacesTool, optimizationHandler.DetectIdleNamespaces)
+	registeredTools = append(registeredTools, "detect_idle_namespaces")
+
+	s.AddTool(listOptimizationReportsTool, optimizationHandler.ListOptimizationReports)
+	registeredTools = append(registeredTools, "list_optimization_reports")
+
+	s.AddTool(compareOptimizationReportsTool, optimizationHandler.CompareOptimizationReports)
+	registeredTools = append(registeredTools, "compare_optimization_reports")
+
+	s.AddTool(exportOptimizationReportTool, optimizationHandler.ExportOptimizationReport)
+	registeredTools = append(registeredTools, "export_optimization_report")
+
+	s.AddTool(sendReportTool, optimizationHandler.SendReport)
+	registeredTools = append(registeredTools, "send_report")
+
+	s.AddTool(snoozeRecommendationTool, optimizationHandler.SnoozeRecommendation)
+	registeredTools = append(registeredTools, "snooze_recommendation")
+
+	s.AddTool(unsnoozeRecommendationTool, optimizationHandler.UnsnoozeRecommendation)
+	registeredTools = append(registeredTools, "unsnooze_recommendation")
+
+	s.AddTool(listSnoozedRecommendationsTool, optimizationHandler.ListSnoozedRecommendations)
+	registeredTools = append(registeredTools, "list_snoozed_recommendations")
+
+	s.AddTool(assessAutopilotSuitabilityTool, optimizationHandler.AssessAutopilotSuitability)
+	registeredTools = append(registeredTools, "assess_autopilot_suitability")
+
+	s.AddTool(compareClustersTool, optimizationHandler.CompareClusters)
+	registeredTools = append(registeredTools, "compare_clusters")
+
+	s.AddTool(analyzeStartupLatencyTool, optimizationHandler.AnalyzeStartupLatency)
+	registeredTools = append(registeredTools, "analyze_startup_latency")
+
+	s.AddTool(analyzePodSecurityTool, optimizationHandler.AnalyzePodSecurity)
+	registeredTools = append(registeredTools, "analyze_pod_security")
+
 	return registeredTools
 }
 
@@ -307,20 +1324,49 @@ func StartStdioServer(s *mcpserver.MCPServer, logger *logger.Logger) error {
 }
 
 // 啟動 SSE (Server-Sent Events) 伺服器
-func StartSSEServer(s *mcpserver.MCPServer, baseURL string, port interface{}, logger *logger.Logger) error {
-	portStr := fmt.Sprintf("%v", port)
+// 設定驗證時，SSE 連線與訊息請求都必須通過驗證；設定頻率限制時依用戶端限制工具呼叫次數
+func StartSSEServer(s *mcpserver.MCPServer, sseConfig config.SSEConfig, logger *logger.Logger) error {
+	portStr := fmt.Sprintf("%v", sseConfig.Port)
+	auth := sseConfig.Auth
 
 	// 確保 baseURL 包含埠號
-	fullBaseURL := fmt.Sprintf("%s:%s", baseURL, portStr)
+	fullBaseURL := fmt.Sprintf("%s:%s", sseConfig.BaseURL, portStr)
 	fmt.Printf("sse 伺服器啟動於 %s\n", fullBaseURL)
 	logger.LogServerStart()
 
 	// 建立 SSE 伺服器 - 使用包含埠號的完整 URL
-	sse := mcpserver.NewSSEServer(s, mcpserver.WithBaseURL(fullBaseURL))
+	sse := mcpserver.NewSSEServer(s,
+		mcpserver.WithBaseURL(fullBaseURL),
+		mcpserver.WithSSEContextFunc(withRemoteIP),
+	)
+
+	if rateLimit := sseConfig.RateLimit; rateLimit.RequestsPerMinute > 0 {
+		limiter := newToolRateLimiter(rateLimit, logger)
+		mcpserver.WithToolHandlerMiddleware(limiter.middleware)(s)
+		logger.Printf("工具呼叫頻率限制: 每分鐘 %d 次，burst %d", rateLimit.RequestsPerMinute, limiter.burst)
+	}
+
+	var handler http.Handler = sse
+	authenticators, err := newAuthenticators(auth)
+	if err != nil {
+		logger.LogServerError(err)
+		return err
+	}
+	if len(authenticators) > 0 {
+		handler = authMiddleware(sse, authenticators, logger)
+		logger.Printf("SSE 驗證已啟用，API key 用戶端 %d 個，OIDC issuer: %s", len(auth.APIKeys), auth.OIDC.Issuer)
+	} else {
+		fmt.Println("警告: 未設定 sse.auth，任何能連線到此埠號的用戶端都能使用所有工具")
+		logger.Println("警告: SSE 驗證未啟用")
+	}
 
 	fmt.Printf("正在啟動 SSE 伺服器於埠號 %s...\n", portStr)
 
-	err := sse.Start(":" + portStr)
+	httpServer := &http.Server{
+		Addr:    ":" + portStr,
+		Handler: handler,
+	}
+	err = httpServer.ListenAndServe()
 
 	if err != nil {
 		errMsg := fmt.Sprintf("伺服器錯誤: %v\n", err)
@@ -344,7 +1390,7 @@ func StartServer(s *mcpserver.MCPServer, appConfig config.Config, logger *logger
 	switch appConfig.ServerType {
 	case config.ServerTypeSSE:
 		fmt.Println("使用 SSE 模式")
-		return StartSSEServer(s, appConfig.SSE.BaseURL, appConfig.SSE.Port, logger)
+		return StartSSEServer(s, appConfig.SSE, logger)
 	case config.ServerTypeStdio:
 		// 在 stdio 模式下不輸出，避免干擾 MCP 協議
 		logger.Println("使用 Stdio 模式")
@@ -0,0 +1,85 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"mcp-gke-monitor/config"
+	"mcp-gke-monitor/logger"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+)
+
+// toolTimeouts 依工具名稱限制執行時間
+type toolTimeouts struct {
+	defaultTimeout time.Duration
+	tools          map[string]time.Duration
+	logger         *logger.Logger
+}
+
+// newToolTimeouts 建立工具執行時間限制
+func newToolTimeouts(cfg config.TimeoutConfig, logger *logger.Logger) *toolTimeouts {
+	tools := make(map[string]time.Duration, len(cfg.Tools))
+	for tool, seconds := range cfg.Tools {
+		tools[tool] = time.Duration(seconds) * time.Second
+	}
+	return &toolTimeouts{
+		defaultTimeout: time.Duration(cfg.DefaultSeconds) * time.Second,
+		tools:          tools,
+		logger:         logger,
+	}
+}
+
+// timeout 工具的執行時間上限，0 表示不限制
+func (t *toolTimeouts) timeout(tool string) time.Duration {
+	if timeout, ok := t.tools[tool]; ok {
+		return timeout
+	}
+	return t.defaultTimeout
+}
+
+// middleware 以帶有期限的 context 執行工具，超過期限時立即回傳逾時錯誤
+// 工具的服務呼叫會因 context 取消而中止，沒有使用 context 的呼叫則在背景執行完畢後捨棄結果
+func (t *toolTimeouts) middleware(next mcpserver.ToolHandlerFunc) mcpserver.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		timeout := t.timeout(request.Params.Name)
+		if timeout <= 0 {
+			return next(ctx, request)
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		type outcome struct {
+			result *mcp.CallToolResult
+			err    error
+		}
+		done := make(chan outcome, 1)
+		go func() {
+			result, err := next(ctx, request)
+			done <- outcome{result: result, err: err}
+		}()
+
+		select {
+		case o := <-done:
+			if o.err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return nil, t.timeoutError(request.Params.Name, timeout)
+			}
+			return o.result, o.err
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return nil, t.timeoutError(request.Params.Name, timeout)
+			}
+			return nil, fmt.Errorf("工具 %s 已取消: %w", request.Params.Name, ctx.Err())
+		}
+	}
+}
+
+// timeoutError 記錄並建立逾時錯誤
+func (t *toolTimeouts) timeoutError(tool string, timeout time.Duration) error {
+	t.logger.Printf("工具 %s 執行超過 %s，已取消", tool, timeout)
+	return fmt.Errorf("工具 %s 執行超過 %s 的時間上限，已取消執行 (已送出的變更不會回復)；可以縮小查詢範圍，或在 config.json 的 timeouts.tools 調整此工具的上限", tool, timeout)
+}